@@ -18,7 +18,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎时节点没有审批人,允许任何人审批(实际使用时应由调用方校验审批人)
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.AllowUnassignedApprovers())),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -36,7 +39,7 @@ func main() {
 		"days": 3,
 		"reason": "个人事务"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "leave-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "leave-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -94,7 +97,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
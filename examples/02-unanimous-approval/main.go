@@ -18,7 +18,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -37,7 +40,7 @@ func main() {
 		"reason": "部门办公设备采购",
 		"supplier": "XX供应商"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "purchase-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "purchase-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -103,7 +106,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -117,15 +120,15 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 
 	// 步骤 3.5: 设置审批人列表(固定审批人需要在节点激活前设置)
 	fmt.Println("步骤 3.5: 设置审批人列表")
-	err = taskMgr.AddApprover(tsk.ID, "unanimous-approval", "finance-001", "设置财务审批人")
+	err = taskMgr.AddApprover(tsk.ID, "unanimous-approval", "admin-001", "finance-001", "设置财务审批人")
 	if err != nil {
 		log.Fatalf("Failed to add finance approver: %v", err)
 	}
-	err = taskMgr.AddApprover(tsk.ID, "unanimous-approval", "purchase-001", "设置采购审批人")
+	err = taskMgr.AddApprover(tsk.ID, "unanimous-approval", "admin-001", "purchase-001", "设置采购审批人")
 	if err != nil {
 		log.Fatalf("Failed to add purchase approver: %v", err)
 	}
-	err = taskMgr.AddApprover(tsk.ID, "unanimous-approval", "manager-001", "设置总经理审批人")
+	err = taskMgr.AddApprover(tsk.ID, "unanimous-approval", "admin-001", "manager-001", "设置总经理审批人")
 	if err != nil {
 		log.Fatalf("Failed to add manager approver: %v", err)
 	}
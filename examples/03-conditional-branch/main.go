@@ -18,7 +18,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -37,7 +40,7 @@ func main() {
 		"description": "出差费用报销"
 	}`, amount))
 
-	tsk, err := taskMgr.Create(tpl.ID, "expense-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "expense-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -215,7 +218,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task, amount float64) {
 	// 步骤 2: 提交任务
 	fmt.Println("步骤 2: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -282,7 +285,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task, amount float64) {
 		// 设置审批人(如果需要)
 		tsk, _ = taskMgr.Get(tsk.ID)
 		if tsk.Approvers[step.nodeID] == nil || len(tsk.Approvers[step.nodeID]) == 0 {
-			err = taskMgr.AddApprover(tsk.ID, step.nodeID, step.approver, "设置审批人")
+			err = taskMgr.AddApprover(tsk.ID, step.nodeID, "admin-001", step.approver, "设置审批人")
 			if err != nil {
 				log.Fatalf("Failed to add approver: %v", err)
 			}
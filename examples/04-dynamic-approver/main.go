@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -31,7 +32,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建 Mock HTTP 客户端
 	fmt.Println("步骤 1: 创建 Mock HTTP 客户端")
@@ -56,7 +60,7 @@ func main() {
 		"budget": 100000,
 		"description": "新产品研发项目立项"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "project-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "project-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -159,7 +163,7 @@ func createTemplate(httpClient node.HTTPClient) *template.Template {
 func runScenario(templateMgr template.TemplateManager, taskMgr task.TaskManager, tsk *task.Task, httpClient node.HTTPClient) {
 	// 步骤 4: 提交任务
 	fmt.Println("步骤 4: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -193,7 +197,7 @@ func runScenario(templateMgr template.TemplateManager, taskMgr task.TaskManager,
 	}
 
 	// 创建节点上下文
-	ctx := &node.NodeContext{
+	nc := &node.NodeContext{
 		Task:    tsk,
 		Node:    tplNode,
 		Params:  tsk.Params,
@@ -204,7 +208,7 @@ func runScenario(templateMgr template.TemplateManager, taskMgr task.TaskManager,
 	// 调用 API 获取审批人
 	fmt.Println("  调用 API: POST http://api.example.com/approvers")
 	fmt.Println("  请求参数: {\"project_type\": \"研发项目\"}")
-	approvers, err := dynamicConfig.GetApprovers(ctx)
+	approvers, err := dynamicConfig.GetApprovers(context.Background(), nc)
 	if err != nil {
 		log.Fatalf("Failed to get approvers: %v", err)
 	}
@@ -215,7 +219,7 @@ func runScenario(templateMgr template.TemplateManager, taskMgr task.TaskManager,
 	// 设置审批人列表(单人审批模式,只使用第一个审批人)
 	if len(approvers) > 0 {
 		approver := approvers[0]
-		err = taskMgr.AddApprover(tsk.ID, "project-approval", approver, "动态获取的审批人")
+		err = taskMgr.AddApprover(tsk.ID, "project-approval", "admin-001", approver, "动态获取的审批人")
 		if err != nil {
 			log.Fatalf("Failed to add approver: %v", err)
 		}
@@ -18,7 +18,11 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人,按节点的审批模式判断审批顺序和节点是否完成
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+		task.WithApprovalDecider(node.NewApprovalDecider(nil)),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -39,7 +43,7 @@ func main() {
 		"probationPeriod": 6,
 		"performance": "优秀"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "promotion-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "promotion-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -107,7 +111,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -133,7 +137,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	}
 
 	for _, approver := range approvers {
-		err = taskMgr.AddApprover(tsk.ID, nodeID, approver, "设置审批人")
+		err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", approver, "设置审批人")
 		if err != nil {
 			log.Fatalf("Failed to add approver: %v", err)
 		}
@@ -18,7 +18,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -38,7 +41,7 @@ func main() {
 		"budget": 1000000,
 		"description": "新产品开发方案审批"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "proposal-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "proposal-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -132,7 +135,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -147,7 +150,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 4: 设置审批人列表
 	fmt.Println("步骤 4: 设置审批人列表")
 	nodeID := "dept-approval"
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "dept-manager-001", "设置部门审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "dept-manager-001", "设置部门审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -169,7 +172,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 6: 设置财务审批人
 	fmt.Println("步骤 6: 设置财务审批人")
 	nodeID = "finance-approval"
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "finance-001", "设置财务审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "finance-001", "设置财务审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -18,7 +18,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -39,7 +42,7 @@ func main() {
 		"partyB": "YY公司",
 		"signDate": "2025-01-15"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "contract-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "contract-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -117,7 +120,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -132,7 +135,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 4: 设置审批人列表
 	fmt.Println("步骤 4: 设置审批人列表")
 	nodeID := "business-approval"
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "business-001", "设置业务部门审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "business-001", "设置业务部门审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -154,7 +157,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 6: 设置财务审批人
 	fmt.Println("步骤 6: 设置财务审批人")
 	nodeID = "finance-approval"
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "finance-001", "设置财务审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "finance-001", "设置财务审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -18,7 +18,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -38,7 +41,7 @@ func main() {
 		"budget": 2000000,
 		"description": "新产品开发项目审批"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "project-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "project-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -105,7 +108,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -121,11 +124,11 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 
 	// 步骤 4: 设置初始审批人列表
 	fmt.Println("步骤 4: 设置初始审批人列表")
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "manager-001", "设置项目经理为审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "manager-001", "设置项目经理为审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "finance-001", "设置财务审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "finance-001", "设置财务审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -134,7 +137,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 5: 加签操作
 	fmt.Println("步骤 5: 加签操作")
 	fmt.Println("  说明: 在审批过程中添加额外的审批人")
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "tech-lead-001", "加签: 添加技术负责人参与审批")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "tech-lead-001", "加签: 添加技术负责人参与审批")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -158,7 +161,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 7: 减签操作
 	fmt.Println("步骤 7: 减签操作")
 	fmt.Println("  说明: 移除部分审批人(需权限控制)")
-	err = taskMgr.RemoveApprover(tsk.ID, nodeID, "tech-lead-001", "减签: 技术负责人无需参与审批")
+	err = taskMgr.RemoveApprover(tsk.ID, nodeID, "admin-001", "tech-lead-001", "减签: 技术负责人无需参与审批")
 	if err != nil {
 		log.Fatalf("Failed to remove approver: %v", err)
 	}
@@ -17,7 +17,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -38,7 +41,7 @@ func main() {
 		"urgency": "high",
 		"description": "紧急采购申请,需要在24小时内完成审批"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "urgent-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "urgent-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -102,7 +105,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -121,7 +124,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 4: 设置审批人
 	fmt.Println("步骤 4: 设置审批人")
 	nodeID := "urgent-approval"
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "manager-001", "设置审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "manager-001", "设置审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -144,15 +147,15 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 注意: 实际场景中,超时时间应该是24小时,这里我们使用短时间进行演示
 	fmt.Println("  模拟场景: 假设任务已超过超时时间")
 	fmt.Println("  说明: 在实际业务系统中,可以通过以下方式处理超时:")
-	fmt.Println("    1. 定时任务定期调用 CheckTimeout 检查超时任务")
+	fmt.Println("    1. 使用 TimeoutScheduler 在节点超时后自动调用 HandleTimeout")
 	fmt.Println("    2. 调用 HandleTimeout 处理超时任务")
 	fmt.Println("    3. 超时后可以自动通过、自动拒绝或发送通知")
 	fmt.Println()
 
 	// 步骤 7: 说明超时处理流程
 	fmt.Println("步骤 7: 超时处理流程说明")
-	fmt.Println("  1. 任务提交后,开始计时")
-	fmt.Println("  2. 定时任务定期检查任务是否超时")
+	fmt.Println("  1. 审批节点激活后,开始计时")
+	fmt.Println("  2. 超时调度器在节点截止时间到期后检查任务是否超时")
 	fmt.Println("  3. 如果超时,调用 HandleTimeout 处理超时任务")
 	fmt.Println("  4. 任务状态变为 timeout,生成超时事件")
 	fmt.Println("  5. 根据业务规则,可以自动通过、自动拒绝或发送通知")
@@ -244,7 +247,7 @@ func printResults(taskMgr task.TaskManager, tsk *task.Task) {
 	fmt.Println("  - 超时处理逻辑已配置")
 	fmt.Println()
 	fmt.Println("  说明: 在实际业务系统中,可以通过以下方式使用超时功能:")
-	fmt.Println("    1. 定时任务定期调用 CheckTimeout 检查超时任务")
+	fmt.Println("    1. 使用 TimeoutScheduler 在节点超时后自动调用 HandleTimeout")
 	fmt.Println("    2. 调用 HandleTimeout 处理超时任务")
 	fmt.Println("    3. 超时后任务状态变为 timeout,生成超时事件")
 	fmt.Println("    4. 根据业务规则,可以自动通过、自动拒绝或发送通知")
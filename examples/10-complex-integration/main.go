@@ -347,7 +347,7 @@ func runScenario(taskMgr task.TaskManager, templateID string, projectType string
 	}
 	paramsJSON, _ := json.Marshal(taskParams)
 
-	tsk, err := taskMgr.Create(templateID, fmt.Sprintf("project-%s-001", projectType), paramsJSON)
+	tsk, err := taskMgr.CreateBy(templateID, fmt.Sprintf("project-%s-001", projectType), "initiator-001", paramsJSON)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -355,7 +355,7 @@ func runScenario(taskMgr task.TaskManager, templateID string, projectType string
 	fmt.Printf("✓ 任务创建成功: ID=%s, 项目类型=%s\n", taskID, projectType)
 
 	// 2. 提交任务
-	if err := taskMgr.Submit(taskID); err != nil {
+	if err := taskMgr.Submit(taskID, "initiator-001"); err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
 	fmt.Printf("✓ 任务已提交\n")
@@ -17,7 +17,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -92,7 +95,7 @@ func demonstrateWithdraw(templateMgr template.TemplateManager, taskMgr task.Task
 		"amount": 10000,
 		"description": "测试任务撤回功能"
 	}`)
-	tsk, err := taskMgr.Create("withdraw-cancel-template", "withdraw-001", params)
+	tsk, err := taskMgr.CreateBy("withdraw-cancel-template", "withdraw-001", "user-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -100,7 +103,7 @@ func demonstrateWithdraw(templateMgr template.TemplateManager, taskMgr task.Task
 
 	// 提交任务
 	fmt.Println("步骤 2: 提交任务")
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "user-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -112,7 +115,7 @@ func demonstrateWithdraw(templateMgr template.TemplateManager, taskMgr task.Task
 	fmt.Println("  说明: 在未产生审批记录前可以撤回任务")
 	fmt.Println("  撤回后任务状态回退到 pending,SubmittedAt 被清空")
 	fmt.Println()
-	err = taskMgr.Withdraw(tsk.ID, "提交后发现错误,需要修改后重新提交")
+	err = taskMgr.Withdraw(tsk.ID, "user-001", "提交后发现错误,需要修改后重新提交")
 	if err != nil {
 		log.Fatalf("Failed to withdraw task: %v", err)
 	}
@@ -142,7 +145,7 @@ func demonstrateCancel(templateMgr template.TemplateManager, taskMgr task.TaskMa
 		"amount": 20000,
 		"description": "测试任务取消功能"
 	}`)
-	tsk, err := taskMgr.Create("withdraw-cancel-template", "cancel-001", params)
+	tsk, err := taskMgr.CreateBy("withdraw-cancel-template", "cancel-001", "user-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -150,7 +153,7 @@ func demonstrateCancel(templateMgr template.TemplateManager, taskMgr task.TaskMa
 
 	// 提交任务
 	fmt.Println("步骤 2: 提交任务")
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "user-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -159,7 +162,7 @@ func demonstrateCancel(templateMgr template.TemplateManager, taskMgr task.TaskMa
 
 	// 设置审批人
 	fmt.Println("步骤 3: 设置审批人")
-	err = taskMgr.AddApprover(tsk.ID, "approval", "manager-001", "设置审批人")
+	err = taskMgr.AddApprover(tsk.ID, "approval", "admin-001", "manager-001", "设置审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -170,7 +173,7 @@ func demonstrateCancel(templateMgr template.TemplateManager, taskMgr task.TaskMa
 	fmt.Println("  说明: 任务可以随时取消(在 pending、submitted 或 approving 状态)")
 	fmt.Println("  取消后任务状态变为 cancelled,无法继续审批")
 	fmt.Println()
-	err = taskMgr.Cancel(tsk.ID, "申请人决定取消此申请")
+	err = taskMgr.Cancel(tsk.ID, "user-001", "申请人决定取消此申请")
 	if err != nil {
 		log.Fatalf("Failed to cancel task: %v", err)
 	}
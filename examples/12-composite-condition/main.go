@@ -146,7 +146,7 @@ func demonstrateCompositeConditions(templateMgr template.TemplateManager, taskMg
 		"department": "技术部",
 		"description": "金额>=50000 且 部门=技术部,应该走高级审批路径"
 	}`)
-	tsk1, err := taskMgr.Create("composite-condition-template", "composite-001", params1)
+	tsk1, err := taskMgr.CreateBy("composite-condition-template", "composite-001", "initiator-001", params1)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -172,7 +172,7 @@ func demonstrateCompositeConditions(templateMgr template.TemplateManager, taskMg
 		"department": "市场部",
 		"description": "金额>=50000 但 部门!=技术部,应该走普通审批路径"
 	}`)
-	tsk2, err := taskMgr.Create("composite-condition-template", "composite-002", params2)
+	tsk2, err := taskMgr.CreateBy("composite-condition-template", "composite-002", "initiator-001", params2)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -199,7 +199,7 @@ func demonstrateCompositeConditions(templateMgr template.TemplateManager, taskMg
 		"department": "技术部",
 		"description": "金额<50000 且 部门=技术部,应该走普通审批路径"
 	}`)
-	tsk3, err := taskMgr.Create("composite-condition-template", "composite-003", params3)
+	tsk3, err := taskMgr.CreateBy("composite-condition-template", "composite-003", "initiator-001", params3)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
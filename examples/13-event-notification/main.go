@@ -66,7 +66,10 @@ func main() {
 	// 4. 创建管理器
 	fmt.Println("步骤 4: 创建管理器")
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 	fmt.Printf("✓ 管理器已创建\n\n")
 
 	// 5. 创建模板
@@ -173,7 +176,7 @@ func runScenario(taskMgr task.TaskManager, tpl *template.Template) {
 		"amount": 50000,
 		"description": "测试事件通知功能"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "event-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "event-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -186,7 +189,7 @@ func runScenario(taskMgr task.TaskManager, tpl *template.Template) {
 
 	// 提交任务
 	fmt.Println("步骤 2: 提交任务")
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -199,7 +202,7 @@ func runScenario(taskMgr task.TaskManager, tpl *template.Template) {
 
 	// 设置审批人
 	fmt.Println("步骤 3: 设置审批人")
-	err = taskMgr.AddApprover(tsk.ID, "approval", "manager-001", "设置审批人")
+	err = taskMgr.AddApprover(tsk.ID, "approval", "admin-001", "manager-001", "设置审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -18,7 +18,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -91,13 +94,13 @@ func createAndProcessTasks(templateMgr template.TemplateManager, taskMgr task.Ta
 	// 任务1: 已通过
 	fmt.Println("  创建任务1: 已通过")
 	params1 := json.RawMessage(`{"requestNo": "REQ-001", "amount": 10000}`)
-	tsk1, err := taskMgr.Create(tpl.ID, "biz-001", params1)
+	tsk1, err := taskMgr.CreateBy(tpl.ID, "biz-001", "initiator-001", params1)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
-	taskMgr.Submit(tsk1.ID)
-	taskMgr.AddApprover(tsk1.ID, "approval", "manager-001", "设置审批人")
-	taskMgr.AddApprover(tsk1.ID, "approval", "finance-001", "设置审批人")
+	taskMgr.Submit(tsk1.ID, "initiator-001")
+	taskMgr.AddApprover(tsk1.ID, "approval", "admin-001", "manager-001", "设置审批人")
+	taskMgr.AddApprover(tsk1.ID, "approval", "admin-001", "finance-001", "设置审批人")
 	taskMgr.Approve(tsk1.ID, "approval", "manager-001", "经理审批通过")
 	taskMgr.Approve(tsk1.ID, "approval", "finance-001", "财务审批通过")
 	tsk1, _ = taskMgr.Get(tsk1.ID)
@@ -107,13 +110,13 @@ func createAndProcessTasks(templateMgr template.TemplateManager, taskMgr task.Ta
 	// 任务2: 审批中
 	fmt.Println("  创建任务2: 审批中")
 	params2 := json.RawMessage(`{"requestNo": "REQ-002", "amount": 20000}`)
-	tsk2, err := taskMgr.Create(tpl.ID, "biz-002", params2)
+	tsk2, err := taskMgr.CreateBy(tpl.ID, "biz-002", "initiator-001", params2)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
-	taskMgr.Submit(tsk2.ID)
-	taskMgr.AddApprover(tsk2.ID, "approval", "manager-001", "设置审批人")
-	taskMgr.AddApprover(tsk2.ID, "approval", "finance-001", "设置审批人")
+	taskMgr.Submit(tsk2.ID, "initiator-001")
+	taskMgr.AddApprover(tsk2.ID, "approval", "admin-001", "manager-001", "设置审批人")
+	taskMgr.AddApprover(tsk2.ID, "approval", "admin-001", "finance-001", "设置审批人")
 	taskMgr.Approve(tsk2.ID, "approval", "manager-001", "经理审批通过")
 	tsk2, _ = taskMgr.Get(tsk2.ID)
 	tasks = append(tasks, tsk2)
@@ -122,12 +125,12 @@ func createAndProcessTasks(templateMgr template.TemplateManager, taskMgr task.Ta
 	// 任务3: 已拒绝
 	fmt.Println("  创建任务3: 已拒绝")
 	params3 := json.RawMessage(`{"requestNo": "REQ-003", "amount": 30000}`)
-	tsk3, err := taskMgr.Create(tpl.ID, "biz-003", params3)
+	tsk3, err := taskMgr.CreateBy(tpl.ID, "biz-003", "initiator-001", params3)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
-	taskMgr.Submit(tsk3.ID)
-	taskMgr.AddApprover(tsk3.ID, "approval", "manager-001", "设置审批人")
+	taskMgr.Submit(tsk3.ID, "initiator-001")
+	taskMgr.AddApprover(tsk3.ID, "approval", "admin-001", "manager-001", "设置审批人")
 	taskMgr.Reject(tsk3.ID, "approval", "manager-001", "经理拒绝")
 	tsk3, _ = taskMgr.Get(tsk3.ID)
 	tasks = append(tasks, tsk3)
@@ -136,11 +139,11 @@ func createAndProcessTasks(templateMgr template.TemplateManager, taskMgr task.Ta
 	// 任务4: 待审批
 	fmt.Println("  创建任务4: 待审批")
 	params4 := json.RawMessage(`{"requestNo": "REQ-004", "amount": 40000}`)
-	tsk4, err := taskMgr.Create(tpl.ID, "biz-004", params4)
+	tsk4, err := taskMgr.CreateBy(tpl.ID, "biz-004", "initiator-001", params4)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
-	taskMgr.Submit(tsk4.ID)
+	taskMgr.Submit(tsk4.ID, "initiator-001")
 	tsk4, _ = taskMgr.Get(tsk4.ID)
 	tasks = append(tasks, tsk4)
 	fmt.Printf("    ✓ 任务4已创建: ID=%s, State=%s\n", tsk4.ID, tsk4.State)
@@ -31,7 +31,7 @@ func main() {
 	// 3. 使用版本1创建任务
 	fmt.Println("步骤 2: 使用版本1创建任务")
 	params1 := json.RawMessage(`{"requestNo": "REQ-001", "amount": 10000}`)
-	tsk1, err := taskMgr.Create(tpl1.ID, "biz-001", params1)
+	tsk1, err := taskMgr.CreateBy(tpl1.ID, "biz-001", "initiator-001", params1)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -49,7 +49,7 @@ func main() {
 	// 5. 使用版本2创建任务
 	fmt.Println("步骤 4: 使用版本2创建任务")
 	params2 := json.RawMessage(`{"requestNo": "REQ-002", "amount": 20000}`)
-	tsk2, err := taskMgr.Create(tpl2.ID, "biz-002", params2)
+	tsk2, err := taskMgr.CreateBy(tpl2.ID, "biz-002", "initiator-001", params2)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -67,7 +67,7 @@ func main() {
 	// 7. 使用版本3创建任务
 	fmt.Println("步骤 6: 使用版本3创建任务")
 	params3 := json.RawMessage(`{"requestNo": "REQ-003", "amount": 30000}`)
-	tsk3, err := taskMgr.Create(tpl3.ID, "biz-003", params3)
+	tsk3, err := taskMgr.CreateBy(tpl3.ID, "biz-003", "initiator-001", params3)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -18,7 +18,11 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人,按节点的审批模式判断审批顺序和节点是否完成
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+		task.WithApprovalDecider(node.NewApprovalDecider(nil)),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -39,7 +43,7 @@ func main() {
 		"urgency": "high",
 		"description": "紧急采购申请,需要快速审批"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "urgent-purchase-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "urgent-purchase-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -106,7 +110,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -126,15 +130,15 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	fmt.Println("  审批人列表: [purchase-manager-001, purchase-manager-002, purchase-manager-003]")
 	fmt.Println()
 
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "purchase-manager-001", "设置采购经理1为审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "purchase-manager-001", "设置采购经理1为审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "purchase-manager-002", "设置采购经理2为审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "purchase-manager-002", "设置采购经理2为审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "purchase-manager-003", "设置采购经理3为审批人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "purchase-manager-003", "设置采购经理3为审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
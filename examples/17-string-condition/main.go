@@ -156,7 +156,7 @@ func demonstrateStringConditions(templateMgr template.TemplateManager, taskMgr t
 		"amount": 100000,
 		"description": "设备采购合同审批"
 	}`)
-	tsk1, err := taskMgr.Create(tpl.ID, "contract-001", params1)
+	tsk1, err := taskMgr.CreateBy(tpl.ID, "contract-001", "initiator-001", params1)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -182,7 +182,7 @@ func demonstrateStringConditions(templateMgr template.TemplateManager, taskMgr t
 		"amount": 50000,
 		"description": "服务外包合同审批"
 	}`)
-	tsk2, err := taskMgr.Create(tpl.ID, "contract-002", params2)
+	tsk2, err := taskMgr.CreateBy(tpl.ID, "contract-002", "initiator-001", params2)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -208,7 +208,7 @@ func demonstrateStringConditions(templateMgr template.TemplateManager, taskMgr t
 		"amount": 30000,
 		"description": "租赁合同审批"
 	}`)
-	tsk3, err := taskMgr.Create(tpl.ID, "contract-003", params3)
+	tsk3, err := taskMgr.CreateBy(tpl.ID, "contract-003", "initiator-001", params3)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -158,7 +158,7 @@ func demonstrateEnumConditions(templateMgr template.TemplateManager, taskMgr tas
 		"days": 5,
 		"description": "年假申请"
 	}`)
-	tsk1, err := taskMgr.Create(tpl.ID, "leave-001", params1)
+	tsk1, err := taskMgr.CreateBy(tpl.ID, "leave-001", "initiator-001", params1)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -186,7 +186,7 @@ func demonstrateEnumConditions(templateMgr template.TemplateManager, taskMgr tas
 		"days": 1,
 		"description": "调休申请"
 	}`)
-	tsk2, err := taskMgr.Create(tpl.ID, "leave-002", params2)
+	tsk2, err := taskMgr.CreateBy(tpl.ID, "leave-002", "initiator-001", params2)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -214,7 +214,7 @@ func demonstrateEnumConditions(templateMgr template.TemplateManager, taskMgr tas
 		"days": 3,
 		"description": "病假申请"
 	}`)
-	tsk3, err := taskMgr.Create(tpl.ID, "leave-003", params3)
+	tsk3, err := taskMgr.CreateBy(tpl.ID, "leave-003", "initiator-001", params3)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -242,7 +242,7 @@ func demonstrateEnumConditions(templateMgr template.TemplateManager, taskMgr tas
 		"days": 6,
 		"description": "其他类型请假申请"
 	}`)
-	tsk4, err := taskMgr.Create(tpl.ID, "leave-004", params4)
+	tsk4, err := taskMgr.CreateBy(tpl.ID, "leave-004", "initiator-001", params4)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -17,7 +17,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -37,7 +40,7 @@ func main() {
 		"budget": 2000000,
 		"description": "新产品开发项目评审"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "project-review-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "project-review-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -173,7 +176,7 @@ func createTemplate() *template.Template {
 func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 3: 提交任务
 	fmt.Println("步骤 3: 提交任务进入审批流程")
-	err := taskMgr.Submit(tsk.ID)
+	err := taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -188,7 +191,7 @@ func runScenario(taskMgr task.TaskManager, tsk *task.Task) {
 	// 步骤 4: 设置技术评审审批人
 	fmt.Println("步骤 4: 设置技术评审审批人")
 	nodeID := "tech-review"
-	err = taskMgr.AddApprover(tsk.ID, nodeID, "tech-lead-001", "设置技术负责人为评审人")
+	err = taskMgr.AddApprover(tsk.ID, nodeID, "admin-001", "tech-lead-001", "设置技术负责人为评审人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
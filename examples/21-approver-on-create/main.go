@@ -51,7 +51,7 @@ func main() {
 		"budget": 2000000,
 		"description": "新产品开发项目立项审批"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "project-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "project-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
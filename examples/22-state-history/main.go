@@ -18,7 +18,10 @@ func main() {
 
 	// 1. 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 2. 创建模板
 	fmt.Println("步骤 1: 创建审批模板")
@@ -92,7 +95,7 @@ func createAndProcessTask(templateMgr template.TemplateManager, taskMgr task.Tas
 		"amount": 50000,
 		"description": "测试状态变更历史功能"
 	}`)
-	tsk, err := taskMgr.Create(tpl.ID, "state-history-001", params)
+	tsk, err := taskMgr.CreateBy(tpl.ID, "state-history-001", "initiator-001", params)
 	if err != nil {
 		log.Fatalf("Failed to create task: %v", err)
 	}
@@ -100,7 +103,7 @@ func createAndProcessTask(templateMgr template.TemplateManager, taskMgr task.Tas
 
 	// 提交任务
 	fmt.Println("  2. 提交任务")
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		log.Fatalf("Failed to submit task: %v", err)
 	}
@@ -109,7 +112,7 @@ func createAndProcessTask(templateMgr template.TemplateManager, taskMgr task.Tas
 
 	// 设置审批人
 	fmt.Println("  3. 设置审批人")
-	err = taskMgr.AddApprover(tsk.ID, "approval", "manager-001", "设置审批人")
+	err = taskMgr.AddApprover(tsk.ID, "approval", "admin-001", "manager-001", "设置审批人")
 	if err != nil {
 		log.Fatalf("Failed to add approver: %v", err)
 	}
@@ -216,7 +216,7 @@ func runScenario(taskMgr task.TaskManager, templateID string, score int, expectP
 	paramsJSON, _ := json.Marshal(taskParams)
 
 	taskID := fmt.Sprintf("task-%d-%d", time.Now().UnixNano(), score)
-	tsk, err := taskMgr.Create(templateID, fmt.Sprintf("project-%d", score), paramsJSON)
+	tsk, err := taskMgr.CreateBy(templateID, fmt.Sprintf("project-%d", score), "initiator-001", paramsJSON)
 	if err != nil {
 		fmt.Printf("❌ 任务创建失败: %v\n", err)
 		return
@@ -225,7 +225,7 @@ func runScenario(taskMgr task.TaskManager, templateID string, score int, expectP
 	fmt.Printf("✓ 任务创建成功: ID=%s, 评分=%d\n", taskID, score)
 
 	// 2. 提交任务
-	if err := taskMgr.Submit(taskID); err != nil {
+	if err := taskMgr.Submit(taskID, "initiator-001"); err != nil {
 		fmt.Printf("❌ 任务提交失败: %v\n", err)
 		return
 	}
@@ -16,7 +16,10 @@ func main() {
 
 	// 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 创建模板
 	tpl := createTemplate()
@@ -115,7 +118,7 @@ func testConcurrentRead(taskMgr task.TaskManager, templateID string) {
 	paramsJSON, _ := json.Marshal(map[string]interface{}{
 		"test": "concurrent-read",
 	})
-	tsk, err := taskMgr.Create(templateID, "concurrent-read-001", paramsJSON)
+	tsk, err := taskMgr.CreateBy(templateID, "concurrent-read-001", "initiator-001", paramsJSON)
 	if err != nil {
 		fmt.Printf("❌ 任务创建失败: %v\n", err)
 		return
@@ -124,7 +127,7 @@ func testConcurrentRead(taskMgr task.TaskManager, templateID string) {
 	fmt.Printf("✓ 任务创建成功: ID=%s\n", taskID)
 
 	// 提交任务
-	if err := taskMgr.Submit(taskID); err != nil {
+	if err := taskMgr.Submit(taskID, "initiator-001"); err != nil {
 		fmt.Printf("❌ 任务提交失败: %v\n", err)
 		return
 	}
@@ -167,7 +170,7 @@ func testConcurrentUpdate(taskMgr task.TaskManager, templateID string) {
 	paramsJSON, _ := json.Marshal(map[string]interface{}{
 		"test": "concurrent-update",
 	})
-	tsk, err := taskMgr.Create(templateID, "concurrent-update-001", paramsJSON)
+	tsk, err := taskMgr.CreateBy(templateID, "concurrent-update-001", "initiator-001", paramsJSON)
 	if err != nil {
 		fmt.Printf("❌ 任务创建失败: %v\n", err)
 		return
@@ -176,7 +179,7 @@ func testConcurrentUpdate(taskMgr task.TaskManager, templateID string) {
 	fmt.Printf("✓ 任务创建成功: ID=%s\n", taskID)
 
 	// 提交任务
-	if err := taskMgr.Submit(taskID); err != nil {
+	if err := taskMgr.Submit(taskID, "initiator-001"); err != nil {
 		fmt.Printf("❌ 任务提交失败: %v\n", err)
 		return
 	}
@@ -222,7 +225,7 @@ func testConcurrentApproval(taskMgr task.TaskManager, templateID string) {
 	paramsJSON, _ := json.Marshal(map[string]interface{}{
 		"test": "concurrent-approval",
 	})
-	tsk, err := taskMgr.Create(templateID, "concurrent-approval-001", paramsJSON)
+	tsk, err := taskMgr.CreateBy(templateID, "concurrent-approval-001", "initiator-001", paramsJSON)
 	if err != nil {
 		fmt.Printf("❌ 任务创建失败: %v\n", err)
 		return
@@ -231,7 +234,7 @@ func testConcurrentApproval(taskMgr task.TaskManager, templateID string) {
 	fmt.Printf("✓ 任务创建成功: ID=%s\n", taskID)
 
 	// 提交任务
-	if err := taskMgr.Submit(taskID); err != nil {
+	if err := taskMgr.Submit(taskID, "initiator-001"); err != nil {
 		fmt.Printf("❌ 任务提交失败: %v\n", err)
 		return
 	}
@@ -240,7 +243,7 @@ func testConcurrentApproval(taskMgr task.TaskManager, templateID string) {
 	// 设置审批人
 	approvers := []string{"approver-001", "approver-002", "approver-003"}
 	for _, approver := range approvers {
-		if err := taskMgr.AddApprover(taskID, "approval", approver, "并发测试添加审批人"); err != nil {
+		if err := taskMgr.AddApprover(taskID, "approval", "admin-001", approver, "并发测试添加审批人"); err != nil {
 			fmt.Printf("❌ 设置审批人失败: %v\n", err)
 			return
 		}
@@ -289,7 +292,7 @@ func testConcurrentReadAndUpdate(taskMgr task.TaskManager, templateID string) {
 	paramsJSON, _ := json.Marshal(map[string]interface{}{
 		"test": "concurrent-read-update",
 	})
-	tsk, err := taskMgr.Create(templateID, "concurrent-read-update-001", paramsJSON)
+	tsk, err := taskMgr.CreateBy(templateID, "concurrent-read-update-001", "initiator-001", paramsJSON)
 	if err != nil {
 		fmt.Printf("❌ 任务创建失败: %v\n", err)
 		return
@@ -298,7 +301,7 @@ func testConcurrentReadAndUpdate(taskMgr task.TaskManager, templateID string) {
 	fmt.Printf("✓ 任务创建成功: ID=%s\n", taskID)
 
 	// 提交任务
-	if err := taskMgr.Submit(taskID); err != nil {
+	if err := taskMgr.Submit(taskID, "initiator-001"); err != nil {
 		fmt.Printf("❌ 任务提交失败: %v\n", err)
 		return
 	}
@@ -15,7 +15,10 @@ func main() {
 
 	// 创建管理器
 	templateMgr := template.NewTemplateManager()
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	// 未启用流程引擎,由管理员 admin-001 设置节点审批人
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))),
+	)
 
 	// 创建模板
 	tpl := createTemplate()
@@ -138,7 +141,7 @@ func runScenario(taskMgr task.TaskManager, templateID string) {
 	}
 	paramsJSON, _ := json.Marshal(taskParams)
 
-	tsk, err := taskMgr.Create(templateID, "project-001", paramsJSON)
+	tsk, err := taskMgr.CreateBy(templateID, "project-001", "initiator-001", paramsJSON)
 	if err != nil {
 		fmt.Printf("❌ 任务创建失败: %v\n", err)
 		return
@@ -147,7 +150,7 @@ func runScenario(taskMgr task.TaskManager, templateID string) {
 	fmt.Printf("✓ 任务创建成功: ID=%s\n", taskID)
 
 	// 2. 提交任务
-	if err := taskMgr.Submit(taskID); err != nil {
+	if err := taskMgr.Submit(taskID, "initiator-001"); err != nil {
 		fmt.Printf("❌ 任务提交失败: %v\n", err)
 		return
 	}
@@ -184,13 +187,13 @@ func runScenario(taskMgr task.TaskManager, templateID string) {
 
 	// 设置审批人
 	fmt.Println("步骤 1: 设置审批人")
-	if err := taskMgr.AddApprover(taskID, "tech-review", "tech-lead-001", "设置技术评审人"); err != nil {
+	if err := taskMgr.AddApprover(taskID, "tech-review", "admin-001", "tech-lead-001", "设置技术评审人"); err != nil {
 		fmt.Printf("❌ 设置技术评审人失败: %v\n", err)
 		return
 	}
 	fmt.Printf("✓ 技术评审人已设置: tech-lead-001\n")
 
-	if err := taskMgr.AddApprover(taskID, "finance-review", "finance-001", "设置财务评审人"); err != nil {
+	if err := taskMgr.AddApprover(taskID, "finance-review", "admin-001", "finance-001", "设置财务评审人"); err != nil {
 		fmt.Printf("❌ 设置财务评审人失败: %v\n", err)
 		return
 	}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mautops/approval-kit/pkg/approvalkit"
+	"github.com/mautops/approval-kit/pkg/event"
+	"github.com/mautops/approval-kit/pkg/node"
+	"github.com/mautops/approval-kit/pkg/task"
+	"github.com/mautops/approval-kit/pkg/template"
+	"github.com/mautops/approval-kit/pkg/types"
+)
+
+// logHandler 打印事件的事件处理器
+type logHandler struct{}
+
+func (h *logHandler) Handle(ctx context.Context, evt *event.Event) error {
+	nodeID := ""
+	if evt.Node != nil {
+		nodeID = evt.Node.ID
+	}
+	fmt.Printf("  [事件] %s node=%s\n", evt.Type, nodeID)
+	return nil
+}
+
+func main() {
+	fmt.Println("=== 场景 26: 通过 approvalkit 构建多级审批流程 ===")
+	fmt.Println()
+
+	// 1. 创建审批工具包(默认启用流程引擎)
+	kit := approvalkit.New(
+		approvalkit.WithEventHandlers(&logHandler{}),
+	)
+	defer kit.Close()
+
+	// 2. 创建模板
+	fmt.Println("步骤 1: 创建审批模板")
+	tpl := createTemplate()
+	if err := kit.Templates().Create(tpl); err != nil {
+		log.Fatalf("Failed to create template: %v", err)
+	}
+	fmt.Printf("✓ 模板创建成功: ID=%s, Name=%s\n\n", tpl.ID, tpl.Name)
+
+	// 3. 创建并提交任务
+	fmt.Println("步骤 2: 创建并提交审批任务")
+	tasks := kit.Tasks()
+	tsk, err := tasks.CreateBy(tpl.ID, "expense-001", "initiator-001", json.RawMessage(`{"amount": 8000}`))
+	if err != nil {
+		log.Fatalf("Failed to create task: %v", err)
+	}
+	if err := tasks.Submit(tsk.ID, "initiator-001"); err != nil {
+		log.Fatalf("Failed to submit task: %v", err)
+	}
+	printTask(tasks, tsk.ID)
+
+	// 4. 逐级审批
+	fmt.Println("步骤 3: 部门经理审批")
+	if err := tasks.Approve(tsk.ID, "manager-approval", "manager-001", "同意"); err != nil {
+		log.Fatalf("Failed to approve: %v", err)
+	}
+	printTask(tasks, tsk.ID)
+
+	fmt.Println("步骤 4: 财务审批(会签)")
+	for _, approver := range []string{"finance-001", "finance-002"} {
+		if err := tasks.Approve(tsk.ID, "finance-approval", approver, "同意"); err != nil {
+			log.Fatalf("Failed to approve: %v", err)
+		}
+	}
+	printTask(tasks, tsk.ID)
+
+	// 等待异步事件输出
+	time.Sleep(100 * time.Millisecond)
+
+	// 5. 验证结果
+	fmt.Println("\n=== 验证结果 ===")
+	tsk, _ = tasks.Get(tsk.ID)
+	if tsk.State == types.TaskStateApproved {
+		fmt.Println("✓ 任务已逐级通过审批")
+	} else {
+		fmt.Printf("✗ 任务状态异常: 期望 %s, 实际 %s\n", types.TaskStateApproved, tsk.State)
+	}
+	fmt.Printf("✓ 已完成节点: %v\n", tsk.CompletedNodes)
+}
+
+// createTemplate 创建报销审批模板
+// 流程: 开始节点 → 部门经理审批(单人审批) → 财务审批(多人会签) → 结束节点
+func createTemplate() *template.Template {
+	now := time.Now()
+	return &template.Template{
+		ID:          "expense-approval-template",
+		Name:        "报销审批模板",
+		Description: "部门经理审批通过后进入财务会签",
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Nodes: map[string]*template.Node{
+			"start": {
+				ID:   "start",
+				Name: "开始",
+				Type: template.NodeTypeStart,
+			},
+			"manager-approval": {
+				ID:   "manager-approval",
+				Name: "部门经理审批",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode: node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{
+						Approvers: []string{"manager-001"},
+					},
+				},
+			},
+			"finance-approval": {
+				ID:   "finance-approval",
+				Name: "财务审批",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode: node.ApprovalModeUnanimous,
+					ApproverConfig: &node.FixedApproverConfig{
+						Approvers: []string{"finance-001", "finance-002"},
+					},
+				},
+			},
+			"end": {
+				ID:   "end",
+				Name: "结束",
+				Type: template.NodeTypeEnd,
+			},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager-approval"},
+			{From: "manager-approval", To: "finance-approval"},
+			{From: "finance-approval", To: "end"},
+		},
+	}
+}
+
+// printTask 输出任务当前状态和节点
+func printTask(tasks task.TaskManager, id string) {
+	tsk, err := tasks.Get(id)
+	if err != nil {
+		log.Fatalf("Failed to get task: %v", err)
+	}
+	fmt.Printf("✓ State=%s, CurrentNode=%s\n\n", tsk.State, tsk.CurrentNode)
+}
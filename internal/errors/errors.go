@@ -22,10 +22,46 @@ var (
 	// ErrApprovalPending 表示审批待处理
 	ErrApprovalPending = fmt.Errorf("approval pending")
 
+	// ErrApprovalOutOfOrder 表示未按审批顺序审批
+	ErrApprovalOutOfOrder = fmt.Errorf("approval out of order")
+
+	// ErrTemplateNotFound 表示模板或模板版本未找到
+	ErrTemplateNotFound = fmt.Errorf("template not found")
+
+	// ErrTemplateVersionExists 表示模板版本已存在
+	ErrTemplateVersionExists = fmt.Errorf("template version already exists")
+
+	// ErrTaskNotFound 表示任务未找到
+	ErrTaskNotFound = fmt.Errorf("task not found")
+
 	// ErrConcurrentModification 表示并发修改冲突
 	ErrConcurrentModification = fmt.Errorf("concurrent modification")
 
 	// ErrEventPushFailed 表示事件推送失败
 	ErrEventPushFailed = fmt.Errorf("event push failed")
+
+	// ErrReminderRateLimited 表示催办过于频繁
+	ErrReminderRateLimited = fmt.Errorf("reminder rate limited")
+
+	// ErrDelegationNotFound 表示委托规则未找到
+	ErrDelegationNotFound = fmt.Errorf("delegation not found")
+
+	// ErrUserNotFound 表示组织架构中的用户未找到
+	ErrUserNotFound = fmt.Errorf("user not found")
+
+	// ErrDepartmentNotFound 表示组织架构中的部门未找到
+	ErrDepartmentNotFound = fmt.Errorf("department not found")
+
+	// ErrForbidden 表示操作人无权执行操作
+	ErrForbidden = fmt.Errorf("forbidden")
+
+	// ErrOutboxAppendFailed 表示任务已保存,但操作产生的事件写入发件箱失败
+	ErrOutboxAppendFailed = fmt.Errorf("outbox append failed")
+
+	// ErrDeliveryNotFound 表示发件箱中的事件投递记录未找到
+	ErrDeliveryNotFound = fmt.Errorf("delivery not found")
+
+	// ErrInvalidSignature 表示 Webhook 请求签名无效或已过期
+	ErrInvalidSignature = fmt.Errorf("invalid signature")
 )
 
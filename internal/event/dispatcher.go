@@ -0,0 +1,261 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 发件箱投递的默认配置
+const (
+	defaultDispatchInterval = time.Second
+	defaultDispatchBatch    = 100
+	defaultMaxAttempts      = 5
+	defaultRetryBackoff     = time.Second
+)
+
+// outboxHandler 命名的事件处理器
+// 名称用于跟踪每个处理器的投递状态,进程重启后应保持不变
+type outboxHandler struct {
+	name    string
+	handler EventHandler
+}
+
+// OutboxDispatcher 发件箱事件投递器
+// 定期从发件箱读取每个处理器待投递的事件并投递,保证至少一次投递:
+// 投递成功后标记为已投递;失败时按指数退避安排重试,失败次数达到上限后进入死信列表
+// 多个进程同时运行投递器时同一事件可能被重复投递,处理器应使用 Event.ID 做幂等处理
+type OutboxDispatcher struct {
+	outbox      Outbox
+	handlers    []outboxHandler
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+	now         func() time.Time
+	onError     func(delivery *Delivery, err error)
+
+	mu      sync.Mutex
+	started bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+// DispatcherOption 发件箱投递器可选配置
+type DispatcherOption func(*OutboxDispatcher)
+
+// WithOutboxHandler 注册事件处理器
+// name: 处理器名称,用于跟踪投递状态和重放死信,不能重复
+func WithOutboxHandler(name string, handler EventHandler) DispatcherOption {
+	return func(d *OutboxDispatcher) {
+		d.handlers = append(d.handlers, outboxHandler{name: name, handler: handler})
+	}
+}
+
+// WithDispatchInterval 设置轮询发件箱的间隔
+// 未设置时为 1 秒
+func WithDispatchInterval(interval time.Duration) DispatcherOption {
+	return func(d *OutboxDispatcher) {
+		d.interval = interval
+	}
+}
+
+// WithDispatchBatchSize 设置每个处理器每次轮询最多投递的事件数量
+// 未设置时为 100
+func WithDispatchBatchSize(size int) DispatcherOption {
+	return func(d *OutboxDispatcher) {
+		d.batchSize = size
+	}
+}
+
+// WithMaxAttempts 设置每个处理器的最大投递次数,达到后进入死信列表
+// 未设置时为 5
+func WithMaxAttempts(attempts int) DispatcherOption {
+	return func(d *OutboxDispatcher) {
+		d.maxAttempts = attempts
+	}
+}
+
+// WithRetryBackoff 设置第一次重试前的等待时间,之后每次失败加倍
+// 未设置时为 1 秒
+func WithRetryBackoff(backoff time.Duration) DispatcherOption {
+	return func(d *OutboxDispatcher) {
+		d.backoff = backoff
+	}
+}
+
+// WithDispatcherClock 设置投递器使用的当前时间函数
+// 未设置时使用 time.Now
+func WithDispatcherClock(now func() time.Time) DispatcherOption {
+	return func(d *OutboxDispatcher) {
+		d.now = now
+	}
+}
+
+// WithDispatchErrorHandler 设置投递失败时的回调(如记录日志、告警)
+// delivery 为失败后的投递状态,Status 为 dead 时表示已进入死信列表
+func WithDispatchErrorHandler(handler func(delivery *Delivery, err error)) DispatcherOption {
+	return func(d *OutboxDispatcher) {
+		d.onError = handler
+	}
+}
+
+// NewOutboxDispatcher 创建发件箱事件投递器
+// 调用 Start 后在后台定期投递,也可以直接调用 DispatchOnce 投递一轮
+func NewOutboxDispatcher(outbox Outbox, opts ...DispatcherOption) (*OutboxDispatcher, error) {
+	d := &OutboxDispatcher{
+		outbox:      outbox,
+		interval:    defaultDispatchInterval,
+		batchSize:   defaultDispatchBatch,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultRetryBackoff,
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if outbox == nil {
+		return nil, fmt.Errorf("outbox is required")
+	}
+	names := make(map[string]bool, len(d.handlers))
+	for _, h := range d.handlers {
+		if h.name == "" || h.handler == nil {
+			return nil, fmt.Errorf("outbox handler requires a name and a handler")
+		}
+		if names[h.name] {
+			return nil, fmt.Errorf("duplicate outbox handler %q", h.name)
+		}
+		names[h.name] = true
+	}
+	if d.interval <= 0 || d.maxAttempts <= 0 || d.backoff <= 0 {
+		return nil, fmt.Errorf("dispatch interval, max attempts and retry backoff must be greater than 0")
+	}
+	return d, nil
+}
+
+// Start 启动后台投递
+func (d *OutboxDispatcher) Start() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.started {
+		return fmt.Errorf("outbox dispatcher already started")
+	}
+	d.started = true
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	go d.run()
+	return nil
+}
+
+// Stop 停止后台投递并等待正在进行的投递结束
+// 正在进行的投递收到的 ctx 被取消,被取消的投递不计入失败次数,下次启动后重新投递
+// 未启动的投递器调用 Stop 直接返回
+func (d *OutboxDispatcher) Stop() {
+	d.mu.Lock()
+	started := d.started
+	cancel := d.cancel
+	d.mu.Unlock()
+	if !started {
+		return
+	}
+
+	cancel()
+	<-d.done
+}
+
+// Wake 唤醒后台投递立即执行一轮,不等待轮询间隔
+// 例如写入事件后调用以减少投递延迟
+func (d *OutboxDispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run 投递循环
+func (d *OutboxDispatcher) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DispatchOnce(d.ctx); err != nil && d.ctx.Err() == nil && d.onError != nil {
+			d.onError(nil, err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-d.wake:
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// DispatchOnce 为每个处理器投递一轮待投递的事件
+// 返回: 本轮投递成功的数量;读取或更新发件箱失败时返回错误
+func (d *OutboxDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	delivered := 0
+	for _, h := range d.handlers {
+		pending, err := d.outbox.Pending(h.name, d.now(), d.batchSize)
+		if err != nil {
+			return delivered, fmt.Errorf("failed to load pending events for handler %q: %w", h.name, err)
+		}
+
+		for _, p := range pending {
+			if err := ctx.Err(); err != nil {
+				return delivered, err
+			}
+			ok, err := d.deliver(ctx, h, p)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+		}
+	}
+	return delivered, nil
+}
+
+// deliver 投递一个事件并保存投递状态
+// 返回: 是否投递成功;保存投递状态失败时返回错误
+func (d *OutboxDispatcher) deliver(ctx context.Context, h outboxHandler, p *OutboxDelivery) (bool, error) {
+	handleErr := h.handler.Handle(ctx, p.Entry.Event)
+	if handleErr != nil && ctx.Err() != nil {
+		// 投递器停止导致的失败不计入失败次数
+		return false, ctx.Err()
+	}
+
+	now := d.now()
+	delivery := p.Delivery
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+	if handleErr == nil {
+		delivery.Status = DeliveryStatusDelivered
+		delivery.LastError = ""
+		delivery.NextAttemptAt = time.Time{}
+	} else {
+		delivery.LastError = handleErr.Error()
+		if delivery.Attempts >= d.maxAttempts {
+			delivery.Status = DeliveryStatusDead
+			delivery.NextAttemptAt = time.Time{}
+		} else {
+			delivery.Status = DeliveryStatusPending
+			delivery.NextAttemptAt = now.Add(d.backoff << (delivery.Attempts - 1))
+		}
+	}
+
+	if err := d.outbox.UpdateDelivery(delivery); err != nil {
+		return false, fmt.Errorf("failed to update delivery of %q to handler %q: %w", delivery.EntryID, delivery.Handler, err)
+	}
+	if handleErr != nil && d.onError != nil {
+		d.onError(delivery, handleErr)
+	}
+	return handleErr == nil, nil
+}
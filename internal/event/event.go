@@ -50,81 +50,111 @@ const (
 
 	// EventTypeNodeCompleted 节点完成事件
 	EventTypeNodeCompleted EventType = "node_completed"
+
+	// EventTypeApproverReminded 审批人催办事件
+	EventTypeApproverReminded EventType = "approver_reminded"
 )
 
 // Event 事件定义
 // 用于通知上层业务系统审批流程中的关键事件
 type Event struct {
 	// ID 事件 ID(用于幂等性保证)
-	ID string
+	ID string `json:"id"`
 
 	// Type 事件类型
-	Type EventType
+	Type EventType `json:"type"`
 
 	// Time 事件时间
-	Time time.Time
+	Time time.Time `json:"time"`
 
 	// Task 任务信息
-	Task *TaskInfo
+	Task *TaskInfo `json:"task"`
 
 	// Node 节点信息(所有事件都包含)
-	Node *NodeInfo
+	Node *NodeInfo `json:"node"`
 
 	// Approval 审批信息(如适用)
-	Approval *ApprovalInfo
+	Approval *ApprovalInfo `json:"approval,omitempty"`
+
+	// Reminder 催办信息(仅催办事件)
+	Reminder *ReminderInfo `json:"reminder,omitempty"`
 
 	// Business 业务信息
-	Business *BusinessInfo
+	Business *BusinessInfo `json:"business"`
 }
 
 // TaskInfo 任务信息
 type TaskInfo struct {
 	// ID 任务 ID
-	ID string
+	ID string `json:"id"`
 
 	// TemplateID 模板 ID
-	TemplateID string
+	TemplateID string `json:"template_id"`
+
+	// TemplateVersion 任务使用的模板版本
+	TemplateVersion int `json:"template_version"`
 
 	// BusinessID 业务 ID
-	BusinessID string
+	BusinessID string `json:"business_id"`
 
 	// State 任务状态
-	State string
+	State string `json:"state"`
 }
 
 // NodeInfo 节点信息
 type NodeInfo struct {
 	// ID 节点 ID
-	ID string
+	ID string `json:"id"`
 
 	// Name 节点名称
-	Name string
+	Name string `json:"name"`
 
 	// Type 节点类型
-	Type string
+	Type string `json:"type"`
 }
 
 // ApprovalInfo 审批信息
 type ApprovalInfo struct {
 	// NodeID 节点 ID
-	NodeID string
+	NodeID string `json:"node_id"`
 
 	// Approver 审批人
-	Approver string
+	Approver string `json:"approver"`
+
+	// OnBehalfOf 原审批人(代理审批时为委托人,否则为空)
+	OnBehalfOf string `json:"on_behalf_of,omitempty"`
 
 	// Result 审批结果(approve/reject/transfer)
-	Result string
+	Result string `json:"result"`
 
 	// Comment 审批意见
-	Comment string
+	Comment string `json:"comment"`
+}
+
+// ReminderInfo 催办信息
+type ReminderInfo struct {
+	// NodeID 节点 ID
+	NodeID string `json:"node_id"`
+
+	// Actor 催办发起人(自动催办时为 system)
+	Actor string `json:"actor"`
+
+	// Approvers 被催办的审批人
+	Approvers []string `json:"approvers"`
+
+	// Message 催办消息
+	Message string `json:"message"`
+
+	// Automatic 是否为按节点配置自动发送的催办
+	Automatic bool `json:"automatic"`
 }
 
 // BusinessInfo 业务信息
 type BusinessInfo struct {
 	// ID 业务 ID
-	ID string
+	ID string `json:"id"`
 
 	// Data 业务数据(JSON 格式)
-	Data json.RawMessage
+	Data json.RawMessage `json:"data,omitempty"`
 }
 
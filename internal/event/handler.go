@@ -1,11 +1,14 @@
 package event
 
+import "context"
+
 // EventHandler 事件处理器接口
 // 用于处理审批流程中的事件通知
 type EventHandler interface {
 	// Handle 处理事件
+	// ctx: 上下文,事件通知器停止时取消,处理器应尽快返回
 	// evt: 事件对象
 	// 返回: 错误信息
-	Handle(evt *Event) error
+	Handle(ctx context.Context, evt *Event) error
 }
 
@@ -1,39 +1,150 @@
 package event
 
 import (
+	"context"
 	"errors"
+	"hash/fnv"
 	"log"
 	"sync"
 	"time"
 )
 
+// defaultNotifierWorkers 事件通知器默认的推送 worker 数量
+const defaultNotifierWorkers = 8
+
 // EventNotifier 事件通知器
-// 使用 channel 和 goroutine 实现异步事件推送,不阻塞主流程
+// 使用 channel 和固定数量的 worker 实现异步事件推送,不阻塞主流程
+// 事件按订阅的过滤条件分发给处理器;同一处理器的同一任务的事件总是由同一个 worker 依次推送,
+// 因此处理器按发生顺序收到同一任务的事件(前一个事件推送完成或重试耗尽后才推送下一个)
+// 事件推送与发起操作的请求解耦,处理器收到的 ctx 在通知器停止时取消
 type EventNotifier struct {
-	handlers []EventHandler
-	queue    chan *Event
-	wg       sync.WaitGroup
-	stop     chan struct{}
-	once     sync.Once
+	subscriptions []*Subscription
+	workerCount   int
+	queue         chan *Event
+	workers       []chan delivery
+	wg            sync.WaitGroup
+	stop          chan struct{}
+	once          sync.Once
+	ctx           context.Context    // 传递给处理器的上下文
+	cancel        context.CancelFunc // 停止时取消正在进行的推送和重试
+}
+
+// delivery 分配给 worker 的一次推送
+type delivery struct {
+	subscription *Subscription
+	evt          *Event
+}
+
+// EventFilter 事件过滤条件
+// 各字段之间为"与"关系,同一字段的多个值之间为"或"关系,空字段不限制
+type EventFilter struct {
+	// Types 事件类型
+	Types []EventType
+
+	// TemplateIDs 任务所用模板 ID
+	TemplateIDs []string
+
+	// NodeIDs 事件关联的节点 ID
+	NodeIDs []string
+
+	// Predicate 自定义过滤函数(可选),返回 true 表示推送
+	Predicate func(evt *Event) bool
+}
+
+// Match 判断事件是否满足过滤条件
+// filter 为 nil 时匹配所有事件
+func (f *EventFilter) Match(evt *Event) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Types) > 0 && !containsValue(f.Types, evt.Type) {
+		return false
+	}
+	if len(f.TemplateIDs) > 0 && (evt.Task == nil || !containsValue(f.TemplateIDs, evt.Task.TemplateID)) {
+		return false
+	}
+	if len(f.NodeIDs) > 0 && (evt.Node == nil || !containsValue(f.NodeIDs, evt.Node.ID)) {
+		return false
+	}
+	if f.Predicate != nil && !f.Predicate(evt) {
+		return false
+	}
+	return true
+}
+
+// containsValue 判断列表中是否包含指定值
+func containsValue[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription 事件订阅
+// 处理器只收到满足过滤条件的事件
+type Subscription struct {
+	// Handler 事件处理器
+	Handler EventHandler
+
+	// Filter 过滤条件,为 nil 时订阅所有事件
+	Filter *EventFilter
+}
+
+// NotifierOption 事件通知器可选配置
+type NotifierOption func(*EventNotifier)
+
+// WithSubscription 注册带过滤条件的事件处理器
+// filter 为 nil 时订阅所有事件,与通过 handlers 参数注册相同
+func WithSubscription(handler EventHandler, filter *EventFilter) NotifierOption {
+	return func(n *EventNotifier) {
+		n.subscriptions = append(n.subscriptions, &Subscription{Handler: handler, Filter: filter})
+	}
+}
+
+// WithNotifierWorkers 设置推送 worker 数量(默认 8)
+// worker 数量即同时进行的推送数量上限,不随事件数量增长
+func WithNotifierWorkers(workers int) NotifierOption {
+	return func(n *EventNotifier) {
+		if workers > 0 {
+			n.workerCount = workers
+		}
+	}
 }
 
 // NewEventNotifier 创建新的事件通知器
-// handlers: 事件处理器列表
-// queueSize: 事件队列大小
-func NewEventNotifier(handlers []EventHandler, queueSize int) *EventNotifier {
+// handlers: 事件处理器列表,订阅所有事件
+// queueSize: 事件队列大小,每个 worker 的待推送队列使用相同大小
+func NewEventNotifier(handlers []EventHandler, queueSize int, opts ...NotifierOption) *EventNotifier {
 	if queueSize <= 0 {
 		queueSize = 100 // 默认队列大小
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	notifier := &EventNotifier{
-		handlers: handlers,
-		queue:    make(chan *Event, queueSize),
-		stop:     make(chan struct{}),
+		workerCount: defaultNotifierWorkers,
+		queue:       make(chan *Event, queueSize),
+		stop:        make(chan struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	for _, handler := range handlers {
+		notifier.subscriptions = append(notifier.subscriptions, &Subscription{Handler: handler})
+	}
+	for _, opt := range opts {
+		opt(notifier)
 	}
 
-	// 启动 worker goroutine
+	// 启动分发 goroutine 和推送 worker
+	notifier.workers = make([]chan delivery, notifier.workerCount)
+	for i := range notifier.workers {
+		notifier.workers[i] = make(chan delivery, queueSize)
+		notifier.wg.Add(1)
+		go notifier.worker(notifier.workers[i])
+	}
 	notifier.wg.Add(1)
-	go notifier.worker()
+	go notifier.dispatch()
 
 	return notifier
 }
@@ -46,48 +157,77 @@ func (n *EventNotifier) Notify(evt *Event) {
 		// 事件成功入队
 	default:
 		// 队列满时记录日志,不阻塞
-		log.Printf("event queue full, dropping event: type=%q, task=%q", evt.Type, evt.Task.ID)
+		log.Printf("event queue full, dropping event: type=%q, task=%q", evt.Type, taskID(evt))
 	}
 }
 
-// worker 事件处理 worker
-func (n *EventNotifier) worker() {
+// dispatch 将队列中的事件按订阅分配给 worker
+// worker 的待推送队列满时阻塞,事件在通知器队列中积压,积压满后 Notify 丢弃新事件
+func (n *EventNotifier) dispatch() {
 	defer n.wg.Done()
 
 	for {
 		select {
 		case evt := <-n.queue:
-			n.pushEvent(evt)
+			for i, sub := range n.subscriptions {
+				if !sub.Filter.Match(evt) {
+					continue
+				}
+				select {
+				case n.workers[n.workerIndex(i, evt)] <- delivery{subscription: sub, evt: evt}:
+				case <-n.stop:
+					return
+				}
+			}
 		case <-n.stop:
 			return
 		}
 	}
 }
 
-// pushEvent 推送事件到所有处理器
-func (n *EventNotifier) pushEvent(evt *Event) {
-	for _, handler := range n.handlers {
-		go func(h EventHandler) {
-			if err := n.pushWithRetry(h, evt); err != nil {
-				log.Printf("failed to push event: type=%q, task=%q, error=%v", evt.Type, evt.Task.ID, err)
+// workerIndex 返回推送事件的 worker
+// 同一订阅的同一任务的事件总是分配给同一个 worker,以保证推送顺序
+func (n *EventNotifier) workerIndex(subscription int, evt *Event) int {
+	h := fnv.New32a()
+	h.Write([]byte(taskID(evt)))
+	return int((h.Sum32() + uint32(subscription)) % uint32(len(n.workers)))
+}
+
+// worker 事件推送 worker,依次推送分配给它的事件
+func (n *EventNotifier) worker(deliveries <-chan delivery) {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case d := <-deliveries:
+			if err := n.pushWithRetry(d.subscription.Handler, d.evt); err != nil {
+				log.Printf("failed to push event: type=%q, task=%q, error=%v", d.evt.Type, taskID(d.evt), err)
 			}
-		}(handler)
+		case <-n.stop:
+			return
+		}
 	}
 }
 
 // pushWithRetry 带重试的推送
-// 使用指数退避策略进行重试
+// 使用指数退避策略进行重试,通知器停止后不再重试
 func (n *EventNotifier) pushWithRetry(handler EventHandler, evt *Event) error {
 	maxRetries := 3
 	backoff := time.Second // 初始退避时间
 
 	for i := 0; i < maxRetries; i++ {
-		if err := handler.Handle(evt); err == nil {
+		if err := handler.Handle(n.ctx, evt); err == nil {
 			return nil
 		}
 		if i < maxRetries-1 {
 			// 指数退避
-			time.Sleep(backoff)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-n.ctx.Done():
+				timer.Stop()
+				return n.ctx.Err()
+			}
 			backoff *= 2
 		}
 	}
@@ -95,12 +235,22 @@ func (n *EventNotifier) pushWithRetry(handler EventHandler, evt *Event) error {
 }
 
 // Stop 停止事件通知器
+// 取消正在进行的推送和重试,并等待分发 goroutine 和 worker 退出;尚未推送的事件被丢弃
 func (n *EventNotifier) Stop() {
 	n.once.Do(func() {
+		n.cancel()
 		close(n.stop)
 		n.wg.Wait()
 	})
 }
 
+// taskID 返回事件关联的任务 ID
+func taskID(evt *Event) string {
+	if evt.Task == nil {
+		return ""
+	}
+	return evt.Task.ID
+}
+
 // ErrEventPushFailed 事件推送失败错误
 var ErrEventPushFailed = errors.New("event push failed after retries")
@@ -0,0 +1,225 @@
+package event
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// DeliveryStatus 事件对单个处理器的投递状态
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending 待投递(尚未投递或投递失败等待重试)
+	DeliveryStatusPending DeliveryStatus = "pending"
+
+	// DeliveryStatusDelivered 已投递成功
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+
+	// DeliveryStatusDead 投递失败次数达到上限,进入死信列表
+	DeliveryStatusDead DeliveryStatus = "dead"
+)
+
+// OutboxEntry 发件箱中的事件
+type OutboxEntry struct {
+	// ID 发件箱条目 ID,写入时生成,全局唯一
+	// 与 Event.ID 不同: Event.ID 供下游做幂等处理,条目 ID 用于跟踪投递状态
+	ID string
+
+	// Event 事件
+	Event *Event
+
+	// CreatedAt 写入时间,决定投递顺序(相同时按 ID 排序)
+	CreatedAt time.Time
+}
+
+// outboxEntryCounter 发件箱条目 ID 计数器
+var outboxEntryCounter int64
+
+// NewOutboxEntry 创建发件箱条目
+// at: 写入时间
+func NewOutboxEntry(evt *Event, at time.Time) *OutboxEntry {
+	counter := atomic.AddInt64(&outboxEntryCounter, 1)
+	return &OutboxEntry{
+		ID:        fmt.Sprintf("outbox-%d-%010d", at.UnixNano(), counter),
+		Event:     evt,
+		CreatedAt: at,
+	}
+}
+
+// Delivery 事件对单个处理器的投递状态
+type Delivery struct {
+	// EntryID 发件箱条目 ID
+	EntryID string
+
+	// Handler 处理器名称
+	Handler string
+
+	// Status 投递状态
+	Status DeliveryStatus
+
+	// Attempts 已尝试投递的次数
+	Attempts int
+
+	// LastError 最近一次投递失败的错误信息
+	LastError string
+
+	// NextAttemptAt 下次投递时间(仅 pending),零值表示立即投递
+	NextAttemptAt time.Time
+
+	// UpdatedAt 状态更新时间
+	UpdatedAt time.Time
+}
+
+// OutboxDelivery 发件箱条目及其对某个处理器的投递状态
+type OutboxDelivery struct {
+	Entry    *OutboxEntry
+	Delivery *Delivery
+}
+
+// Outbox 事件发件箱接口
+// 事件先持久化到发件箱,再由 OutboxDispatcher 投递给各处理器(至少一次),
+// 每个处理器的投递状态单独跟踪,失败次数达到上限的投递进入死信列表,可以查看和重放
+type Outbox interface {
+	// Append 写入事件
+	Append(entries ...*OutboxEntry) error
+
+	// Pending 返回处理器待投递的事件: 尚未投递成功、未进入死信且已到投递时间,按写入顺序
+	// 处理器还没有投递记录的事件返回 Attempts 为 0 的 pending 投递状态
+	// limit: 最多返回的数量,小于等于 0 时不限制
+	Pending(handler string, now time.Time, limit int) ([]*OutboxDelivery, error)
+
+	// UpdateDelivery 保存投递状态(不存在时创建)
+	UpdateDelivery(delivery *Delivery) error
+
+	// Deliveries 返回事件在各处理器的投递状态(只包含已有投递记录的处理器),按处理器名称排序
+	Deliveries(entryID string) ([]*Delivery, error)
+
+	// DeadLetters 返回死信列表,按写入顺序
+	DeadLetters() ([]*OutboxDelivery, error)
+
+	// Replay 将死信重新置为待投递,尝试次数清零
+	// 返回: 投递记录不存在或不是死信时返回 ErrDeliveryNotFound
+	Replay(entryID string, handler string) error
+}
+
+// MemoryOutbox 内存实现的事件发件箱
+// 进程重启后数据丢失,适用于测试和单机场景;需要持久化时使用 sqlstore.Outbox
+type MemoryOutbox struct {
+	mu         sync.RWMutex
+	entries    []*OutboxEntry                  // 按写入顺序
+	deliveries map[string]map[string]*Delivery // entryID -> handler -> Delivery
+}
+
+var _ Outbox = (*MemoryOutbox)(nil)
+
+// NewMemoryOutbox 创建内存事件发件箱
+func NewMemoryOutbox() *MemoryOutbox {
+	return &MemoryOutbox{
+		deliveries: make(map[string]map[string]*Delivery),
+	}
+}
+
+// Append 写入事件
+func (o *MemoryOutbox) Append(entries ...*OutboxEntry) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, entry := range entries {
+		stored := *entry
+		o.entries = append(o.entries, &stored)
+	}
+	return nil
+}
+
+// Pending 返回处理器待投递的事件
+func (o *MemoryOutbox) Pending(handler string, now time.Time, limit int) ([]*OutboxDelivery, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var result []*OutboxDelivery
+	for _, entry := range o.entries {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		delivery, exists := o.deliveries[entry.ID][handler]
+		if !exists {
+			delivery = &Delivery{EntryID: entry.ID, Handler: handler, Status: DeliveryStatusPending}
+		} else if delivery.Status != DeliveryStatusPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		entryCopy, deliveryCopy := *entry, *delivery
+		result = append(result, &OutboxDelivery{Entry: &entryCopy, Delivery: &deliveryCopy})
+	}
+	return result, nil
+}
+
+// UpdateDelivery 保存投递状态
+func (o *MemoryOutbox) UpdateDelivery(delivery *Delivery) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.deliveries[delivery.EntryID] == nil {
+		o.deliveries[delivery.EntryID] = make(map[string]*Delivery)
+	}
+	stored := *delivery
+	o.deliveries[delivery.EntryID][delivery.Handler] = &stored
+	return nil
+}
+
+// Deliveries 返回事件在各处理器的投递状态
+func (o *MemoryOutbox) Deliveries(entryID string) ([]*Delivery, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	result := make([]*Delivery, 0, len(o.deliveries[entryID]))
+	for _, delivery := range o.deliveries[entryID] {
+		d := *delivery
+		result = append(result, &d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Handler < result[j].Handler
+	})
+	return result, nil
+}
+
+// DeadLetters 返回死信列表
+func (o *MemoryOutbox) DeadLetters() ([]*OutboxDelivery, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var result []*OutboxDelivery
+	for _, entry := range o.entries {
+		handlers := make([]string, 0, len(o.deliveries[entry.ID]))
+		for handler, delivery := range o.deliveries[entry.ID] {
+			if delivery.Status == DeliveryStatusDead {
+				handlers = append(handlers, handler)
+			}
+		}
+		sort.Strings(handlers)
+		for _, handler := range handlers {
+			entryCopy, deliveryCopy := *entry, *o.deliveries[entry.ID][handler]
+			result = append(result, &OutboxDelivery{Entry: &entryCopy, Delivery: &deliveryCopy})
+		}
+	}
+	return result, nil
+}
+
+// Replay 将死信重新置为待投递
+func (o *MemoryOutbox) Replay(entryID string, handler string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delivery, exists := o.deliveries[entryID][handler]
+	if !exists || delivery.Status != DeliveryStatusDead {
+		return fmt.Errorf("%w: dead letter %q for handler %q", errors.ErrDeliveryNotFound, entryID, handler)
+	}
+	delivery.Status = DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Time{}
+	delivery.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,241 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SchemaVersion 事件 JSON 结构的版本
+// 字段只增不删;删除或修改字段含义时升级版本,接收方按 schema_version 字段选择解析方式
+const SchemaVersion = "1.0"
+
+// Message 序列化后的事件消息
+type Message struct {
+	// Headers 需要随消息发送的请求头(包含 Content-Type)
+	Headers map[string]string
+
+	// Body 消息体
+	Body []byte
+}
+
+// Serializer 事件序列化器
+// Webhook 推送事件时使用,可以替换为自定义格式以便在不影响现有接收方的情况下演进消息结构
+type Serializer interface {
+	// Serialize 将事件序列化为消息
+	Serialize(evt *Event) (*Message, error)
+
+	// Deserialize 从消息中解析事件,供接收方使用
+	// headers 的键不区分大小写
+	Deserialize(headers map[string]string, body []byte) (*Event, error)
+}
+
+// jsonEvent 事件 JSON 结构: 在事件字段之外增加 schema_version
+type jsonEvent struct {
+	SchemaVersion string `json:"schema_version"`
+	*Event
+}
+
+// JSONSerializer 默认的 JSON 序列化器
+// 消息体为事件的 JSON 结构(字段为 snake_case,包含 schema_version),Content-Type 为 application/json
+type JSONSerializer struct{}
+
+// Serialize 将事件序列化为 JSON 消息
+func (JSONSerializer) Serialize(evt *Event) (*Message, error) {
+	body, err := marshalEvent(evt)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    body,
+	}, nil
+}
+
+// Deserialize 从 JSON 消息中解析事件
+func (JSONSerializer) Deserialize(headers map[string]string, body []byte) (*Event, error) {
+	return unmarshalEvent(body)
+}
+
+// marshalEvent 将事件编码为 JSON 结构
+func marshalEvent(evt *Event) ([]byte, error) {
+	data, err := json.Marshal(&jsonEvent{SchemaVersion: SchemaVersion, Event: evt})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalEvent 从 JSON 结构解码事件
+// schema_version 的主版本与 SchemaVersion 不同时返回错误
+func unmarshalEvent(data []byte) (*Event, error) {
+	wire := &jsonEvent{Event: &Event{}}
+	if err := json.Unmarshal(data, wire); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	if wire.SchemaVersion != "" && majorVersion(wire.SchemaVersion) != majorVersion(SchemaVersion) {
+		return nil, fmt.Errorf("unsupported event schema version %q", wire.SchemaVersion)
+	}
+	return wire.Event, nil
+}
+
+// majorVersion 返回版本号的主版本
+func majorVersion(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
+
+// CloudEvents 内容模式
+const (
+	// CloudEventsStructured 结构化模式: 事件属性和数据都在消息体中,Content-Type 为 application/cloudevents+json
+	CloudEventsStructured = "structured"
+
+	// CloudEventsBinary 二进制模式: 事件属性在 ce- 请求头中,消息体为事件数据
+	CloudEventsBinary = "binary"
+)
+
+// CloudEvents 默认属性
+const (
+	// DefaultCloudEventsSource 默认的 source 属性
+	DefaultCloudEventsSource = "approval-kit"
+
+	// DefaultCloudEventsTypePrefix 默认的 type 属性前缀,type 为前缀加事件类型,如 io.approvalkit.task_approved
+	DefaultCloudEventsTypePrefix = "io.approvalkit."
+)
+
+// cloudEventsSpecVersion CloudEvents 规范版本
+const cloudEventsSpecVersion = "1.0"
+
+// CloudEventsSerializer CloudEvents 1.0 序列化器(HTTP 协议绑定)
+// 事件属性: id 为事件 ID,source 为 Source,type 为 TypePrefix 加事件类型,subject 为任务 ID,
+// time 为事件时间,datacontenttype 为 application/json,data 为 JSONSerializer 的消息体
+type CloudEventsSerializer struct {
+	// Mode 内容模式(CloudEventsStructured/CloudEventsBinary),为空时使用结构化模式
+	Mode string
+
+	// Source source 属性,为空时使用 DefaultCloudEventsSource
+	Source string
+
+	// TypePrefix type 属性前缀,为空时使用 DefaultCloudEventsTypePrefix
+	TypePrefix string
+
+	// DataSchema dataschema 属性(可选),描述 data 结构的 URI
+	DataSchema string
+}
+
+// cloudEvent CloudEvents 结构化模式的 JSON 结构
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// Serialize 将事件序列化为 CloudEvents 消息
+func (s CloudEventsSerializer) Serialize(evt *Event) (*Message, error) {
+	data, err := marshalEvent(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	ce := &cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              evt.ID,
+		Source:          s.source(),
+		Type:            s.typePrefix() + string(evt.Type),
+		DataContentType: "application/json",
+		DataSchema:      s.DataSchema,
+		Data:            data,
+	}
+	if evt.Task != nil {
+		ce.Subject = evt.Task.ID
+	}
+	if !evt.Time.IsZero() {
+		ce.Time = evt.Time.UTC().Format(time.RFC3339Nano)
+	}
+
+	switch s.Mode {
+	case "", CloudEventsStructured:
+		body, err := json.Marshal(ce)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal cloud event: %w", err)
+		}
+		return &Message{
+			Headers: map[string]string{"Content-Type": "application/cloudevents+json"},
+			Body:    body,
+		}, nil
+	case CloudEventsBinary:
+		headers := map[string]string{
+			"Content-Type":   ce.DataContentType,
+			"ce-specversion": ce.SpecVersion,
+			"ce-id":          ce.ID,
+			"ce-source":      ce.Source,
+			"ce-type":        ce.Type,
+		}
+		if ce.Subject != "" {
+			headers["ce-subject"] = ce.Subject
+		}
+		if ce.Time != "" {
+			headers["ce-time"] = ce.Time
+		}
+		if ce.DataSchema != "" {
+			headers["ce-dataschema"] = ce.DataSchema
+		}
+		return &Message{Headers: headers, Body: data}, nil
+	default:
+		return nil, fmt.Errorf("unsupported cloud events mode %q", s.Mode)
+	}
+}
+
+// Deserialize 从 CloudEvents 消息中解析事件
+// 按 Content-Type 识别内容模式: application/cloudevents+json 为结构化模式,否则为二进制模式
+func (s CloudEventsSerializer) Deserialize(headers map[string]string, body []byte) (*Event, error) {
+	contentType := headerValue(headers, "Content-Type")
+	if strings.HasPrefix(contentType, "application/cloudevents+json") {
+		ce := &cloudEvent{}
+		if err := json.Unmarshal(body, ce); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cloud event: %w", err)
+		}
+		if ce.SpecVersion != cloudEventsSpecVersion {
+			return nil, fmt.Errorf("unsupported cloud events spec version %q", ce.SpecVersion)
+		}
+		return unmarshalEvent(ce.Data)
+	}
+
+	if specVersion := headerValue(headers, "ce-specversion"); specVersion != cloudEventsSpecVersion {
+		return nil, fmt.Errorf("unsupported cloud events spec version %q", specVersion)
+	}
+	return unmarshalEvent(body)
+}
+
+// source 返回 source 属性
+func (s CloudEventsSerializer) source() string {
+	if s.Source == "" {
+		return DefaultCloudEventsSource
+	}
+	return s.Source
+}
+
+// typePrefix 返回 type 属性前缀
+func (s CloudEventsSerializer) typePrefix() string {
+	if s.TypePrefix == "" {
+		return DefaultCloudEventsTypePrefix
+	}
+	return s.TypePrefix
+}
+
+// headerValue 不区分大小写地读取请求头
+func headerValue(headers map[string]string, key string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
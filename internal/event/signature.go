@@ -0,0 +1,82 @@
+package event
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// Webhook 请求头
+const (
+	// HeaderEventID 事件 ID,接收方用于幂等处理
+	HeaderEventID = "X-Approval-Event-Id"
+
+	// HeaderTimestamp 签名时间(Unix 秒),接收方用于拒绝过期请求,防止重放
+	HeaderTimestamp = "X-Approval-Timestamp"
+
+	// HeaderSignature 签名,格式为 "sha256=<hex>"
+	HeaderSignature = "X-Approval-Signature"
+)
+
+// signaturePrefix 签名的算法前缀
+const signaturePrefix = "sha256="
+
+// DefaultSignatureTolerance 验证签名时允许的默认时间偏差
+const DefaultSignatureTolerance = 5 * time.Minute
+
+// SignWebhook 计算 Webhook 请求签名
+// 签名内容为 "<timestamp>.<body>",使用 key 计算 HMAC-SHA256,返回 "sha256=<hex>"
+func SignWebhook(key string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature 验证 Webhook 请求签名
+// timestamp: HeaderTimestamp 请求头的值
+// signature: HeaderSignature 请求头的值
+// tolerance: 允许的时间偏差,签名时间与 now 相差超过该值时视为重放;小于等于 0 时使用 DefaultSignatureTolerance
+// 返回: 签名无效或已过期时返回 ErrInvalidSignature
+func VerifyWebhookSignature(key string, timestamp string, body []byte, signature string, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp %q", errors.ErrInvalidSignature, timestamp)
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultSignatureTolerance
+	}
+	if skew := now.Sub(time.Unix(ts, 0)); skew > tolerance || skew < -tolerance {
+		return fmt.Errorf("%w: timestamp %d is outside the tolerance of %s", errors.ErrInvalidSignature, ts, tolerance)
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return fmt.Errorf("%w: unsupported signature format", errors.ErrInvalidSignature)
+	}
+	if !hmac.Equal([]byte(signature), []byte(SignWebhook(key, ts, body))) {
+		return fmt.Errorf("%w: signature mismatch", errors.ErrInvalidSignature)
+	}
+	return nil
+}
+
+// VerifyWebhookRequest 验证 Webhook 请求签名并返回请求体
+// 供接收方在 HTTP 处理函数中使用,读取并验证请求体后再解析事件
+// 返回: 请求体;签名无效或已过期时返回 ErrInvalidSignature
+func VerifyWebhookRequest(r *http.Request, key string, tolerance time.Duration) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if err := VerifyWebhookSignature(key, r.Header.Get(HeaderTimestamp), body, r.Header.Get(HeaderSignature), tolerance, time.Now()); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,88 @@
+package event
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// defaultWebhookTimeout 模板 Webhook 请求的默认超时时间
+const defaultWebhookTimeout = 30 * time.Second
+
+// TemplateWebhookHandler 按模板路由的 Webhook 事件处理器
+// 实现 EventHandler 接口,将任务事件推送到任务所用模板版本的 TemplateConfig.Webhooks 中声明的所有地址,
+// 按每个 Webhook 的认证配置携带 Bearer Token 或 HMAC-SHA256 签名
+// 任一地址推送失败时返回错误;与 OutboxDispatcher 一起使用时重试会重新推送到所有地址,
+// 接收方应使用 HeaderEventID 请求头做幂等处理
+type TemplateWebhookHandler struct {
+	templates  template.TemplateManager
+	httpClient *http.Client
+	serializer Serializer
+}
+
+// TemplateWebhookOption 按模板路由的 Webhook 事件处理器可选配置
+type TemplateWebhookOption func(*TemplateWebhookHandler)
+
+// WithWebhookSerializer 设置事件序列化器(如 CloudEventsSerializer)
+// 未设置时使用 JSONSerializer
+func WithWebhookSerializer(serializer Serializer) TemplateWebhookOption {
+	return func(h *TemplateWebhookHandler) {
+		h.serializer = serializer
+	}
+}
+
+// NewTemplateWebhookHandler 创建按模板路由的 Webhook 事件处理器
+// templates: 模板管理器,用于获取任务所用模板版本的 Webhook 配置
+// client: HTTP 客户端(可选),未设置时使用超时时间为 30 秒的默认客户端
+func NewTemplateWebhookHandler(templates template.TemplateManager, client *http.Client, opts ...TemplateWebhookOption) EventHandler {
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	h := &TemplateWebhookHandler{
+		templates:  templates,
+		httpClient: client,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Handle 处理事件(实现 EventHandler 接口)
+// 模板没有配置 Webhook 时直接返回
+func (h *TemplateWebhookHandler) Handle(ctx context.Context, evt *Event) error {
+	if evt.Task == nil || evt.Task.TemplateID == "" {
+		return nil
+	}
+
+	tpl, err := h.templates.Get(evt.Task.TemplateID, evt.Task.TemplateVersion)
+	if err != nil {
+		return fmt.Errorf("failed to get template %q version %d: %w", evt.Task.TemplateID, evt.Task.TemplateVersion, err)
+	}
+	if tpl.Config == nil || len(tpl.Config.Webhooks) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, webhook := range tpl.Config.Webhooks {
+		config := webhookConfig(webhook)
+		config.Serializer = h.serializer
+		if err := postWebhook(ctx, h.httpClient, config, evt); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %q: %w", webhook.URL, err))
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// webhookConfig 将模板中的 Webhook 配置转换为事件推送使用的配置
+func webhookConfig(w *template.WebhookConfig) *WebhookConfig {
+	config := &WebhookConfig{URL: w.URL, Method: w.Method, Headers: w.Headers}
+	if w.Auth != nil {
+		config.Auth = &WebhookAuth{Type: w.Auth.Type, Token: w.Auth.Token, Key: w.Auth.Key}
+	}
+	return config
+}
@@ -2,10 +2,11 @@ package event
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -22,6 +23,34 @@ type WebhookConfig struct {
 
 	// Timeout 超时时间(秒,默认 30)
 	Timeout int
+
+	// Auth 认证配置(可选)
+	Auth *WebhookAuth
+
+	// Serializer 事件序列化器(可选),未设置时使用 JSONSerializer
+	Serializer Serializer
+}
+
+// Webhook 认证类型
+const (
+	// WebhookAuthToken 在 Authorization 请求头中携带 Bearer Token
+	WebhookAuthToken = "token"
+
+	// WebhookAuthSignature 使用 Key 对时间戳和请求体计算 HMAC-SHA256 签名,
+	// 签名和时间戳分别放在 HeaderSignature 和 HeaderTimestamp 请求头中,接收方使用 VerifyWebhookRequest 验证
+	WebhookAuthSignature = "signature"
+)
+
+// WebhookAuth Webhook 认证配置
+type WebhookAuth struct {
+	// Type 认证类型(WebhookAuthToken/WebhookAuthSignature)
+	Type string
+
+	// Token Bearer Token(仅 token)
+	Token string
+
+	// Key 签名密钥(仅 signature)
+	Key string
 }
 
 // WebhookHandler Webhook 事件处理器
@@ -49,27 +78,58 @@ func NewWebhookHandler(config *WebhookConfig) EventHandler {
 }
 
 // Handle 处理事件(实现 EventHandler 接口)
-func (h *WebhookHandler) Handle(evt *Event) error {
-	// 序列化事件为 JSON
-	data, err := json.Marshal(evt)
+// 请求在 ctx 取消或达到配置的超时时间时中止
+func (h *WebhookHandler) Handle(ctx context.Context, evt *Event) error {
+	return postWebhook(ctx, h.httpClient, h.config, evt)
+}
+
+// postWebhook 使用配置的序列化器序列化事件并推送到 Webhook URL
+// 按认证配置设置 Bearer Token 或 HMAC-SHA256 签名(签名内容为消息体),响应状态不是 2xx 时返回错误
+func postWebhook(ctx context.Context, client *http.Client, config *WebhookConfig, evt *Event) error {
+	serializer := config.Serializer
+	if serializer == nil {
+		serializer = JSONSerializer{}
+	}
+	msg, err := serializer.Serialize(evt)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		return err
+	}
+	data := msg.Body
+
+	method := config.Method
+	if method == "" {
+		method = "POST"
 	}
 
 	// 创建 HTTP 请求
-	req, err := http.NewRequest(h.config.Method, h.config.URL, bytes.NewReader(data))
+	req, err := http.NewRequestWithContext(ctx, method, config.URL, bytes.NewReader(data))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// 设置请求头
-	req.Header.Set("Content-Type", "application/json")
-	for k, v := range h.config.Headers {
+	for k, v := range msg.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(HeaderEventID, evt.ID)
+	for k, v := range config.Headers {
 		req.Header.Set(k, v)
 	}
+	if auth := config.Auth; auth != nil {
+		switch auth.Type {
+		case WebhookAuthToken:
+			req.Header.Set("Authorization", "Bearer "+auth.Token)
+		case WebhookAuthSignature:
+			timestamp := time.Now().Unix()
+			req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+			req.Header.Set(HeaderSignature, SignWebhook(auth.Key, timestamp, data))
+		default:
+			return fmt.Errorf("unsupported webhook auth type %q", auth.Type)
+		}
+	}
 
 	// 发送请求
-	resp, err := h.httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
@@ -84,3 +144,17 @@ func (h *WebhookHandler) Handle(evt *Event) error {
 	return nil
 }
 
+
+// DecodeWebhookEvent 从 Webhook 请求中解析事件,供接收方使用
+// body: 请求体(使用 VerifyWebhookRequest 验证签名时为其返回值)
+// serializer: 与发送方一致的序列化器,为 nil 时使用 JSONSerializer
+func DecodeWebhookEvent(r *http.Request, body []byte, serializer Serializer) (*Event, error) {
+	if serializer == nil {
+		serializer = JSONSerializer{}
+	}
+	headers := make(map[string]string, len(r.Header))
+	for k := range r.Header {
+		headers[k] = r.Header.Get(k)
+	}
+	return serializer.Deserialize(headers, body)
+}
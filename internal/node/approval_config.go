@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -27,15 +28,24 @@ type ApprovalNodeConfig struct {
 
 	// 比例会签配置(仅用于 ApprovalModeProportional)
 	ProportionalThreshold *ProportionalThreshold // 比例阈值
+
+	// 超时策略(可选),设置后按策略依次执行超时动作,优先于 Timeout
+	TimeoutPolicy *TimeoutPolicy
+
+	// 自动催办配置(可选),设置后节点激活后按间隔自动催办尚未审批的审批人
+	ReminderPolicy *ReminderPolicy
+
+	// 审批人去重和跳过规则(可选),优先于模板全局配置的规则
+	SkipPolicy *template.SkipPolicy
 }
 
 // ProportionalThreshold 比例会签阈值配置
 type ProportionalThreshold struct {
 	// Required 需要同意的审批人数量
-	Required int
+	Required int `json:"required"`
 
 	// Total 总审批人数量
-	Total int
+	Total int `json:"total"`
 }
 
 // NodeType 返回节点类型(实现 NodeConfig 接口)
@@ -44,7 +54,15 @@ func (c *ApprovalNodeConfig) NodeType() template.NodeType {
 }
 
 // Validate 验证配置的有效性(实现 NodeConfig 接口)
+// 只接受内置的审批模式,使用自定义审批模式时请使用 ValidateWithRegistry
 func (c *ApprovalNodeConfig) Validate() error {
+	return c.ValidateWithRegistry(nil)
+}
+
+// ValidateWithRegistry 使用审批模式处理器注册表验证配置的有效性
+// registry 中注册了处理器的审批模式(包括自定义模式)均视为有效
+// registry 为 nil 时只接受内置的审批模式
+func (c *ApprovalNodeConfig) ValidateWithRegistry(registry ApprovalModeHandlerRegistry) error {
 	// 验证审批模式
 	if c.Mode == "" {
 		return fmt.Errorf("%w: approval mode is required", errors.ErrInvalidTemplate)
@@ -58,7 +76,7 @@ func (c *ApprovalNodeConfig) Validate() error {
 		ApprovalModeProportional: true,
 		ApprovalModeSequential:   true,
 	}
-	if !validModes[c.Mode] {
+	if !validModes[c.Mode] && (registry == nil || registry.GetHandler(c.Mode) == nil) {
 		return fmt.Errorf("%w: invalid approval mode: %q", errors.ErrInvalidTemplate, c.Mode)
 	}
 
@@ -66,6 +84,11 @@ func (c *ApprovalNodeConfig) Validate() error {
 	if c.ApproverConfig == nil {
 		return fmt.Errorf("%w: approver config is required", errors.ErrInvalidTemplate)
 	}
+	if validator, ok := c.ApproverConfig.(approverConfigValidator); ok {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	}
 
 	// 验证比例会签配置(如果使用比例会签模式)
 	if c.Mode == ApprovalModeProportional {
@@ -95,6 +118,27 @@ func (c *ApprovalNodeConfig) Validate() error {
 		return fmt.Errorf("%w: timeout must be greater than 0", errors.ErrInvalidTemplate)
 	}
 
+	// 验证超时策略
+	if c.TimeoutPolicy != nil {
+		if err := c.TimeoutPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// 验证自动催办配置
+	if c.ReminderPolicy != nil {
+		if err := c.ReminderPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// 验证跳过规则
+	if c.SkipPolicy != nil {
+		if err := c.SkipPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -128,6 +172,11 @@ func (c *ApprovalNodeConfig) GetRejectTargetNode() string {
 	return c.RejectTargetNode
 }
 
+// GetSkipPolicy 返回跳过规则(实现 SkipPolicyAccessor 接口)
+func (c *ApprovalNodeConfig) GetSkipPolicy() *template.SkipPolicy {
+	return c.SkipPolicy
+}
+
 // permissionsAccessor 权限访问器,实现 OperationPermissionsAccessor 接口
 type permissionsAccessor struct {
 	perms OperationPermissions
@@ -165,21 +214,22 @@ const (
 // OperationPermissions 操作权限配置
 type OperationPermissions struct {
 	// AllowTransfer 允许转交审批
-	AllowTransfer bool
+	AllowTransfer bool `json:"allow_transfer,omitempty"`
 
 	// AllowAddApprover 允许加签
-	AllowAddApprover bool
+	AllowAddApprover bool `json:"allow_add_approver,omitempty"`
 
 	// AllowRemoveApprover 允许减签
-	AllowRemoveApprover bool
+	AllowRemoveApprover bool `json:"allow_remove_approver,omitempty"`
 }
 
 // ApproverConfig 审批人配置接口
 type ApproverConfig interface {
 	// GetApprovers 获取审批人列表
-	// ctx: 节点执行上下文
+	// ctx: 请求上下文,取消或超时后应尽快返回
+	// nc: 节点执行上下文
 	// 返回: 审批人 ID 列表和错误信息
-	GetApprovers(ctx *NodeContext) ([]string, error)
+	GetApprovers(ctx context.Context, nc *NodeContext) ([]string, error)
 
 	// GetTiming 返回获取时机
 	// 返回: 获取时机(任务创建时或节点激活时)
@@ -200,11 +250,11 @@ const (
 // FixedApproverConfig 固定审批人配置
 // 在模板中预设审批人列表
 type FixedApproverConfig struct {
-	Approvers []string // 审批人列表(用户 ID)
+	Approvers []string `json:"approvers"` // 审批人列表(用户 ID)
 }
 
 // GetApprovers 获取审批人列表(实现 ApproverConfig 接口)
-func (c *FixedApproverConfig) GetApprovers(ctx *NodeContext) ([]string, error) {
+func (c *FixedApproverConfig) GetApprovers(ctx context.Context, nc *NodeContext) ([]string, error) {
 	// 返回固定审批人列表的副本
 	result := make([]string, len(c.Approvers))
 	copy(result, c.Approvers)
@@ -0,0 +1,64 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// approvalDecider 基于审批模式处理器注册表的节点完成判断
+type approvalDecider struct {
+	registry ApprovalModeHandlerRegistry
+}
+
+// NewApprovalDecider 创建基于审批模式处理器注册表的节点完成判断
+// 通过 task.WithApprovalDecider 注入未使用流程引擎的任务管理器,使审批模式(包括自定义审批模式)的
+// 顺序约束和完成判断生效;registry 为 nil 时使用只包含内置审批模式的注册表
+func NewApprovalDecider(registry ApprovalModeHandlerRegistry) task.ApprovalDecider {
+	if registry == nil {
+		registry = NewApprovalModeHandlerRegistry()
+	}
+	return &approvalDecider{registry: registry}
+}
+
+// CheckApprover 按审批模式检查审批人当前是否可以审批(实现 task.ApprovalDecider 接口)
+// 审批模式处理器实现了 ApproverOrderChecker 接口时(如顺序审批),按处理器的规则检查
+func (d *approvalDecider) CheckApprover(node *template.Node, approvers []string, approvals map[string]*task.Approval, approver string) error {
+	config, ok := node.Config.(*ApprovalNodeConfig)
+	if !ok {
+		return nil
+	}
+	return checkApproverOrder(d.registry, config, approvers, approvals, approver)
+}
+
+// DecideApproval 按节点的审批模式判断节点是否完成(实现 task.ApprovalDecider 接口)
+func (d *approvalDecider) DecideApproval(node *template.Node, approvers []string, approvals map[string]*task.Approval) (bool, string, error) {
+	config, ok := node.Config.(*ApprovalNodeConfig)
+	if !ok {
+		return false, "", fmt.Errorf("node %q has no approval node config", node.ID)
+	}
+	handler := d.registry.GetHandler(config.Mode)
+	if handler == nil {
+		return false, "", fmt.Errorf("no handler found for approval mode: %q", config.Mode)
+	}
+	completed, result := handler.CheckCompletion(approvers, approvals, config)
+	if !completed || result == nil {
+		return false, "", nil
+	}
+	return true, result.Result, nil
+}
+
+// checkApproverOrder 使用注册表中审批模式的处理器检查审批顺序
+// 处理器未实现 ApproverOrderChecker 接口时不限制顺序
+func checkApproverOrder(registry ApprovalModeHandlerRegistry, config *ApprovalNodeConfig, approvers []string, approvals map[string]*task.Approval, approver string) error {
+	handler := registry.GetHandler(config.Mode)
+	if handler == nil {
+		return fmt.Errorf("no handler found for approval mode: %q", config.Mode)
+	}
+	checker, ok := handler.(ApproverOrderChecker)
+	if !ok {
+		return nil
+	}
+	return checker.CheckOrder(approvers, approvals, approver)
+}
@@ -32,26 +32,41 @@ func (e *ApprovalNodeExecutor) NodeType() template.NodeType {
 
 // Execute 执行审批节点逻辑(实现 NodeExecutor 接口)
 func (e *ApprovalNodeExecutor) Execute(ctx *NodeContext) (*NodeResult, error) {
+	// 未绑定配置时使用节点自身的配置(由流程引擎执行时)
+	config := e.config
+	if config == nil {
+		nodeConfig, ok := ctx.Node.Config.(*ApprovalNodeConfig)
+		if !ok || nodeConfig == nil {
+			return nil, fmt.Errorf("node %q has no approval node config", ctx.Node.ID)
+		}
+		config = nodeConfig
+	}
+
 	// 1. 获取审批人列表
-	approvers, err := e.config.ApproverConfig.GetApprovers(ctx)
-	if err != nil {
-		return nil, err
+	// 优先使用任务中已确定的审批人(创建时/激活时获取,或经过加签、转交等调整)
+	nodeID := ctx.Node.ID
+	approvers := ctx.Task.Approvers[nodeID]
+	if len(approvers) == 0 {
+		var err error
+		approvers, err = config.ApproverConfig.GetApprovers(ctx.Context(), ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// 2. 获取当前节点的审批记录
-	nodeID := ctx.Node.ID
 	approvals, exists := ctx.Task.Approvals[nodeID]
 	if !exists {
 		approvals = make(map[string]*task.Approval)
 	}
 
 	// 3. 根据审批模式检查审批状态
-	handler := e.registry.GetHandler(e.config.Mode)
+	handler := e.registry.GetHandler(config.Mode)
 	if handler == nil {
-		return nil, fmt.Errorf("no handler found for approval mode: %q", e.config.Mode)
+		return nil, fmt.Errorf("no handler found for approval mode: %q", config.Mode)
 	}
 
-	completed, result := handler.CheckCompletion(approvers, approvals, e.config)
+	completed, result := handler.CheckCompletion(approvers, approvals, config)
 
 	// 4. 如果审批未完成,返回错误
 	if !completed {
@@ -1,6 +1,8 @@
 package node
 
 import (
+	"sync"
+
 	"github.com/mautops/approval-kit/internal/task"
 )
 
@@ -18,8 +20,20 @@ type ApprovalModeHandler interface {
 	Mode() ApprovalMode
 }
 
+// ApproverOrderChecker 审批顺序检查接口(可选)
+// 审批模式处理器实现该接口时,流程引擎会在记录审批结果前检查审批人是否可以审批(如顺序审批)
+type ApproverOrderChecker interface {
+	// CheckOrder 检查审批人当前是否可以审批
+	// approvers: 审批人列表
+	// approvals: 当前节点已有的审批结果(审批人 -> 审批结果)
+	// approver: 待审批的审批人
+	// 返回: 不能审批时返回错误
+	CheckOrder(approvers []string, approvals map[string]*task.Approval, approver string) error
+}
+
 // ApprovalModeHandlerRegistry 审批模式处理器注册表
 // 用于管理和查找不同审批模式的处理器
+// 可以注册自定义审批模式的处理器,或替换内置模式的处理器
 type ApprovalModeHandlerRegistry interface {
 	// GetHandler 根据审批模式获取对应的处理器
 	// mode: 审批模式
@@ -33,7 +47,9 @@ type ApprovalModeHandlerRegistry interface {
 }
 
 // approvalModeHandlerRegistry 审批模式处理器注册表实现
+// 并发安全,支持在运行时注册处理器
 type approvalModeHandlerRegistry struct {
+	mu       sync.RWMutex
 	handlers map[ApprovalMode]ApprovalModeHandler
 }
 
@@ -55,11 +71,15 @@ func NewApprovalModeHandlerRegistry() ApprovalModeHandlerRegistry {
 
 // GetHandler 根据审批模式获取对应的处理器
 func (r *approvalModeHandlerRegistry) GetHandler(mode ApprovalMode) ApprovalModeHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	return r.handlers[mode]
 }
 
 // RegisterHandler 注册审批模式处理器
 func (r *approvalModeHandlerRegistry) RegisterHandler(mode ApprovalMode, handler ApprovalModeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.handlers[mode] = handler
 }
 
@@ -1,6 +1,9 @@
 package node
 
 import (
+	"fmt"
+
+	"github.com/mautops/approval-kit/internal/errors"
 	"github.com/mautops/approval-kit/internal/task"
 )
 
@@ -165,6 +168,7 @@ func (h *proportionalModeHandler) CheckCompletion(approvers []string, approvals
 
 	// 统计审批结果
 	approvedCount := 0
+	rejectedCount := 0
 
 	// 检查每个审批人的审批结果
 	for _, approver := range approvers {
@@ -176,8 +180,9 @@ func (h *proportionalModeHandler) CheckCompletion(approvers []string, approvals
 
 		if approval.Result == "approve" {
 			approvedCount++
+		} else if approval.Result == "reject" {
+			rejectedCount++
 		}
-		// 拒绝的数量不需要统计,因为只要达到阈值即可通过
 	}
 
 	// 如果同意的数量达到阈值,审批完成
@@ -189,6 +194,11 @@ func (h *proportionalModeHandler) CheckCompletion(approvers []string, approvals
 		}
 	}
 
+	// 剩余审批人全部同意也无法达到阈值时,根据配置决定行为
+	if len(approvers)-rejectedCount < threshold.Required {
+		return true, createRejectResult(config)
+	}
+
 	// 其他情况(有审批人还未审批或未达到阈值),审批未完成
 	// 比例会签模式下,只要达到阈值即可通过,未达到阈值时继续等待
 	return false, nil
@@ -262,6 +272,21 @@ func (h *sequentialModeHandler) CheckCompletion(approvers []string, approvals ma
 	return false, nil
 }
 
+// CheckOrder 检查审批人是否轮到审批(实现 ApproverOrderChecker 接口)
+// 只有第一个尚未审批的审批人可以审批
+func (h *sequentialModeHandler) CheckOrder(approvers []string, approvals map[string]*task.Approval, approver string) error {
+	for _, current := range approvers {
+		if approval, exists := approvals[current]; exists && approval != nil {
+			continue
+		}
+		if current != approver {
+			return fmt.Errorf("%w: approver %q must wait for %q", errors.ErrApprovalOutOfOrder, approver, current)
+		}
+		return nil
+	}
+	return nil
+}
+
 // createRejectResult 创建拒绝结果
 // 根据拒绝后行为配置决定下一步
 func createRejectResult(config *ApprovalNodeConfig) *ApprovalResult {
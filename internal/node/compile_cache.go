@@ -0,0 +1,66 @@
+package node
+
+import (
+	"container/list"
+	"sync"
+)
+
+// defaultCompileCacheSize 编译结果缓存的默认容量
+const defaultCompileCacheSize = 1024
+
+// compileCache 编译结果缓存(如表达式、JSONPath 路径),键为源文本
+// 容量有限,超出容量时淘汰最久未使用的条目,避免模板不断变化时缓存无限增长
+type compileCache[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List               // 按使用时间排序,最近使用的在前
+	entries  map[string]*list.Element // 源文本 -> order 中的条目
+}
+
+// compileCacheEntry 缓存条目
+type compileCacheEntry[V any] struct {
+	key   string
+	value V
+}
+
+// newCompileCache 创建容量为 capacity 的编译结果缓存
+func newCompileCache[V any](capacity int) *compileCache[V] {
+	return &compileCache[V]{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element, capacity),
+	}
+}
+
+// get 返回源文本的编译结果,不存在时调用 compile 编译并缓存
+// 编译失败时不缓存
+func (c *compileCache[V]) get(key string, compile func(string) (V, error)) (V, error) {
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		value := elem.Value.(*compileCacheEntry[V]).value
+		c.mu.Unlock()
+		return value, nil
+	}
+	c.mu.Unlock()
+
+	value, err := compile(key)
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		// 其他协程已编译同一源文本
+		c.order.MoveToFront(elem)
+		return elem.Value.(*compileCacheEntry[V]).value, nil
+	}
+	c.entries[key] = c.order.PushFront(&compileCacheEntry[V]{key: key, value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*compileCacheEntry[V]).key)
+	}
+	return value, nil
+}
@@ -2,16 +2,17 @@ package node
 
 import (
 	"fmt"
+	"sync"
 )
 
 // CompositeConditionConfig 组合条件配置
 type CompositeConditionConfig struct {
 	// Operator 组合操作符
 	// 支持: "and"(与), "or"(或)
-	Operator string
+	Operator string `json:"operator"`
 
 	// Conditions 子条件列表
-	Conditions []*Condition
+	Conditions []*Condition `json:"conditions"`
 }
 
 // ConditionType 返回条件类型(实现 ConditionConfig 接口)
@@ -19,6 +20,19 @@ func (c *CompositeConditionConfig) ConditionType() string {
 	return "composite"
 }
 
+// Validate 验证所有子条件(子条件的配置实现 Validate 时一并验证,如表达式条件)
+func (c *CompositeConditionConfig) Validate() error {
+	for i, sub := range c.Conditions {
+		if sub == nil {
+			return fmt.Errorf("CompositeConditionConfig.Conditions[%d] is nil", i)
+		}
+		if err := sub.Validate(); err != nil {
+			return fmt.Errorf("CompositeConditionConfig.Conditions[%d] validation failed: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // CompositeConditionEvaluator 组合条件评估器
 type CompositeConditionEvaluator struct {
 	registry *ConditionEvaluatorRegistry
@@ -103,20 +117,27 @@ func (e *CompositeConditionEvaluator) combine(results []bool, operator string) (
 }
 
 // ConditionEvaluatorRegistry 条件评估器注册表
+// 同时保存自定义条件("custom")使用的函数
 type ConditionEvaluatorRegistry struct {
 	evaluators map[string]ConditionEvaluator
+	mu         sync.RWMutex
+	functions  map[string]CustomConditionFunc
 }
 
 // NewConditionEvaluatorRegistry 创建新的条件评估器注册表
 func NewConditionEvaluatorRegistry() *ConditionEvaluatorRegistry {
 	registry := &ConditionEvaluatorRegistry{
 		evaluators: make(map[string]ConditionEvaluator),
+		functions:  make(map[string]CustomConditionFunc),
 	}
 
 	// 注册默认评估器
 	registry.Register(NewNumericConditionEvaluator())
 	registry.Register(NewStringConditionEvaluator())
 	registry.Register(NewEnumConditionEvaluator())
+	registry.Register(NewExprConditionEvaluator())
+	registry.Register(NewCustomConditionEvaluator(registry))
+	registry.Register(NewHTTPConditionEvaluator())
 	// 注册组合条件评估器(支持嵌套,传入已注册基础评估器的 registry)
 	compositeEvaluator := NewCompositeConditionEvaluator(registry)
 	registry.Register(compositeEvaluator)
@@ -127,7 +148,7 @@ func NewConditionEvaluatorRegistry() *ConditionEvaluatorRegistry {
 // Register 注册条件评估器
 func (r *ConditionEvaluatorRegistry) Register(evaluator ConditionEvaluator) {
 	// 注册所有支持的条件类型
-	for _, conditionType := range []string{"numeric", "string", "enum", "composite"} {
+	for _, conditionType := range []string{"numeric", "string", "enum", "expr", "custom", "http", "composite"} {
 		if evaluator.Supports(conditionType) {
 			r.evaluators[conditionType] = evaluator
 		}
@@ -139,3 +160,18 @@ func (r *ConditionEvaluatorRegistry) GetEvaluator(conditionType string) Conditio
 	return r.evaluators[conditionType]
 }
 
+
+// RegisterFunction 注册自定义条件函数
+// 条件类型为 "custom" 的条件按 CustomConditionConfig.Name 调用对应的函数,重复注册同一函数名时覆盖之前的注册
+func (r *ConditionEvaluatorRegistry) RegisterFunction(name string, fn CustomConditionFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.functions[name] = fn
+}
+
+// GetFunction 获取自定义条件函数,未注册时返回 nil
+func (r *ConditionEvaluatorRegistry) GetFunction(name string) CustomConditionFunc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.functions[name]
+}
@@ -6,7 +6,7 @@ import "fmt"
 // 用于条件节点,根据条件结果决定流程走向
 type Condition struct {
 	// Type 条件类型
-	// 支持的类型: "numeric"(数值比较), "string"(字符串匹配), "enum"(枚举判断), "custom"(自定义函数), "composite"(组合条件)
+	// 支持的类型: "numeric"(数值比较), "string"(字符串匹配), "enum"(枚举判断), "expr"(表达式), "custom"(自定义函数), "http"(HTTP 决策服务), "composite"(组合条件)
 	Type string
 
 	// Config 条件配置(根据类型不同而不同)
@@ -28,6 +28,13 @@ func (c *Condition) Validate() error {
 		return fmt.Errorf("Condition.Config.ConditionType() = %q, want %q", c.Config.ConditionType(), c.Type)
 	}
 
+	// 配置实现 Validate 时验证配置(如表达式条件在此编译和类型检查)
+	if validator, ok := c.Config.(interface{ Validate() error }); ok {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -10,7 +10,7 @@ type ConditionEvaluator interface {
 	Evaluate(condition *Condition, ctx *NodeContext) (bool, error)
 
 	// Supports 检查是否支持指定的条件类型
-	// conditionType: 条件类型(如 "numeric", "string", "enum", "custom", "composite")
+	// conditionType: 条件类型(如 "numeric", "string", "enum", "expr", "custom", "http", "composite")
 	// 返回: 是否支持该条件类型
 	Supports(conditionType string) bool
 }
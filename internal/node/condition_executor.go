@@ -3,6 +3,7 @@ package node
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/mautops/approval-kit/internal/template"
 )
@@ -19,6 +20,14 @@ func NewConditionNodeExecutor() NodeExecutor {
 	}
 }
 
+// NewConditionNodeExecutorWithRegistry 使用指定的条件评估器注册表创建条件节点执行器
+// 注册表中注册的自定义条件函数和条件评估器对该执行器生效
+func NewConditionNodeExecutorWithRegistry(registry *ConditionEvaluatorRegistry) NodeExecutor {
+	return &ConditionNodeExecutor{
+		registry: registry,
+	}
+}
+
 // NodeType 返回节点类型(实现 NodeExecutor 接口)
 func (e *ConditionNodeExecutor) NodeType() template.NodeType {
 	return template.NodeTypeCondition
@@ -37,6 +46,11 @@ func (e *ConditionNodeExecutor) Execute(ctx *NodeContext) (*NodeResult, error) {
 		return nil, fmt.Errorf("condition node config validation failed: %w", err)
 	}
 
+	// 排他网关按出边上的条件选择分支
+	if config.IsExclusiveGateway() {
+		return e.executeGateway(ctx)
+	}
+
 	// 3. 获取条件评估器
 	evaluator := e.registry.GetEvaluator(config.Condition.Type)
 	if evaluator == nil {
@@ -57,8 +71,19 @@ func (e *ConditionNodeExecutor) Execute(ctx *NodeContext) (*NodeResult, error) {
 		nextNodeID = config.FalseNodeID
 	}
 
-	// 6. 生成输出数据
+	// 6. 生成输出数据,条件评估过程中记录的数据(如 HTTP 条件的原始响应)一并写入
 	output := json.RawMessage(`{"condition_result": ` + boolToJSON(result) + `, "next_node_id": "` + nextNodeID + `"}`)
+	if len(ctx.conditionOutputs) > 0 {
+		data := make(map[string]interface{}, len(ctx.conditionOutputs)+2)
+		for key, value := range ctx.conditionOutputs {
+			data[key] = value
+		}
+		data["condition_result"] = result
+		data["next_node_id"] = nextNodeID
+		if output, err = json.Marshal(data); err != nil {
+			return nil, fmt.Errorf("failed to marshal condition output: %w", err)
+		}
+	}
 
 	return &NodeResult{
 		NextNodeID: nextNodeID,
@@ -67,6 +92,64 @@ func (e *ConditionNodeExecutor) Execute(ctx *NodeContext) (*NodeResult, error) {
 	}, nil
 }
 
+// executeGateway 执行排他网关
+// 按 Priority 升序(相同时按边的顺序)评估出边条件,第一个为 true 的出边生效,都不满足时使用默认出边
+func (e *ConditionNodeExecutor) executeGateway(ctx *NodeContext) (*NodeResult, error) {
+	if ctx.Template == nil {
+		return nil, fmt.Errorf("exclusive gateway %q requires the template to read outgoing edges", ctx.Node.ID)
+	}
+
+	var edges []*template.Edge
+	var defaultEdge *template.Edge
+	for _, edge := range ctx.Template.Edges {
+		if edge == nil || edge.From != ctx.Node.ID {
+			continue
+		}
+		if edge.Default {
+			defaultEdge = edge
+			continue
+		}
+		edges = append(edges, edge)
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Priority < edges[j].Priority
+	})
+
+	next := defaultEdge
+	for _, edge := range edges {
+		program, err := compileExprCached(edge.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid edge condition %q: %w", edge.Condition, err)
+		}
+		matched, err := program.evaluate(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate edge condition %q: %w", edge.Condition, err)
+		}
+		if matched {
+			next = edge
+			break
+		}
+	}
+	if next == nil {
+		return nil, fmt.Errorf("no edge condition of exclusive gateway %q matched and no default edge is configured", ctx.Node.ID)
+	}
+
+	output, err := json.Marshal(map[string]interface{}{
+		"next_node_id":      next.To,
+		"matched_condition": next.Condition,
+		"default":           next.Default,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal gateway output: %w", err)
+	}
+
+	return &NodeResult{
+		NextNodeID: next.To,
+		Output:     output,
+		Events:     []Event{},
+	}, nil
+}
+
 // boolToJSON 将 bool 转换为 JSON 字符串
 func boolToJSON(b bool) string {
 	if b {
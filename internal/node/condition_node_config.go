@@ -6,17 +6,30 @@ import (
 	"github.com/mautops/approval-kit/internal/template"
 )
 
+// 条件节点模式
+const (
+	// ConditionModeBranch 二分支模式(默认): 根据 Condition 的结果跳转到 TrueNodeID 或 FalseNodeID
+	ConditionModeBranch = "branch"
+
+	// ConditionModeExclusive 排他网关模式: 按 Priority 顺序评估出边上的条件表达式,
+	// 第一个为 true 的出边生效,都不满足时使用默认出边(Edge.Default)
+	ConditionModeExclusive = "exclusive"
+)
+
 // ConditionNodeConfig 条件节点配置
 // 实现 NodeConfig 接口
 type ConditionNodeConfig struct {
-	// Condition 条件定义
-	Condition *Condition
+	// Mode 条件节点模式: "branch"(默认)或 "exclusive"
+	Mode string `json:"mode,omitempty"`
+
+	// Condition 条件定义(二分支模式必填,排他网关模式不使用)
+	Condition *Condition `json:"condition,omitempty"`
 
 	// TrueNodeID 条件为 true 时跳转的节点 ID
-	TrueNodeID string
+	TrueNodeID string `json:"true_node_id,omitempty"`
 
 	// FalseNodeID 条件为 false 时跳转的节点 ID
-	FalseNodeID string
+	FalseNodeID string `json:"false_node_id,omitempty"`
 }
 
 // NodeType 返回节点类型(实现 NodeConfig 接口)
@@ -25,7 +38,19 @@ func (c *ConditionNodeConfig) NodeType() template.NodeType {
 }
 
 // Validate 验证配置的有效性(实现 NodeConfig 接口)
+// 排他网关的出边在模板验证时检查
 func (c *ConditionNodeConfig) Validate() error {
+	switch c.Mode {
+	case "", ConditionModeBranch:
+	case ConditionModeExclusive:
+		if c.Condition != nil || c.TrueNodeID != "" || c.FalseNodeID != "" {
+			return fmt.Errorf("ConditionNodeConfig in exclusive mode uses edge conditions, Condition, TrueNodeID and FalseNodeID must be empty")
+		}
+		return nil
+	default:
+		return fmt.Errorf("ConditionNodeConfig.Mode %q is invalid, must be %q or %q", c.Mode, ConditionModeBranch, ConditionModeExclusive)
+	}
+
 	if c.Condition == nil {
 		return fmt.Errorf("ConditionNodeConfig.Condition is required")
 	}
@@ -45,3 +70,48 @@ func (c *ConditionNodeConfig) Validate() error {
 	return nil
 }
 
+// GetTrueNodeID 返回条件为 true 时跳转的节点 ID(实现 ConditionNodeConfigAccessor 接口)
+func (c *ConditionNodeConfig) GetTrueNodeID() string {
+	return c.TrueNodeID
+}
+
+// GetFalseNodeID 返回条件为 false 时跳转的节点 ID(实现 ConditionNodeConfigAccessor 接口)
+func (c *ConditionNodeConfig) GetFalseNodeID() string {
+	return c.FalseNodeID
+}
+
+// IsExclusiveGateway 返回是否为排他网关模式(实现 ExclusiveGatewayAccessor 接口)
+func (c *ConditionNodeConfig) IsExclusiveGateway() bool {
+	return c.Mode == ConditionModeExclusive
+}
+
+// ValidateEdgeCondition 编译出边上的条件表达式(实现 ExclusiveGatewayAccessor 接口)
+func (c *ConditionNodeConfig) ValidateEdgeCondition(condition string) error {
+	if _, err := compileExprCached(condition); err != nil {
+		return fmt.Errorf("invalid edge condition %q: %w", condition, err)
+	}
+	return nil
+}
+
+// ValidateExpressions 编译条件(含组合条件的子条件)中的表达式(实现 ExpressionAccessor 接口)
+func (c *ConditionNodeConfig) ValidateExpressions() error {
+	return validateConditionExpressions(c.Condition)
+}
+
+// validateConditionExpressions 递归编译条件中的表达式
+func validateConditionExpressions(condition *Condition) error {
+	if condition == nil {
+		return nil
+	}
+	switch config := condition.Config.(type) {
+	case *ExprConditionConfig:
+		return config.Validate()
+	case *CompositeConditionConfig:
+		for _, sub := range config.Conditions {
+			if err := validateConditionExpressions(sub); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// typedConfig 带类型标识的配置
+// 用于序列化接口类型的配置(审批人配置、条件配置),type 决定 config 解码为哪个具体类型
+type typedConfig struct {
+	Type   string          `json:"type"`
+	Config json.RawMessage `json:"config"`
+}
+
+// approvalNodeConfigJSON ApprovalNodeConfig 的 JSON 结构
+type approvalNodeConfigJSON struct {
+	Mode                  ApprovalMode           `json:"mode"`
+	Approver              *typedConfig           `json:"approver,omitempty"`
+	Timeout               string                 `json:"timeout,omitempty"`
+	RejectBehavior        RejectBehavior         `json:"reject_behavior,omitempty"`
+	RejectTargetNode      string                 `json:"reject_target_node,omitempty"`
+	Permissions           OperationPermissions   `json:"permissions"`
+	RequireComment        bool                   `json:"require_comment,omitempty"`
+	RequireAttachments    bool                   `json:"require_attachments,omitempty"`
+	ProportionalThreshold *ProportionalThreshold `json:"proportional_threshold,omitempty"`
+	TimeoutPolicy         *TimeoutPolicy         `json:"timeout_policy,omitempty"`
+	ReminderPolicy        *ReminderPolicy        `json:"reminder_policy,omitempty"`
+	SkipPolicy            *template.SkipPolicy   `json:"skip_policy,omitempty"`
+}
+
+// MarshalJSON 将审批节点配置编码为 JSON
+// 审批人配置编码为 {"type": ..., "config": ...},超时时间编码为 time.Duration 字符串(如 "24h")
+func (c *ApprovalNodeConfig) MarshalJSON() ([]byte, error) {
+	data := approvalNodeConfigJSON{
+		Mode:                  c.Mode,
+		RejectBehavior:        c.RejectBehavior,
+		RejectTargetNode:      c.RejectTargetNode,
+		Permissions:           c.Permissions,
+		RequireComment:        c.RequireCommentField,
+		RequireAttachments:    c.RequireAttachmentsField,
+		ProportionalThreshold: c.ProportionalThreshold,
+		TimeoutPolicy:         c.TimeoutPolicy,
+		ReminderPolicy:        c.ReminderPolicy,
+		SkipPolicy:            c.SkipPolicy,
+	}
+	if c.Timeout != nil {
+		data.Timeout = c.Timeout.String()
+	}
+	if c.ApproverConfig != nil {
+		approverType, err := configTypes.approverType(c.ApproverConfig)
+		if err != nil {
+			return nil, err
+		}
+		config, err := json.Marshal(c.ApproverConfig)
+		if err != nil {
+			return nil, err
+		}
+		data.Approver = &typedConfig{Type: approverType, Config: config}
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON 从 JSON 解码审批节点配置
+func (c *ApprovalNodeConfig) UnmarshalJSON(b []byte) error {
+	var data approvalNodeConfigJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	*c = ApprovalNodeConfig{
+		Mode:                    data.Mode,
+		RejectBehavior:          data.RejectBehavior,
+		RejectTargetNode:        data.RejectTargetNode,
+		Permissions:             data.Permissions,
+		RequireCommentField:     data.RequireComment,
+		RequireAttachmentsField: data.RequireAttachments,
+		ProportionalThreshold:   data.ProportionalThreshold,
+		TimeoutPolicy:           data.TimeoutPolicy,
+		ReminderPolicy:          data.ReminderPolicy,
+		SkipPolicy:              data.SkipPolicy,
+	}
+	if data.Timeout != "" {
+		timeout, err := time.ParseDuration(data.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", data.Timeout, err)
+		}
+		c.Timeout = &timeout
+	}
+	if data.Approver != nil {
+		config, err := configTypes.newApproverConfig(data.Approver.Type)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data.Approver.Config, config); err != nil {
+			return fmt.Errorf("invalid %s approver config: %w", data.Approver.Type, err)
+		}
+		c.ApproverConfig = config
+	}
+	return nil
+}
+
+// timeoutActionJSON TimeoutAction 的 JSON 结构
+type timeoutActionJSON struct {
+	Type       TimeoutActionType `json:"type"`
+	After      string            `json:"after"`
+	EscalateTo []string          `json:"escalate_to,omitempty"`
+}
+
+// MarshalJSON 将超时动作编码为 JSON,触发偏移编码为 time.Duration 字符串(如 "48h")
+func (a TimeoutAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(timeoutActionJSON{
+		Type:       a.Type,
+		After:      a.After.String(),
+		EscalateTo: a.EscalateTo,
+	})
+}
+
+// UnmarshalJSON 从 JSON 解码超时动作
+func (a *TimeoutAction) UnmarshalJSON(b []byte) error {
+	var data timeoutActionJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	after, err := time.ParseDuration(data.After)
+	if err != nil {
+		return fmt.Errorf("invalid timeout action offset %q: %w", data.After, err)
+	}
+	*a = TimeoutAction{
+		Type:       data.Type,
+		After:      after,
+		EscalateTo: data.EscalateTo,
+	}
+	return nil
+}
+
+// reminderPolicyJSON ReminderPolicy 的 JSON 结构
+type reminderPolicyJSON struct {
+	Interval string `json:"interval"`
+	MaxTimes int    `json:"max_times,omitempty"`
+}
+
+// MarshalJSON 将自动催办配置编码为 JSON,催办间隔编码为 time.Duration 字符串(如 "4h")
+func (p ReminderPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(reminderPolicyJSON{
+		Interval: p.Interval.String(),
+		MaxTimes: p.MaxTimes,
+	})
+}
+
+// UnmarshalJSON 从 JSON 解码自动催办配置
+func (p *ReminderPolicy) UnmarshalJSON(b []byte) error {
+	var data reminderPolicyJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	interval, err := time.ParseDuration(data.Interval)
+	if err != nil {
+		return fmt.Errorf("invalid reminder interval %q: %w", data.Interval, err)
+	}
+	*p = ReminderPolicy{
+		Interval: interval,
+		MaxTimes: data.MaxTimes,
+	}
+	return nil
+}
+
+// MarshalJSON 将条件编码为 {"type": ..., "config": ...}
+func (c *Condition) MarshalJSON() ([]byte, error) {
+	data := typedConfig{Type: c.Type}
+	if c.Config != nil {
+		config, err := json.Marshal(c.Config)
+		if err != nil {
+			return nil, err
+		}
+		data.Config = config
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON 从 JSON 解码条件,按 type 解码为对应的条件配置
+func (c *Condition) UnmarshalJSON(b []byte) error {
+	var data typedConfig
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	*c = Condition{Type: data.Type}
+	if len(data.Config) == 0 || string(data.Config) == "null" {
+		return nil
+	}
+	config, err := configTypes.newConditionConfig(data.Type)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data.Config, config); err != nil {
+		return fmt.Errorf("invalid %s condition config: %w", data.Type, err)
+	}
+	c.Config = config
+	return nil
+}
+
+// nodeConfigCodec 节点配置的 JSON 编解码器
+type nodeConfigCodec struct{}
+
+// NewNodeConfigCodec 创建节点配置编解码器
+// 按节点类型从配置类型注册表中查找具体配置类型,支持内置配置和通过 RegisterNodeConfig 注册的配置;
+// 开始节点和结束节点没有配置
+func NewNodeConfigCodec() template.NodeConfigCodec {
+	return &nodeConfigCodec{}
+}
+
+// Encode 将节点配置编码为 JSON,配置为 nil 时返回 nil
+func (c *nodeConfigCodec) Encode(config template.NodeConfig) (json.RawMessage, error) {
+	if config == nil {
+		return nil, nil
+	}
+	return json.Marshal(config)
+}
+
+// Decode 按节点类型将 JSON 解码为节点配置,数据为空时返回 nil
+func (c *nodeConfigCodec) Decode(nodeType template.NodeType, data json.RawMessage) (template.NodeConfig, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	config, err := configTypes.newNodeConfig(nodeType)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid %s node config: %w", nodeType, err)
+	}
+	return config, nil
+}
@@ -0,0 +1,137 @@
+package node
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// configRegistry 配置类型注册表
+// 将序列化时的类型标识映射到具体的配置类型:
+// 节点类型 -> 节点配置,审批人配置类型 -> 审批人配置,条件类型 -> 条件配置
+type configRegistry struct {
+	mu               sync.RWMutex
+	nodeConfigs      map[template.NodeType]func() template.NodeConfig
+	approverConfigs  map[string]func() ApproverConfig
+	approverTypes    map[reflect.Type]string // 审批人配置的具体类型 -> 类型标识,用于编码
+	conditionConfigs map[string]func() ConditionConfig
+}
+
+// configTypes 全局配置类型注册表
+// 解码发生在 json.Unmarshal 的回调中,无法传入注册表实例,因此使用全局注册表
+var configTypes = newConfigRegistry()
+
+// newConfigRegistry 创建注册了内置配置类型的注册表
+func newConfigRegistry() *configRegistry {
+	r := &configRegistry{
+		nodeConfigs:      make(map[template.NodeType]func() template.NodeConfig),
+		approverConfigs:  make(map[string]func() ApproverConfig),
+		approverTypes:    make(map[reflect.Type]string),
+		conditionConfigs: make(map[string]func() ConditionConfig),
+	}
+
+	r.registerNodeConfig(template.NodeTypeApproval, func() template.NodeConfig { return &ApprovalNodeConfig{} })
+	r.registerNodeConfig(template.NodeTypeCondition, func() template.NodeConfig { return &ConditionNodeConfig{} })
+
+	r.registerApproverConfig("fixed", func() ApproverConfig { return &FixedApproverConfig{} })
+	r.registerApproverConfig("dynamic", func() ApproverConfig { return &DynamicApproverConfig{} })
+	r.registerApproverConfig("role", func() ApproverConfig { return &RoleApproverConfig{} })
+	r.registerApproverConfig("department_head", func() ApproverConfig { return &DepartmentHeadApproverConfig{} })
+	r.registerApproverConfig("manager", func() ApproverConfig { return &ManagerApproverConfig{} })
+
+	r.registerConditionConfig("numeric", func() ConditionConfig { return &NumericConditionConfig{} })
+	r.registerConditionConfig("string", func() ConditionConfig { return &StringConditionConfig{} })
+	r.registerConditionConfig("enum", func() ConditionConfig { return &EnumConditionConfig{} })
+	r.registerConditionConfig("expr", func() ConditionConfig { return &ExprConditionConfig{} })
+	r.registerConditionConfig("custom", func() ConditionConfig { return &CustomConditionConfig{} })
+	r.registerConditionConfig("http", func() ConditionConfig { return &HTTPConditionConfig{} })
+	r.registerConditionConfig("composite", func() ConditionConfig { return &CompositeConditionConfig{} })
+	return r
+}
+
+// RegisterNodeConfig 注册节点配置类型
+// nodeType: 节点类型,模板定义中节点的 type 字段
+// factory: 创建空配置的函数,配置需要能够通过 encoding/json 编解码
+// 重复注册同一节点类型时覆盖之前的注册
+func RegisterNodeConfig(nodeType template.NodeType, factory func() template.NodeConfig) {
+	configTypes.registerNodeConfig(nodeType, factory)
+}
+
+// RegisterApproverConfig 注册审批人配置类型
+// approverType: 审批人配置类型标识,模板定义中 approver 的 type 字段
+// factory: 创建空配置的函数,配置需要能够通过 encoding/json 编解码
+func RegisterApproverConfig(approverType string, factory func() ApproverConfig) {
+	configTypes.registerApproverConfig(approverType, factory)
+}
+
+// RegisterConditionConfig 注册条件配置类型
+// conditionType: 条件类型,与配置的 ConditionType() 一致
+// factory: 创建空配置的函数,配置需要能够通过 encoding/json 编解码
+func RegisterConditionConfig(conditionType string, factory func() ConditionConfig) {
+	configTypes.registerConditionConfig(conditionType, factory)
+}
+
+func (r *configRegistry) registerNodeConfig(nodeType template.NodeType, factory func() template.NodeConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nodeConfigs[nodeType] = factory
+}
+
+func (r *configRegistry) registerApproverConfig(approverType string, factory func() ApproverConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.approverConfigs[approverType] = factory
+	r.approverTypes[reflect.TypeOf(factory())] = approverType
+}
+
+func (r *configRegistry) registerConditionConfig(conditionType string, factory func() ConditionConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.conditionConfigs[conditionType] = factory
+}
+
+// newNodeConfig 按节点类型创建空的节点配置
+func (r *configRegistry) newNodeConfig(nodeType template.NodeType) (template.NodeConfig, error) {
+	r.mu.RLock()
+	factory, exists := r.nodeConfigs[nodeType]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("unsupported config for node type %q", nodeType)
+	}
+	return factory(), nil
+}
+
+// newApproverConfig 按类型标识创建空的审批人配置
+func (r *configRegistry) newApproverConfig(approverType string) (ApproverConfig, error) {
+	r.mu.RLock()
+	factory, exists := r.approverConfigs[approverType]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("unsupported approver config type %q", approverType)
+	}
+	return factory(), nil
+}
+
+// approverType 返回审批人配置的类型标识
+func (r *configRegistry) approverType(config ApproverConfig) (string, error) {
+	r.mu.RLock()
+	approverType, exists := r.approverTypes[reflect.TypeOf(config)]
+	r.mu.RUnlock()
+	if !exists {
+		return "", fmt.Errorf("unsupported approver config type %T", config)
+	}
+	return approverType, nil
+}
+
+// newConditionConfig 按条件类型创建空的条件配置
+func (r *configRegistry) newConditionConfig(conditionType string) (ConditionConfig, error) {
+	r.mu.RLock()
+	factory, exists := r.conditionConfigs[conditionType]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("unsupported condition type %q", conditionType)
+	}
+	return factory(), nil
+}
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CustomConditionFunc 自定义条件函数
+// ctx: 节点执行上下文,包含任务参数、节点输出数据等
+// args: 条件配置中的参数(JSON 格式,未配置时为 nil)
+// 返回: 条件评估结果(true/false)和错误信息
+type CustomConditionFunc func(ctx *NodeContext, args json.RawMessage) (bool, error)
+
+// CustomConditionConfig 自定义条件配置
+// 调用通过 ConditionEvaluatorRegistry.RegisterFunction 注册的函数,
+// 模板以数据形式定义时也可以使用业务相关的判断(如 isVIPCustomer)
+type CustomConditionConfig struct {
+	// Name 已注册的自定义条件函数名
+	Name string `json:"name"`
+
+	// Args 传给函数的参数(可选,JSON 格式)
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// ConditionType 返回条件类型(实现 ConditionConfig 接口)
+func (c *CustomConditionConfig) ConditionType() string {
+	return "custom"
+}
+
+// Validate 验证配置的有效性
+// 函数是否已注册在评估时检查(模板验证时没有注册表)
+func (c *CustomConditionConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("CustomConditionConfig.Name is required")
+	}
+	if len(c.Args) > 0 && !json.Valid(c.Args) {
+		return fmt.Errorf("CustomConditionConfig.Args is not valid JSON")
+	}
+	return nil
+}
+
+// CustomConditionEvaluator 自定义条件评估器
+// 从注册表中查找条件配置指定的函数并调用
+type CustomConditionEvaluator struct {
+	registry *ConditionEvaluatorRegistry
+}
+
+// NewCustomConditionEvaluator 创建新的自定义条件评估器
+// registry: 条件评估器注册表,自定义条件函数注册在注册表中
+func NewCustomConditionEvaluator(registry *ConditionEvaluatorRegistry) ConditionEvaluator {
+	return &CustomConditionEvaluator{
+		registry: registry,
+	}
+}
+
+// Supports 检查是否支持指定的条件类型(实现 ConditionEvaluator 接口)
+func (e *CustomConditionEvaluator) Supports(conditionType string) bool {
+	return conditionType == "custom"
+}
+
+// Evaluate 评估自定义条件(实现 ConditionEvaluator 接口)
+func (e *CustomConditionEvaluator) Evaluate(condition *Condition, ctx *NodeContext) (bool, error) {
+	config, ok := condition.Config.(*CustomConditionConfig)
+	if !ok {
+		return false, fmt.Errorf("invalid condition config type for custom condition")
+	}
+
+	fn := e.registry.GetFunction(config.Name)
+	if fn == nil {
+		return false, fmt.Errorf("custom condition function not registered: %q", config.Name)
+	}
+
+	result, err := fn(ctx, config.Args)
+	if err != nil {
+		return false, fmt.Errorf("custom condition %q failed: %w", config.Name, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,186 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// DirectoryUser 组织架构中的用户
+type DirectoryUser struct {
+	// ID 用户 ID
+	ID string
+
+	// Name 用户名称
+	Name string
+
+	// Department 所属部门 ID
+	Department string
+
+	// Manager 直属上级的用户 ID(可选),为空时上级为所属部门的负责人
+	Manager string
+
+	// Roles 用户拥有的角色
+	Roles []string
+}
+
+// Department 组织架构中的部门
+type Department struct {
+	// ID 部门 ID
+	ID string
+
+	// Name 部门名称
+	Name string
+
+	// Head 部门负责人的用户 ID
+	Head string
+
+	// Parent 上级部门 ID,为空时为顶级部门
+	Parent string
+}
+
+// Directory 组织架构目录接口
+// 基于组织架构的审批人配置(角色、部门负责人、上级)通过目录查询审批人,
+// 可以对接企业的用户中心、LDAP 等系统
+type Directory interface {
+	// GetUser 查询用户
+	// 返回: 用户信息,用户不存在时返回 ErrUserNotFound
+	GetUser(ctx context.Context, userID string) (*DirectoryUser, error)
+
+	// GetUsersByRole 查询拥有指定角色的所有用户
+	// 返回: 用户 ID 列表,没有用户拥有该角色时返回空列表
+	GetUsersByRole(ctx context.Context, role string) ([]string, error)
+
+	// GetDepartment 查询部门
+	// 返回: 部门信息,部门不存在时返回 ErrDepartmentNotFound
+	GetDepartment(ctx context.Context, departmentID string) (*Department, error)
+
+	// GetManager 查询用户的直属上级
+	// 返回: 上级的用户 ID,用户没有上级时(如最高负责人)返回空字符串
+	GetManager(ctx context.Context, userID string) (string, error)
+}
+
+// MemoryDirectory 内存实现的组织架构目录
+// 适用于测试和组织架构较小、变化不频繁的场景
+type MemoryDirectory struct {
+	mu          sync.RWMutex
+	users       map[string]*DirectoryUser // userID -> DirectoryUser
+	departments map[string]*Department    // departmentID -> Department
+}
+
+var _ Directory = (*MemoryDirectory)(nil)
+
+// NewMemoryDirectory 创建内存组织架构目录
+func NewMemoryDirectory() *MemoryDirectory {
+	return &MemoryDirectory{
+		users:       make(map[string]*DirectoryUser),
+		departments: make(map[string]*Department),
+	}
+}
+
+// AddUser 添加用户,用户 ID 已存在时替换
+func (d *MemoryDirectory) AddUser(user *DirectoryUser) error {
+	if user == nil || user.ID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	stored := *user
+	stored.Roles = append([]string(nil), user.Roles...)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.users[user.ID] = &stored
+	return nil
+}
+
+// AddDepartment 添加部门,部门 ID 已存在时替换
+func (d *MemoryDirectory) AddDepartment(department *Department) error {
+	if department == nil || department.ID == "" {
+		return fmt.Errorf("department ID is required")
+	}
+
+	stored := *department
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.departments[department.ID] = &stored
+	return nil
+}
+
+// GetUser 查询用户
+func (d *MemoryDirectory) GetUser(ctx context.Context, userID string) (*DirectoryUser, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	user, exists := d.users[userID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", errors.ErrUserNotFound, userID)
+	}
+	result := *user
+	result.Roles = append([]string(nil), user.Roles...)
+	return &result, nil
+}
+
+// GetUsersByRole 查询拥有指定角色的所有用户,按用户 ID 排序
+func (d *MemoryDirectory) GetUsersByRole(ctx context.Context, role string) ([]string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	users := []string{}
+	for _, user := range d.users {
+		for _, r := range user.Roles {
+			if r == role {
+				users = append(users, user.ID)
+				break
+			}
+		}
+	}
+	sort.Strings(users)
+	return users, nil
+}
+
+// GetDepartment 查询部门
+func (d *MemoryDirectory) GetDepartment(ctx context.Context, departmentID string) (*Department, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	department, exists := d.departments[departmentID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", errors.ErrDepartmentNotFound, departmentID)
+	}
+	result := *department
+	return &result, nil
+}
+
+// GetManager 查询用户的直属上级
+// 用户设置了 Manager 时返回 Manager,否则沿部门层级向上查找第一个不是用户本人的部门负责人
+func (d *MemoryDirectory) GetManager(ctx context.Context, userID string) (string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	user, exists := d.users[userID]
+	if !exists {
+		return "", fmt.Errorf("%w: %q", errors.ErrUserNotFound, userID)
+	}
+	if user.Manager != "" {
+		return user.Manager, nil
+	}
+
+	// 沿部门层级向上查找,visited 防止部门层级出现环
+	visited := make(map[string]bool)
+	for departmentID := user.Department; departmentID != "" && !visited[departmentID]; {
+		visited[departmentID] = true
+		department, exists := d.departments[departmentID]
+		if !exists {
+			return "", fmt.Errorf("%w: %q", errors.ErrDepartmentNotFound, departmentID)
+		}
+		if department.Head != "" && department.Head != userID {
+			return department.Head, nil
+		}
+		departmentID = department.Parent
+	}
+	return "", nil
+}
@@ -0,0 +1,252 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// DefaultInitiatorParam 任务没有发起人时,上级审批人配置默认读取发起人的任务参数路径
+const DefaultInitiatorParam = "initiator"
+
+// approverConfigValidator 可以验证自身的审批人配置
+// 审批节点配置验证时调用
+type approverConfigValidator interface {
+	Validate() error
+}
+
+// RoleApproverConfig 角色审批人配置
+// 拥有指定角色的所有用户作为审批人,例如: 所有 finance-approver
+type RoleApproverConfig struct {
+	// Role 角色
+	Role string `json:"role"`
+
+	// Timing 获取时机
+	Timing ApproverTiming `json:"timing,omitempty"`
+
+	// Directory 组织架构目录(依赖注入),为 nil 时使用流程引擎的目录
+	Directory Directory `json:"-"`
+}
+
+// Validate 验证配置的有效性
+func (c *RoleApproverConfig) Validate() error {
+	if c.Role == "" {
+		return fmt.Errorf("%w: role approver config requires role", errors.ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// GetApprovers 获取审批人列表(实现 ApproverConfig 接口)
+func (c *RoleApproverConfig) GetApprovers(ctx context.Context, nc *NodeContext) ([]string, error) {
+	directory, err := resolveDirectory(c.Directory, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers, err := directory.GetUsersByRole(ctx, c.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users with role %q: %w", c.Role, err)
+	}
+	if len(approvers) == 0 {
+		return nil, fmt.Errorf("%w: no user has role %q", errors.ErrApproverNotFound, c.Role)
+	}
+	return approvers, nil
+}
+
+// GetTiming 返回获取时机(实现 ApproverConfig 接口)
+func (c *RoleApproverConfig) GetTiming() ApproverTiming {
+	return timingOrDefault(c.Timing)
+}
+
+// DepartmentHeadApproverConfig 部门负责人审批人配置
+// 部门负责人作为审批人,部门可以在模板中固定,也可以从任务参数中读取(如 params.dept)
+type DepartmentHeadApproverConfig struct {
+	// Department 部门 ID(与 DepartmentParam 二选一)
+	Department string `json:"department,omitempty"`
+
+	// DepartmentParam 部门 ID 在任务参数中的路径(如 "dept" 或 "applicant.dept")
+	DepartmentParam string `json:"department_param,omitempty"`
+
+	// Timing 获取时机
+	Timing ApproverTiming `json:"timing,omitempty"`
+
+	// Directory 组织架构目录(依赖注入),为 nil 时使用流程引擎的目录
+	Directory Directory `json:"-"`
+}
+
+// Validate 验证配置的有效性
+func (c *DepartmentHeadApproverConfig) Validate() error {
+	if (c.Department == "") == (c.DepartmentParam == "") {
+		return fmt.Errorf("%w: department head approver config requires exactly one of department and department_param", errors.ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// GetApprovers 获取审批人列表(实现 ApproverConfig 接口)
+func (c *DepartmentHeadApproverConfig) GetApprovers(ctx context.Context, nc *NodeContext) ([]string, error) {
+	directory, err := resolveDirectory(c.Directory, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	departmentID := c.Department
+	if departmentID == "" {
+		departmentID, err = paramString(nc.Params, c.DepartmentParam)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	department, err := directory.GetDepartment(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	if department.Head == "" {
+		return nil, fmt.Errorf("%w: department %q has no head", errors.ErrApproverNotFound, departmentID)
+	}
+	return []string{department.Head}, nil
+}
+
+// GetTiming 返回获取时机(实现 ApproverConfig 接口)
+func (c *DepartmentHeadApproverConfig) GetTiming() ApproverTiming {
+	return timingOrDefault(c.Timing)
+}
+
+// ManagerApproverConfig 上级审批人配置
+// 沿管理链向上查找用户(默认为发起人)的上级作为审批人,例如: 直属上级、向上第 N 级上级
+type ManagerApproverConfig struct {
+	// UserParam 用户 ID 在任务参数中的路径
+	// 为空时使用任务的发起人(Task.Initiator),任务没有发起人时使用 DefaultInitiatorParam
+	UserParam string `json:"user_param,omitempty"`
+
+	// Levels 向上查找的层级数,为 0 时为 1(直属上级)
+	// 管理链不足 Levels 级时使用最高一级的上级
+	Levels int `json:"levels,omitempty"`
+
+	// Chain 是否返回管理链上的每一级上级(由近及远),而不只是第 Levels 级上级
+	// 通常与顺序审批模式一起使用,实现逐级审批
+	Chain bool `json:"chain,omitempty"`
+
+	// Timing 获取时机
+	Timing ApproverTiming `json:"timing,omitempty"`
+
+	// Directory 组织架构目录(依赖注入),为 nil 时使用流程引擎的目录
+	Directory Directory `json:"-"`
+}
+
+// Validate 验证配置的有效性
+func (c *ManagerApproverConfig) Validate() error {
+	if c.Levels < 0 {
+		return fmt.Errorf("%w: manager approver config levels must not be negative", errors.ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// GetApprovers 获取审批人列表(实现 ApproverConfig 接口)
+func (c *ManagerApproverConfig) GetApprovers(ctx context.Context, nc *NodeContext) ([]string, error) {
+	directory, err := resolveDirectory(c.Directory, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := c.userID(nc)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := c.Levels
+	if levels == 0 {
+		levels = 1
+	}
+
+	// visited 防止管理链出现环
+	var chain []string
+	visited := map[string]bool{userID: true}
+	current := userID
+	for len(chain) < levels {
+		manager, err := directory.GetManager(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get manager of %q: %w", current, err)
+		}
+		if manager == "" || visited[manager] {
+			break
+		}
+		visited[manager] = true
+		chain = append(chain, manager)
+		current = manager
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("%w: user %q has no manager", errors.ErrApproverNotFound, userID)
+	}
+	if c.Chain {
+		return chain, nil
+	}
+	return chain[len(chain)-1:], nil
+}
+
+// GetTiming 返回获取时机(实现 ApproverConfig 接口)
+func (c *ManagerApproverConfig) GetTiming() ApproverTiming {
+	return timingOrDefault(c.Timing)
+}
+
+// userID 返回查找上级的用户 ID
+// 设置了 UserParam 时从任务参数读取;否则使用任务的发起人,任务没有发起人时从 DefaultInitiatorParam 读取
+func (c *ManagerApproverConfig) userID(nc *NodeContext) (string, error) {
+	if c.UserParam == "" && nc.Task != nil && nc.Task.Initiator != "" {
+		return nc.Task.Initiator, nil
+	}
+	userParam := c.UserParam
+	if userParam == "" {
+		userParam = DefaultInitiatorParam
+	}
+	return paramString(nc.Params, userParam)
+}
+
+// resolveDirectory 返回审批人配置使用的组织架构目录
+// 配置中注入的目录优先,其次是节点上下文中的目录
+func resolveDirectory(directory Directory, nc *NodeContext) (Directory, error) {
+	if directory != nil {
+		return directory, nil
+	}
+	if nc != nil && nc.Directory != nil {
+		return nc.Directory, nil
+	}
+	return nil, fmt.Errorf("directory is required for organization-based approver config")
+}
+
+// timingOrDefault 返回获取时机,未设置时为节点激活时
+func timingOrDefault(timing ApproverTiming) ApproverTiming {
+	if timing == "" {
+		return ApproverTimingOnActivate
+	}
+	return timing
+}
+
+// paramString 从任务参数中按路径读取字符串值,数值转换为字符串
+func paramString(params json.RawMessage, path string) (string, error) {
+	var data map[string]interface{}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &data); err != nil {
+			return "", fmt.Errorf("failed to parse task params: %w", err)
+		}
+	}
+
+	value, err := getValueByPath(data, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read task param: %w", err)
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return v, nil
+		}
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("task param %q must be a non-empty string, got %v", path, value)
+}
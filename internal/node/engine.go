@@ -0,0 +1,337 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// FlowEngine 流程引擎
+// 实现 task.FlowEngine 接口: 节点完成后执行已注册的节点执行器,
+// 并沿模板的边推进流程,直到到达等待审批的节点或结束节点
+type FlowEngine struct {
+	executors   map[template.NodeType]NodeExecutor
+	registry    ApprovalModeHandlerRegistry
+	conditions  *ConditionEvaluatorRegistry
+	httpClient  HTTPClient
+	delegations task.DelegationStore
+	directory   Directory
+}
+
+// FlowEngineOption 流程引擎可选配置
+type FlowEngineOption func(*FlowEngine)
+
+// WithNodeExecutor 注册节点执行器
+// 同一节点类型只保留最后注册的执行器,可用于替换默认执行器
+func WithNodeExecutor(executor NodeExecutor) FlowEngineOption {
+	return func(e *FlowEngine) {
+		e.executors[executor.NodeType()] = executor
+	}
+}
+
+// WithApprovalModeRegistry 设置审批模式处理器注册表
+// 审批节点根据节点配置的审批模式从注册表中查找处理器判断审批是否完成,
+// 可以通过注册表扩展自定义审批模式
+func WithApprovalModeRegistry(registry ApprovalModeHandlerRegistry) FlowEngineOption {
+	return func(e *FlowEngine) {
+		e.registry = registry
+	}
+}
+
+// WithConditionEvaluatorRegistry 设置条件评估器注册表
+// 默认的条件节点执行器从注册表中查找条件评估器,
+// 可以通过注册表注册自定义条件函数(RegisterFunction)或自定义条件评估器
+func WithConditionEvaluatorRegistry(registry *ConditionEvaluatorRegistry) FlowEngineOption {
+	return func(e *FlowEngine) {
+		e.conditions = registry
+	}
+}
+
+// WithHTTPClient 设置 HTTP 客户端
+// 用于节点激活时获取未配置 HTTPClient 的动态审批人,以及评估未配置 HTTPClient 的 HTTP 条件
+func WithHTTPClient(client HTTPClient) FlowEngineOption {
+	return func(e *FlowEngine) {
+		e.httpClient = client
+	}
+}
+
+// WithDelegationStore 设置委托规则存储
+// 节点激活时获取的审批人中有生效委托规则的,替换为代理人
+func WithDelegationStore(store task.DelegationStore) FlowEngineOption {
+	return func(e *FlowEngine) {
+		e.delegations = store
+	}
+}
+
+// WithDirectory 设置组织架构目录
+// 用于获取未注入目录的角色、部门负责人、上级审批人
+func WithDirectory(directory Directory) FlowEngineOption {
+	return func(e *FlowEngine) {
+		e.directory = directory
+	}
+}
+
+// NewFlowEngine 创建新的流程引擎
+// 默认注册开始、审批、条件、结束节点执行器,未通过 WithNodeExecutor 替换的节点类型使用默认执行器
+func NewFlowEngine(opts ...FlowEngineOption) *FlowEngine {
+	e := &FlowEngine{
+		executors: make(map[template.NodeType]NodeExecutor),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.registry == nil {
+		e.registry = NewApprovalModeHandlerRegistry()
+	}
+	if e.conditions == nil {
+		e.conditions = NewConditionEvaluatorRegistry()
+	}
+
+	// 注册默认执行器
+	// 审批节点执行器不绑定配置,执行时使用节点自身的 ApprovalNodeConfig
+	defaults := []NodeExecutor{
+		NewStartNodeExecutor(),
+		&ApprovalNodeExecutor{registry: e.registry},
+		&ConditionNodeExecutor{registry: e.conditions},
+		NewEndNodeExecutor(),
+	}
+	for _, executor := range defaults {
+		if _, exists := e.executors[executor.NodeType()]; !exists {
+			e.executors[executor.NodeType()] = executor
+		}
+	}
+
+	return e
+}
+
+// CheckApprover 检查审批人当前是否可以对节点进行审批(实现 task.FlowEngine 接口)
+// 审批模式处理器实现了 ApproverOrderChecker 接口时(如顺序审批),按处理器的规则检查
+func (e *FlowEngine) CheckApprover(tpl *template.Template, tsk *task.Task, nodeID string, approver string) error {
+	tplNode, exists := tpl.Nodes[nodeID]
+	if !exists {
+		return fmt.Errorf("%w: %q", errors.ErrNodeNotFound, nodeID)
+	}
+
+	config, ok := tplNode.Config.(*ApprovalNodeConfig)
+	if !ok {
+		return nil
+	}
+	return checkApproverOrder(e.registry, config, tsk.Approvers[nodeID], tsk.Approvals[nodeID], approver)
+}
+
+// Advance 从指定节点开始推进流程(实现 task.FlowEngine 接口)
+// 依次执行节点: 节点完成后记录输出并沿边进入下一个节点;
+// 审批节点等待审批或被拒绝时停止,到达结束节点时流程完成
+// ctx 传递给节点执行器和审批人获取,ctx 取消或超时后停止推进
+func (e *FlowEngine) Advance(ctx context.Context, tpl *template.Template, tsk *task.Task, nodeID string) (*task.FlowResult, error) {
+	result := &task.FlowResult{}
+	cache := NewContextCache()
+
+	if tsk.NodeOutputs == nil {
+		tsk.NodeOutputs = make(map[string]json.RawMessage)
+	}
+
+	// 每个节点最多经过一次,超过节点数量说明自动节点之间存在环
+	current := nodeID
+	for step := 0; step <= len(tpl.Nodes); step++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		tplNode, exists := tpl.Nodes[current]
+		if !exists {
+			return nil, fmt.Errorf("%w: %q", errors.ErrNodeNotFound, current)
+		}
+
+		executor, exists := e.executors[tplNode.Type]
+		if !exists {
+			return nil, fmt.Errorf("no executor registered for node type %q", tplNode.Type)
+		}
+
+		// 进入新节点时激活节点
+		// 节点可能被再次进入(如拒绝后跳转、回退),清除上一轮的审批结果
+		// 作为推进起点且没有审批结果的节点(如回退后重新激活)同样视为刚激活
+		activated := step > 0 || len(tsk.Approvals[current]) == 0
+		if step > 0 {
+			delete(tsk.Approvals, current)
+			result.Steps = append(result.Steps, &task.FlowStep{NodeID: current, Action: task.FlowActionActivated})
+		}
+		tsk.CurrentNode = current
+		result.CurrentNode = current
+
+		if err := e.resolveApprovers(ctx, tpl, tplNode, tsk, cache); err != nil {
+			return nil, fmt.Errorf("failed to resolve approvers for node %q: %w", current, err)
+		}
+
+		// 节点激活时应用跳过规则
+		skipped := false
+		if activated {
+			var err error
+			skipped, err = e.applySkipPolicy(ctx, tpl, tplNode, tsk)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply skip policy for node %q: %w", current, err)
+			}
+		}
+
+		// 执行节点,被跳过的审批节点视为通过
+		var nodeResult *NodeResult
+		if skipped {
+			nodeResult = &NodeResult{Output: skippedNodeOutput}
+		} else {
+			nc := &NodeContext{
+				Task:     tsk,
+				Node:     tplNode,
+				Template:   tpl,
+				Params:     tsk.Params,
+				Outputs:    tsk.NodeOutputs,
+				Cache:      cache,
+				Ctx:        ctx,
+				HTTPClient: e.httpClient,
+			}
+			var err error
+			nodeResult, err = executor.Execute(nc)
+			if stderrors.Is(err, errors.ErrApprovalPending) {
+				// 审批未完成,停留在当前节点
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute node %q: %w", current, err)
+			}
+		}
+
+		tsk.NodeOutputs[current] = nodeResult.Output
+
+		// 审批节点被拒绝时停止,拒绝后的流程走向由任务管理器处理
+		if tplNode.Type == template.NodeTypeApproval && approvalOutcome(nodeResult.Output) == "reject" {
+			result.Rejected = true
+			return result, nil
+		}
+
+		// 节点完成
+		markCompleted(tsk, current)
+		result.Steps = append(result.Steps, &task.FlowStep{NodeID: current, Action: task.FlowActionCompleted})
+
+		if tplNode.Type == template.NodeTypeEnd {
+			result.Finished = true
+			return result, nil
+		}
+
+		next, err := nextNodeID(tpl, current, nodeResult)
+		if err != nil {
+			return nil, err
+		}
+		current = next
+	}
+
+	return nil, fmt.Errorf("flow did not reach an approval or end node within %d steps, check for cycles near node %q", len(tpl.Nodes), current)
+}
+
+// resolveApprovers 节点激活时获取审批人
+// 仅处理尚未获取审批人的审批节点,已获取的审批人(任务创建时获取、加签、转交等)保持不变
+// 设置了委托规则存储时,按激活时生效的委托规则替换审批人
+// 跳过规则设置了审批人列表为空时跳过节点的,未找到审批人(ErrApproverNotFound)视为审批人列表为空
+func (e *FlowEngine) resolveApprovers(ctx context.Context, tpl *template.Template, tplNode *template.Node, tsk *task.Task, cache *ContextCache) error {
+	if tplNode.Type != template.NodeTypeApproval {
+		return nil
+	}
+	if len(tsk.Approvers[tplNode.ID]) > 0 {
+		return nil
+	}
+
+	config, ok := tplNode.Config.(*ApprovalNodeConfig)
+	if !ok || config.ApproverConfig == nil {
+		// 配置无效时由审批节点执行器报告错误
+		return nil
+	}
+
+	approverConfig := config.ApproverConfig
+	// 动态审批人未配置 HTTP 客户端时使用引擎的客户端
+	// 复制配置,避免修改模板中共享的配置对象
+	if dynamicConfig, ok := approverConfig.(*DynamicApproverConfig); ok && dynamicConfig.HTTPClient == nil && e.httpClient != nil {
+		configCopy := *dynamicConfig
+		configCopy.HTTPClient = e.httpClient
+		approverConfig = &configCopy
+	}
+
+	nc := &NodeContext{
+		Task:      tsk,
+		Node:      tplNode,
+		Params:    tsk.Params,
+		Outputs:   tsk.NodeOutputs,
+		Cache:     cache,
+		Ctx:       ctx,
+		Directory: e.directory,
+	}
+	approvers, err := approverConfig.GetApprovers(ctx, nc)
+	if stderrors.Is(err, errors.ErrApproverNotFound) && skipsEmptyApprovers(tpl, tplNode) {
+		approvers, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// 按委托规则替换审批人
+	approvers, err = task.ApplyDelegations(e.delegations, tsk, tplNode.ID, approvers, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if tsk.Approvers == nil {
+		tsk.Approvers = make(map[string][]string)
+	}
+	tsk.Approvers[tplNode.ID] = approvers
+	return nil
+}
+
+// nextNodeID 确定下一个节点
+// 优先使用执行器返回的 NextNodeID(如条件节点),否则使用节点唯一的出边
+func nextNodeID(tpl *template.Template, nodeID string, result *NodeResult) (string, error) {
+	if result.NextNodeID != "" {
+		return result.NextNodeID, nil
+	}
+
+	var targets []string
+	for _, edge := range tpl.Edges {
+		if edge.From == nodeID {
+			targets = append(targets, edge.To)
+		}
+	}
+
+	switch len(targets) {
+	case 0:
+		return "", fmt.Errorf("node %q has no outgoing edge", nodeID)
+	case 1:
+		return targets[0], nil
+	default:
+		return "", fmt.Errorf("node %q has %d outgoing edges, use a condition node to choose a branch", nodeID, len(targets))
+	}
+}
+
+// markCompleted 将节点添加到任务的已完成节点列表(去重)
+func markCompleted(tsk *task.Task, nodeID string) {
+	for _, completedNodeID := range tsk.CompletedNodes {
+		if completedNodeID == nodeID {
+			return
+		}
+	}
+	tsk.CompletedNodes = append(tsk.CompletedNodes, nodeID)
+}
+
+// approvalOutcome 从审批节点输出中解析审批结果
+func approvalOutcome(output json.RawMessage) string {
+	var data struct {
+		Result string `json:"result"`
+	}
+	if err := json.Unmarshal(output, &data); err != nil {
+		return ""
+	}
+	return data.Result
+}
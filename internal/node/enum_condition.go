@@ -7,22 +7,22 @@ import (
 
 // EnumConditionConfig 枚举判断条件配置
 type EnumConditionConfig struct {
-	// Field 字段名(JSON 路径)
-	Field string
+	// Field 字段名或 JSONPath 路径(如 "amount"、"$.items[0].price")
+	Field string `json:"field"`
 
 	// Operator 判断操作符
 	// 支持: "in"(在列表中), "not_in"(不在列表中)
-	Operator string
+	Operator string `json:"operator"`
 
 	// Values 枚举值列表
-	Values []string
+	Values []string `json:"values"`
 
 	// Source 数据源
 	// 支持: "task_params"(任务参数), "node_outputs"(节点输出数据)
-	Source string
+	Source string `json:"source,omitempty"`
 
 	// NodeID 节点 ID(当 Source 为 "node_outputs" 时必填)
-	NodeID string
+	NodeID string `json:"node_id,omitempty"`
 }
 
 // ConditionType 返回条件类型(实现 ConditionConfig 接口)
@@ -30,6 +30,18 @@ func (c *EnumConditionConfig) ConditionType() string {
 	return "enum"
 }
 
+// Validate 验证配置的有效性
+// 检查 Field 是否为有效的 JSONPath 路径
+func (c *EnumConditionConfig) Validate() error {
+	if c.Field == "" {
+		return nil
+	}
+	if _, err := CompileJSONPath(c.Field); err != nil {
+		return fmt.Errorf("EnumConditionConfig.Field is invalid: %w", err)
+	}
+	return nil
+}
+
 // EnumConditionEvaluator 枚举判断条件评估器
 type EnumConditionEvaluator struct{}
 
@@ -81,14 +93,15 @@ func (e *EnumConditionEvaluator) getValue(config *EnumConditionConfig, ctx *Node
 	}
 
 	// 解析 JSON 获取字段值
-	var jsonData map[string]interface{}
+	var jsonData interface{}
 	if err := json.Unmarshal(data, &jsonData); err != nil {
 		return "", fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	fieldValue, exists := jsonData[config.Field]
-	if !exists {
-		return "", fmt.Errorf("field not found: %q", config.Field)
+	// 按 JSONPath 路径读取字段值,如 "amount"、"$.items[0].price"
+	fieldValue, err := getValueByPath(jsonData, config.Field)
+	if err != nil {
+		return "", fmt.Errorf("field not found: %q: %w", config.Field, err)
 	}
 
 	// 转换为 string
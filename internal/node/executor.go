@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"encoding/json"
 	"sync"
 	"time"
@@ -31,6 +32,10 @@ type NodeContext struct {
 	// Node 当前节点
 	Node *template.Node
 
+	// Template 任务使用的模板(可选)
+	// 排他网关从模板中读取当前节点的出边
+	Template *template.Template
+
 	// Params 任务参数(JSON 格式)
 	Params json.RawMessage
 
@@ -40,6 +45,39 @@ type NodeContext struct {
 
 	// Cache 上下文缓存,避免重复查询
 	Cache *ContextCache
+
+	// Ctx 请求上下文,用于取消和超时控制(可选)
+	// 通过 Context() 读取,未设置时为 context.Background()
+	Ctx context.Context
+
+	// Directory 组织架构目录(可选)
+	// 供基于组织架构的审批人配置(角色、部门负责人、上级)查询审批人
+	Directory Directory
+
+	// HTTPClient HTTP 客户端(可选)
+	// 供未配置 HTTPClient 的 HTTP 条件调用决策服务
+	HTTPClient HTTPClient
+
+	// conditionOutputs 条件评估过程中记录的数据,由条件节点执行器写入节点输出
+	conditionOutputs map[string]json.RawMessage
+}
+
+// recordConditionOutput 记录条件评估过程中的数据(如 HTTP 条件的原始响应)
+// 条件节点执行器将记录的数据以 key 为字段写入节点输出
+func (c *NodeContext) recordConditionOutput(key string, data json.RawMessage) {
+	if c.conditionOutputs == nil {
+		c.conditionOutputs = make(map[string]json.RawMessage)
+	}
+	c.conditionOutputs[key] = data
+}
+
+// Context 返回节点执行的请求上下文
+// 未设置时返回 context.Background()
+func (c *NodeContext) Context() context.Context {
+	if c == nil || c.Ctx == nil {
+		return context.Background()
+	}
+	return c.Ctx
 }
 
 // ContextCache 上下文缓存
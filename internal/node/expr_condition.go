@@ -0,0 +1,132 @@
+package node
+
+import (
+	"fmt"
+)
+
+// ExprConditionConfig 表达式条件配置
+// 使用表达式描述条件,如 amount > 10000 && dept in ['rd', 'ops'] || params.urgent
+//
+// 变量:
+//   - params: 任务参数,params.amount 可以简写为 amount
+//   - outputs: 节点输出数据,如 outputs.risk_check.score
+//   - task: 任务信息,字段为 id、template_id、template_version、business_id、initiator、
+//     state、current_node、created_at、submitted_at(时间为 Unix 秒)
+//
+// 运算: 算术(+ - * / %,+ 也用于字符串拼接),比较(== != < <= > >=),
+// 逻辑(&& || !,也可以写作 and or not),包含(in、not in,用于列表、字符串和 map 的键)
+//
+// 函数: len、lower、upper、trim、contains、startsWith、endsWith、matches(正则匹配)
+//
+// 字段不存在时值为 null,null 在逻辑运算中视为 false
+type ExprConditionConfig struct {
+	// Expression 条件表达式,结果必须为 bool
+	Expression string `json:"expression"`
+}
+
+// ConditionType 返回条件类型(实现 ConditionConfig 接口)
+func (c *ExprConditionConfig) ConditionType() string {
+	return "expr"
+}
+
+// Validate 编译并检查表达式
+// 检查语法、函数名和参数、task 字段名以及字面量的类型;编译结果缓存,求值时直接使用
+func (c *ExprConditionConfig) Validate() error {
+	if c.Expression == "" {
+		return fmt.Errorf("ExprConditionConfig.Expression is required")
+	}
+	if _, err := compileExprCached(c.Expression); err != nil {
+		return fmt.Errorf("invalid expression %q: %w", c.Expression, err)
+	}
+	return nil
+}
+
+// exprProgram 编译后的表达式
+type exprProgram struct {
+	root exprNode
+}
+
+// exprPrograms 编译后的表达式缓存,键为表达式
+var exprPrograms = newCompileCache[*exprProgram](defaultCompileCacheSize)
+
+// compileExprCached 编译表达式,缓存中的表达式不重复编译
+func compileExprCached(src string) (*exprProgram, error) {
+	return exprPrograms.get(src, compileExpr)
+}
+
+// compileExpr 解析表达式并进行类型检查
+func compileExpr(src string) (*exprProgram, error) {
+	root, err := parseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	resultType, err := exprChecker{}.check(root)
+	if err != nil {
+		return nil, err
+	}
+	if !resultType.is(typeBool, typeNull) {
+		return nil, fmt.Errorf("expression must evaluate to bool, got %s", resultType)
+	}
+	return &exprProgram{root: root}, nil
+}
+
+// compileFilter 编译 JSONPath 过滤条件,条件中可以使用 @ 和 $
+func compileFilter(src string) (*exprProgram, error) {
+	root, err := parseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	resultType, err := exprChecker{filter: true}.check(root)
+	if err != nil {
+		return nil, err
+	}
+	if !resultType.is(typeBool, typeNull) {
+		return nil, fmt.Errorf("filter must evaluate to bool, got %s", resultType)
+	}
+	return &exprProgram{root: root}, nil
+}
+
+// evaluate 计算表达式的 bool 结果
+func (p *exprProgram) evaluate(ctx *NodeContext) (bool, error) {
+	env, err := newExprEnv(ctx)
+	if err != nil {
+		return false, err
+	}
+	value, err := env.eval(p.root)
+	if err != nil {
+		return false, err
+	}
+	return truthy(p.root.position(), value)
+}
+
+// ExprConditionEvaluator 表达式条件评估器
+type ExprConditionEvaluator struct{}
+
+// NewExprConditionEvaluator 创建新的表达式条件评估器
+func NewExprConditionEvaluator() ConditionEvaluator {
+	return &ExprConditionEvaluator{}
+}
+
+// Supports 检查是否支持指定的条件类型(实现 ConditionEvaluator 接口)
+func (e *ExprConditionEvaluator) Supports(conditionType string) bool {
+	return conditionType == "expr"
+}
+
+// Evaluate 评估表达式条件(实现 ConditionEvaluator 接口)
+// 使用模板验证时缓存的编译结果,未验证过的表达式在首次评估时编译
+func (e *ExprConditionEvaluator) Evaluate(condition *Condition, ctx *NodeContext) (bool, error) {
+	config, ok := condition.Config.(*ExprConditionConfig)
+	if !ok {
+		return false, fmt.Errorf("invalid condition config type for expr condition")
+	}
+
+	program, err := compileExprCached(config.Expression)
+	if err != nil {
+		return false, fmt.Errorf("invalid expression %q: %w", config.Expression, err)
+	}
+	result, err := program.evaluate(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression %q: %w", config.Expression, err)
+	}
+	return result, nil
+}
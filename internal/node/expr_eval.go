@@ -0,0 +1,693 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// exprType 表达式的静态类型
+// 任务参数和节点输出的结构在模板中没有声明,类型为 typeAny,在求值时检查
+type exprType int
+
+const (
+	typeAny exprType = iota
+	typeBool
+	typeNumber
+	typeString
+	typeList
+	typeMap
+	typeNull
+)
+
+// String 返回类型名称
+func (t exprType) String() string {
+	switch t {
+	case typeBool:
+		return "bool"
+	case typeNumber:
+		return "number"
+	case typeString:
+		return "string"
+	case typeList:
+		return "list"
+	case typeMap:
+		return "map"
+	case typeNull:
+		return "null"
+	default:
+		return "any"
+	}
+}
+
+// is 判断类型是否可能为指定类型之一(typeAny 可能为任意类型)
+func (t exprType) is(types ...exprType) bool {
+	if t == typeAny {
+		return true
+	}
+	for _, want := range types {
+		if t == want {
+			return true
+		}
+	}
+	return false
+}
+
+// 表达式的根变量
+const (
+	exprRootParams  = "params"
+	exprRootOutputs = "outputs"
+	exprRootTask    = "task"
+
+	// exprCurrent JSONPath 过滤条件中的当前元素
+	exprCurrent = "@"
+
+	// exprRoot JSONPath 过滤条件中的根节点
+	exprRoot = "$"
+)
+
+// exprTaskFields task 变量的字段及类型
+var exprTaskFields = map[string]exprType{
+	"id":               typeString,
+	"template_id":      typeString,
+	"template_version": typeNumber,
+	"business_id":      typeString,
+	"initiator":        typeString,
+	"state":            typeString,
+	"current_node":     typeString,
+	"created_at":       typeNumber,
+	"submitted_at":     typeAny, // 未提交时为 null
+}
+
+// exprFunction 表达式内置函数
+type exprFunction struct {
+	params []exprType
+	result exprType
+	call   func(n *callNode, args []interface{}) (interface{}, error)
+}
+
+// exprFunctions 表达式内置函数
+var exprFunctions = map[string]*exprFunction{
+	"len": {params: []exprType{typeAny}, result: typeNumber, call: func(n *callNode, args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case string:
+			return float64(len([]rune(v))), nil
+		case []interface{}:
+			return float64(len(v)), nil
+		case map[string]interface{}:
+			return float64(len(v)), nil
+		case nil:
+			return float64(0), nil
+		}
+		return nil, exprErrorf(n.pos, "len() argument must be string, list or map, got %s", valueType(args[0]))
+	}},
+	"lower": stringFunction(typeString, func(args []string) interface{} { return strings.ToLower(args[0]) }, 1),
+	"upper": stringFunction(typeString, func(args []string) interface{} { return strings.ToUpper(args[0]) }, 1),
+	"trim":  stringFunction(typeString, func(args []string) interface{} { return strings.TrimSpace(args[0]) }, 1),
+	"contains": stringFunction(typeBool, func(args []string) interface{} {
+		return strings.Contains(args[0], args[1])
+	}, 2),
+	"startsWith": stringFunction(typeBool, func(args []string) interface{} {
+		return strings.HasPrefix(args[0], args[1])
+	}, 2),
+	"endsWith": stringFunction(typeBool, func(args []string) interface{} {
+		return strings.HasSuffix(args[0], args[1])
+	}, 2),
+	"matches": {params: []exprType{typeString, typeString}, result: typeBool, call: func(n *callNode, args []interface{}) (interface{}, error) {
+		s, pattern, err := stringArgs2(n, args)
+		if err != nil {
+			return nil, err
+		}
+		re := n.regex
+		if re == nil {
+			if re, err = regexp.Compile(pattern); err != nil {
+				return nil, exprErrorf(n.pos, "invalid regular expression %q: %v", pattern, err)
+			}
+		}
+		return re.MatchString(s), nil
+	}},
+}
+
+// stringFunction 创建参数均为字符串的内置函数
+func stringFunction(result exprType, fn func(args []string) interface{}, arity int) *exprFunction {
+	params := make([]exprType, arity)
+	for i := range params {
+		params[i] = typeString
+	}
+	return &exprFunction{params: params, result: result, call: func(n *callNode, args []interface{}) (interface{}, error) {
+		strs := make([]string, len(args))
+		for i, arg := range args {
+			s, ok := arg.(string)
+			if !ok {
+				return nil, exprErrorf(n.pos, "%s() argument %d must be string, got %s", n.name, i+1, valueType(arg))
+			}
+			strs[i] = s
+		}
+		return fn(strs), nil
+	}}
+}
+
+// stringArgs2 读取两个字符串参数
+func stringArgs2(n *callNode, args []interface{}) (string, string, error) {
+	a, ok := args[0].(string)
+	if !ok {
+		return "", "", exprErrorf(n.pos, "%s() argument 1 must be string, got %s", n.name, valueType(args[0]))
+	}
+	b, ok := args[1].(string)
+	if !ok {
+		return "", "", exprErrorf(n.pos, "%s() argument 2 must be string, got %s", n.name, valueType(args[1]))
+	}
+	return a, b, nil
+}
+
+// exprChecker 表达式类型检查器
+type exprChecker struct {
+	filter bool // 是否为 JSONPath 过滤条件(允许使用 @ 和 $)
+}
+
+// check 检查节点的类型并返回静态类型
+func (c exprChecker) check(node exprNode) (exprType, error) {
+	switch n := node.(type) {
+	case *literalNode:
+		return valueStaticType(n.value), nil
+	case *identNode:
+		switch n.name {
+		case exprRootParams, exprRootOutputs, exprRootTask:
+			return typeMap, nil
+		case exprCurrent, exprRoot:
+			if !c.filter {
+				return typeAny, exprErrorf(n.pos, "%s is only allowed in JSONPath filters", n.name)
+			}
+		}
+		return typeAny, nil // 任务参数字段的简写
+	case *memberNode:
+		return c.checkMember(n, n.name, true)
+	case *indexNode:
+		indexType, err := c.check(n.index)
+		if err != nil {
+			return typeAny, err
+		}
+		if !indexType.is(typeString, typeNumber) {
+			return typeAny, exprErrorf(n.pos, "index must be string or number, got %s", indexType)
+		}
+		if lit, ok := n.index.(*literalNode); ok {
+			if name, ok := lit.value.(string); ok {
+				return c.checkMember(n, name, true)
+			}
+		}
+		return c.checkMember(n, "", false)
+	case *listNode:
+		for _, item := range n.items {
+			if _, err := c.check(item); err != nil {
+				return typeAny, err
+			}
+		}
+		return typeList, nil
+	case *unaryNode:
+		t, err := c.check(n.operand)
+		if err != nil {
+			return typeAny, err
+		}
+		if n.op == "-" {
+			if !t.is(typeNumber) {
+				return typeAny, exprErrorf(n.pos, "operator - requires number, got %s", t)
+			}
+			return typeNumber, nil
+		}
+		if !t.is(typeBool, typeNull) {
+			return typeAny, exprErrorf(n.pos, "operator ! requires bool, got %s", t)
+		}
+		return typeBool, nil
+	case *binaryNode:
+		return c.checkBinary(n)
+	case *callNode:
+		return c.checkCall(n)
+	}
+	return typeAny, exprErrorf(node.position(), "unsupported expression")
+}
+
+// checkMember 检查字段或下标访问
+// 对 task 变量的字段访问检查字段是否存在
+func (c exprChecker) checkMember(node exprNode, name string, named bool) (exprType, error) {
+	var target exprNode
+	switch n := node.(type) {
+	case *memberNode:
+		target = n.target
+	case *indexNode:
+		target = n.target
+	}
+	targetType, err := c.check(target)
+	if err != nil {
+		return typeAny, err
+	}
+	if !targetType.is(typeMap, typeList, typeNull) {
+		return typeAny, exprErrorf(node.position(), "cannot access field of %s", targetType)
+	}
+	if ident, ok := target.(*identNode); ok && ident.name == exprRootTask && named {
+		fieldType, exists := exprTaskFields[name]
+		if !exists {
+			return typeAny, exprErrorf(node.position(), "unknown task field %q", name)
+		}
+		return fieldType, nil
+	}
+	return typeAny, nil
+}
+
+// checkBinary 检查二元运算
+func (c exprChecker) checkBinary(n *binaryNode) (exprType, error) {
+	left, err := c.check(n.left)
+	if err != nil {
+		return typeAny, err
+	}
+	right, err := c.check(n.right)
+	if err != nil {
+		return typeAny, err
+	}
+
+	switch n.op {
+	case "||", "&&":
+		if !left.is(typeBool, typeNull) || !right.is(typeBool, typeNull) {
+			return typeAny, exprErrorf(n.pos, "operator %s requires bool operands, got %s and %s", n.op, left, right)
+		}
+		return typeBool, nil
+	case "==", "!=":
+		if left != typeAny && right != typeAny && left != typeNull && right != typeNull && left != right {
+			return typeAny, exprErrorf(n.pos, "cannot compare %s with %s", left, right)
+		}
+		return typeBool, nil
+	case "<", "<=", ">", ">=":
+		if !(left.is(typeNumber) && right.is(typeNumber)) && !(left.is(typeString) && right.is(typeString)) {
+			return typeAny, exprErrorf(n.pos, "operator %s requires two numbers or two strings, got %s and %s", n.op, left, right)
+		}
+		return typeBool, nil
+	case "in", "not in":
+		if !right.is(typeList, typeString, typeMap, typeNull) {
+			return typeAny, exprErrorf(n.pos, "operator %s requires list, string or map on the right, got %s", n.op, right)
+		}
+		if (right == typeString || right == typeMap) && !left.is(typeString) {
+			return typeAny, exprErrorf(n.pos, "operator %s on %s requires string on the left, got %s", n.op, right, left)
+		}
+		return typeBool, nil
+	case "+":
+		switch {
+		case left == typeNumber && right == typeNumber:
+			return typeNumber, nil
+		case left == typeString && right == typeString:
+			return typeString, nil
+		case left.is(typeNumber, typeString) && right.is(typeNumber, typeString) && (left == typeAny || right == typeAny):
+			return typeAny, nil
+		}
+		return typeAny, exprErrorf(n.pos, "operator + requires two numbers or two strings, got %s and %s", left, right)
+	default: // - * / %
+		if !left.is(typeNumber) || !right.is(typeNumber) {
+			return typeAny, exprErrorf(n.pos, "operator %s requires numbers, got %s and %s", n.op, left, right)
+		}
+		return typeNumber, nil
+	}
+}
+
+// checkCall 检查函数调用的函数名、参数数量和参数类型
+func (c exprChecker) checkCall(n *callNode) (exprType, error) {
+	fn, exists := exprFunctions[n.name]
+	if !exists {
+		return typeAny, exprErrorf(n.pos, "unknown function %q", n.name)
+	}
+	if len(n.args) != len(fn.params) {
+		return typeAny, exprErrorf(n.pos, "%s() takes %d argument(s), got %d", n.name, len(fn.params), len(n.args))
+	}
+	for i, arg := range n.args {
+		t, err := c.check(arg)
+		if err != nil {
+			return typeAny, err
+		}
+		if fn.params[i] != typeAny && !t.is(fn.params[i]) {
+			return typeAny, exprErrorf(arg.position(), "%s() argument %d must be %s, got %s", n.name, i+1, fn.params[i], t)
+		}
+	}
+	if n.name == "matches" {
+		if lit, ok := n.args[1].(*literalNode); ok {
+			re, err := regexp.Compile(lit.value.(string))
+			if err != nil {
+				return typeAny, exprErrorf(lit.pos, "invalid regular expression %q: %v", lit.value, err)
+			}
+			n.regex = re
+		}
+	}
+	return fn.result, nil
+}
+
+// valueStaticType 返回字面量的静态类型
+func valueStaticType(v interface{}) exprType {
+	switch v.(type) {
+	case bool:
+		return typeBool
+	case float64:
+		return typeNumber
+	case string:
+		return typeString
+	case nil:
+		return typeNull
+	}
+	return typeAny
+}
+
+// valueType 返回运行时值的类型名称,用于错误信息
+func valueType(v interface{}) string {
+	switch v.(type) {
+	case []interface{}:
+		return typeList.String()
+	case map[string]interface{}:
+		return typeMap.String()
+	}
+	return valueStaticType(v).String()
+}
+
+// exprEnv 表达式求值环境
+type exprEnv struct {
+	params  interface{}
+	outputs map[string]interface{}
+	task    map[string]interface{}
+	current interface{} // JSONPath 过滤条件中的当前元素
+	root    interface{} // JSONPath 过滤条件中的根节点
+}
+
+// newExprEnv 从节点执行上下文创建求值环境
+// 任务参数优先使用 ctx.Params,节点输出优先使用 ctx.Outputs,未设置时从 ctx.Task 读取
+func newExprEnv(ctx *NodeContext) (*exprEnv, error) {
+	env := &exprEnv{outputs: make(map[string]interface{}), task: make(map[string]interface{})}
+	if ctx == nil {
+		env.params = map[string]interface{}{}
+		return env, nil
+	}
+
+	params, outputs := ctx.Params, ctx.Outputs
+	if ctx.Task != nil {
+		if len(params) == 0 {
+			params = ctx.Task.Params
+		}
+		if outputs == nil {
+			outputs = ctx.Task.NodeOutputs
+		}
+		env.task["id"] = ctx.Task.ID
+		env.task["template_id"] = ctx.Task.TemplateID
+		env.task["template_version"] = float64(ctx.Task.TemplateVersion)
+		env.task["business_id"] = ctx.Task.BusinessID
+		env.task["initiator"] = ctx.Task.Initiator
+		env.task["state"] = string(ctx.Task.State)
+		env.task["current_node"] = ctx.Task.CurrentNode
+		env.task["created_at"] = float64(ctx.Task.CreatedAt.Unix())
+		env.task["submitted_at"] = nil
+		if ctx.Task.SubmittedAt != nil {
+			env.task["submitted_at"] = float64(ctx.Task.SubmittedAt.Unix())
+		}
+	}
+
+	env.params = map[string]interface{}{}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &env.params); err != nil {
+			return nil, fmt.Errorf("failed to parse task params: %w", err)
+		}
+	}
+	for nodeID, output := range outputs {
+		var value interface{}
+		if len(output) > 0 {
+			if err := json.Unmarshal(output, &value); err != nil {
+				return nil, fmt.Errorf("failed to parse output of node %q: %w", nodeID, err)
+			}
+		}
+		env.outputs[nodeID] = value
+	}
+	return env, nil
+}
+
+// eval 计算表达式节点的值
+// 值的类型为 bool、float64、string、nil、[]interface{} 或 map[string]interface{}
+func (env *exprEnv) eval(node exprNode) (interface{}, error) {
+	switch n := node.(type) {
+	case *literalNode:
+		return n.value, nil
+	case *identNode:
+		switch n.name {
+		case exprRootParams:
+			return env.params, nil
+		case exprRootOutputs:
+			return env.outputs, nil
+		case exprRootTask:
+			return env.task, nil
+		case exprCurrent:
+			return env.current, nil
+		case exprRoot:
+			return env.root, nil
+		}
+		return field(env.params, n.name), nil
+	case *memberNode:
+		target, err := env.eval(n.target)
+		if err != nil {
+			return nil, err
+		}
+		return env.member(n.pos, target, n.name)
+	case *indexNode:
+		target, err := env.eval(n.target)
+		if err != nil {
+			return nil, err
+		}
+		index, err := env.eval(n.index)
+		if err != nil {
+			return nil, err
+		}
+		return env.member(n.pos, target, index)
+	case *listNode:
+		items := make([]interface{}, len(n.items))
+		for i, item := range n.items {
+			v, err := env.eval(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = v
+		}
+		return items, nil
+	case *unaryNode:
+		v, err := env.eval(n.operand)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == "-" {
+			num, ok := v.(float64)
+			if !ok {
+				return nil, exprErrorf(n.pos, "operator - requires number, got %s", valueType(v))
+			}
+			return -num, nil
+		}
+		b, err := truthy(n.pos, v)
+		if err != nil {
+			return nil, err
+		}
+		return !b, nil
+	case *binaryNode:
+		return env.evalBinary(n)
+	case *callNode:
+		args := make([]interface{}, len(n.args))
+		for i, arg := range n.args {
+			v, err := env.eval(arg)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+		}
+		return exprFunctions[n.name].call(n, args)
+	}
+	return nil, exprErrorf(node.position(), "unsupported expression")
+}
+
+// member 读取字段或下标,字段不存在或目标为 null 时返回 null
+func (env *exprEnv) member(pos int, target interface{}, key interface{}) (interface{}, error) {
+	switch t := target.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		name, ok := key.(string)
+		if !ok {
+			return nil, exprErrorf(pos, "map key must be string, got %s", valueType(key))
+		}
+		return t[name], nil
+	case []interface{}:
+		index, ok := key.(float64)
+		if !ok || index != math.Trunc(index) {
+			return nil, exprErrorf(pos, "list index must be integer, got %v", key)
+		}
+		if index < 0 || int(index) >= len(t) {
+			return nil, nil
+		}
+		return t[int(index)], nil
+	}
+	return nil, exprErrorf(pos, "cannot access field of %s", valueType(target))
+}
+
+// field 读取 map 字段,目标不是 map 时返回 nil
+func field(target interface{}, name string) interface{} {
+	if m, ok := target.(map[string]interface{}); ok {
+		return m[name]
+	}
+	return nil
+}
+
+// evalBinary 计算二元运算,&& 和 || 短路求值
+func (env *exprEnv) evalBinary(n *binaryNode) (interface{}, error) {
+	left, err := env.eval(n.left)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "&&" || n.op == "||" {
+		l, err := truthy(n.pos, left)
+		if err != nil {
+			return nil, err
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := env.eval(n.right)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(n.pos, right)
+	}
+
+	right, err := env.eval(n.right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		return compareValues(n, left, right)
+	case "in":
+		return contains(n.pos, right, left)
+	case "not in":
+		found, err := contains(n.pos, right, left)
+		if err != nil {
+			return nil, err
+		}
+		return !found, nil
+	case "+":
+		if l, ok := left.(string); ok {
+			if r, ok := right.(string); ok {
+				return l + r, nil
+			}
+		}
+	}
+
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, exprErrorf(n.pos, "operator %s requires numbers, got %s and %s", n.op, valueType(left), valueType(right))
+	}
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, exprErrorf(n.pos, "division by zero")
+		}
+		return l / r, nil
+	default: // %
+		if r == 0 {
+			return nil, exprErrorf(n.pos, "division by zero")
+		}
+		return math.Mod(l, r), nil
+	}
+}
+
+// truthy 将值转换为 bool,null 视为 false
+func truthy(pos int, v interface{}) (bool, error) {
+	switch b := v.(type) {
+	case bool:
+		return b, nil
+	case nil:
+		return false, nil
+	}
+	return false, exprErrorf(pos, "expected bool, got %s", valueType(v))
+}
+
+// valuesEqual 判断两个值是否相等
+func valuesEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// compareValues 比较两个数字或两个字符串
+func compareValues(n *binaryNode, left, right interface{}) (bool, error) {
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false, exprErrorf(n.pos, "cannot compare %s with %s", valueType(left), valueType(right))
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false, exprErrorf(n.pos, "cannot compare %s with %s", valueType(left), valueType(right))
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return false, exprErrorf(n.pos, "cannot compare %s with %s", valueType(left), valueType(right))
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+// contains 判断 collection 是否包含 item
+// 列表判断元素,字符串判断子串,map 判断键,null 不包含任何值
+func contains(pos int, collection, item interface{}) (bool, error) {
+	switch c := collection.(type) {
+	case nil:
+		return false, nil
+	case []interface{}:
+		for _, v := range c {
+			if valuesEqual(v, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case string:
+		s, ok := item.(string)
+		if !ok {
+			return false, exprErrorf(pos, "operator in on string requires string, got %s", valueType(item))
+		}
+		return strings.Contains(c, s), nil
+	case map[string]interface{}:
+		s, ok := item.(string)
+		if !ok {
+			return false, exprErrorf(pos, "operator in on map requires string, got %s", valueType(item))
+		}
+		_, exists := c[s]
+		return exists, nil
+	}
+	return false, exprErrorf(pos, "operator in requires list, string or map, got %s", valueType(collection))
+}
@@ -0,0 +1,476 @@
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// exprTokenKind 表达式词法单元类型
+type exprTokenKind int
+
+const (
+	tokenEOF exprTokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+// exprToken 表达式词法单元
+type exprToken struct {
+	kind  exprTokenKind
+	text  string
+	value interface{} // 数字和字符串字面量的值
+	pos   int         // 在表达式中的位置(从 0 开始)
+}
+
+// exprOperators 表达式操作符,按长度从长到短匹配
+var exprOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "+", "-", "*", "/", "%", "<", ">", "!", "(", ")", "[", "]", ",", "."}
+
+// tokenizeExpr 将表达式拆分为词法单元
+func tokenizeExpr(src string) ([]exprToken, error) {
+	var tokens []exprToken
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.' || src[i] == '_') {
+				i++
+			}
+			value, err := strconv.ParseFloat(strings.ReplaceAll(src[start:i], "_", ""), 64)
+			if err != nil {
+				return nil, exprErrorf(start, "invalid number %q", src[start:i])
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: src[start:i], value: value, pos: start})
+		case c == '\'' || c == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(src) {
+					return nil, exprErrorf(start, "unterminated string")
+				}
+				if src[i] == c {
+					i++
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+					switch src[i] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					default:
+						sb.WriteByte(src[i])
+					}
+					i++
+					continue
+				}
+				sb.WriteByte(src[i])
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenString, text: src[start:i], value: sb.String(), pos: start})
+		case c == '@' || c == '$':
+			// JSONPath 过滤条件中的当前元素和根节点
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: string(c), pos: i})
+			i++
+		case isIdentByte(c) && !(c >= '0' && c <= '9'):
+			start := i
+			for i < len(src) && isIdentByte(src[i]) {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: src[start:i], pos: start})
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(src[i:], op) {
+					tokens = append(tokens, exprToken{kind: tokenOperator, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, exprErrorf(i, "unexpected character %q", c)
+			}
+		}
+	}
+	return append(tokens, exprToken{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// isIdentByte 判断字符是否可以用于标识符(字母、数字、下划线)
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// exprNode 表达式语法树节点
+type exprNode interface {
+	// position 返回节点在表达式中的位置
+	position() int
+}
+
+// literalNode 字面量: 数字、字符串、true、false、null
+type literalNode struct {
+	pos   int
+	value interface{}
+}
+
+// identNode 标识符: params、outputs、task、任务参数字段,或 JSONPath 过滤条件中的 @ 和 $
+type identNode struct {
+	pos  int
+	name string
+}
+
+// memberNode 字段访问: target.name
+type memberNode struct {
+	pos    int
+	target exprNode
+	name   string
+}
+
+// indexNode 下标访问: target[index]
+type indexNode struct {
+	pos    int
+	target exprNode
+	index  exprNode
+}
+
+// listNode 列表字面量: [a, b, c]
+type listNode struct {
+	pos   int
+	items []exprNode
+}
+
+// unaryNode 一元运算: !x、-x
+type unaryNode struct {
+	pos     int
+	op      string
+	operand exprNode
+}
+
+// binaryNode 二元运算
+type binaryNode struct {
+	pos         int
+	op          string
+	left, right exprNode
+}
+
+// callNode 函数调用
+type callNode struct {
+	pos   int
+	name  string
+	args  []exprNode
+	regex *regexp.Regexp // matches 函数的正则表达式为字面量时,类型检查阶段预编译
+}
+
+func (n *literalNode) position() int { return n.pos }
+func (n *identNode) position() int   { return n.pos }
+func (n *memberNode) position() int  { return n.pos }
+func (n *indexNode) position() int   { return n.pos }
+func (n *listNode) position() int    { return n.pos }
+func (n *unaryNode) position() int   { return n.pos }
+func (n *binaryNode) position() int  { return n.pos }
+func (n *callNode) position() int    { return n.pos }
+
+// exprParser 表达式语法分析器(递归下降)
+// 优先级从低到高: || / or,&& / and,比较(== != < <= > >= in,not in),+ -,* / %,一元 ! not -,字段和下标访问
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+// parseExpr 解析表达式为语法树
+func parseExpr(src string) (exprNode, error) {
+	tokens, err := tokenizeExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, exprErrorf(tok.pos, "unexpected %q", tok.text)
+	}
+	return node, nil
+}
+
+// peek 返回当前词法单元
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+// next 返回当前词法单元并前进
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept 当前词法单元为指定操作符或关键字时前进并返回 true
+func (p *exprParser) accept(texts ...string) (exprToken, bool) {
+	tok := p.peek()
+	if tok.kind != tokenOperator && tok.kind != tokenIdent {
+		return tok, false
+	}
+	for _, text := range texts {
+		if tok.text == text {
+			p.pos++
+			return tok, true
+		}
+	}
+	return tok, false
+}
+
+// expect 要求当前词法单元为指定操作符
+func (p *exprParser) expect(text string) error {
+	if tok, ok := p.accept(text); !ok {
+		return exprErrorf(tok.pos, "expected %q, got %s", text, describeToken(tok))
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.accept("||", "or")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: tok.pos, op: "||", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.accept("&&", "and")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: tok.pos, op: "&&", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		op := ""
+		switch {
+		case tok.kind == tokenOperator && (tok.text == "==" || tok.text == "!=" || tok.text == "<" || tok.text == "<=" || tok.text == ">" || tok.text == ">="):
+			op = tok.text
+			p.next()
+		case tok.kind == tokenIdent && tok.text == "in":
+			op = "in"
+			p.next()
+		case tok.kind == tokenIdent && tok.text == "not" && p.tokens[p.pos+1].kind == tokenIdent && p.tokens[p.pos+1].text == "in":
+			op = "not in"
+			p.pos += 2
+		default:
+			return left, nil
+		}
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: tok.pos, op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: tok.pos, op: tok.text, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.accept("*", "/", "%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: tok.pos, op: tok.text, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if tok, ok := p.accept("!", "not", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		op := tok.text
+		if op == "not" {
+			op = "!"
+		}
+		return &unaryNode{pos: tok.pos, op: op, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *exprParser) parsePostfix() (exprNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if tok, ok := p.accept("."); ok {
+			name := p.next()
+			if name.kind != tokenIdent {
+				return nil, exprErrorf(name.pos, "expected field name after '.', got %s", describeToken(name))
+			}
+			node = &memberNode{pos: tok.pos, target: node, name: name.text}
+			continue
+		}
+		if tok, ok := p.accept("["); ok {
+			index, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			node = &indexNode{pos: tok.pos, target: node, index: index}
+			continue
+		}
+		return node, nil
+	}
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber, tokenString:
+		return &literalNode{pos: tok.pos, value: tok.value}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{pos: tok.pos, value: true}, nil
+		case "false":
+			return &literalNode{pos: tok.pos, value: false}, nil
+		case "null":
+			return &literalNode{pos: tok.pos, value: nil}, nil
+		case "and", "or", "not", "in":
+			return nil, exprErrorf(tok.pos, "unexpected keyword %q", tok.text)
+		}
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(tok)
+		}
+		return &identNode{pos: tok.pos, name: tok.text}, nil
+	case tokenOperator:
+		switch tok.text {
+		case "(":
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		case "[":
+			list := &listNode{pos: tok.pos}
+			if _, ok := p.accept("]"); ok {
+				return list, nil
+			}
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+				if _, ok := p.accept(","); ok {
+					continue
+				}
+				if err := p.expect("]"); err != nil {
+					return nil, err
+				}
+				return list, nil
+			}
+		}
+	}
+	return nil, exprErrorf(tok.pos, "unexpected %s", describeToken(tok))
+}
+
+// parseCall 解析函数调用的参数列表(左括号已读取)
+func (p *exprParser) parseCall(name exprToken) (exprNode, error) {
+	call := &callNode{pos: name.pos, name: name.text}
+	if _, ok := p.accept(")"); ok {
+		return call, nil
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		if _, ok := p.accept(","); ok {
+			continue
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return call, nil
+	}
+}
+
+// describeToken 返回词法单元的描述,用于错误信息
+func describeToken(tok exprToken) string {
+	if tok.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(tok.text)
+}
+
+// exprErrorf 创建带位置信息的表达式错误
+func exprErrorf(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("at position %d: %s", pos, fmt.Sprintf(format, args...))
+}
@@ -7,46 +7,47 @@ import (
 )
 
 // HTTPAPIConfig HTTP API 配置
-// 用于动态审批人获取和条件节点评估
+// 用于动态审批人获取(DynamicApproverConfig)和 HTTP 决策条件评估(HTTPConditionConfig)
 type HTTPAPIConfig struct {
 	// URL API 地址
-	URL string
+	URL string `json:"url"`
 
 	// Method 请求方法(GET/POST/PUT/DELETE 等)
-	Method string
+	Method string `json:"method,omitempty"`
 
 	// Headers 请求头
-	Headers map[string]string
+	Headers map[string]string `json:"headers,omitempty"`
 
 	// ParamMapping 参数映射规则
 	// 定义如何从流程上下文中提取参数并映射到 API 请求参数
-	ParamMapping *ParamMapping
+	ParamMapping *ParamMapping `json:"param_mapping,omitempty"`
 
 	// ResponseMapping 响应数据解析规则
-	// 定义如何从 API 响应中解析审批人列表
-	ResponseMapping *ResponseMapping
+	// 定义如何从 API 响应中解析审批人列表或决策结果
+	ResponseMapping *ResponseMapping `json:"response_mapping,omitempty"`
 }
 
 // ParamMapping 参数映射规则
 type ParamMapping struct {
 	// Source 参数来源(task_params/node_outputs/context)
-	Source string
+	Source string `json:"source,omitempty"`
 
 	// Path 参数路径(JSONPath 或字段名)
-	Path string
+	// Source 为 node_outputs 时第一个字段为节点 ID,如 "risk_check.items[0].price"
+	Path string `json:"path,omitempty"`
 
 	// Target 目标参数名(API 请求参数名)
-	Target string
+	Target string `json:"target,omitempty"`
 }
 
 // ResponseMapping 响应数据解析规则
 type ResponseMapping struct {
 	// Path 响应数据路径(JSONPath 或字段名)
-	// 例如: "data.approvers" 或 "approvers"
-	Path string
+	// 例如: "data.approvers"、"approvers" 或 "data.list[*].user_id"
+	Path string `json:"path,omitempty"`
 
 	// Format 响应格式(json)
-	Format string
+	Format string `json:"format,omitempty"`
 }
 
 // Validate 验证 HTTPAPIConfig 配置
@@ -95,11 +96,28 @@ func (c *HTTPAPIConfig) Validate() error {
 	// 标准化 Method
 	c.Method = methodUpper
 
+	// 验证 ParamMapping 路径
+	if c.ParamMapping != nil && c.ParamMapping.Path != "" {
+		var err error
+		switch c.ParamMapping.Source {
+		case "task_params":
+			_, err = CompileJSONPath(c.ParamMapping.Path)
+		case "node_outputs":
+			_, _, err = splitNodePath(c.ParamMapping.Path)
+		}
+		if err != nil {
+			return fmt.Errorf("HTTPAPIConfig.ParamMapping.Path is invalid: %w", err)
+		}
+	}
+
 	// 验证 ResponseMapping
 	if c.ResponseMapping != nil {
 		if c.ResponseMapping.Path == "" {
 			return fmt.Errorf("HTTPAPIConfig.ResponseMapping.Path is required")
 		}
+		if _, err := CompileJSONPath(c.ResponseMapping.Path); err != nil {
+			return fmt.Errorf("HTTPAPIConfig.ResponseMapping.Path is invalid: %w", err)
+		}
 		if c.ResponseMapping.Format == "" {
 			// 默认使用 json
 			c.ResponseMapping.Format = "json"
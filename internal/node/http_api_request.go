@@ -0,0 +1,272 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// call 按配置调用 HTTP API,返回响应体
+// 参数按 ParamMapping 从节点上下文中映射,请求失败时按指数退避重试,ctx 取消或超时后停止请求和重试
+func (c *HTTPAPIConfig) call(ctx context.Context, client HTTPClient, nc *NodeContext) ([]byte, error) {
+	// 1. 构建 HTTP 请求
+	req, err := c.buildRequest(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	// 2. 执行 HTTP 请求(带重试机制)
+	resp, err := c.doWithRetry(ctx, client, req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 3. 检查响应状态码
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP request failed with status code: %d", resp.StatusCode)
+	}
+
+	// 4. 读取响应体
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, nil
+}
+
+// buildRequest 构建 HTTP 请求
+func (c *HTTPAPIConfig) buildRequest(ctx context.Context, nc *NodeContext) (*http.Request, error) {
+	var req *http.Request
+	var err error
+
+	// 根据请求方法构建请求
+	if c.Method == "GET" {
+		// GET 请求: 参数放在 URL 中
+		url := c.URL
+		if c.ParamMapping != nil {
+			// 添加查询参数
+			params := c.mapParams(nc)
+			if len(params) > 0 {
+				urlWithParams, err := c.addQueryParams(url, params)
+				if err == nil {
+					url = urlWithParams
+				}
+			}
+		}
+		req, err = http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// POST/PUT/DELETE 等请求: 参数放在 Body 中
+		body := c.buildRequestBody(nc)
+		req, err = http.NewRequestWithContext(ctx, c.Method, c.URL, body)
+		if err != nil {
+			return nil, err
+		}
+		// 设置 Content-Type
+		if c.Headers == nil || c.Headers["Content-Type"] == "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	}
+
+	// 设置请求头
+	if c.Headers != nil {
+		for key, value := range c.Headers {
+			req.Header.Set(key, value)
+		}
+	}
+
+	return req, nil
+}
+
+// buildRequestBody 构建请求体
+func (c *HTTPAPIConfig) buildRequestBody(ctx *NodeContext) io.Reader {
+	// 如果有参数映射配置,使用映射逻辑
+	if c.ParamMapping != nil {
+		params := c.mapParams(ctx)
+		body, err := json.Marshal(params)
+		if err != nil {
+			// 如果序列化失败,返回空对象
+			return strings.NewReader("{}")
+		}
+		return strings.NewReader(string(body))
+	}
+
+	// 如果没有参数映射配置,直接使用任务参数
+	if ctx.Params != nil && len(ctx.Params) > 0 {
+		return strings.NewReader(string(ctx.Params))
+	}
+	return strings.NewReader("{}")
+}
+
+// mapParams 根据参数映射规则映射参数
+func (c *HTTPAPIConfig) mapParams(ctx *NodeContext) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	// 如果只有一个参数映射,直接映射
+	if c.ParamMapping != nil {
+		value := c.getValueBySource(ctx, c.ParamMapping.Source, c.ParamMapping.Path)
+		if value != nil {
+			result[c.ParamMapping.Target] = value
+		}
+	}
+
+	return result
+}
+
+// getValueBySource 根据数据源获取值
+func (c *HTTPAPIConfig) getValueBySource(ctx *NodeContext, source, path string) interface{} {
+	switch source {
+	case "task_params":
+		return c.getValueFromJSON(ctx.Params, path)
+	case "node_outputs":
+		// 从节点输出中获取值
+		// path 格式: "node_id.field"、"node_id.items[0].price" 或 "node_id"
+		nodeID, subPath, err := splitNodePath(path)
+		if err != nil {
+			return nil
+		}
+		output, exists := ctx.Outputs[nodeID]
+		if !exists {
+			return nil
+		}
+		var data interface{}
+		if err := json.Unmarshal(output, &data); err != nil {
+			return nil
+		}
+		// 如果没有子路径,返回整个输出
+		if subPath == nil {
+			return data
+		}
+		value, err := subPath.Get(data)
+		if err != nil {
+			return nil
+		}
+		return value
+	case "context":
+		// 从上下文中获取值(使用缓存)
+		value, _ := ctx.Cache.Get(path)
+		return value
+	default:
+		return nil
+	}
+}
+
+// getValueFromJSON 从 JSON 数据中根据 JSONPath 路径获取值
+// 路径无效或字段不存在时返回 nil
+func (c *HTTPAPIConfig) getValueFromJSON(data json.RawMessage, path string) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil
+	}
+
+	value, err := getValueByPath(obj, path)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// addQueryParams 添加查询参数到 URL
+func (c *HTTPAPIConfig) addQueryParams(baseURL string, params map[string]interface{}) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL, err
+	}
+
+	q := u.Query()
+	for key, value := range params {
+		// 将值转换为字符串
+		var strValue string
+		switch v := value.(type) {
+		case string:
+			strValue = v
+		case int, int64, float64:
+			strValue = fmt.Sprintf("%v", v)
+		case bool:
+			if v {
+				strValue = "true"
+			} else {
+				strValue = "false"
+			}
+		default:
+			// 对于复杂类型,序列化为 JSON
+			jsonBytes, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			strValue = string(jsonBytes)
+		}
+		q.Set(key, strValue)
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// doWithRetry 带重试机制的 HTTP 请求执行
+// 使用指数退避策略,ctx 取消或超时后立即停止重试
+// 请求体在首次发送时已被读取,重试时通过 req.GetBody 重新生成请求体
+func (c *HTTPAPIConfig) doWithRetry(ctx context.Context, client HTTPClient, req *http.Request) (*http.Response, error) {
+	maxRetries := 3
+	baseDelay := 100 * time.Millisecond
+
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		// 重试时重新生成请求体
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to reset request body: %w", err)
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		// 执行请求
+		resp, err := client.Do(attemptReq)
+		if err == nil {
+			// 请求成功,检查状态码
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return resp, nil
+			}
+			// 状态码错误,关闭响应体
+			resp.Body.Close()
+			lastErr = fmt.Errorf("HTTP request failed with status code: %d", resp.StatusCode)
+		} else {
+			lastErr = err
+		}
+
+		// 请求已取消或超时,不再重试
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		// 如果不是最后一次尝试,等待后重试
+		if attempt < maxRetries-1 {
+			// 指数退避: delay = baseDelay * 2^attempt
+			delay := baseDelay * time.Duration(1<<uint(attempt))
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return nil, lastErr
+}
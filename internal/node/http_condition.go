@@ -0,0 +1,124 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// defaultHTTPConditionOutputKey HTTP 条件原始响应在节点输出中的默认字段名
+const defaultHTTPConditionOutputKey = "response"
+
+// HTTPConditionConfig HTTP 决策条件配置
+// 按 API.ParamMapping 映射参数调用决策服务,从响应的 API.ResponseMapping.Path 读取 bool 结果,
+// 如决策服务返回 {"allow": true, "reason": "..."} 时 Path 为 "allow"
+//
+// 原始响应记录在条件节点输出的 OutputKey 字段中;
+// 使用 Fallback 时记录 {"fallback": true, "error": "..."}
+type HTTPConditionConfig struct {
+	// API HTTP API 配置,ResponseMapping.Path 为响应中 bool 结果的 JSONPath 路径
+	API *HTTPAPIConfig `json:"api"`
+
+	// Fallback 决策服务不可用(请求失败或响应状态码不是 2xx)时的条件结果(可选)
+	// 未设置时决策服务不可用返回错误
+	Fallback *bool `json:"fallback,omitempty"`
+
+	// OutputKey 原始响应在节点输出中的字段名(可选,默认为 "response")
+	// 组合条件中使用多个 HTTP 条件时需要设置不同的字段名
+	OutputKey string `json:"output_key,omitempty"`
+
+	// HTTPClient HTTP 客户端(依赖注入,未设置时使用流程引擎的 HTTP 客户端)
+	HTTPClient HTTPClient `json:"-"`
+}
+
+// ConditionType 返回条件类型(实现 ConditionConfig 接口)
+func (c *HTTPConditionConfig) ConditionType() string {
+	return "http"
+}
+
+// Validate 验证配置的有效性
+func (c *HTTPConditionConfig) Validate() error {
+	if c.API == nil {
+		return fmt.Errorf("HTTPConditionConfig.API is required")
+	}
+	if err := c.API.Validate(); err != nil {
+		return fmt.Errorf("HTTPConditionConfig.API validation failed: %w", err)
+	}
+	if c.API.ResponseMapping == nil {
+		return fmt.Errorf("HTTPConditionConfig.API.ResponseMapping is required to read the decision result")
+	}
+	return nil
+}
+
+// outputKey 返回原始响应在节点输出中的字段名
+func (c *HTTPConditionConfig) outputKey() string {
+	if c.OutputKey == "" {
+		return defaultHTTPConditionOutputKey
+	}
+	return c.OutputKey
+}
+
+// HTTPConditionEvaluator HTTP 决策条件评估器
+type HTTPConditionEvaluator struct{}
+
+// NewHTTPConditionEvaluator 创建新的 HTTP 决策条件评估器
+func NewHTTPConditionEvaluator() ConditionEvaluator {
+	return &HTTPConditionEvaluator{}
+}
+
+// Supports 检查是否支持指定的条件类型(实现 ConditionEvaluator 接口)
+func (e *HTTPConditionEvaluator) Supports(conditionType string) bool {
+	return conditionType == "http"
+}
+
+// Evaluate 评估 HTTP 决策条件(实现 ConditionEvaluator 接口)
+// 请求被取消或超时(ctx.Context() 结束)时返回错误,不使用 Fallback
+func (e *HTTPConditionEvaluator) Evaluate(condition *Condition, ctx *NodeContext) (bool, error) {
+	config, ok := condition.Config.(*HTTPConditionConfig)
+	if !ok {
+		return false, fmt.Errorf("invalid condition config type for http condition")
+	}
+	if err := config.Validate(); err != nil {
+		return false, err
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = ctx.HTTPClient
+	}
+	if client == nil {
+		return false, fmt.Errorf("HTTPConditionConfig.HTTPClient is required")
+	}
+
+	// 1. 调用决策服务
+	body, err := config.API.call(ctx.Context(), client, ctx)
+	if err != nil {
+		if config.Fallback == nil || ctx.Context().Err() != nil {
+			return false, fmt.Errorf("decision service unavailable: %w", err)
+		}
+		record, _ := json.Marshal(map[string]interface{}{"fallback": true, "error": err.Error()})
+		ctx.recordConditionOutput(config.outputKey(), record)
+		return *config.Fallback, nil
+	}
+
+	// 2. 记录原始响应
+	if !json.Valid(body) {
+		return false, fmt.Errorf("decision service returned invalid JSON: %s", body)
+	}
+	ctx.recordConditionOutput(config.outputKey(), json.RawMessage(body))
+
+	// 3. 读取 bool 结果
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return false, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	path := config.API.ResponseMapping.Path
+	value, err := getValueByPath(data, path)
+	if err != nil {
+		return false, fmt.Errorf("failed to get value by path %q: %w", path, err)
+	}
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("decision result at path %q is not a bool: %T", path, value)
+	}
+	return result, nil
+}
@@ -0,0 +1,563 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath 编译后的 JSONPath 路径
+// 用于条件字段(NumericConditionConfig.Field 等)、参数映射(ParamMapping.Path)和响应映射(ResponseMapping.Path)
+//
+// 支持的语法:
+//   - 根节点: $(可省略,items[0] 与 $.items[0] 相同)
+//   - 字段: .name、['name']、["name"],字段名中的 . [ 和 \ 可以用 \ 转义(如 a\.b),引号中的字段名可以包含任意字符
+//   - 数组下标: [0]、[-1](从末尾计数)
+//   - 通配符: [*]、.*(数组的所有元素或对象的所有字段值)
+//   - 切片: [start:end:step](与 Python 切片相同)
+//   - 并集: [0,2]、['a','b']
+//   - 递归下降: ..name、..*、..[0]
+//   - 过滤: [?(@.price > 100 && @.sku in ['a', 'b'])],过滤条件使用表达式条件的语法,@ 为当前元素,$ 为根节点
+//
+// 只包含字段和下标的路径为确定路径,返回单个值;包含通配符、切片、并集、递归下降或过滤的路径返回所有匹配值的数组
+type JSONPath struct {
+	path     string
+	segments []pathSegment
+}
+
+// pathSegment 路径中的一段
+type pathSegment struct {
+	recursive bool           // 递归下降(..)
+	selectors []pathSelector // 多个选择器时为并集
+}
+
+// pathSelectorKind 选择器类型
+type pathSelectorKind int
+
+const (
+	selectName pathSelectorKind = iota
+	selectIndex
+	selectWildcard
+	selectSlice
+	selectFilter
+)
+
+// pathSelector 选择器
+type pathSelector struct {
+	kind   pathSelectorKind
+	name   string
+	index  int
+	slice  [3]*int // start、end、step
+	filter *exprProgram
+}
+
+// jsonPaths 编译后的路径缓存,键为路径
+var jsonPaths = newCompileCache[*JSONPath](defaultCompileCacheSize)
+
+// CompileJSONPath 编译 JSONPath 路径
+func CompileJSONPath(path string) (*JSONPath, error) {
+	return jsonPaths.get(path, compileJSONPath)
+}
+
+// compileJSONPath 解析 JSONPath 路径
+func compileJSONPath(path string) (*JSONPath, error) {
+	p := &jsonPathParser{src: path}
+	segments, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", path, err)
+	}
+	return &JSONPath{path: path, segments: segments}, nil
+}
+
+// String 返回路径
+func (p *JSONPath) String() string {
+	return p.path
+}
+
+// Definite 判断路径是否为确定路径(只包含字段和下标)
+func (p *JSONPath) Definite() bool {
+	for _, seg := range p.segments {
+		if seg.recursive || len(seg.selectors) != 1 {
+			return false
+		}
+		if kind := seg.selectors[0].kind; kind != selectName && kind != selectIndex {
+			return false
+		}
+	}
+	return true
+}
+
+// Get 按路径读取值
+// 确定路径返回单个值,字段或下标不存在时返回错误;其他路径返回所有匹配值的数组(可能为空)
+// data 为 json.Unmarshal 解码得到的值(map[string]interface{}、[]interface{} 等)
+func (p *JSONPath) Get(data interface{}) (interface{}, error) {
+	if !p.Definite() {
+		return p.Query(data), nil
+	}
+
+	current := data
+	for _, seg := range p.segments {
+		sel := seg.selectors[0]
+		switch sel.kind {
+		case selectName:
+			m, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("path %q is invalid: expected object at %q", p.path, sel.name)
+			}
+			value, exists := m[sel.name]
+			if !exists {
+				return nil, fmt.Errorf("path %q is invalid: field %q not found", p.path, sel.name)
+			}
+			current = value
+		case selectIndex:
+			arr, ok := current.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("path %q is invalid: expected array at [%d]", p.path, sel.index)
+			}
+			index, ok := normalizeIndex(sel.index, len(arr))
+			if !ok {
+				return nil, fmt.Errorf("path %q is invalid: index %d out of range", p.path, sel.index)
+			}
+			current = arr[index]
+		}
+	}
+	return current, nil
+}
+
+// Query 按路径返回所有匹配的值
+func (p *JSONPath) Query(data interface{}) []interface{} {
+	nodes := []interface{}{data}
+	for _, seg := range p.segments {
+		next := []interface{}{}
+		for _, node := range nodes {
+			if seg.recursive {
+				for _, descendant := range descendants(node) {
+					next = append(next, seg.apply(descendant, data)...)
+				}
+				continue
+			}
+			next = append(next, seg.apply(node, data)...)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// apply 对节点应用路径段的所有选择器
+func (seg pathSegment) apply(node, root interface{}) []interface{} {
+	var result []interface{}
+	for _, sel := range seg.selectors {
+		result = append(result, sel.apply(node, root)...)
+	}
+	return result
+}
+
+// apply 对节点应用选择器
+func (sel pathSelector) apply(node, root interface{}) []interface{} {
+	switch sel.kind {
+	case selectName:
+		if m, ok := node.(map[string]interface{}); ok {
+			if value, exists := m[sel.name]; exists {
+				return []interface{}{value}
+			}
+		}
+	case selectIndex:
+		if arr, ok := node.([]interface{}); ok {
+			if index, ok := normalizeIndex(sel.index, len(arr)); ok {
+				return []interface{}{arr[index]}
+			}
+		}
+	case selectWildcard:
+		return children(node)
+	case selectSlice:
+		if arr, ok := node.([]interface{}); ok {
+			return sliceArray(arr, sel.slice)
+		}
+	case selectFilter:
+		var result []interface{}
+		for _, child := range children(node) {
+			env := &exprEnv{current: child, root: root}
+			value, err := env.eval(sel.filter.root)
+			if err != nil {
+				continue // 过滤条件求值失败(如类型不匹配)的元素不匹配
+			}
+			if matched, err := truthy(0, value); err == nil && matched {
+				result = append(result, child)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// children 返回数组的所有元素或对象按字段名排序的所有字段值
+func children(node interface{}) []interface{} {
+	switch n := node.(type) {
+	case []interface{}:
+		return n
+	case map[string]interface{}:
+		keys := make([]string, 0, len(n))
+		for key := range n {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = n[key]
+		}
+		return values
+	}
+	return nil
+}
+
+// descendants 返回节点自身及所有后代节点(先序)
+func descendants(node interface{}) []interface{} {
+	result := []interface{}{node}
+	for _, child := range children(node) {
+		result = append(result, descendants(child)...)
+	}
+	return result
+}
+
+// normalizeIndex 将负数下标转换为从头计数的下标
+func normalizeIndex(index, length int) (int, bool) {
+	if index < 0 {
+		index += length
+	}
+	return index, index >= 0 && index < length
+}
+
+// sliceArray 按切片选择数组元素
+func sliceArray(arr []interface{}, slice [3]*int) []interface{} {
+	step := 1
+	if slice[2] != nil {
+		step = *slice[2]
+	}
+	if step == 0 {
+		return nil
+	}
+
+	length := len(arr)
+	bound := func(p *int, def int) int {
+		if p == nil {
+			return def
+		}
+		i := *p
+		if i < 0 {
+			i += length
+		}
+		if step > 0 {
+			return min(max(i, 0), length)
+		}
+		return min(max(i, -1), length-1)
+	}
+
+	var result []interface{}
+	if step > 0 {
+		for i := bound(slice[0], 0); i < bound(slice[1], length); i += step {
+			result = append(result, arr[i])
+		}
+	} else {
+		for i := bound(slice[0], length-1); i > bound(slice[1], -1); i += step {
+			result = append(result, arr[i])
+		}
+	}
+	return result
+}
+
+// jsonPathParser JSONPath 语法分析器
+type jsonPathParser struct {
+	src string
+	pos int
+}
+
+// parse 解析路径为路径段
+func (p *jsonPathParser) parse() ([]pathSegment, error) {
+	p.src = strings.TrimSpace(p.src)
+	if p.src == "" {
+		return nil, fmt.Errorf("path is empty")
+	}
+
+	var segments []pathSegment
+	if p.src[0] == '$' {
+		p.pos++
+	} else if p.src[0] != '[' && p.src[0] != '.' {
+		// 省略 $ 时第一个字段名前没有 .
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, pathSegment{selectors: []pathSelector{{kind: selectName, name: name}}})
+	}
+
+	for p.pos < len(p.src) {
+		seg := pathSegment{}
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], ".."):
+			p.pos += 2
+			seg.recursive = true
+			if p.pos < len(p.src) && p.src[p.pos] == '[' {
+				selectors, err := p.parseBracket()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = selectors
+				break
+			}
+			selector, err := p.parseDotSelector()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = []pathSelector{selector}
+		case p.src[p.pos] == '.':
+			p.pos++
+			selector, err := p.parseDotSelector()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = []pathSelector{selector}
+		case p.src[p.pos] == '[':
+			selectors, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = selectors
+		default:
+			return nil, fmt.Errorf("at position %d: unexpected %q", p.pos, p.src[p.pos])
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+// parseDotSelector 解析 . 之后的字段名或通配符
+func (p *jsonPathParser) parseDotSelector() (pathSelector, error) {
+	if p.pos < len(p.src) && p.src[p.pos] == '*' {
+		p.pos++
+		return pathSelector{kind: selectWildcard}, nil
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return pathSelector{}, err
+	}
+	return pathSelector{kind: selectName, name: name}, nil
+}
+
+// parseName 解析未加引号的字段名,遇到未转义的 . 或 [ 结束
+func (p *jsonPathParser) parseName() (string, error) {
+	start := p.pos
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '.' || c == '[' {
+			break
+		}
+		if c == '\\' {
+			if p.pos+1 >= len(p.src) {
+				return "", fmt.Errorf("at position %d: dangling escape", p.pos)
+			}
+			p.pos++
+			c = p.src[p.pos]
+		}
+		sb.WriteByte(c)
+		p.pos++
+	}
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("at position %d: field name is empty", start)
+	}
+	return sb.String(), nil
+}
+
+// parseBracket 解析 [...] 中的选择器
+func (p *jsonPathParser) parseBracket() ([]pathSelector, error) {
+	start := p.pos
+	p.pos++ // [
+	p.skipSpaces()
+
+	if p.pos < len(p.src) && p.src[p.pos] == '?' {
+		p.pos++
+		end, err := p.findBracketEnd()
+		if err != nil {
+			return nil, err
+		}
+		program, err := compileFilter(p.src[p.pos:end])
+		if err != nil {
+			return nil, fmt.Errorf("at position %d: invalid filter: %w", p.pos, err)
+		}
+		p.pos = end + 1
+		return []pathSelector{{kind: selectFilter, filter: program}}, nil
+	}
+
+	if p.pos < len(p.src) && p.src[p.pos] == '*' {
+		p.pos++
+		p.skipSpaces()
+		if err := p.expect(']'); err != nil {
+			return nil, err
+		}
+		return []pathSelector{{kind: selectWildcard}}, nil
+	}
+
+	var selectors []pathSelector
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.src) {
+			return nil, fmt.Errorf("at position %d: unterminated '['", start)
+		}
+		var selector pathSelector
+		var err error
+		if c := p.src[p.pos]; c == '\'' || c == '"' {
+			selector.kind = selectName
+			selector.name, err = p.parseQuoted()
+		} else {
+			selector, err = p.parseIndexOrSlice()
+		}
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+
+		p.skipSpaces()
+		if p.pos < len(p.src) && p.src[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		if err := p.expect(']'); err != nil {
+			return nil, err
+		}
+		return selectors, nil
+	}
+}
+
+// parseQuoted 解析引号中的字段名,支持 \ 转义
+func (p *jsonPathParser) parseQuoted() (string, error) {
+	quote := p.src[p.pos]
+	start := p.pos
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			sb.WriteByte(p.src[p.pos])
+		default:
+			sb.WriteByte(c)
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("at position %d: unterminated string", start)
+}
+
+// parseIndexOrSlice 解析下标或切片
+func (p *jsonPathParser) parseIndexOrSlice() (pathSelector, error) {
+	var parts [3]*int
+	part := 0
+	for {
+		p.skipSpaces()
+		start := p.pos
+		for p.pos < len(p.src) && (p.src[p.pos] == '-' || p.src[p.pos] >= '0' && p.src[p.pos] <= '9') {
+			p.pos++
+		}
+		if p.pos > start {
+			n, err := strconv.Atoi(p.src[start:p.pos])
+			if err != nil {
+				return pathSelector{}, fmt.Errorf("at position %d: invalid index %q", start, p.src[start:p.pos])
+			}
+			parts[part] = &n
+		}
+		p.skipSpaces()
+		if p.pos < len(p.src) && p.src[p.pos] == ':' && part < 2 {
+			p.pos++
+			part++
+			continue
+		}
+		break
+	}
+
+	if part == 0 {
+		if parts[0] == nil {
+			if p.pos < len(p.src) {
+				return pathSelector{}, fmt.Errorf("at position %d: unexpected %q", p.pos, p.src[p.pos])
+			}
+			return pathSelector{}, fmt.Errorf("at position %d: unterminated '['", p.pos)
+		}
+		return pathSelector{kind: selectIndex, index: *parts[0]}, nil
+	}
+	return pathSelector{kind: selectSlice, slice: parts}, nil
+}
+
+// findBracketEnd 查找过滤条件结束的 ],跳过引号中的内容和嵌套的括号
+func (p *jsonPathParser) findBracketEnd() (int, error) {
+	depth := 0
+	for i := p.pos; i < len(p.src); i++ {
+		switch c := p.src[i]; c {
+		case '\'', '"':
+			for i++; i < len(p.src) && p.src[i] != c; i++ {
+				if p.src[i] == '\\' {
+					i++
+				}
+			}
+		case '[', '(':
+			depth++
+		case ')':
+			depth--
+		case ']':
+			if depth == 0 {
+				return i, nil
+			}
+			depth--
+		}
+	}
+	return 0, fmt.Errorf("at position %d: unterminated filter", p.pos)
+}
+
+// skipSpaces 跳过空白
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// expect 要求当前字符为 c
+func (p *jsonPathParser) expect(c byte) error {
+	if p.pos >= len(p.src) || p.src[p.pos] != c {
+		return fmt.Errorf("at position %d: expected %q", p.pos, c)
+	}
+	p.pos++
+	return nil
+}
+
+// getValueByPath 根据 JSONPath 路径获取值
+// 确定路径(如 "approvers"、"data.approvers"、"$.items[0].price")返回单个值,字段不存在时返回错误;
+// 包含通配符或过滤的路径(如 "data.list[*].user_id")返回所有匹配值的数组
+func getValueByPath(data interface{}, path string) (interface{}, error) {
+	compiled, err := CompileJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.Get(data)
+}
+
+// splitNodePath 将节点输出路径拆分为节点 ID 和节点输出中的子路径
+// 路径的第一个字段为节点 ID,如 "risk_check.items[0].price";只有节点 ID 时子路径为 nil
+func splitNodePath(path string) (string, *JSONPath, error) {
+	compiled, err := CompileJSONPath(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(compiled.segments) == 0 {
+		return "", nil, fmt.Errorf("path %q must start with a node ID", path)
+	}
+	first := compiled.segments[0]
+	if first.recursive || len(first.selectors) != 1 || first.selectors[0].kind != selectName {
+		return "", nil, fmt.Errorf("path %q must start with a node ID", path)
+	}
+	if len(compiled.segments) == 1 {
+		return first.selectors[0].name, nil, nil
+	}
+	return first.selectors[0].name, &JSONPath{path: path, segments: compiled.segments[1:]}, nil
+}
@@ -7,22 +7,22 @@ import (
 
 // NumericConditionConfig 数值比较条件配置
 type NumericConditionConfig struct {
-	// Field 字段名(JSON 路径)
-	Field string
+	// Field 字段名或 JSONPath 路径(如 "amount"、"$.items[0].price")
+	Field string `json:"field"`
 
 	// Operator 比较操作符
 	// 支持: "gt"(大于), "lt"(小于), "eq"(等于), "gte"(大于等于), "lte"(小于等于)
-	Operator string
+	Operator string `json:"operator"`
 
 	// Value 比较值
-	Value float64
+	Value float64 `json:"value"`
 
 	// Source 数据源
 	// 支持: "task_params"(任务参数), "node_outputs"(节点输出数据)
-	Source string
+	Source string `json:"source,omitempty"`
 
 	// NodeID 节点 ID(当 Source 为 "node_outputs" 时必填)
-	NodeID string
+	NodeID string `json:"node_id,omitempty"`
 }
 
 // ConditionType 返回条件类型(实现 ConditionConfig 接口)
@@ -30,6 +30,18 @@ func (c *NumericConditionConfig) ConditionType() string {
 	return "numeric"
 }
 
+// Validate 验证配置的有效性
+// 检查 Field 是否为有效的 JSONPath 路径
+func (c *NumericConditionConfig) Validate() error {
+	if c.Field == "" {
+		return nil
+	}
+	if _, err := CompileJSONPath(c.Field); err != nil {
+		return fmt.Errorf("NumericConditionConfig.Field is invalid: %w", err)
+	}
+	return nil
+}
+
 // NumericConditionEvaluator 数值比较条件评估器
 type NumericConditionEvaluator struct{}
 
@@ -81,14 +93,15 @@ func (e *NumericConditionEvaluator) getValue(config *NumericConditionConfig, ctx
 	}
 
 	// 解析 JSON 获取字段值
-	var jsonData map[string]interface{}
+	var jsonData interface{}
 	if err := json.Unmarshal(data, &jsonData); err != nil {
 		return 0, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	fieldValue, exists := jsonData[config.Field]
-	if !exists {
-		return 0, fmt.Errorf("field not found: %q", config.Field)
+	// 按 JSONPath 路径读取字段值,如 "amount"、"$.items[0].price"
+	fieldValue, err := getValueByPath(jsonData, config.Field)
+	if err != nil {
+		return 0, fmt.Errorf("field not found: %q: %w", config.Field, err)
 	}
 
 	// 转换为 float64
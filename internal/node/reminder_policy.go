@@ -0,0 +1,43 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// ReminderPolicy 自动催办配置
+// 节点激活后每隔 Interval 自动催办一次尚未审批的审批人,节点完成后停止
+// 例如: Interval 为 4h、MaxTimes 为 3 时,在激活后 4h、8h、12h 各催办一次
+type ReminderPolicy struct {
+	// Interval 催办间隔
+	Interval time.Duration
+
+	// MaxTimes 最多自动催办次数,0 表示不限次数
+	MaxTimes int
+}
+
+// Validate 验证自动催办配置的有效性
+func (p *ReminderPolicy) Validate() error {
+	if p.Interval <= 0 {
+		return fmt.Errorf("%w: reminder interval must be greater than 0", errors.ErrInvalidTemplate)
+	}
+	if p.MaxTimes < 0 {
+		return fmt.Errorf("%w: reminder max times must not be negative", errors.ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// GetReminderSchedule 返回自动催办计划(实现 ReminderPolicyAccessor 接口)
+// 未配置自动催办时返回 nil
+func (c *ApprovalNodeConfig) GetReminderSchedule() *template.ReminderSchedule {
+	if c.ReminderPolicy == nil {
+		return nil
+	}
+	return &template.ReminderSchedule{
+		Interval: c.ReminderPolicy.Interval,
+		MaxTimes: c.ReminderPolicy.MaxTimes,
+	}
+}
@@ -0,0 +1,140 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// skippedNodeOutput 审批人列表为空而被跳过的审批节点的输出
+// 结果为 approve,后续条件节点按节点通过处理
+var skippedNodeOutput = json.RawMessage(`{"result": "approve", "skipped": true}`)
+
+// skipsEmptyApprovers 判断审批节点生效的跳过规则是否在审批人列表为空时跳过节点
+func skipsEmptyApprovers(tpl *template.Template, tplNode *template.Node) bool {
+	policy := template.EffectiveSkipPolicy(tpl, tplNode)
+	return policy != nil && policy.EmptyApprovers == template.SkipActionSkip
+}
+
+// applySkipPolicy 审批节点激活时应用跳过规则
+// 按规则跳过节点、将发起人替换为其上级、自动同意重复的审批人或发起人,并生成系统审批记录
+// 返回: 节点是否被跳过(视为通过)
+func (e *FlowEngine) applySkipPolicy(ctx context.Context, tpl *template.Template, tplNode *template.Node, tsk *task.Task) (bool, error) {
+	if tplNode.Type != template.NodeTypeApproval {
+		return false, nil
+	}
+	policy := template.EffectiveSkipPolicy(tpl, tplNode)
+	if policy == nil {
+		return false, nil
+	}
+
+	nodeID := tplNode.ID
+	now := time.Now()
+
+	if len(tsk.Approvers[nodeID]) == 0 {
+		if policy.EmptyApprovers != template.SkipActionSkip {
+			return false, nil
+		}
+		task.AppendSystemRecord(tsk, nodeID, task.RecordResultSkipNode, "node skipped: no approvers resolved", now)
+		return true, nil
+	}
+
+	if policy.InitiatorApprover == template.SkipActionEscalate {
+		if err := e.escalateInitiator(ctx, tsk, nodeID, now); err != nil {
+			return false, err
+		}
+	}
+
+	for _, approver := range tsk.Approvers[nodeID] {
+		if _, decided := tsk.Approvals[nodeID][approver]; decided {
+			continue
+		}
+
+		var reason string
+		if policy.InitiatorApprover == template.SkipActionAutoApprove && tsk.Initiator != "" && approver == tsk.Initiator {
+			reason = fmt.Sprintf("approver %q is the initiator", approver)
+		} else if policy.DuplicateApprover == template.SkipActionAutoApprove {
+			if approvedNodeID := priorApprovedNode(tsk, nodeID, approver); approvedNodeID != "" {
+				reason = fmt.Sprintf("approver %q already approved node %q", approver, approvedNodeID)
+			}
+		}
+		if reason == "" {
+			continue
+		}
+
+		comment := "auto-approved: " + reason
+		if tsk.Approvals == nil {
+			tsk.Approvals = make(map[string]map[string]*task.Approval)
+		}
+		if tsk.Approvals[nodeID] == nil {
+			tsk.Approvals[nodeID] = make(map[string]*task.Approval)
+		}
+		tsk.Approvals[nodeID][approver] = &task.Approval{Result: "approve", Comment: comment, CreatedAt: now}
+		task.AppendSystemRecord(tsk, nodeID, task.RecordResultSkipApprover, comment, now)
+	}
+	return false, nil
+}
+
+// escalateInitiator 审批人中有任务发起人时,将发起人替换为其上级
+// 上级通过流程引擎的组织架构目录查询,上级已是审批人时只移除发起人
+func (e *FlowEngine) escalateInitiator(ctx context.Context, tsk *task.Task, nodeID string, at time.Time) error {
+	approvers := tsk.Approvers[nodeID]
+	index := -1
+	for i, approver := range approvers {
+		if tsk.Initiator != "" && approver == tsk.Initiator {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+
+	if e.directory == nil {
+		return fmt.Errorf("directory is required to escalate initiator %q", tsk.Initiator)
+	}
+	manager, err := e.directory.GetManager(ctx, tsk.Initiator)
+	if err != nil {
+		return fmt.Errorf("failed to get manager of initiator %q: %w", tsk.Initiator, err)
+	}
+	if manager == "" {
+		return fmt.Errorf("%w: initiator %q has no manager to escalate to", errors.ErrApproverNotFound, tsk.Initiator)
+	}
+
+	// 在发起人的位置替换为上级,保持顺序审批的顺序
+	result := make([]string, 0, len(approvers))
+	seen := make(map[string]bool, len(approvers))
+	for i, approver := range approvers {
+		if i == index {
+			approver = manager
+		}
+		if !seen[approver] {
+			seen[approver] = true
+			result = append(result, approver)
+		}
+	}
+	tsk.Approvers[nodeID] = result
+
+	comment := fmt.Sprintf("approver %q is the initiator, escalated to manager %q", tsk.Initiator, manager)
+	task.AppendSystemRecord(tsk, nodeID, task.RecordResultEscalateInitiator, comment, at)
+	return nil
+}
+
+// priorApprovedNode 返回审批人已同意过的之前完成的节点 ID,没有时返回空字符串
+func priorApprovedNode(tsk *task.Task, nodeID string, approver string) string {
+	completed := make(map[string]bool, len(tsk.CompletedNodes))
+	for _, completedNodeID := range tsk.CompletedNodes {
+		completed[completedNodeID] = true
+	}
+	for _, record := range tsk.Records {
+		if record.Approver == approver && record.Result == "approve" && record.NodeID != nodeID && completed[record.NodeID] {
+			return record.NodeID
+		}
+	}
+	return ""
+}
@@ -8,22 +8,22 @@ import (
 
 // StringConditionConfig 字符串匹配条件配置
 type StringConditionConfig struct {
-	// Field 字段名(JSON 路径)
-	Field string
+	// Field 字段名或 JSONPath 路径(如 "amount"、"$.items[0].price")
+	Field string `json:"field"`
 
 	// Operator 匹配操作符
 	// 支持: "eq"(等于), "contains"(包含), "starts_with"(以...开始), "ends_with"(以...结束)
-	Operator string
+	Operator string `json:"operator"`
 
 	// Value 匹配值
-	Value string
+	Value string `json:"value"`
 
 	// Source 数据源
 	// 支持: "task_params"(任务参数), "node_outputs"(节点输出数据)
-	Source string
+	Source string `json:"source,omitempty"`
 
 	// NodeID 节点 ID(当 Source 为 "node_outputs" 时必填)
-	NodeID string
+	NodeID string `json:"node_id,omitempty"`
 }
 
 // ConditionType 返回条件类型(实现 ConditionConfig 接口)
@@ -31,6 +31,18 @@ func (c *StringConditionConfig) ConditionType() string {
 	return "string"
 }
 
+// Validate 验证配置的有效性
+// 检查 Field 是否为有效的 JSONPath 路径
+func (c *StringConditionConfig) Validate() error {
+	if c.Field == "" {
+		return nil
+	}
+	if _, err := CompileJSONPath(c.Field); err != nil {
+		return fmt.Errorf("StringConditionConfig.Field is invalid: %w", err)
+	}
+	return nil
+}
+
 // StringConditionEvaluator 字符串匹配条件评估器
 type StringConditionEvaluator struct{}
 
@@ -82,14 +94,15 @@ func (e *StringConditionEvaluator) getValue(config *StringConditionConfig, ctx *
 	}
 
 	// 解析 JSON 获取字段值
-	var jsonData map[string]interface{}
+	var jsonData interface{}
 	if err := json.Unmarshal(data, &jsonData); err != nil {
 		return "", fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	fieldValue, exists := jsonData[config.Field]
-	if !exists {
-		return "", fmt.Errorf("field not found: %q", config.Field)
+	// 按 JSONPath 路径读取字段值,如 "amount"、"$.items[0].price"
+	fieldValue, err := getValueByPath(jsonData, config.Field)
+	if err != nil {
+		return "", fmt.Errorf("field not found: %q: %w", config.Field, err)
 	}
 
 	// 转换为 string
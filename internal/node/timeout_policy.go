@@ -0,0 +1,112 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// TimeoutActionType 超时动作类型
+type TimeoutActionType string
+
+const (
+	// TimeoutActionNotify 发送提醒,节点继续等待审批
+	TimeoutActionNotify TimeoutActionType = "notify"
+
+	// TimeoutActionEscalate 升级审批: 尚未审批的审批人替换为升级目标,节点继续等待审批
+	TimeoutActionEscalate TimeoutActionType = "escalate"
+
+	// TimeoutActionAutoApprove 自动同意: 尚未审批的审批人视为同意,按审批模式判断节点结果
+	TimeoutActionAutoApprove TimeoutActionType = "auto_approve"
+
+	// TimeoutActionAutoReject 自动拒绝: 尚未审批的审批人视为拒绝,按审批模式和拒绝后行为处理
+	TimeoutActionAutoReject TimeoutActionType = "auto_reject"
+
+	// TimeoutActionTerminate 任务进入 timeout 终态
+	TimeoutActionTerminate TimeoutActionType = "terminate"
+)
+
+// TimeoutPolicy 超时策略
+// 按触发偏移依次执行超时动作,偏移从节点激活时间开始计算
+// 例如: 24h 提醒、48h 升级、72h 自动拒绝
+type TimeoutPolicy struct {
+	// Actions 超时动作列表
+	Actions []TimeoutAction `json:"actions"`
+}
+
+// TimeoutAction 超时动作
+type TimeoutAction struct {
+	// Type 动作类型
+	Type TimeoutActionType `json:"type"`
+
+	// After 相对节点激活时间的触发偏移
+	After time.Duration `json:"after"`
+
+	// EscalateTo 升级目标审批人(仅用于 TimeoutActionEscalate)
+	// 为空时使用任务管理器的升级审批人解析函数(task.WithEscalationResolver)
+	// 仍无法确定升级目标时记录升级失败,审批人保持不变,继续执行后续超时动作
+	EscalateTo []string `json:"escalate_to,omitempty"`
+}
+
+// Validate 验证超时策略的有效性
+// 动作的触发偏移必须大于 0 且不能重复;终结动作(自动同意、自动拒绝、终止)之后不能再有其他动作
+func (p *TimeoutPolicy) Validate() error {
+	if len(p.Actions) == 0 {
+		return fmt.Errorf("%w: timeout policy must have at least one action", errors.ErrInvalidTemplate)
+	}
+
+	actions := p.sortedActions()
+	for i, action := range actions {
+		switch action.Type {
+		case TimeoutActionNotify, TimeoutActionEscalate:
+		case TimeoutActionAutoApprove, TimeoutActionAutoReject, TimeoutActionTerminate:
+			if i != len(actions)-1 {
+				return fmt.Errorf("%w: timeout action %q must be the last action", errors.ErrInvalidTemplate, action.Type)
+			}
+		default:
+			return fmt.Errorf("%w: invalid timeout action type: %q", errors.ErrInvalidTemplate, action.Type)
+		}
+
+		if action.After <= 0 {
+			return fmt.Errorf("%w: timeout action %q offset must be greater than 0", errors.ErrInvalidTemplate, action.Type)
+		}
+		if i > 0 && action.After == actions[i-1].After {
+			return fmt.Errorf("%w: duplicate timeout action offset %s", errors.ErrInvalidTemplate, action.After)
+		}
+	}
+	return nil
+}
+
+// sortedActions 返回按触发偏移升序排列的动作副本
+func (p *TimeoutPolicy) sortedActions() []TimeoutAction {
+	actions := make([]TimeoutAction, len(p.Actions))
+	copy(actions, p.Actions)
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].After < actions[j].After
+	})
+	return actions
+}
+
+// GetTimeoutActions 返回按触发偏移升序排列的超时动作(实现 TimeoutPolicyAccessor 接口)
+// 未配置超时策略时返回 nil,此时按 Timeout 配置在超时后终止任务
+func (c *ApprovalNodeConfig) GetTimeoutActions() []template.TimeoutActionSpec {
+	if c.TimeoutPolicy == nil {
+		return nil
+	}
+
+	actions := c.TimeoutPolicy.sortedActions()
+	specs := make([]template.TimeoutActionSpec, len(actions))
+	for i, action := range actions {
+		escalateTo := make([]string, len(action.EscalateTo))
+		copy(escalateTo, action.EscalateTo)
+		specs[i] = template.TimeoutActionSpec{
+			Type:       string(action.Type),
+			After:      action.After,
+			EscalateTo: escalateTo,
+		}
+	}
+	return specs
+}
@@ -1,7 +1,9 @@
 package node
 
 import (
+	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mautops/approval-kit/internal/task"
 	"github.com/mautops/approval-kit/internal/template"
@@ -11,6 +13,25 @@ import (
 // 遍历模板中的所有节点,查找配置为 on_create 时机的动态审批人节点并获取审批人
 // 这个函数应该在 TaskManager.Create 中调用,但由于循环依赖,需要通过依赖注入的方式调用
 func FetchApproversOnCreate(tpl *template.Template, tsk *task.Task, httpClient HTTPClient) error {
+	return FetchApproversOnCreateWithDelegation(tpl, tsk, httpClient, nil)
+}
+
+// FetchApproversOnCreateWithDelegation 在任务创建时获取审批人,并按委托规则替换审批人
+// 与 FetchApproversOnCreate 相同,获取到的审批人中在任务创建时有生效委托规则的,替换为代理人
+// delegations: 委托规则存储(可选),为 nil 时不替换
+func FetchApproversOnCreateWithDelegation(tpl *template.Template, tsk *task.Task, httpClient HTTPClient, delegations task.DelegationStore) error {
+	return fetchApproversOnCreate(tpl, tsk, httpClient, delegations, nil)
+}
+
+// FetchApproversOnCreate 在任务创建时获取审批人
+// 使用流程引擎的 HTTP 客户端、委托规则存储和组织架构目录,
+// 可以作为 TaskManager 的审批人获取函数
+func (e *FlowEngine) FetchApproversOnCreate(tpl *template.Template, tsk *task.Task) error {
+	return fetchApproversOnCreate(tpl, tsk, e.httpClient, e.delegations, e.directory)
+}
+
+// fetchApproversOnCreate 获取模板中所有获取时机为 on_create 的审批节点的审批人
+func fetchApproversOnCreate(tpl *template.Template, tsk *task.Task, httpClient HTTPClient, delegations task.DelegationStore, directory Directory) error {
 	// 遍历模板中的所有节点
 	for _, tplNode := range tpl.Nodes {
 		if tplNode.Type != template.NodeTypeApproval {
@@ -41,22 +62,29 @@ func FetchApproversOnCreate(tpl *template.Template, tsk *task.Task, httpClient H
 		}
 
 		// 创建节点上下文
-		ctx := &NodeContext{
-			Task:    tsk,
-			Node:    tplNode,
-			Params:  tsk.Params,
-			Outputs: make(map[string]json.RawMessage),
-			Cache:   NewContextCache(),
+		nc := &NodeContext{
+			Task:      tsk,
+			Node:      tplNode,
+			Params:    tsk.Params,
+			Outputs:   make(map[string]json.RawMessage),
+			Cache:     NewContextCache(),
+			Directory: directory,
 		}
 
 		// 获取审批人列表
-		approvers, err := config.ApproverConfig.GetApprovers(ctx)
+		approvers, err := config.ApproverConfig.GetApprovers(context.Background(), nc)
 		if err != nil {
 			// 如果获取失败,记录错误但不阻止任务创建
 			// 审批人可以在节点激活时重新获取
 			continue
 		}
 
+		// 按委托规则替换审批人
+		approvers, err = task.ApplyDelegations(delegations, tsk, tplNode.ID, approvers, time.Now())
+		if err != nil {
+			return err
+		}
+
 		// 保存审批人列表
 		if tsk.Approvers == nil {
 			tsk.Approvers = make(map[string][]string)
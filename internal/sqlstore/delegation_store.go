@@ -0,0 +1,120 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/task"
+)
+
+// DelegationStore 基于 database/sql 的委托规则存储
+// 每条委托规则保存为 approval_delegations 表的一行
+type DelegationStore struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+var _ task.DelegationStore = (*DelegationStore)(nil)
+
+// delegationColumns approval_delegations 表的列,顺序与 scanDelegation 一致
+const delegationColumns = `id, delegator, delegate, template_id, start_at, end_at, reason`
+
+// NewDelegationStore 创建 SQL 委托规则存储
+// 创建时执行表结构迁移
+func NewDelegationStore(db *sql.DB, opts ...Option) (*DelegationStore, error) {
+	if err := Migrate(db, opts...); err != nil {
+		return nil, err
+	}
+	return &DelegationStore{db: db, dialect: newOptions(opts).dialect}, nil
+}
+
+// Save 保存委托规则,ID 已存在时替换
+func (s *DelegationStore) Save(rule *task.DelegationRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(s.dialect.rebind(`DELETE FROM approval_delegations WHERE id = ?`), rule.ID); err != nil {
+		return fmt.Errorf("failed to replace delegation %q: %w", rule.ID, err)
+	}
+	_, err = tx.Exec(s.dialect.rebind(`INSERT INTO approval_delegations (`+delegationColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		rule.ID, rule.Delegator, rule.Delegate, rule.TemplateID, rule.StartAt.UnixNano(), rule.EndAt.UnixNano(), rule.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to insert delegation %q: %w", rule.ID, err)
+	}
+	return tx.Commit()
+}
+
+// Delete 删除委托规则
+func (s *DelegationStore) Delete(id string) error {
+	result, err := s.db.Exec(s.dialect.rebind(`DELETE FROM approval_delegations WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete delegation %q: %w", id, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("%w: %q", errors.ErrDelegationNotFound, id)
+	}
+	return nil
+}
+
+// List 查询委托人的所有委托规则,按生效时间排序
+func (s *DelegationStore) List(delegator string) ([]*task.DelegationRule, error) {
+	query := `SELECT ` + delegationColumns + ` FROM approval_delegations`
+	var args []interface{}
+	if delegator != "" {
+		query += ` WHERE delegator = ?`
+		args = append(args, delegator)
+	}
+	query += ` ORDER BY start_at, id`
+
+	rows, err := s.db.Query(s.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delegations: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDelegations(rows)
+}
+
+// FindActive 查找委托人在指定时间对指定模板生效的委托规则
+func (s *DelegationStore) FindActive(delegator string, templateID string, at time.Time) (*task.DelegationRule, error) {
+	rows, err := s.db.Query(s.dialect.rebind(`SELECT `+delegationColumns+` FROM approval_delegations
+		WHERE delegator = ? AND start_at <= ? AND end_at > ?`), delegator, at.UnixNano(), at.UnixNano())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delegations of %q: %w", delegator, err)
+	}
+	defer rows.Close()
+
+	rules, err := scanDelegations(rows)
+	if err != nil {
+		return nil, err
+	}
+	return task.ActiveDelegationRule(rules, templateID, at), nil
+}
+
+// scanDelegations 从查询结果中读取委托规则
+func scanDelegations(rows *sql.Rows) ([]*task.DelegationRule, error) {
+	var rules []*task.DelegationRule
+	for rows.Next() {
+		rule := &task.DelegationRule{}
+		var startAt, endAt int64
+		if err := rows.Scan(&rule.ID, &rule.Delegator, &rule.Delegate, &rule.TemplateID, &startAt, &endAt, &rule.Reason); err != nil {
+			return nil, err
+		}
+		rule.StartAt = time.Unix(0, startAt)
+		rule.EndAt = time.Unix(0, endAt)
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
@@ -0,0 +1,249 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+)
+
+// Outbox 基于 database/sql 的事件发件箱
+// 事件以 JSON 保存在 approval_outbox_entries 表,每个处理器的投递状态保存在 approval_outbox_deliveries 表
+// 与 TaskStore 使用同一个数据库时,任务操作产生的事件通过 TaskStore.SaveWithEvents 与任务在同一事务中写入
+type Outbox struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+var _ event.Outbox = (*Outbox)(nil)
+
+// deliveryColumns approval_outbox_deliveries 表的列,顺序与 scanDelivery 一致
+const deliveryColumns = `entry_id, handler, status, attempts, last_error, next_attempt_at, updated_at`
+
+// NewOutbox 创建 SQL 事件发件箱
+// 创建时执行表结构迁移
+func NewOutbox(db *sql.DB, opts ...Option) (*Outbox, error) {
+	if err := Migrate(db, opts...); err != nil {
+		return nil, err
+	}
+	return &Outbox{db: db, dialect: newOptions(opts).dialect}, nil
+}
+
+// Append 写入事件
+func (o *Outbox) Append(entries ...*event.OutboxEntry) error {
+	tx, err := o.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := appendOutboxEntries(tx, o.dialect, entries); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Pending 返回处理器待投递的事件
+func (o *Outbox) Pending(handler string, now time.Time, limit int) ([]*event.OutboxDelivery, error) {
+	query := `SELECT e.id, e.event, e.created_at, d.status, d.attempts, d.last_error, d.next_attempt_at, d.updated_at
+		FROM approval_outbox_entries e
+		LEFT JOIN approval_outbox_deliveries d ON d.entry_id = e.id AND d.handler = ?
+		WHERE d.entry_id IS NULL OR (d.status = ? AND d.next_attempt_at <= ?)
+		ORDER BY e.created_at, e.id`
+	args := []interface{}{handler, string(event.DeliveryStatusPending), now.UnixNano()}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := o.db.Query(o.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending events of handler %q: %w", handler, err)
+	}
+	defer rows.Close()
+
+	var result []*event.OutboxDelivery
+	for rows.Next() {
+		var (
+			id, data                 string
+			createdAt                int64
+			status, lastError        sql.NullString
+			attempts                 sql.NullInt64
+			nextAttemptAt, updatedAt sql.NullInt64
+		)
+		if err := rows.Scan(&id, &data, &createdAt, &status, &attempts, &lastError, &nextAttemptAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		entry, err := decodeOutboxEntry(id, data, createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery := &event.Delivery{EntryID: id, Handler: handler, Status: event.DeliveryStatusPending}
+		if status.Valid {
+			delivery.Attempts = int(attempts.Int64)
+			delivery.LastError = lastError.String
+			delivery.NextAttemptAt = decodeDeliveryTime(nextAttemptAt.Int64)
+			delivery.UpdatedAt = decodeDeliveryTime(updatedAt.Int64)
+		}
+		result = append(result, &event.OutboxDelivery{Entry: entry, Delivery: delivery})
+	}
+	return result, rows.Err()
+}
+
+// UpdateDelivery 保存投递状态
+func (o *Outbox) UpdateDelivery(delivery *event.Delivery) error {
+	tx, err := o.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(o.dialect.rebind(`DELETE FROM approval_outbox_deliveries WHERE entry_id = ? AND handler = ?`), delivery.EntryID, delivery.Handler); err != nil {
+		return fmt.Errorf("failed to replace delivery of %q to handler %q: %w", delivery.EntryID, delivery.Handler, err)
+	}
+	_, err = tx.Exec(o.dialect.rebind(`INSERT INTO approval_outbox_deliveries (`+deliveryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		delivery.EntryID, delivery.Handler, string(delivery.Status), delivery.Attempts, delivery.LastError,
+		encodeDeliveryTime(delivery.NextAttemptAt), encodeDeliveryTime(delivery.UpdatedAt))
+	if err != nil {
+		return fmt.Errorf("failed to insert delivery of %q to handler %q: %w", delivery.EntryID, delivery.Handler, err)
+	}
+	return tx.Commit()
+}
+
+// Deliveries 返回事件在各处理器的投递状态
+func (o *Outbox) Deliveries(entryID string) ([]*event.Delivery, error) {
+	rows, err := o.db.Query(o.dialect.rebind(`SELECT `+deliveryColumns+` FROM approval_outbox_deliveries
+		WHERE entry_id = ? ORDER BY handler`), entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deliveries of %q: %w", entryID, err)
+	}
+	defer rows.Close()
+
+	deliveries := []*event.Delivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// DeadLetters 返回死信列表
+func (o *Outbox) DeadLetters() ([]*event.OutboxDelivery, error) {
+	rows, err := o.db.Query(o.dialect.rebind(`SELECT e.id, e.event, e.created_at,
+		d.entry_id, d.handler, d.status, d.attempts, d.last_error, d.next_attempt_at, d.updated_at
+		FROM approval_outbox_deliveries d
+		JOIN approval_outbox_entries e ON e.id = d.entry_id
+		WHERE d.status = ?
+		ORDER BY e.created_at, e.id, d.handler`), string(event.DeliveryStatusDead))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*event.OutboxDelivery
+	for rows.Next() {
+		var (
+			id, data  string
+			createdAt int64
+		)
+		delivery, err := scanDelivery(rows, &id, &data, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		entry, err := decodeOutboxEntry(id, data, createdAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &event.OutboxDelivery{Entry: entry, Delivery: delivery})
+	}
+	return result, rows.Err()
+}
+
+// Replay 将死信重新置为待投递
+func (o *Outbox) Replay(entryID string, handler string) error {
+	result, err := o.db.Exec(o.dialect.rebind(`UPDATE approval_outbox_deliveries
+		SET status = ?, attempts = 0, next_attempt_at = 0, updated_at = ?
+		WHERE entry_id = ? AND handler = ? AND status = ?`),
+		string(event.DeliveryStatusPending), time.Now().UnixNano(), entryID, handler, string(event.DeliveryStatusDead))
+	if err != nil {
+		return fmt.Errorf("failed to replay dead letter %q for handler %q: %w", entryID, handler, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("%w: dead letter %q for handler %q", errors.ErrDeliveryNotFound, entryID, handler)
+	}
+	return nil
+}
+
+// appendOutboxEntries 在事务中写入事件
+func appendOutboxEntries(tx *sql.Tx, dialect Dialect, entries []*event.OutboxEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	insert := dialect.rebind(`INSERT INTO approval_outbox_entries (id, event, created_at) VALUES (?, ?, ?)`)
+	for _, entry := range entries {
+		data, err := json.Marshal(entry.Event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event of outbox entry %q: %w", entry.ID, err)
+		}
+		if _, err := tx.Exec(insert, entry.ID, string(data), entry.CreatedAt.UnixNano()); err != nil {
+			return fmt.Errorf("failed to insert outbox entry %q: %w", entry.ID, err)
+		}
+	}
+	return nil
+}
+
+// decodeOutboxEntry 从查询结果构建发件箱条目
+func decodeOutboxEntry(id string, data string, createdAt int64) (*event.OutboxEntry, error) {
+	evt := &event.Event{}
+	if err := json.Unmarshal([]byte(data), evt); err != nil {
+		return nil, fmt.Errorf("failed to decode event of outbox entry %q: %w", id, err)
+	}
+	return &event.OutboxEntry{ID: id, Event: evt, CreatedAt: time.Unix(0, createdAt)}, nil
+}
+
+// scanDelivery 从查询结果中读取投递状态
+// prefix: 投递状态列之前的列
+func scanDelivery(rows *sql.Rows, prefix ...interface{}) (*event.Delivery, error) {
+	delivery := &event.Delivery{}
+	var (
+		status                   string
+		attempts                 int64
+		nextAttemptAt, updatedAt int64
+	)
+	dest := append(prefix, &delivery.EntryID, &delivery.Handler, &status, &attempts, &delivery.LastError, &nextAttemptAt, &updatedAt)
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	delivery.Status = event.DeliveryStatus(status)
+	delivery.Attempts = int(attempts)
+	delivery.NextAttemptAt = decodeDeliveryTime(nextAttemptAt)
+	delivery.UpdatedAt = decodeDeliveryTime(updatedAt)
+	return delivery, nil
+}
+
+// encodeDeliveryTime 将投递时间编码为 UnixNano,零值编码为 0
+func encodeDeliveryTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// decodeDeliveryTime 解码投递时间,0 解码为零值
+func decodeDeliveryTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
@@ -0,0 +1,369 @@
+// Package sqlstore 基于 database/sql 的持久化存储实现
+// 不依赖具体数据库驱动,由调用方打开 *sql.DB 并传入;表结构通过版本化迁移创建和升级
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// Dialect SQL 方言
+// 不同数据库的差异仅在于参数占位符
+type Dialect int
+
+const (
+	// DialectSQLite SQLite,使用 ? 占位符
+	DialectSQLite Dialect = iota
+
+	// DialectMySQL MySQL,使用 ? 占位符
+	DialectMySQL
+
+	// DialectPostgres PostgreSQL,使用 $1、$2 占位符
+	DialectPostgres
+)
+
+// Option 存储可选配置
+type Option func(*options)
+
+// options 存储配置项
+type options struct {
+	dialect Dialect
+	codec   template.NodeConfigCodec
+}
+
+// WithDialect 设置 SQL 方言
+// 未设置时使用 DialectSQLite
+func WithDialect(dialect Dialect) Option {
+	return func(o *options) {
+		o.dialect = dialect
+	}
+}
+
+// WithNodeConfigCodec 设置模板存储使用的节点配置编解码器
+// 使用自定义节点配置时需要设置,未设置时使用内置节点配置的编解码器
+func WithNodeConfigCodec(codec template.NodeConfigCodec) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
+
+// newOptions 应用可选配置
+func newOptions(opts []Option) *options {
+	o := &options{dialect: DialectSQLite}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// rebind 将查询中的 ? 占位符转换为方言对应的占位符
+func (d Dialect) rebind(query string) string {
+	if d != DialectPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// isDuplicateKey 判断错误是否为主键或唯一约束冲突
+// 存储不依赖具体数据库驱动,按各数据库的错误码和错误信息判断
+func (d Dialect) isDuplicateKey(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	switch d {
+	case DialectMySQL:
+		return strings.Contains(msg, "1062") || strings.Contains(msg, "Duplicate entry")
+	case DialectPostgres:
+		return strings.Contains(msg, "23505") || strings.Contains(msg, "duplicate key value")
+	default:
+		return strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "PRIMARY KEY constraint failed")
+	}
+}
+
+// migration 表结构迁移
+// version 从 1 开始递增,已发布的迁移不能修改,只能追加新的迁移
+type migration struct {
+	version    int
+	statements []string
+}
+
+// migrations 所有表结构迁移,按版本号升序排列
+var migrations = []migration{
+	{
+		version: 1,
+		statements: []string{
+			`CREATE TABLE approval_tasks (
+				id VARCHAR(191) NOT NULL PRIMARY KEY,
+				template_id VARCHAR(191) NOT NULL,
+				template_version BIGINT NOT NULL,
+				business_id VARCHAR(191) NOT NULL,
+				params TEXT NOT NULL,
+				state VARCHAR(32) NOT NULL,
+				current_node VARCHAR(191) NOT NULL,
+				paused_at BIGINT NULL,
+				paused_state VARCHAR(32) NOT NULL,
+				created_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL,
+				submitted_at BIGINT NULL,
+				node_outputs TEXT NOT NULL,
+				approvers TEXT NOT NULL,
+				approvals TEXT NOT NULL,
+				completed_nodes TEXT NOT NULL,
+				version BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_approval_tasks_state ON approval_tasks (state)`,
+			`CREATE INDEX idx_approval_tasks_template ON approval_tasks (template_id)`,
+			`CREATE INDEX idx_approval_tasks_business ON approval_tasks (business_id)`,
+			`CREATE INDEX idx_approval_tasks_created_at ON approval_tasks (created_at)`,
+			`CREATE TABLE approval_task_records (
+				task_id VARCHAR(191) NOT NULL,
+				seq BIGINT NOT NULL,
+				id VARCHAR(191) NOT NULL,
+				node_id VARCHAR(191) NOT NULL,
+				approver VARCHAR(191) NOT NULL,
+				result VARCHAR(32) NOT NULL,
+				comment TEXT NOT NULL,
+				attachments TEXT NOT NULL,
+				created_at BIGINT NOT NULL,
+				PRIMARY KEY (task_id, seq)
+			)`,
+			`CREATE TABLE approval_task_state_history (
+				task_id VARCHAR(191) NOT NULL,
+				seq BIGINT NOT NULL,
+				from_state VARCHAR(32) NOT NULL,
+				to_state VARCHAR(32) NOT NULL,
+				reason TEXT NOT NULL,
+				changed_at BIGINT NOT NULL,
+				PRIMARY KEY (task_id, seq)
+			)`,
+		},
+	},
+	{
+		version: 2,
+		statements: []string{
+			`CREATE TABLE approval_templates (
+				id VARCHAR(191) NOT NULL,
+				version BIGINT NOT NULL,
+				name VARCHAR(191) NOT NULL,
+				description TEXT NOT NULL,
+				created_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL,
+				nodes TEXT NOT NULL,
+				edges TEXT NOT NULL,
+				config TEXT NOT NULL,
+				PRIMARY KEY (id, version)
+			)`,
+		},
+	},
+	{
+		version: 3,
+		statements: []string{
+			`ALTER TABLE approval_tasks ADD COLUMN node_activated_at TEXT NULL`,
+		},
+	},
+	{
+		version: 4,
+		statements: []string{
+			`ALTER TABLE approval_tasks ADD COLUMN reminders TEXT NULL`,
+		},
+	},
+	{
+		version: 5,
+		statements: []string{
+			`ALTER TABLE approval_tasks ADD COLUMN delegations TEXT NULL`,
+			`ALTER TABLE approval_task_records ADD COLUMN on_behalf_of VARCHAR(191) NOT NULL DEFAULT ''`,
+			`CREATE TABLE approval_delegations (
+				id VARCHAR(191) NOT NULL PRIMARY KEY,
+				delegator VARCHAR(191) NOT NULL,
+				delegate VARCHAR(191) NOT NULL,
+				template_id VARCHAR(191) NOT NULL,
+				start_at BIGINT NOT NULL,
+				end_at BIGINT NOT NULL,
+				reason TEXT NOT NULL
+			)`,
+			`CREATE INDEX idx_approval_delegations_delegator ON approval_delegations (delegator)`,
+		},
+	},
+	{
+		version: 6,
+		statements: []string{
+			`ALTER TABLE approval_tasks ADD COLUMN initiator VARCHAR(191) NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version: 7,
+		statements: []string{
+			`CREATE TABLE approval_outbox_entries (
+				id VARCHAR(191) NOT NULL PRIMARY KEY,
+				event TEXT NOT NULL,
+				created_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX idx_approval_outbox_entries_created_at ON approval_outbox_entries (created_at)`,
+			`CREATE TABLE approval_outbox_deliveries (
+				entry_id VARCHAR(191) NOT NULL,
+				handler VARCHAR(191) NOT NULL,
+				status VARCHAR(32) NOT NULL,
+				attempts BIGINT NOT NULL,
+				last_error TEXT NOT NULL,
+				next_attempt_at BIGINT NOT NULL,
+				updated_at BIGINT NOT NULL,
+				PRIMARY KEY (entry_id, handler)
+			)`,
+			`CREATE INDEX idx_approval_outbox_deliveries_status ON approval_outbox_deliveries (status)`,
+		},
+	},
+	{
+		version: 8,
+		statements: []string{
+			`ALTER TABLE approval_tasks ADD COLUMN node_aliases TEXT NULL`,
+		},
+	},
+}
+
+// 迁移锁,多个实例同时迁移时只有持有锁的实例执行迁移
+const (
+	// migrationLockName MySQL 迁移锁的名称
+	migrationLockName = "approval_kit_migrations"
+
+	// migrationLockKey PostgreSQL 迁移锁的键
+	migrationLockKey = 7045126391
+)
+
+// Migrate 执行表结构迁移
+// 已执行的迁移记录在 approval_kit_migrations 表中,重复调用只会执行尚未执行的迁移
+// 多个实例共享数据库时可以同时调用:迁移前获取迁移锁,每个迁移在事务中重新检查是否已执行
+func Migrate(db *sql.DB, opts ...Option) error {
+	o := newOptions(opts)
+	ctx := context.Background()
+
+	// 迁移锁和迁移使用同一个连接
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	unlock, err := o.dialect.lockMigrations(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer unlock()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS approval_kit_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		applied_at BIGINT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	var current sql.NullInt64
+	if err := conn.QueryRowContext(ctx, `SELECT MAX(version) FROM approval_kit_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for _, m := range migrations {
+		if int64(m.version) <= current.Int64 {
+			continue
+		}
+		if err := applyMigration(ctx, conn, o.dialect, m); err != nil {
+			return fmt.Errorf("failed to apply migration %d: %w", m.version, err)
+		}
+	}
+	return nil
+}
+
+// lockMigrations 在连接上获取迁移锁,返回释放锁的函数
+// PostgreSQL 和 MySQL 使用会话级 advisory lock;
+// SQLite 没有 advisory lock,由 applyMigration 在事务开始时获取数据库写锁
+func (d Dialect) lockMigrations(ctx context.Context, conn *sql.Conn) (func(), error) {
+	switch d {
+	case DialectPostgres:
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+			return nil, err
+		}
+		return func() {
+			conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockKey)
+		}, nil
+	case DialectMySQL:
+		// MySQL 的 DDL 会隐式提交事务,必须在整个迁移期间持有会话级锁
+		var acquired sql.NullInt64
+		if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, -1)`, migrationLockName).Scan(&acquired); err != nil {
+			return nil, err
+		}
+		if acquired.Int64 != 1 {
+			return nil, fmt.Errorf("lock %q not acquired", migrationLockName)
+		}
+		return func() {
+			conn.ExecContext(ctx, `SELECT RELEASE_LOCK(?)`, migrationLockName)
+		}, nil
+	default:
+		return func() {}, nil
+	}
+}
+
+// applyMigration 在事务中执行单个迁移并记录版本
+// 事务内重新检查迁移是否已由其他实例执行,已执行时跳过
+func applyMigration(ctx context.Context, conn *sql.Conn, dialect Dialect, m migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 事务以写语句开始,SQLite 在此获取数据库写锁,其他实例的迁移事务等待该事务结束
+	if _, err := tx.ExecContext(ctx, dialect.rebind(`UPDATE approval_kit_migrations SET applied_at = applied_at WHERE version = ?`), m.version); err != nil {
+		return err
+	}
+	var applied int
+	if err := tx.QueryRowContext(ctx, dialect.rebind(`SELECT COUNT(*) FROM approval_kit_migrations WHERE version = ?`), m.version).Scan(&applied); err != nil {
+		return err
+	}
+	if applied > 0 {
+		return nil
+	}
+
+	for _, stmt := range m.statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, dialect.rebind(`INSERT INTO approval_kit_migrations (version, applied_at) VALUES (?, ?)`), m.version, time.Now().UnixNano()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// toNullTime 将可选时间转换为可空的 Unix 纳秒时间戳
+func toNullTime(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.UnixNano(), Valid: true}
+}
+
+// fromNullTime 将可空的 Unix 纳秒时间戳转换为可选时间
+func fromNullTime(n sql.NullInt64) *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	t := time.Unix(0, n.Int64)
+	return &t
+}
@@ -0,0 +1,491 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// TaskStore 基于 database/sql 的任务存储
+// 任务主体保存在 approval_tasks 表,Params、NodeOutputs、Approvers、Approvals、CompletedNodes、NodeActivatedAt、Reminders、Delegations、NodeAliases 以 JSON 存储;
+// 审批记录和状态变更历史分别保存在 approval_task_records 和 approval_task_state_history 表
+// 实现了 task.OutboxTaskStore,与 Outbox 使用同一个数据库时事件与任务在同一事务中写入
+type TaskStore struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+var _ task.OutboxTaskStore = (*TaskStore)(nil)
+
+// taskColumns approval_tasks 表的列,顺序与 scanTask 一致
+const taskColumns = `id, template_id, template_version, business_id, initiator, params, state, current_node,
+	paused_at, paused_state, created_at, updated_at, submitted_at,
+	node_outputs, approvers, approvals, completed_nodes, node_activated_at, reminders, delegations, node_aliases, version`
+
+// NewTaskStore 创建 SQL 任务存储
+// 创建时执行表结构迁移
+func NewTaskStore(db *sql.DB, opts ...Option) (*TaskStore, error) {
+	if err := Migrate(db, opts...); err != nil {
+		return nil, err
+	}
+	return &TaskStore{db: db, dialect: newOptions(opts).dialect}, nil
+}
+
+// Get 获取任务
+func (s *TaskStore) Get(id string) (*task.Task, error) {
+	row := s.db.QueryRow(s.dialect.rebind(`SELECT `+taskColumns+` FROM approval_tasks WHERE id = ?`), id)
+	tsk, err := scanTask(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task %q: %w", id, err)
+	}
+
+	if err := s.loadRecords([]*task.Task{tsk}); err != nil {
+		return nil, fmt.Errorf("failed to load records of task %q: %w", id, err)
+	}
+	if err := s.loadStateHistory([]*task.Task{tsk}); err != nil {
+		return nil, fmt.Errorf("failed to load state history of task %q: %w", id, err)
+	}
+	return tsk, nil
+}
+
+// Save 保存任务
+// 在同一事务中写入任务主体、审批记录和状态变更历史
+func (s *TaskStore) Save(tsk *task.Task) error {
+	return s.save(tsk, nil)
+}
+
+// SaveWithEvents 保存任务,并在同一事务中将事件写入 approval_outbox_entries 表
+// 事件由同一数据库上的 Outbox 读取和投递
+func (s *TaskStore) SaveWithEvents(tsk *task.Task, entries []*event.OutboxEntry) error {
+	return s.save(tsk, entries)
+}
+
+// save 在同一事务中保存任务和事件
+func (s *TaskStore) save(tsk *task.Task, entries []*event.OutboxEntry) error {
+	data, err := encodeTask(tsk)
+	if err != nil {
+		return fmt.Errorf("failed to encode task %q: %w", tsk.ID, err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	version := tsk.Version + 1
+	if tsk.Version == 0 {
+		err = s.insertTask(tx, tsk, data, version)
+	} else {
+		err = s.updateTask(tx, tsk, data, version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.saveRecords(tx, tsk); err != nil {
+		return fmt.Errorf("failed to save records of task %q: %w", tsk.ID, err)
+	}
+	if err := s.saveStateHistory(tx, tsk); err != nil {
+		return fmt.Errorf("failed to save state history of task %q: %w", tsk.ID, err)
+	}
+	if err := appendOutboxEntries(tx, s.dialect, entries); err != nil {
+		return fmt.Errorf("failed to append events of task %q: %w", tsk.ID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	tsk.Version = version
+	return nil
+}
+
+// Query 查询任务
+// 状态、模板、业务 ID 和时间范围在 SQL 中过滤,审批人在加载后过滤
+// 匹配任务的审批记录和状态变更历史按批次加载,不逐个任务查询
+func (s *TaskStore) Query(filter *task.TaskFilter) ([]*task.Task, error) {
+	var conds []string
+	var args []interface{}
+	if filter.State != types.TaskState("") {
+		conds = append(conds, "state = ?")
+		args = append(args, string(filter.State))
+	}
+	if filter.TemplateID != "" {
+		conds = append(conds, "template_id = ?")
+		args = append(args, filter.TemplateID)
+	}
+	if filter.BusinessID != "" {
+		conds = append(conds, "business_id = ?")
+		args = append(args, filter.BusinessID)
+	}
+	if !filter.StartTime.IsZero() {
+		conds = append(conds, "created_at >= ?")
+		args = append(args, filter.StartTime.UnixNano())
+	}
+	if !filter.EndTime.IsZero() {
+		conds = append(conds, "created_at <= ?")
+		args = append(args, filter.EndTime.UnixNano())
+	}
+
+	query := `SELECT ` + taskColumns + ` FROM approval_tasks`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	query += ` ORDER BY created_at, id`
+
+	rows, err := s.db.Query(s.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	var results []*task.Task
+	for rows.Next() {
+		tsk, err := scanTask(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to query tasks: %w", err)
+		}
+		if filter.Match(tsk) {
+			results = append(results, tsk)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+
+	if err := s.loadRecords(results); err != nil {
+		return nil, fmt.Errorf("failed to load task records: %w", err)
+	}
+	if err := s.loadStateHistory(results); err != nil {
+		return nil, fmt.Errorf("failed to load task state history: %w", err)
+	}
+	return results, nil
+}
+
+// Delete 删除任务及其审批记录和状态变更历史
+func (s *TaskStore) Delete(id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(s.dialect.rebind(`DELETE FROM approval_tasks WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete task %q: %w", id, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if _, err := tx.Exec(s.dialect.rebind(`DELETE FROM approval_task_records WHERE task_id = ?`), id); err != nil {
+		return fmt.Errorf("failed to delete records of task %q: %w", id, err)
+	}
+	if _, err := tx.Exec(s.dialect.rebind(`DELETE FROM approval_task_state_history WHERE task_id = ?`), id); err != nil {
+		return fmt.Errorf("failed to delete state history of task %q: %w", id, err)
+	}
+	return tx.Commit()
+}
+
+// insertTask 插入新任务
+// 任务已存在(主键冲突)说明其他进程已先保存,返回并发修改错误
+func (s *TaskStore) insertTask(tx *sql.Tx, tsk *task.Task, data *taskData, version int) error {
+	_, err := tx.Exec(s.dialect.rebind(`INSERT INTO approval_tasks (`+taskColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		tsk.ID, tsk.TemplateID, tsk.TemplateVersion, tsk.BusinessID, tsk.Initiator, data.params, string(tsk.State), tsk.CurrentNode,
+		toNullTime(tsk.PausedAt), string(tsk.PausedState), tsk.CreatedAt.UnixNano(), tsk.UpdatedAt.UnixNano(), toNullTime(tsk.SubmittedAt),
+		data.nodeOutputs, data.approvers, data.approvals, data.completedNodes, data.nodeActivatedAt, data.reminders, data.delegations, data.nodeAliases, version)
+	if s.dialect.isDuplicateKey(err) {
+		return fmt.Errorf("task %q already exists: %w", tsk.ID, errors.ErrConcurrentModification)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert task %q: %w", tsk.ID, err)
+	}
+	return nil
+}
+
+// updateTask 更新任务
+// 仅当存储中的版本与 tsk.Version 一致时更新,否则返回并发修改错误
+func (s *TaskStore) updateTask(tx *sql.Tx, tsk *task.Task, data *taskData, version int) error {
+	result, err := tx.Exec(s.dialect.rebind(`UPDATE approval_tasks SET
+		template_id = ?, template_version = ?, business_id = ?, initiator = ?, params = ?, state = ?, current_node = ?,
+		paused_at = ?, paused_state = ?, created_at = ?, updated_at = ?, submitted_at = ?,
+		node_outputs = ?, approvers = ?, approvals = ?, completed_nodes = ?, node_activated_at = ?, reminders = ?, delegations = ?, node_aliases = ?, version = ?
+		WHERE id = ? AND version = ?`),
+		tsk.TemplateID, tsk.TemplateVersion, tsk.BusinessID, tsk.Initiator, data.params, string(tsk.State), tsk.CurrentNode,
+		toNullTime(tsk.PausedAt), string(tsk.PausedState), tsk.CreatedAt.UnixNano(), tsk.UpdatedAt.UnixNano(), toNullTime(tsk.SubmittedAt),
+		data.nodeOutputs, data.approvers, data.approvals, data.completedNodes, data.nodeActivatedAt, data.reminders, data.delegations, data.nodeAliases, version,
+		tsk.ID, tsk.Version)
+	if err != nil {
+		return fmt.Errorf("failed to update task %q: %w", tsk.ID, err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("task %q version %d is stale: %w", tsk.ID, tsk.Version, errors.ErrConcurrentModification)
+	}
+	return nil
+}
+
+// saveRecords 重写任务的审批记录
+func (s *TaskStore) saveRecords(tx *sql.Tx, tsk *task.Task) error {
+	if _, err := tx.Exec(s.dialect.rebind(`DELETE FROM approval_task_records WHERE task_id = ?`), tsk.ID); err != nil {
+		return err
+	}
+
+	insert := s.dialect.rebind(`INSERT INTO approval_task_records
+		(task_id, seq, id, node_id, approver, on_behalf_of, result, comment, attachments, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	for i, r := range tsk.Records {
+		attachments, err := json.Marshal(r.Attachments)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(insert, tsk.ID, i, r.ID, r.NodeID, r.Approver, r.OnBehalfOf, r.Result, r.Comment, string(attachments), r.CreatedAt.UnixNano()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveStateHistory 重写任务的状态变更历史
+func (s *TaskStore) saveStateHistory(tx *sql.Tx, tsk *task.Task) error {
+	if _, err := tx.Exec(s.dialect.rebind(`DELETE FROM approval_task_state_history WHERE task_id = ?`), tsk.ID); err != nil {
+		return err
+	}
+
+	insert := s.dialect.rebind(`INSERT INTO approval_task_state_history
+		(task_id, seq, from_state, to_state, reason, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?)`)
+	for i, sc := range tsk.StateHistory {
+		if _, err := tx.Exec(insert, tsk.ID, i, string(sc.From), string(sc.To), sc.Reason, sc.Time.UnixNano()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadBatchSize 批量加载审批记录和状态变更历史时每条查询包含的任务数量,避免超出数据库的参数数量限制
+const loadBatchSize = 500
+
+// loadRecords 批量加载任务的审批记录
+func (s *TaskStore) loadRecords(tasks []*task.Task) error {
+	return s.loadByTask(tasks, `SELECT task_id, id, node_id, approver, on_behalf_of, result, comment, attachments, created_at
+		FROM approval_task_records`, func(tsk *task.Task) {
+		tsk.Records = []*task.Record{}
+	}, func(rows *sql.Rows, byID map[string]*task.Task) error {
+		r := &task.Record{}
+		var attachments string
+		var createdAt int64
+		if err := rows.Scan(&r.TaskID, &r.ID, &r.NodeID, &r.Approver, &r.OnBehalfOf, &r.Result, &r.Comment, &attachments, &createdAt); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(attachments), &r.Attachments); err != nil {
+			return err
+		}
+		r.CreatedAt = time.Unix(0, createdAt)
+		tsk := byID[r.TaskID]
+		tsk.Records = append(tsk.Records, r)
+		return nil
+	})
+}
+
+// loadStateHistory 批量加载任务的状态变更历史
+func (s *TaskStore) loadStateHistory(tasks []*task.Task) error {
+	return s.loadByTask(tasks, `SELECT task_id, from_state, to_state, reason, changed_at
+		FROM approval_task_state_history`, func(tsk *task.Task) {
+		tsk.StateHistory = []*task.StateChange{}
+	}, func(rows *sql.Rows, byID map[string]*task.Task) error {
+		var taskID, from, to string
+		var changedAt int64
+		sc := &task.StateChange{}
+		if err := rows.Scan(&taskID, &from, &to, &sc.Reason, &changedAt); err != nil {
+			return err
+		}
+		sc.From = types.TaskState(from)
+		sc.To = types.TaskState(to)
+		sc.Time = time.Unix(0, changedAt)
+		tsk := byID[taskID]
+		tsk.StateHistory = append(tsk.StateHistory, sc)
+		return nil
+	})
+}
+
+// loadByTask 按任务 ID 分批查询任务的子表数据
+// query 为不含条件的查询语句,按 task_id IN (...) 过滤并按 seq 排序;
+// reset 在查询前初始化每个任务的数据,scan 读取一行并追加到所属任务
+func (s *TaskStore) loadByTask(tasks []*task.Task, query string, reset func(*task.Task), scan func(*sql.Rows, map[string]*task.Task) error) error {
+	for start := 0; start < len(tasks); start += loadBatchSize {
+		batch := tasks[start:min(start+loadBatchSize, len(tasks))]
+		byID := make(map[string]*task.Task, len(batch))
+		args := make([]interface{}, len(batch))
+		for i, tsk := range batch {
+			reset(tsk)
+			byID[tsk.ID] = tsk
+			args[i] = tsk.ID
+		}
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(batch)), ", ")
+		rows, err := s.db.Query(s.dialect.rebind(query+` WHERE task_id IN (`+placeholders+`) ORDER BY task_id, seq`), args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			if err := scan(rows, byID); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// taskData 任务中以 JSON 存储的字段
+type taskData struct {
+	params          string
+	nodeOutputs     string
+	approvers       string
+	approvals       string
+	completedNodes  string
+	nodeActivatedAt string
+	reminders       string
+	delegations     string
+	nodeAliases     string
+}
+
+// encodeTask 将任务的 JSON 字段编码为字符串
+func encodeTask(tsk *task.Task) (*taskData, error) {
+	data := &taskData{params: "{}"}
+	if len(tsk.Params) > 0 {
+		data.params = string(tsk.Params)
+	}
+
+	fields := []struct {
+		dst *string
+		src interface{}
+	}{
+		{&data.nodeOutputs, tsk.NodeOutputs},
+		{&data.approvers, tsk.Approvers},
+		{&data.approvals, tsk.Approvals},
+		{&data.completedNodes, tsk.CompletedNodes},
+		{&data.nodeActivatedAt, encodeTimes(tsk.NodeActivatedAt)},
+		{&data.reminders, tsk.Reminders},
+		{&data.delegations, tsk.Delegations},
+		{&data.nodeAliases, tsk.NodeAliases},
+	}
+	for _, f := range fields {
+		b, err := json.Marshal(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = string(b)
+	}
+	return data, nil
+}
+
+// rowScanner 查询结果的一行,*sql.Row 和 *sql.Rows 均实现
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask 从查询结果中读取任务主体
+func scanTask(row rowScanner) (*task.Task, error) {
+	tsk := &task.Task{}
+	var params, state, pausedState, nodeOutputs, approvers, approvals, completedNodes string
+	var nodeActivatedAt, reminders, delegations, nodeAliases sql.NullString
+	var pausedAt, submittedAt sql.NullInt64
+	var createdAt, updatedAt int64
+
+	err := row.Scan(&tsk.ID, &tsk.TemplateID, &tsk.TemplateVersion, &tsk.BusinessID, &tsk.Initiator, &params, &state, &tsk.CurrentNode,
+		&pausedAt, &pausedState, &createdAt, &updatedAt, &submittedAt,
+		&nodeOutputs, &approvers, &approvals, &completedNodes, &nodeActivatedAt, &reminders, &delegations, &nodeAliases, &tsk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	tsk.Params = json.RawMessage(params)
+	tsk.State = types.TaskState(state)
+	tsk.PausedState = types.TaskState(pausedState)
+	tsk.PausedAt = fromNullTime(pausedAt)
+	tsk.CreatedAt = time.Unix(0, createdAt)
+	tsk.UpdatedAt = time.Unix(0, updatedAt)
+	tsk.SubmittedAt = fromNullTime(submittedAt)
+
+	tsk.NodeOutputs = make(map[string]json.RawMessage)
+	tsk.Approvers = make(map[string][]string)
+	tsk.Approvals = make(map[string]map[string]*task.Approval)
+	fields := []struct {
+		src string
+		dst interface{}
+	}{
+		{nodeOutputs, &tsk.NodeOutputs},
+		{approvers, &tsk.Approvers},
+		{approvals, &tsk.Approvals},
+		{completedNodes, &tsk.CompletedNodes},
+	}
+	for _, f := range fields {
+		if err := json.Unmarshal([]byte(f.src), f.dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// node_activated_at 列由迁移 3 添加,旧数据为 NULL
+	tsk.NodeActivatedAt = make(map[string]time.Time)
+	if nodeActivatedAt.Valid {
+		var times map[string]int64
+		if err := json.Unmarshal([]byte(nodeActivatedAt.String), &times); err != nil {
+			return nil, err
+		}
+		for nodeID, t := range times {
+			tsk.NodeActivatedAt[nodeID] = time.Unix(0, t)
+		}
+	}
+
+	// reminders 列由迁移 4 添加,delegations 列由迁移 5 添加,旧数据为 NULL
+	if reminders.Valid {
+		if err := json.Unmarshal([]byte(reminders.String), &tsk.Reminders); err != nil {
+			return nil, err
+		}
+	}
+	if delegations.Valid {
+		if err := json.Unmarshal([]byte(delegations.String), &tsk.Delegations); err != nil {
+			return nil, err
+		}
+	}
+
+	// node_aliases 列由迁移 8 添加,旧数据为 NULL
+	if nodeAliases.Valid {
+		if err := json.Unmarshal([]byte(nodeAliases.String), &tsk.NodeAliases); err != nil {
+			return nil, err
+		}
+	}
+	return tsk, nil
+}
+
+// encodeTimes 将以节点 ID 为键的时间转换为 Unix 纳秒时间戳,与其他时间列的存储精度一致
+func encodeTimes(times map[string]time.Time) map[string]int64 {
+	result := make(map[string]int64, len(times))
+	for nodeID, t := range times {
+		result[nodeID] = t.UnixNano()
+	}
+	return result
+}
@@ -0,0 +1,284 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// TemplateStore 基于 database/sql 的模板存储
+// 每个模板版本保存为 approval_templates 表的一行,已保存的版本不会被修改;
+// Nodes、Edges 和 TemplateConfig 以 JSON 存储,节点配置通过 NodeConfigCodec 编解码
+type TemplateStore struct {
+	db      *sql.DB
+	dialect Dialect
+	codec   template.NodeConfigCodec
+}
+
+var _ template.TemplateStore = (*TemplateStore)(nil)
+
+// templateColumns approval_templates 表的列,顺序与 scanTemplate 一致
+const templateColumns = `id, version, name, description, created_at, updated_at, nodes, edges, config`
+
+// NewTemplateStore 创建 SQL 模板存储
+// 创建时执行表结构迁移;未通过 WithNodeConfigCodec 设置编解码器时使用 node.NewNodeConfigCodec
+func NewTemplateStore(db *sql.DB, opts ...Option) (*TemplateStore, error) {
+	if err := Migrate(db, opts...); err != nil {
+		return nil, err
+	}
+
+	o := newOptions(opts)
+	codec := o.codec
+	if codec == nil {
+		codec = node.NewNodeConfigCodec()
+	}
+	return &TemplateStore{db: db, dialect: o.dialect, codec: codec}, nil
+}
+
+// SaveVersion 保存模板的一个版本
+func (s *TemplateStore) SaveVersion(tpl *template.Template) error {
+	nodes, edges, config, err := s.encodeTemplate(tpl)
+	if err != nil {
+		return fmt.Errorf("failed to encode template %q: %w", tpl.ID, err)
+	}
+
+	exists, err := s.versionExists(tpl.ID, tpl.Version)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("template %q version %d: %w", tpl.ID, tpl.Version, errors.ErrTemplateVersionExists)
+	}
+
+	_, err = s.db.Exec(s.dialect.rebind(`INSERT INTO approval_templates (`+templateColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		tpl.ID, tpl.Version, tpl.Name, tpl.Description, tpl.CreatedAt.UnixNano(), tpl.UpdatedAt.UnixNano(), nodes, edges, config)
+	if err != nil {
+		// 其他实例可能已先保存了相同版本
+		if exists, _ := s.versionExists(tpl.ID, tpl.Version); exists {
+			return fmt.Errorf("template %q version %d: %w", tpl.ID, tpl.Version, errors.ErrTemplateVersionExists)
+		}
+		return fmt.Errorf("failed to insert template %q version %d: %w", tpl.ID, tpl.Version, err)
+	}
+	return nil
+}
+
+// Get 获取指定版本的模板,version 为 0 时返回最新版本
+func (s *TemplateStore) Get(id string, version int) (*template.Template, error) {
+	var row *sql.Row
+	if version == 0 {
+		row = s.db.QueryRow(s.dialect.rebind(`SELECT `+templateColumns+` FROM approval_templates
+			WHERE id = ? ORDER BY version DESC LIMIT 1`), id)
+	} else {
+		row = s.db.QueryRow(s.dialect.rebind(`SELECT `+templateColumns+` FROM approval_templates
+			WHERE id = ? AND version = ?`), id, version)
+	}
+
+	tpl, err := s.scanTemplate(row)
+	if err == sql.ErrNoRows {
+		if version == 0 {
+			return nil, fmt.Errorf("template %q: %w", id, errors.ErrTemplateNotFound)
+		}
+		return nil, fmt.Errorf("template %q version %d: %w", id, version, errors.ErrTemplateNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template %q: %w", id, err)
+	}
+	return tpl, nil
+}
+
+// ListVersions 列出模板的所有版本号
+func (s *TemplateStore) ListVersions(id string) ([]int, error) {
+	rows, err := s.db.Query(s.dialect.rebind(`SELECT version FROM approval_templates WHERE id = ? ORDER BY version`), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions of template %q: %w", id, err)
+	}
+	defer rows.Close()
+
+	var versions []int
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("failed to list versions of template %q: %w", id, err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list versions of template %q: %w", id, err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("template %q: %w", id, errors.ErrTemplateNotFound)
+	}
+	return versions, nil
+}
+
+// Delete 删除模板的所有版本
+func (s *TemplateStore) Delete(id string) error {
+	result, err := s.db.Exec(s.dialect.rebind(`DELETE FROM approval_templates WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete template %q: %w", id, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("template %q: %w", id, errors.ErrTemplateNotFound)
+	}
+	return nil
+}
+
+// versionExists 检查模板版本是否已存在
+func (s *TemplateStore) versionExists(id string, version int) (bool, error) {
+	var count int
+	err := s.db.QueryRow(s.dialect.rebind(`SELECT COUNT(*) FROM approval_templates WHERE id = ? AND version = ?`), id, version).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check template %q version %d: %w", id, version, err)
+	}
+	return count > 0, nil
+}
+
+// nodeJSON 节点的存储结构
+type nodeJSON struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Type   template.NodeType `json:"type"`
+	Order  int               `json:"order,omitempty"`
+	Config json.RawMessage   `json:"config,omitempty"`
+}
+
+// edgeJSON 边的存储结构
+type edgeJSON struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Condition string `json:"condition,omitempty"`
+	Priority  int    `json:"priority,omitempty"`
+	Default   bool   `json:"default,omitempty"`
+}
+
+// templateConfigJSON 模板全局配置的存储结构
+type templateConfigJSON struct {
+	Webhooks []*webhookJSON `json:"webhooks,omitempty"`
+}
+
+// webhookJSON Webhook 配置的存储结构
+type webhookJSON struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Auth    *authJSON         `json:"auth,omitempty"`
+}
+
+// authJSON 认证配置的存储结构
+type authJSON struct {
+	Type  string `json:"type"`
+	Token string `json:"token,omitempty"`
+	Key   string `json:"key,omitempty"`
+}
+
+// encodeTemplate 将模板的节点、边和全局配置编码为 JSON
+// 节点按 ID 排序,保证相同模板的编码结果一致
+func (s *TemplateStore) encodeTemplate(tpl *template.Template) (string, string, string, error) {
+	ids := make([]string, 0, len(tpl.Nodes))
+	for id := range tpl.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	nodes := make([]*nodeJSON, 0, len(ids))
+	for _, id := range ids {
+		n := tpl.Nodes[id]
+		config, err := s.codec.Encode(n.Config)
+		if err != nil {
+			return "", "", "", fmt.Errorf("node %q: %w", id, err)
+		}
+		nodes = append(nodes, &nodeJSON{ID: n.ID, Name: n.Name, Type: n.Type, Order: n.Order, Config: config})
+	}
+
+	edges := make([]*edgeJSON, 0, len(tpl.Edges))
+	for _, e := range tpl.Edges {
+		edges = append(edges, &edgeJSON{
+			From: e.From, To: e.To, Condition: e.Condition, Priority: e.Priority, Default: e.Default,
+		})
+	}
+
+	var config *templateConfigJSON
+	if tpl.Config != nil {
+		config = &templateConfigJSON{}
+		for _, w := range tpl.Config.Webhooks {
+			webhook := &webhookJSON{URL: w.URL, Method: w.Method, Headers: w.Headers}
+			if w.Auth != nil {
+				webhook.Auth = &authJSON{Type: w.Auth.Type, Token: w.Auth.Token, Key: w.Auth.Key}
+			}
+			config.Webhooks = append(config.Webhooks, webhook)
+		}
+	}
+
+	encoded := make([]string, 0, 3)
+	for _, v := range []interface{}{nodes, edges, config} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", "", "", err
+		}
+		encoded = append(encoded, string(b))
+	}
+	return encoded[0], encoded[1], encoded[2], nil
+}
+
+// scanTemplate 从查询结果中读取模板
+func (s *TemplateStore) scanTemplate(row *sql.Row) (*template.Template, error) {
+	tpl := &template.Template{}
+	var createdAt, updatedAt int64
+	var nodesData, edgesData, configData string
+	if err := row.Scan(&tpl.ID, &tpl.Version, &tpl.Name, &tpl.Description, &createdAt, &updatedAt, &nodesData, &edgesData, &configData); err != nil {
+		return nil, err
+	}
+	tpl.CreatedAt = time.Unix(0, createdAt)
+	tpl.UpdatedAt = time.Unix(0, updatedAt)
+
+	var nodes []*nodeJSON
+	if err := json.Unmarshal([]byte(nodesData), &nodes); err != nil {
+		return nil, fmt.Errorf("invalid nodes: %w", err)
+	}
+	tpl.Nodes = make(map[string]*template.Node, len(nodes))
+	for _, n := range nodes {
+		config, err := s.codec.Decode(n.Type, n.Config)
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", n.ID, err)
+		}
+		tpl.Nodes[n.ID] = &template.Node{ID: n.ID, Name: n.Name, Type: n.Type, Order: n.Order, Config: config}
+	}
+
+	var edges []*edgeJSON
+	if err := json.Unmarshal([]byte(edgesData), &edges); err != nil {
+		return nil, fmt.Errorf("invalid edges: %w", err)
+	}
+	tpl.Edges = make([]*template.Edge, 0, len(edges))
+	for _, e := range edges {
+		tpl.Edges = append(tpl.Edges, &template.Edge{
+			From: e.From, To: e.To, Condition: e.Condition, Priority: e.Priority, Default: e.Default,
+		})
+	}
+
+	var config *templateConfigJSON
+	if err := json.Unmarshal([]byte(configData), &config); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if config != nil {
+		tpl.Config = &template.TemplateConfig{}
+		for _, w := range config.Webhooks {
+			webhook := &template.WebhookConfig{URL: w.URL, Method: w.Method, Headers: w.Headers}
+			if w.Auth != nil {
+				webhook.Auth = &template.AuthConfig{Type: w.Auth.Type, Token: w.Auth.Token, Key: w.Auth.Key}
+			}
+			tpl.Config.Webhooks = append(tpl.Config.Webhooks, webhook)
+		}
+	}
+	return tpl, nil
+}
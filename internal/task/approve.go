@@ -1,7 +1,9 @@
 package task
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -13,13 +15,22 @@ import (
 
 // Approve 审批人进行同意操作
 func (m *memoryTaskManager) Approve(id string, nodeID string, approver string, comment string) error {
+	return m.ApproveCtx(context.Background(), id, nodeID, approver, comment)
+}
+
+// ApproveCtx 审批人进行同意操作,ctx 用于取消和超时控制
+func (m *memoryTaskManager) ApproveCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 1. 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(ctx, ActionApprove, approver, tsk, nodeID); err != nil {
+		return err
 	}
 
 	// 2. 验证任务状态(只有 submitted 或 approving 状态才能审批)
@@ -32,9 +43,9 @@ func (m *memoryTaskManager) Approve(id string, nodeID string, approver string, c
 	}
 
 	// 2.1 获取模板和节点配置,验证审批意见必填
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return fmt.Errorf("failed to get template %q: %w", tsk.TemplateID, err)
+		return err
 	}
 
 	node, exists := tpl.Nodes[nodeID]
@@ -51,7 +62,14 @@ func (m *memoryTaskManager) Approve(id string, nodeID string, approver string, c
 		}
 	}
 
+	// 2.2 遵循审批模式的顺序约束(如顺序审批),使用流程引擎时只能审批当前节点
+	if err := m.checkApprover(tpl, tsk, node, approver); err != nil {
+		return err
+	}
+
 	// 3. 更新任务状态为 approving(如果还是 submitted)
+	// 保留操作前的任务副本,流程推进或节点完成判断失败时用于恢复
+	backup := tsk.Clone()
 	tsk.mu.Lock()
 	if tsk.State == types.TaskStateSubmitted {
 		tsk.State = types.TaskStateApproving
@@ -80,6 +98,7 @@ func (m *memoryTaskManager) Approve(id string, nodeID string, approver string, c
 		TaskID:      id,
 		NodeID:      nodeID,
 		Approver:    approver,
+		OnBehalfOf:  tsk.delegatorOf(nodeID, approver),
 		Result:      "approve",
 		Comment:     comment,
 		CreatedAt:   time.Now(),
@@ -94,124 +113,42 @@ func (m *memoryTaskManager) Approve(id string, nodeID string, approver string, c
 
 	// 添加到记录列表
 	tsk.Records = append(tsk.Records, record)
-
-	// 6. 检查审批是否完成(对于单人审批模式,审批人同意后立即完成)
-	// 获取审批人列表和当前状态
-	approvers := tsk.Approvers[nodeID]
-	currentState := tsk.State
-	shouldTransition := false
-
-	// 获取节点配置以确定审批模式
-	if node.Type == template.NodeTypeApproval {
-		// 尝试从配置中获取审批人列表(如果任务中的审批人列表为空)
-		if len(approvers) == 0 {
-			// 尝试通过类型断言获取固定审批人配置
-			// 注意: 这里我们无法直接访问 node 包的类型,所以简化处理
-			// 如果只有一个审批记录,且是当前审批人的同意记录,可能是单人审批模式
-			if len(tsk.Approvals[nodeID]) == 1 {
-				if approval, exists := tsk.Approvals[nodeID][approver]; exists && approval.Result == "approve" {
-					// 对于单人审批模式,如果审批人已同意,将任务状态转换为已通过
-					if m.stateMachine.CanTransition(currentState, types.TaskStateApproved) {
-						shouldTransition = true
-					}
-				}
-			}
-		} else if len(approvers) == 1 && approvers[0] == approver {
-			// 如果只有一个审批人且就是当前审批人,且已同意,将任务状态转换为已通过
-			// 这是单人审批模式
-			if m.stateMachine.CanTransition(currentState, types.TaskStateApproved) {
-				shouldTransition = true
-			}
-		} else if len(approvers) > 1 {
-			// 多人审批模式: 检查是否所有审批人都已同意
-			// 对于多人会签模式,需要所有审批人都同意
-			allApproved := true
-			for _, approverID := range approvers {
-				approval, exists := tsk.Approvals[nodeID][approverID]
-				if !exists || approval == nil || approval.Result != "approve" {
-					allApproved = false
-					break
-				}
-			}
-			// 如果所有审批人都已同意,则完成
-			// 注意: 这里我们简化处理,只要所有审批人都已同意就转换状态
-			// 实际应该根据审批模式(会签/或签/比例/顺序)来决定
-			if allApproved {
-				if m.stateMachine.CanTransition(currentState, types.TaskStateApproved) {
-					shouldTransition = true
-				}
-			}
-		}
-	}
-
-	// 7. 更新任务更新时间
 	tsk.UpdatedAt = time.Now()
 	tsk.mu.Unlock()
 
-	// 8. 如果需要转换状态,执行状态转换(在释放锁之后)
-	if shouldTransition {
-		// 重新获取任务(因为锁已释放)
-		tsk = m.tasks[id]
-		adapter := &taskAdapter{task: tsk}
-		newTask, err := m.stateMachine.Transition(adapter, types.TaskStateApproved, "all approvers approved")
-		if err == nil {
-			tsk = newTask.(*taskAdapter).task
-			tsk.mu.Lock()
-			tsk.UpdatedAt = time.Now()
-			// 节点完成,添加到已完成节点列表
-			if tsk.CompletedNodes == nil {
-				tsk.CompletedNodes = []string{}
-			}
-			// 检查节点是否已在列表中
-			found := false
-			for _, completedNodeID := range tsk.CompletedNodes {
-				if completedNodeID == nodeID {
-					found = true
-					break
-				}
-			}
-			if !found {
-				tsk.CompletedNodes = append(tsk.CompletedNodes, nodeID)
-			}
-			tsk.mu.Unlock()
-			m.tasks[id] = tsk
-		}
-	} else {
-		// 保存更新后的任务
-		m.tasks[id] = tsk
+	// 6. 如果设置了流程引擎,由引擎判断节点是否完成并推进到下一个节点
+	if m.engine != nil {
+		return m.advanceWithEngine(ctx, id, tsk, backup, tpl, node, &event.ApprovalInfo{
+			NodeID:     nodeID,
+			Approver:   approver,
+			OnBehalfOf: record.OnBehalfOf,
+			Result:     "approve",
+			Comment:    comment,
+		})
 	}
 
-	// 9. 生成审批事件
-	if m.eventNotifier != nil {
-		approvalInfo := &event.ApprovalInfo{
-			NodeID:   nodeID,
-			Approver: approver,
-			Result:   "approve",
-			Comment:  comment,
-		}
-		m.generateEvent(event.EventTypeApprovalOp, tsk, node, approvalInfo)
-		
-		// 如果状态已转换为 approved,生成任务通过事件和节点完成事件
-		if shouldTransition && tsk.State == types.TaskStateApproved {
-			// 生成节点完成事件
-			m.generateEvent(event.EventTypeNodeCompleted, tsk, node, nil)
-			// 生成任务通过事件
-			m.generateEvent(event.EventTypeTaskApproved, tsk, node, nil)
-		}
-	}
-
-	return nil
+	// 未设置流程引擎时,按审批模式判断节点是否完成
+	return m.completeApproval(id, tsk, backup, node, record)
 }
 
 // ApproveWithAttachments 审批人进行同意操作(带附件)
 func (m *memoryTaskManager) ApproveWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error {
+	return m.ApproveWithAttachmentsCtx(context.Background(), id, nodeID, approver, comment, attachments)
+}
+
+// ApproveWithAttachmentsCtx 审批人进行同意操作(带附件),ctx 用于取消和超时控制
+func (m *memoryTaskManager) ApproveWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 1. 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(ctx, ActionApprove, approver, tsk, nodeID); err != nil {
+		return err
 	}
 
 	// 2. 验证任务状态(只有 submitted 或 approving 状态才能审批)
@@ -224,9 +161,9 @@ func (m *memoryTaskManager) ApproveWithAttachments(id string, nodeID string, app
 	}
 
 	// 2.1 获取模板和节点配置,验证审批意见和附件要求
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return fmt.Errorf("failed to get template %q: %w", tsk.TemplateID, err)
+		return err
 	}
 
 	node, exists := tpl.Nodes[nodeID]
@@ -246,7 +183,14 @@ func (m *memoryTaskManager) ApproveWithAttachments(id string, nodeID string, app
 		}
 	}
 
+	// 2.2 遵循审批模式的顺序约束(如顺序审批),使用流程引擎时只能审批当前节点
+	if err := m.checkApprover(tpl, tsk, node, approver); err != nil {
+		return err
+	}
+
 	// 3. 更新任务状态为 approving(如果还是 submitted)
+	// 保留操作前的任务副本,流程推进或节点完成判断失败时用于恢复
+	backup := tsk.Clone()
 	tsk.mu.Lock()
 	if tsk.State == types.TaskStateSubmitted {
 		tsk.State = types.TaskStateApproving
@@ -275,6 +219,7 @@ func (m *memoryTaskManager) ApproveWithAttachments(id string, nodeID string, app
 		TaskID:      id,
 		NodeID:      nodeID,
 		Approver:    approver,
+		OnBehalfOf:  tsk.delegatorOf(nodeID, approver),
 		Result:      "approve",
 		Comment:     comment,
 		CreatedAt:   time.Now(),
@@ -294,18 +239,39 @@ func (m *memoryTaskManager) ApproveWithAttachments(id string, nodeID string, app
 	tsk.UpdatedAt = time.Now()
 	tsk.mu.Unlock()
 
-	return nil
+	// 7. 如果设置了流程引擎,由引擎判断节点是否完成并推进到下一个节点
+	if m.engine != nil {
+		return m.advanceWithEngine(ctx, id, tsk, backup, tpl, node, &event.ApprovalInfo{
+			NodeID:     nodeID,
+			Approver:   approver,
+			OnBehalfOf: record.OnBehalfOf,
+			Result:     "approve",
+			Comment:    comment,
+		})
+	}
+
+	// 未设置流程引擎时,按审批模式判断节点是否完成
+	return m.completeApproval(id, tsk, backup, node, record)
 }
 
 // Reject 审批人进行拒绝操作
 func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, comment string) error {
+	return m.RejectCtx(context.Background(), id, nodeID, approver, comment)
+}
+
+// RejectCtx 审批人进行拒绝操作,ctx 用于取消和超时控制
+func (m *memoryTaskManager) RejectCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 1. 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(ctx, ActionReject, approver, tsk, nodeID); err != nil {
+		return err
 	}
 
 	// 2. 验证任务状态(只有 submitted 或 approving 状态才能拒绝)
@@ -318,9 +284,9 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 	}
 
 	// 2.1 获取模板和节点配置,验证审批意见必填
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return fmt.Errorf("failed to get template %q: %w", tsk.TemplateID, err)
+		return err
 	}
 
 	node, exists := tpl.Nodes[nodeID]
@@ -337,7 +303,14 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 		}
 	}
 
+	// 2.2 遵循审批模式的顺序约束(如顺序审批),使用流程引擎时只能审批当前节点
+	if err := m.checkApprover(tpl, tsk, node, approver); err != nil {
+		return err
+	}
+
 	// 3. 更新任务状态为 approving(如果还是 submitted)
+	// 保留操作前的任务副本,流程推进或节点完成判断失败时用于恢复
+	backup := tsk.Clone()
 	tsk.mu.Lock()
 	if tsk.State == types.TaskStateSubmitted {
 		tsk.State = types.TaskStateApproving
@@ -366,6 +339,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 		TaskID:      id,
 		NodeID:      nodeID,
 		Approver:    approver,
+		OnBehalfOf:  tsk.delegatorOf(nodeID, approver),
 		Result:      "reject",
 		Comment:     comment,
 		CreatedAt:   time.Now(),
@@ -385,7 +359,241 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 	// 先释放任务锁,避免在状态机转换时死锁
 	// 但保持管理器锁,确保任务不会被其他操作修改
 	tsk.mu.Unlock()
+
+	// 如果设置了流程引擎,由审批模式判断节点是否被拒绝(如或签模式需要全部拒绝)
+	if m.engine != nil {
+		return m.advanceWithEngine(ctx, id, tsk, backup, tpl, node, &event.ApprovalInfo{
+			NodeID:     nodeID,
+			Approver:   approver,
+			OnBehalfOf: record.OnBehalfOf,
+			Result:     "reject",
+			Comment:    comment,
+		})
+	}
 	
+	// 未设置流程引擎时,按审批模式判断节点是否被拒绝并处理拒绝后行为
+	return m.completeRejection(id, tsk, backup, tpl, node, record)
+}
+
+// RejectWithAttachments 审批人进行拒绝操作(带附件)
+func (m *memoryTaskManager) RejectWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error {
+	return m.RejectWithAttachmentsCtx(context.Background(), id, nodeID, approver, comment, attachments)
+}
+
+// RejectWithAttachmentsCtx 审批人进行拒绝操作(带附件),ctx 用于取消和超时控制
+func (m *memoryTaskManager) RejectWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 1. 获取任务
+	tsk, exists := m.tasks[id]
+	if !exists {
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(ctx, ActionReject, approver, tsk, nodeID); err != nil {
+		return err
+	}
+
+	// 2. 验证任务状态(只有 submitted 或 approving 状态才能拒绝)
+	tsk.mu.RLock()
+	state := tsk.State
+	tsk.mu.RUnlock()
+
+	if state != types.TaskStateSubmitted && state != types.TaskStateApproving {
+		return fmt.Errorf("%w: task state %q cannot be rejected", errors.ErrInvalidStateTransition, state)
+	}
+
+	// 2.1 获取模板和节点配置,验证审批意见和附件要求
+	tpl, err := m.taskTemplate(tsk)
+	if err != nil {
+		return err
+	}
+
+	node, exists := tpl.Nodes[nodeID]
+	if !exists {
+		return fmt.Errorf("node %q not found in template", nodeID)
+	}
+
+	if node.Type == template.NodeTypeApproval {
+		approvalConfig, ok := node.Config.(template.ApprovalNodeConfigAccessor)
+		if ok {
+			if approvalConfig.RequireComment() && comment == "" {
+				return fmt.Errorf("comment is required for approval node %q", nodeID)
+			}
+			if approvalConfig.RequireAttachments() && len(attachments) == 0 {
+				return fmt.Errorf("attachments are required for approval node %q", nodeID)
+			}
+		}
+	}
+
+	// 2.2 遵循审批模式的顺序约束(如顺序审批),使用流程引擎时只能审批当前节点
+	if err := m.checkApprover(tpl, tsk, node, approver); err != nil {
+		return err
+	}
+
+	// 3. 更新任务状态为 approving(如果还是 submitted)
+	// 保留操作前的任务副本,流程推进或节点完成判断失败时用于恢复
+	backup := tsk.Clone()
+	tsk.mu.Lock()
+	if tsk.State == types.TaskStateSubmitted {
+		tsk.State = types.TaskStateApproving
+		tsk.UpdatedAt = time.Now()
+	}
+
+	// 4. 记录审批结果
+	// 初始化节点审批记录
+	if tsk.Approvals == nil {
+		tsk.Approvals = make(map[string]map[string]*Approval)
+	}
+	if tsk.Approvals[nodeID] == nil {
+		tsk.Approvals[nodeID] = make(map[string]*Approval)
+	}
+
+	// 记录审批结果
+	tsk.Approvals[nodeID][approver] = &Approval{
+		Result:    "reject",
+		Comment:   comment,
+		CreatedAt: time.Now(),
+	}
+
+	// 5. 生成审批记录
+	record := &Record{
+		ID:          generateRecordID(),
+		TaskID:      id,
+		NodeID:      nodeID,
+		Approver:    approver,
+		OnBehalfOf:  tsk.delegatorOf(nodeID, approver),
+		Result:      "reject",
+		Comment:     comment,
+		CreatedAt:   time.Now(),
+		Attachments: attachments,
+	}
+
+	// 验证记录
+	if err := record.Validate(); err != nil {
+		tsk.mu.Unlock()
+		return fmt.Errorf("invalid record: %w", err)
+	}
+
+	// 添加到记录列表
+	tsk.Records = append(tsk.Records, record)
+
+	// 6. 更新任务更新时间
+	tsk.UpdatedAt = time.Now()
+	tsk.mu.Unlock()
+
+	// 7. 如果设置了流程引擎,由审批模式判断节点是否被拒绝并处理拒绝后行为
+	if m.engine != nil {
+		return m.advanceWithEngine(ctx, id, tsk, backup, tpl, node, &event.ApprovalInfo{
+			NodeID:     nodeID,
+			Approver:   approver,
+			OnBehalfOf: record.OnBehalfOf,
+			Result:     "reject",
+			Comment:    comment,
+		})
+	}
+
+	// 未设置流程引擎时,按审批模式判断节点是否被拒绝并处理拒绝后行为
+	return m.completeRejection(id, tsk, backup, tpl, node, record)
+}
+
+// completeApproval 未设置流程引擎时,同意操作后按审批模式判断节点是否完成
+// 节点以同意完成时任务转换为已通过状态(单节点审批: 当前节点审批完成即整个任务完成)
+// 判断失败时使用 backup 恢复任务(撤销已记录的审批结果)
+func (m *memoryTaskManager) completeApproval(id string, tsk *Task, backup *Task, node *template.Node, record *Record) error {
+	completed, result, err := m.decideApproval(tsk, node)
+	if err != nil {
+		m.tasks[id] = backup
+		return fmt.Errorf("failed to decide approval of node %q: %w", node.ID, err)
+	}
+
+	tsk.mu.Lock()
+	shouldTransition := completed && result == "approve" && m.stateMachine.CanTransition(tsk.State, types.TaskStateApproved)
+	tsk.UpdatedAt = time.Now()
+	tsk.mu.Unlock()
+
+	// 8. 如果需要转换状态,执行状态转换(在释放锁之后)
+	if shouldTransition {
+		// 重新获取任务(因为锁已释放)
+		tsk = m.tasks[id]
+		adapter := &taskAdapter{task: tsk}
+		newTask, err := m.stateMachine.Transition(adapter, types.TaskStateApproved, "all approvers approved")
+		if err == nil {
+			tsk = newTask.(*taskAdapter).task
+			tsk.mu.Lock()
+			tsk.UpdatedAt = time.Now()
+			// 节点完成,添加到已完成节点列表
+			if tsk.CompletedNodes == nil {
+				tsk.CompletedNodes = []string{}
+			}
+			// 检查节点是否已在列表中
+			found := false
+			for _, completedNodeID := range tsk.CompletedNodes {
+				if completedNodeID == record.NodeID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				tsk.CompletedNodes = append(tsk.CompletedNodes, record.NodeID)
+			}
+			tsk.mu.Unlock()
+			m.tasks[id] = tsk
+		}
+	} else {
+		// 保存更新后的任务
+		m.tasks[id] = tsk
+	}
+
+	// 9. 生成审批事件
+	if m.eventsEnabled() {
+		approvalInfo := &event.ApprovalInfo{
+			NodeID:     record.NodeID,
+			Approver:   record.Approver,
+			OnBehalfOf: record.OnBehalfOf,
+			Result:     "approve",
+			Comment:    record.Comment,
+		}
+		m.generateEvent(event.EventTypeApprovalOp, tsk, node, approvalInfo)
+		
+		// 如果状态已转换为 approved,生成任务通过事件和节点完成事件
+		if shouldTransition && tsk.State == types.TaskStateApproved {
+			// 生成节点完成事件
+			m.generateEvent(event.EventTypeNodeCompleted, tsk, node, nil)
+			// 生成任务通过事件
+			m.generateEvent(event.EventTypeTaskApproved, tsk, node, nil)
+		}
+	}
+
+	return nil
+}
+
+// completeRejection 未设置流程引擎时,拒绝操作后按审批模式判断节点是否被拒绝
+// 节点被拒绝时按节点的拒绝后行为处理(终止、回退或跳转);未被拒绝时(如或签模式还有审批人未审批)节点继续等待审批
+// 处理失败时使用 backup 恢复任务(撤销已记录的审批结果)
+func (m *memoryTaskManager) completeRejection(id string, tsk *Task, backup *Task, tpl *template.Template, node *template.Node, record *Record) error {
+	completed, result, err := m.decideApproval(tsk, node)
+	if err != nil {
+		m.tasks[id] = backup
+		return fmt.Errorf("failed to decide approval of node %q: %w", node.ID, err)
+	}
+	if !completed || result != "reject" {
+		tsk.mu.Lock()
+		tsk.UpdatedAt = time.Now()
+		tsk.mu.Unlock()
+		if m.eventsEnabled() {
+			m.generateEvent(event.EventTypeApprovalOp, tsk, node, &event.ApprovalInfo{
+				NodeID:     record.NodeID,
+				Approver:   record.Approver,
+				OnBehalfOf: record.OnBehalfOf,
+				Result:     "reject",
+				Comment:    record.Comment,
+			})
+		}
+		return nil
+	}
+
 	// 记录拒绝前的状态,用于事件生成
 	rejectBeforeState := types.TaskStateApproving
 
@@ -404,6 +612,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 
 				newTaskAdapter, err := m.stateMachine.Transition(adapter, types.TaskStateRejected, "rejected by approver")
 				if err != nil {
+					m.tasks[id] = backup
 					return fmt.Errorf("failed to transition to rejected state: %w", err)
 				}
 				m.tasks[id] = newTaskAdapter.(*taskAdapter).task
@@ -413,7 +622,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 				// 查找上一节点(通过 Edges 查找)
 				prevNodeID := ""
 				for _, edge := range tpl.Edges {
-					if edge.To == nodeID {
+					if edge.To == record.NodeID {
 						prevNodeID = edge.From
 						break
 					}
@@ -427,6 +636,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 
 					newTaskAdapter, err := m.stateMachine.Transition(adapter, types.TaskStateRejected, "rejected by approver, no previous node")
 					if err != nil {
+						m.tasks[id] = backup
 						return fmt.Errorf("failed to transition to rejected state: %w", err)
 					}
 					m.tasks[id] = newTaskAdapter.(*taskAdapter).task
@@ -439,6 +649,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 					tsk.State = types.TaskStateApproving
 					tsk.UpdatedAt = time.Now()
 					tsk.mu.Unlock()
+					m.markNodeActivated(tsk, prevNodeID)
 				}
 			case "jump":
 				// 拒绝后跳转到指定节点
@@ -452,6 +663,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 
 					newTaskAdapter, err := m.stateMachine.Transition(adapter, types.TaskStateRejected, "rejected by approver, no target node")
 					if err != nil {
+						m.tasks[id] = backup
 						return fmt.Errorf("failed to transition to rejected state: %w", err)
 					}
 					m.tasks[id] = newTaskAdapter.(*taskAdapter).task
@@ -459,6 +671,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 				} else {
 					// 验证目标节点存在
 					if _, exists := tpl.Nodes[targetNodeID]; !exists {
+						m.tasks[id] = backup
 						return fmt.Errorf("reject target node %q not found in template", targetNodeID)
 					}
 					// 跳转到目标节点
@@ -468,6 +681,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 					tsk.State = types.TaskStateApproving
 					tsk.UpdatedAt = time.Now()
 					tsk.mu.Unlock()
+					m.markNodeActivated(tsk, targetNodeID)
 				}
 			default:
 				// 默认行为: 终止流程
@@ -478,6 +692,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 
 				newTaskAdapter, err := m.stateMachine.Transition(adapter, types.TaskStateRejected, "rejected by approver")
 				if err != nil {
+					m.tasks[id] = backup
 					return fmt.Errorf("failed to transition to rejected state: %w", err)
 				}
 				m.tasks[id] = newTaskAdapter.(*taskAdapter).task
@@ -492,6 +707,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 
 			newTaskAdapter, err := m.stateMachine.Transition(adapter, types.TaskStateRejected, "rejected by approver")
 			if err != nil {
+				m.tasks[id] = backup
 				return fmt.Errorf("failed to transition to rejected state: %w", err)
 			}
 			m.tasks[id] = newTaskAdapter.(*taskAdapter).task
@@ -506,6 +722,7 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 
 		newTaskAdapter, err := m.stateMachine.Transition(adapter, types.TaskStateRejected, "rejected by approver")
 		if err != nil {
+			m.tasks[id] = backup
 			return fmt.Errorf("failed to transition to rejected state: %w", err)
 		}
 		m.tasks[id] = newTaskAdapter.(*taskAdapter).task
@@ -519,14 +736,15 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 	tsk.mu.Unlock()
 
 	// 8. 生成事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		// 如果拒绝前状态是 approving,先生成审批操作事件
 		if rejectBeforeState == types.TaskStateApproving {
 			m.generateEvent(event.EventTypeApprovalOp, tsk, node, &event.ApprovalInfo{
-				NodeID:   nodeID,
-				Approver: approver,
-				Result:   "reject",
-				Comment:  comment,
+				NodeID:     record.NodeID,
+				Approver:   record.Approver,
+				OnBehalfOf: record.OnBehalfOf,
+				Result:     "reject",
+				Comment:    record.Comment,
 			})
 		}
 		
@@ -542,98 +760,46 @@ func (m *memoryTaskManager) Reject(id string, nodeID string, approver string, co
 	return nil
 }
 
-// RejectWithAttachments 审批人进行拒绝操作(带附件)
-func (m *memoryTaskManager) RejectWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// 1. 获取任务
-	tsk, exists := m.tasks[id]
-	if !exists {
-		return fmt.Errorf("task %q not found", id)
-	}
-
-	// 2. 验证任务状态(只有 submitted 或 approving 状态才能拒绝)
+// decideApproval 未设置流程引擎时,按节点的审批模式判断节点是否完成
+// 使用 WithApprovalDecider 设置的判断;未设置时任一审批人拒绝即拒绝,所有审批人同意即通过
+// 任务中没有节点的审批人列表时,以已审批的审批人作为审批人列表
+func (m *memoryTaskManager) decideApproval(tsk *Task, node *template.Node) (bool, string, error) {
 	tsk.mu.RLock()
-	state := tsk.State
-	tsk.mu.RUnlock()
-
-	if state != types.TaskStateSubmitted && state != types.TaskStateApproving {
-		return fmt.Errorf("%w: task state %q cannot be rejected", errors.ErrInvalidStateTransition, state)
-	}
-
-	// 2.1 获取模板和节点配置,验证审批意见和附件要求
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
-	if err != nil {
-		return fmt.Errorf("failed to get template %q: %w", tsk.TemplateID, err)
-	}
-
-	node, exists := tpl.Nodes[nodeID]
-	if !exists {
-		return fmt.Errorf("node %q not found in template", nodeID)
+	approvers := append([]string(nil), tsk.Approvers[node.ID]...)
+	approvals := make(map[string]*Approval, len(tsk.Approvals[node.ID]))
+	for approver, approval := range tsk.Approvals[node.ID] {
+		approvals[approver] = approval
 	}
+	tsk.mu.RUnlock()
 
-	if node.Type == template.NodeTypeApproval {
-		approvalConfig, ok := node.Config.(template.ApprovalNodeConfigAccessor)
-		if ok {
-			if approvalConfig.RequireComment() && comment == "" {
-				return fmt.Errorf("comment is required for approval node %q", nodeID)
-			}
-			if approvalConfig.RequireAttachments() && len(attachments) == 0 {
-				return fmt.Errorf("attachments are required for approval node %q", nodeID)
-			}
+	if len(approvers) == 0 {
+		for approver := range approvals {
+			approvers = append(approvers, approver)
 		}
+		sort.Strings(approvers)
 	}
 
-	// 3. 更新任务状态为 approving(如果还是 submitted)
-	tsk.mu.Lock()
-	if tsk.State == types.TaskStateSubmitted {
-		tsk.State = types.TaskStateApproving
-		tsk.UpdatedAt = time.Now()
-	}
-
-	// 4. 记录审批结果
-	// 初始化节点审批记录
-	if tsk.Approvals == nil {
-		tsk.Approvals = make(map[string]map[string]*Approval)
-	}
-	if tsk.Approvals[nodeID] == nil {
-		tsk.Approvals[nodeID] = make(map[string]*Approval)
+	if m.decider != nil {
+		return m.decider.DecideApproval(node, approvers, approvals)
 	}
 
-	// 记录审批结果
-	tsk.Approvals[nodeID][approver] = &Approval{
-		Result:    "reject",
-		Comment:   comment,
-		CreatedAt: time.Now(),
-	}
-
-	// 5. 生成审批记录
-	record := &Record{
-		ID:          generateRecordID(),
-		TaskID:      id,
-		NodeID:      nodeID,
-		Approver:    approver,
-		Result:      "reject",
-		Comment:     comment,
-		CreatedAt:   time.Now(),
-		Attachments: attachments,
+	approved := 0
+	for _, approver := range approvers {
+		approval := approvals[approver]
+		if approval == nil {
+			continue
+		}
+		if approval.Result == "reject" {
+			return true, "reject", nil
+		}
+		if approval.Result == "approve" {
+			approved++
+		}
 	}
-
-	// 验证记录
-	if err := record.Validate(); err != nil {
-		tsk.mu.Unlock()
-		return fmt.Errorf("invalid record: %w", err)
+	if len(approvers) > 0 && approved == len(approvers) {
+		return true, "approve", nil
 	}
-
-	// 添加到记录列表
-	tsk.Records = append(tsk.Records, record)
-
-	// 6. 更新任务更新时间
-	tsk.UpdatedAt = time.Now()
-	tsk.mu.Unlock()
-
-	return nil
+	return false, "", nil
 }
 
 // recordIDCounter 记录 ID 计数器,用于确保唯一性
@@ -0,0 +1,215 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// Action 任务操作类型
+// 授权检查时用于区分操作
+type Action string
+
+const (
+	ActionCreate          Action = "create"
+	ActionSubmit          Action = "submit"
+	ActionApprove         Action = "approve"
+	ActionReject          Action = "reject"
+	ActionCancel          Action = "cancel"
+	ActionWithdraw        Action = "withdraw"
+	ActionTransfer        Action = "transfer"
+	ActionAddApprover     Action = "add_approver"
+	ActionRemoveApprover  Action = "remove_approver"
+	ActionReplaceApprover Action = "replace_approver"
+	ActionPause           Action = "pause"
+	ActionResume          Action = "resume"
+	ActionRollback        Action = "rollback"
+	ActionRemind          Action = "remind"
+	ActionMigrate         Action = "migrate"
+	ActionHandleTimeout   Action = "handle_timeout"
+	ActionHandleReminders Action = "handle_reminders"
+)
+
+// AuthorizationRequest 授权检查请求
+type AuthorizationRequest struct {
+	// Action 操作类型
+	Action Action
+
+	// Actor 操作人
+	// 审批、拒绝为审批人,转交为原审批人,催办为催办发起人,创建为发起人;
+	// 系统操作(如超时处理)为空
+	Actor string
+
+	// Task 操作前的任务快照,创建时为待创建的任务
+	Task *Task
+
+	// NodeID 操作的节点 ID,与节点无关的操作为空
+	NodeID string
+}
+
+// Authorizer 授权检查接口
+// 任务管理器在执行每个修改任务的操作前调用,返回错误时操作不执行
+type Authorizer interface {
+	// Authorize 检查操作人是否可以执行操作
+	// 返回: 不允许时返回 *ForbiddenError(可以通过 errors.Is(err, ErrForbidden) 判断)
+	Authorize(ctx context.Context, req *AuthorizationRequest) error
+}
+
+// AuthorizerFunc 函数形式的授权检查
+type AuthorizerFunc func(ctx context.Context, req *AuthorizationRequest) error
+
+// Authorize 调用函数本身(实现 Authorizer 接口)
+func (f AuthorizerFunc) Authorize(ctx context.Context, req *AuthorizationRequest) error {
+	return f(ctx, req)
+}
+
+// ForbiddenError 操作人无权执行操作
+// 可以通过 errors.Is(err, ErrForbidden) 判断
+type ForbiddenError struct {
+	Action Action
+	Actor  string
+	TaskID string
+	Reason string
+}
+
+// Error 返回错误描述
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("%v: %q cannot %s task %q: %s", errors.ErrForbidden, e.Actor, e.Action, e.TaskID, e.Reason)
+}
+
+// Unwrap 返回 ErrForbidden
+func (e *ForbiddenError) Unwrap() error {
+	return errors.ErrForbidden
+}
+
+// defaultAuthorizer 默认授权策略
+type defaultAuthorizer struct {
+	admins          map[string]bool
+	allowUnassigned bool
+}
+
+// DefaultAuthorizerOption 默认授权策略的配置选项
+type DefaultAuthorizerOption func(*defaultAuthorizer)
+
+// WithAdmins 设置管理员
+// 管理员可以执行发起人可以执行的操作,以及加签、减签、替换审批人、回退和迁移
+func WithAdmins(admins ...string) DefaultAuthorizerOption {
+	return func(a *defaultAuthorizer) {
+		for _, admin := range admins {
+			a.admins[admin] = true
+		}
+	}
+}
+
+// AllowUnassignedApprovers 允许任何人审批或拒绝尚未获取审批人的节点
+// 未启用流程引擎、也未在创建任务时获取审批人时,节点的审批人列表为空,默认拒绝所有审批;
+// 由调用方自行校验审批人时可以使用此选项
+func AllowUnassignedApprovers() DefaultAuthorizerOption {
+	return func(a *defaultAuthorizer) {
+		a.allowUnassigned = true
+	}
+}
+
+// NewDefaultAuthorizer 创建默认授权策略
+//   - 审批、拒绝、转交: 必须是节点的审批人(节点没有审批人时拒绝,除非使用 AllowUnassignedApprovers)
+//   - 提交、撤回、取消、暂停、恢复: 必须是发起人或管理员(任务未记录发起人时只有管理员可以操作)
+//   - 加签: 必须是节点的审批人或管理员
+//   - 减签、替换审批人、回退、迁移: 必须是管理员
+//   - 创建、催办和系统操作(超时处理、提醒处理): 不限制
+func NewDefaultAuthorizer(opts ...DefaultAuthorizerOption) Authorizer {
+	a := &defaultAuthorizer{admins: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Authorize 按默认策略检查操作(实现 Authorizer 接口)
+func (a *defaultAuthorizer) Authorize(ctx context.Context, req *AuthorizationRequest) error {
+	switch req.Action {
+	case ActionApprove, ActionReject, ActionTransfer:
+		approvers := req.Task.Approvers[req.NodeID]
+		if len(approvers) == 0 && a.allowUnassigned {
+			return nil
+		}
+		if a.isApprover(req) {
+			return nil
+		}
+		return forbidden(req, fmt.Sprintf("not an approver of node %q", req.NodeID))
+	case ActionSubmit, ActionCancel, ActionWithdraw, ActionPause, ActionResume:
+		// 未记录发起人的任务(通过 Create 创建)只有管理员可以操作
+		if req.Task.Initiator != "" && req.Actor == req.Task.Initiator {
+			return nil
+		}
+		if a.isAdmin(req) {
+			return nil
+		}
+		return forbidden(req, "only the initiator or an admin can "+string(req.Action))
+	case ActionAddApprover:
+		if a.isApprover(req) || a.isAdmin(req) {
+			return nil
+		}
+		return forbidden(req, fmt.Sprintf("only an approver of node %q or an admin can %s", req.NodeID, req.Action))
+	case ActionRemoveApprover, ActionReplaceApprover, ActionRollback, ActionMigrate:
+		if a.isAdmin(req) {
+			return nil
+		}
+		return forbidden(req, "only an admin can "+string(req.Action))
+	}
+	return nil
+}
+
+// isApprover 判断操作人是否为节点的审批人
+func (a *defaultAuthorizer) isApprover(req *AuthorizationRequest) bool {
+	if req.Actor == "" {
+		return false
+	}
+	for _, approver := range req.Task.Approvers[req.NodeID] {
+		if approver == req.Actor {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdmin 判断操作人是否为管理员
+func (a *defaultAuthorizer) isAdmin(req *AuthorizationRequest) bool {
+	return req.Actor != "" && a.admins[req.Actor]
+}
+
+// AllowAllAuthorizer 不做任何限制的授权策略
+// 适用于由调用方自行完成权限控制的场景
+func AllowAllAuthorizer() Authorizer {
+	return AuthorizerFunc(func(ctx context.Context, req *AuthorizationRequest) error {
+		return nil
+	})
+}
+
+// WithAuthorizer 设置授权策略
+// 未设置时使用 NewDefaultAuthorizer()(没有管理员,节点没有审批人时拒绝审批)
+func WithAuthorizer(authorizer Authorizer) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.authorizer = authorizer
+	}
+}
+
+// forbidden 创建授权请求被拒绝的错误
+func forbidden(req *AuthorizationRequest, reason string) error {
+	return &ForbiddenError{Action: req.Action, Actor: req.Actor, TaskID: req.Task.ID, Reason: reason}
+}
+
+// authorize 执行操作前的授权检查
+// 授权策略只能看到任务快照,不能修改任务
+// 调用方必须持有任务管理器的锁(创建任务时任务尚未存储,除外)
+func (m *memoryTaskManager) authorize(ctx context.Context, action Action, actor string, tsk *Task, nodeID string) error {
+	if m.authorizer == nil {
+		return nil
+	}
+	return m.authorizer.Authorize(ctx, &AuthorizationRequest{
+		Action: action,
+		Actor:  actor,
+		Task:   tsk.Clone(),
+		NodeID: nodeID,
+	})
+}
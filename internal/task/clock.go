@@ -0,0 +1,35 @@
+package task
+
+import "time"
+
+// Clock 时钟接口
+// 任务管理器通过 Clock 记录节点激活时间和判断超时,超时调度器通过 Clock 等待截止时间
+// 测试中可以注入可控的时钟,无需真实等待
+type Clock interface {
+	// Now 返回当前时间
+	Now() time.Time
+
+	// After 等待 d 后向返回的通道发送当前时间
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock 使用系统时间的时钟
+type realClock struct{}
+
+// Now 返回系统当前时间
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After 等待 d 后向返回的通道发送当前时间
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// WithClock 设置任务管理器使用的时钟
+// 未设置时使用超时调度器的时钟(如果设置了 WithTimeoutScheduler),否则使用系统时间
+func WithClock(clock Clock) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.clock = clock
+	}
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // Clone 创建任务的深拷贝
@@ -17,11 +18,13 @@ func (t *Task) Clone() *Task {
 		TemplateID:     t.TemplateID,
 		TemplateVersion: t.TemplateVersion,
 		BusinessID:     t.BusinessID,
+		Initiator:      t.Initiator,
 		State:          t.State,
 		CurrentNode:    t.CurrentNode,
 		PausedState:    t.PausedState,
 		CreatedAt:      t.CreatedAt,
 		UpdatedAt:      t.UpdatedAt,
+		Version:        t.Version,
 	}
 
 	// 复制 Params
@@ -42,6 +45,14 @@ func (t *Task) Clone() *Task {
 		clone.PausedAt = &pausedAt
 	}
 
+	// 复制 NodeActivatedAt
+	if t.NodeActivatedAt != nil {
+		clone.NodeActivatedAt = make(map[string]time.Time, len(t.NodeActivatedAt))
+		for k, v := range t.NodeActivatedAt {
+			clone.NodeActivatedAt[k] = v
+		}
+	}
+
 	// 复制 NodeOutputs
 	clone.NodeOutputs = make(map[string]json.RawMessage)
 	for k, v := range t.NodeOutputs {
@@ -72,6 +83,26 @@ func (t *Task) Clone() *Task {
 		clone.Approvals[k] = approvals
 	}
 
+	// 复制 Delegations
+	if t.Delegations != nil {
+		clone.Delegations = make(map[string]map[string]string, len(t.Delegations))
+		for nodeID, delegations := range t.Delegations {
+			nodeDelegations := make(map[string]string, len(delegations))
+			for delegate, delegator := range delegations {
+				nodeDelegations[delegate] = delegator
+			}
+			clone.Delegations[nodeID] = nodeDelegations
+		}
+	}
+
+	// 复制 NodeAliases
+	if t.NodeAliases != nil {
+		clone.NodeAliases = make(map[string]string, len(t.NodeAliases))
+		for from, to := range t.NodeAliases {
+			clone.NodeAliases[from] = to
+		}
+	}
+
 	// 复制 CompletedNodes
 	clone.CompletedNodes = make([]string, len(t.CompletedNodes))
 	copy(clone.CompletedNodes, t.CompletedNodes)
@@ -84,6 +115,7 @@ func (t *Task) Clone() *Task {
 			TaskID:     r.TaskID,
 			NodeID:     r.NodeID,
 			Approver:   r.Approver,
+			OnBehalfOf: r.OnBehalfOf,
 			Result:     r.Result,
 			Comment:    r.Comment,
 			CreatedAt:  r.CreatedAt,
@@ -103,6 +135,21 @@ func (t *Task) Clone() *Task {
 		}
 	}
 
+	// 复制 Reminders
+	if t.Reminders != nil {
+		clone.Reminders = make([]*Reminder, len(t.Reminders))
+		for i, r := range t.Reminders {
+			clone.Reminders[i] = &Reminder{
+				NodeID:    r.NodeID,
+				Actor:     r.Actor,
+				Approvers: append([]string(nil), r.Approvers...),
+				Message:   r.Message,
+				Automatic: r.Automatic,
+				CreatedAt: r.CreatedAt,
+			}
+		}
+	}
+
 	return clone
 }
 
@@ -117,6 +164,7 @@ func (r *Record) cloneRecord() *Record {
 		TaskID:      r.TaskID,
 		NodeID:      r.NodeID,
 		Approver:    r.Approver,
+		OnBehalfOf:  r.OnBehalfOf,
 		Result:      r.Result,
 		Comment:     r.Comment,
 		CreatedAt:   r.CreatedAt,
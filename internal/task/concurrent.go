@@ -87,6 +87,7 @@ func (t *Task) Snapshot() *TaskSnapshot {
 		TemplateID:     t.TemplateID,
 		TemplateVersion: t.TemplateVersion,
 		BusinessID:     t.BusinessID,
+		Initiator:      t.Initiator,
 		State:          t.State,
 		CurrentNode:    t.CurrentNode,
 		CreatedAt:      t.CreatedAt,
@@ -138,6 +139,7 @@ func (t *Task) Snapshot() *TaskSnapshot {
 			TaskID:     r.TaskID,
 			NodeID:     r.NodeID,
 			Approver:   r.Approver,
+			OnBehalfOf: r.OnBehalfOf,
 			Result:     r.Result,
 			Comment:    r.Comment,
 			CreatedAt:  r.CreatedAt,
@@ -167,6 +169,7 @@ type TaskSnapshot struct {
 	TemplateID     string
 	TemplateVersion int
 	BusinessID     string
+	Initiator      string
 	Params         json.RawMessage
 	State          TaskState
 	CurrentNode    string
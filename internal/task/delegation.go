@@ -0,0 +1,242 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// maxDelegationDepth 委托链的最大长度
+// 委托人的代理人也设置了委托时沿委托链查找,超过最大长度或出现环时停止
+const maxDelegationDepth = 5
+
+// DelegationRule 委托规则
+// 在有效期内,委托人(Delegator)的审批由代理人(Delegate)代为处理,
+// 例如: 请假期间将模板 X 的审批转给 bob
+type DelegationRule struct {
+	// ID 规则 ID
+	ID string
+
+	// Delegator 委托人(原审批人)
+	Delegator string
+
+	// Delegate 代理人
+	Delegate string
+
+	// TemplateID 生效的模板 ID(可选),为空时对所有模板生效
+	TemplateID string
+
+	// StartAt 生效时间(包含)
+	StartAt time.Time
+
+	// EndAt 失效时间(不包含)
+	EndAt time.Time
+
+	// Reason 委托原因(如 "年假")
+	Reason string
+}
+
+// Validate 验证委托规则的有效性
+func (r *DelegationRule) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("delegation rule ID is required")
+	}
+	if r.Delegator == "" || r.Delegate == "" {
+		return fmt.Errorf("delegation rule %q requires both delegator and delegate", r.ID)
+	}
+	if r.Delegator == r.Delegate {
+		return fmt.Errorf("delegation rule %q delegates %q to itself", r.ID, r.Delegator)
+	}
+	if r.StartAt.IsZero() || r.EndAt.IsZero() {
+		return fmt.Errorf("delegation rule %q requires a validity window", r.ID)
+	}
+	if !r.EndAt.After(r.StartAt) {
+		return fmt.Errorf("delegation rule %q must end after it starts", r.ID)
+	}
+	return nil
+}
+
+// Active 判断规则在指定时间对指定模板是否生效
+func (r *DelegationRule) Active(templateID string, at time.Time) bool {
+	if r.TemplateID != "" && r.TemplateID != templateID {
+		return false
+	}
+	return !at.Before(r.StartAt) && at.Before(r.EndAt)
+}
+
+// DelegationStore 委托规则存储接口
+type DelegationStore interface {
+	// Save 保存委托规则,ID 已存在时替换
+	Save(rule *DelegationRule) error
+
+	// Delete 删除委托规则
+	// 返回: 错误信息,规则不存在时返回 ErrDelegationNotFound
+	Delete(id string) error
+
+	// List 查询委托人的所有委托规则,delegator 为空时返回所有规则
+	List(delegator string) ([]*DelegationRule, error)
+
+	// FindActive 查找委托人在指定时间对指定模板生效的委托规则
+	// 返回: 生效的规则,没有生效的规则时返回 nil
+	FindActive(delegator string, templateID string, at time.Time) (*DelegationRule, error)
+}
+
+// ActiveDelegationRule 从规则列表中选出在指定时间对指定模板生效的规则
+// 多条规则同时生效时,指定了模板的规则优先,其次是生效时间最晚的规则
+// 供 DelegationStore 的实现使用
+func ActiveDelegationRule(rules []*DelegationRule, templateID string, at time.Time) *DelegationRule {
+	var active *DelegationRule
+	for _, rule := range rules {
+		if !rule.Active(templateID, at) {
+			continue
+		}
+		if active == nil ||
+			(rule.TemplateID != "" && active.TemplateID == "") ||
+			((rule.TemplateID != "") == (active.TemplateID != "") && rule.StartAt.After(active.StartAt)) {
+			active = rule
+		}
+	}
+	return active
+}
+
+// ApplyDelegations 按委托规则替换审批人
+// 生效的委托规则将委托人替换为代理人,并在任务中记录代理关系(Task.Delegations),
+// 代理人审批时生成的审批记录同时保留代理人和原审批人
+// store: 委托规则存储,为 nil 时原样返回审批人
+// tsk: 任务对象,使用任务的模板 ID 匹配规则
+// nodeID: 审批节点 ID
+// approvers: 审批人获取结果
+// at: 匹配规则的时间(通常为审批人获取的时间)
+// 返回: 替换后的审批人列表(去重)
+func ApplyDelegations(store DelegationStore, tsk *Task, nodeID string, approvers []string, at time.Time) ([]string, error) {
+	if store == nil {
+		return approvers, nil
+	}
+
+	original := make(map[string]bool, len(approvers))
+	for _, approver := range approvers {
+		original[approver] = true
+	}
+
+	result := make([]string, 0, len(approvers))
+	seen := make(map[string]bool, len(approvers))
+	for _, approver := range approvers {
+		acting, err := resolveDelegate(store, approver, tsk.TemplateID, at)
+		if err != nil {
+			return nil, err
+		}
+
+		// 代理人本身也是审批人时,按其本人身份审批
+		if acting != approver && !original[acting] {
+			if tsk.Delegations == nil {
+				tsk.Delegations = make(map[string]map[string]string)
+			}
+			if tsk.Delegations[nodeID] == nil {
+				tsk.Delegations[nodeID] = make(map[string]string)
+			}
+			tsk.Delegations[nodeID][acting] = approver
+		}
+
+		if !seen[acting] {
+			seen[acting] = true
+			result = append(result, acting)
+		}
+	}
+	return result, nil
+}
+
+// resolveDelegate 沿委托链查找实际处理审批的代理人
+func resolveDelegate(store DelegationStore, approver string, templateID string, at time.Time) (string, error) {
+	visited := map[string]bool{approver: true}
+	acting := approver
+	for i := 0; i < maxDelegationDepth; i++ {
+		rule, err := store.FindActive(acting, templateID, at)
+		if err != nil {
+			return "", fmt.Errorf("failed to find delegation of %q: %w", acting, err)
+		}
+		if rule == nil || visited[rule.Delegate] {
+			break
+		}
+		visited[rule.Delegate] = true
+		acting = rule.Delegate
+	}
+	return acting, nil
+}
+
+// delegatorOf 返回代理人在节点中代为审批的原审批人,不是代理审批时返回空字符串
+// 调用方必须持有任务锁
+func (t *Task) delegatorOf(nodeID string, approver string) string {
+	return t.Delegations[nodeID][approver]
+}
+
+// memoryDelegationStore 内存实现的委托规则存储
+type memoryDelegationStore struct {
+	mu    sync.RWMutex
+	rules map[string]*DelegationRule // ruleID -> DelegationRule
+}
+
+// NewMemoryDelegationStore 创建内存委托规则存储
+// 进程重启后数据丢失,适用于测试和单机场景
+func NewMemoryDelegationStore() DelegationStore {
+	return &memoryDelegationStore{
+		rules: make(map[string]*DelegationRule),
+	}
+}
+
+// Save 保存委托规则
+func (s *memoryDelegationStore) Save(rule *DelegationRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *rule
+	s.rules[rule.ID] = &stored
+	return nil
+}
+
+// Delete 删除委托规则
+func (s *memoryDelegationStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.rules[id]; !exists {
+		return fmt.Errorf("%w: %q", errors.ErrDelegationNotFound, id)
+	}
+	delete(s.rules, id)
+	return nil
+}
+
+// List 查询委托人的所有委托规则,按生效时间排序
+func (s *memoryDelegationStore) List(delegator string) ([]*DelegationRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rules []*DelegationRule
+	for _, rule := range s.rules {
+		if delegator == "" || rule.Delegator == delegator {
+			stored := *rule
+			rules = append(rules, &stored)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if !rules[i].StartAt.Equal(rules[j].StartAt) {
+			return rules[i].StartAt.Before(rules[j].StartAt)
+		}
+		return rules[i].ID < rules[j].ID
+	})
+	return rules, nil
+}
+
+// FindActive 查找生效的委托规则
+func (s *memoryDelegationStore) FindActive(delegator string, templateID string, at time.Time) (*DelegationRule, error) {
+	rules, err := s.List(delegator)
+	if err != nil {
+		return nil, err
+	}
+	return ActiveDelegationRule(rules, templateID, at), nil
+}
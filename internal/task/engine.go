@@ -0,0 +1,101 @@
+package task
+
+import (
+	"context"
+
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// FlowEngine 流程引擎接口
+// 负责在节点完成后执行节点执行器,并沿模板的边推进流程
+// 由 node 包实现(node.NewFlowEngine),通过依赖注入的方式提供给任务管理器,避免循环依赖
+type FlowEngine interface {
+	// Advance 从指定节点开始推进流程
+	// ctx: 请求上下文,传递给节点执行器和审批人获取,取消或超时后停止推进
+	// tpl: 任务所属模板
+	// tsk: 任务对象(引擎会直接更新 CurrentNode、CompletedNodes、NodeOutputs、Approvers 等运行时数据)
+	// nodeID: 起始节点 ID(开始节点或当前审批节点)
+	// 返回: 推进结果和错误信息
+	// 注意: 引擎执行到等待审批的节点或结束节点时停止
+	Advance(ctx context.Context, tpl *template.Template, tsk *Task, nodeID string) (*FlowResult, error)
+
+	// CheckApprover 检查审批人当前是否可以对节点进行审批
+	// 在记录审批结果前调用,用于审批模式的顺序约束(如顺序审批)
+	// 返回: 不能审批时返回错误
+	CheckApprover(tpl *template.Template, tsk *Task, nodeID string, approver string) error
+}
+
+// FlowResult 流程推进结果
+type FlowResult struct {
+	// CurrentNode 推进结束后任务所在的节点 ID
+	CurrentNode string
+
+	// Rejected CurrentNode 审批节点是否已被拒绝(由审批模式判定)
+	// 拒绝后的流程走向由任务管理器根据节点的拒绝后行为决定
+	Rejected bool
+
+	// Steps 本次推进中经过的步骤(按执行顺序),用于生成节点激活和节点完成事件
+	Steps []*FlowStep
+
+	// Finished 是否已到达结束节点
+	Finished bool
+}
+
+// FlowStep 流程推进步骤
+type FlowStep struct {
+	NodeID string     // 节点 ID
+	Action FlowAction // 节点动作
+}
+
+// FlowAction 流程推进中的节点动作
+type FlowAction string
+
+const (
+	// FlowActionActivated 节点被激活
+	FlowActionActivated FlowAction = "activated"
+
+	// FlowActionCompleted 节点已完成
+	FlowActionCompleted FlowAction = "completed"
+)
+
+// ApprovalDecider 审批节点完成判断接口
+// 未设置流程引擎时,审批操作前由 ApprovalDecider 检查审批模式的顺序约束,审批操作后按节点的审批模式判断节点是否完成
+// 由 node 包实现(node.NewApprovalDecider),通过依赖注入的方式提供给任务管理器,避免循环依赖
+type ApprovalDecider interface {
+	// CheckApprover 检查审批人当前是否可以对节点进行审批(如顺序审批需要按顺序审批)
+	// node: 审批节点
+	// approvers: 节点的审批人列表
+	// approvals: 节点已有的审批结果(审批人 -> 审批结果)
+	// approver: 审批人
+	// 返回: 不可以审批时返回错误(如 ErrApprovalOutOfOrder)
+	CheckApprover(node *template.Node, approvers []string, approvals map[string]*Approval, approver string) error
+
+	// DecideApproval 判断审批节点是否完成
+	// node: 审批节点
+	// approvers: 节点的审批人列表
+	// approvals: 节点已有的审批结果(审批人 -> 审批结果)
+	// 返回: 是否完成、审批结果(approve/reject)和错误信息
+	DecideApproval(node *template.Node, approvers []string, approvals map[string]*Approval) (bool, string, error)
+}
+
+// ManagerOption 任务管理器可选配置
+type ManagerOption func(*memoryTaskManager)
+
+// WithFlowEngine 设置流程引擎
+// 设置后,提交和审批操作会通过引擎沿模板的边逐个节点推进流程
+// 未设置时保持单节点审批的行为: 当前节点审批完成即整个任务完成
+func WithFlowEngine(engine FlowEngine) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.engine = engine
+	}
+}
+
+// WithApprovalDecider 设置审批节点完成判断
+// 未设置流程引擎时生效,使审批模式(包括自定义审批模式)的顺序约束和完成判断生效,
+// 通常使用 node.NewApprovalDecider(registry);
+// 未设置时不检查顺序,任一审批人拒绝即拒绝,所有审批人同意即通过
+func WithApprovalDecider(decider ApprovalDecider) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.decider = decider
+	}
+}
@@ -15,10 +15,27 @@ func generateEventID(taskID string, eventType event.EventType, t time.Time) stri
 
 // generateEvent 生成事件
 func (m *memoryTaskManager) generateEvent(eventType event.EventType, tsk *Task, node *template.Node, approval *event.ApprovalInfo) {
-	if m.eventNotifier == nil {
+	if !m.eventsEnabled() {
 		return
 	}
 
+	m.publishEvent(m.buildEvent(eventType, tsk, node, approval))
+}
+
+// generateReminderEvent 生成催办事件
+func (m *memoryTaskManager) generateReminderEvent(tsk *Task, node *template.Node, reminder *event.ReminderInfo) {
+	if !m.eventsEnabled() {
+		return
+	}
+
+	evt := m.buildEvent(event.EventTypeApproverReminded, tsk, node, nil)
+	evt.Reminder = reminder
+	m.publishEvent(evt)
+}
+
+// buildEvent 构建事件
+// node 为 nil 时使用任务当前节点的信息
+func (m *memoryTaskManager) buildEvent(eventType event.EventType, tsk *Task, node *template.Node, approval *event.ApprovalInfo) *event.Event {
 	// 获取节点信息
 	var nodeInfo *event.NodeInfo
 	if node != nil {
@@ -34,7 +51,7 @@ func (m *memoryTaskManager) generateEvent(eventType event.EventType, tsk *Task,
 		tsk.mu.RUnlock()
 
 		// 从模板获取节点信息
-		if tpl, err := m.templateMgr.Get(tsk.TemplateID, 0); err == nil {
+		if tpl, err := m.taskTemplate(tsk); err == nil {
 			if n, exists := tpl.Nodes[currentNodeID]; exists {
 				nodeInfo = &event.NodeInfo{
 					ID:   n.ID,
@@ -59,6 +76,7 @@ func (m *memoryTaskManager) generateEvent(eventType event.EventType, tsk *Task,
 	taskInfo := &event.TaskInfo{
 		ID:         tsk.ID,
 		TemplateID: tsk.TemplateID,
+		TemplateVersion: tsk.TemplateVersion,
 		BusinessID: tsk.BusinessID,
 		State:      string(tsk.State),
 	}
@@ -74,7 +92,7 @@ func (m *memoryTaskManager) generateEvent(eventType event.EventType, tsk *Task,
 	eventID := generateEventID(tsk.ID, eventType, time.Now())
 
 	// 创建事件
-	evt := &event.Event{
+	return &event.Event{
 		ID:        eventID,
 		Type:      eventType,
 		Time:      time.Now(),
@@ -83,8 +101,5 @@ func (m *memoryTaskManager) generateEvent(eventType event.EventType, tsk *Task,
 		Approval:  approval,
 		Business:  businessInfo,
 	}
-
-	// 异步推送事件
-	m.eventNotifier.Notify(evt)
 }
 
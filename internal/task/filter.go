@@ -28,3 +28,53 @@ type TaskFilter struct {
 	EndTime time.Time
 }
 
+
+// Match 判断任务是否满足过滤条件(并发安全)
+// 未设置的条件不参与过滤,所有已设置的条件均满足时返回 true
+func (f *TaskFilter) Match(tsk *Task) bool {
+	tsk.mu.RLock()
+	defer tsk.mu.RUnlock()
+
+	// 按状态过滤
+	if f.State != types.TaskState("") && tsk.State != f.State {
+		return false
+	}
+
+	// 按模板 ID 过滤
+	if f.TemplateID != "" && tsk.TemplateID != f.TemplateID {
+		return false
+	}
+
+	// 按业务 ID 过滤
+	if f.BusinessID != "" && tsk.BusinessID != f.BusinessID {
+		return false
+	}
+
+	// 按审批人过滤(查询待审批任务)
+	// 检查该审批人是否在任一节点的审批人列表中
+	if f.Approver != "" && !hasApprover(tsk.Approvers, f.Approver) {
+		return false
+	}
+
+	// 按时间范围过滤
+	if !f.StartTime.IsZero() && tsk.CreatedAt.Before(f.StartTime) {
+		return false
+	}
+	if !f.EndTime.IsZero() && tsk.CreatedAt.After(f.EndTime) {
+		return false
+	}
+
+	return true
+}
+
+// hasApprover 检查审批人是否在任一节点的审批人列表中
+func hasApprover(approvers map[string][]string, approver string) bool {
+	for _, list := range approvers {
+		for _, a := range list {
+			if a == approver {
+				return true
+			}
+		}
+	}
+	return false
+}
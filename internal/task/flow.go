@@ -0,0 +1,248 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// submitWithEngine 通过流程引擎提交任务
+// 从开始节点推进到第一个等待审批的节点(或直接到达结束节点)
+// 调用方必须持有管理器锁,tsk 为已转换为 submitted 状态的任务
+func (m *memoryTaskManager) submitWithEngine(ctx context.Context, id string, tsk *Task) error {
+	tpl, err := m.taskTemplate(tsk)
+	if err != nil {
+		return err
+	}
+
+	flow, err := m.advanceFlow(ctx, tpl, tsk, tsk.CurrentNode)
+	if err != nil {
+		return err
+	}
+
+	if flow.Finished {
+		tsk, err = m.finishFlow(tsk)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 保存更新后的任务
+	m.tasks[id] = tsk
+
+	if m.eventsEnabled() {
+		m.generateEvent(event.EventTypeTaskSubmitted, tsk, nil, nil)
+		m.generateFlowEvents(tpl, tsk, flow)
+	}
+
+	return nil
+}
+
+// advanceFlow 调用流程引擎推进流程
+// 引擎会直接修改任务的运行时数据,因此在任务锁内执行
+func (m *memoryTaskManager) advanceFlow(ctx context.Context, tpl *template.Template, tsk *Task, nodeID string) (*FlowResult, error) {
+	tsk.mu.Lock()
+	flow, err := m.engine.Advance(ctx, tpl, tsk, nodeID)
+	tsk.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to advance flow from node %q: %w", nodeID, err)
+	}
+
+	// 记录推进过程中激活的节点
+	var activated []string
+	for _, step := range flow.Steps {
+		if step.Action == FlowActionActivated {
+			activated = append(activated, step.NodeID)
+		}
+	}
+	if len(activated) > 0 {
+		m.markNodeActivated(tsk, activated...)
+	}
+	return flow, nil
+}
+
+// finishFlow 流程到达结束节点后将任务转换为已通过状态
+// 返回状态转换后的任务对象
+func (m *memoryTaskManager) finishFlow(tsk *Task) (*Task, error) {
+	// 状态机不允许从 submitted 直接转换为 approved,需要先进入 approving
+	if tsk.GetState() == types.TaskStateSubmitted {
+		newTask, err := m.stateMachine.Transition(&taskAdapter{task: tsk}, types.TaskStateApproving, "flow started")
+		if err != nil {
+			return nil, fmt.Errorf("state transition failed: %w", err)
+		}
+		tsk = newTask.(*taskAdapter).task
+	}
+
+	newTask, err := m.stateMachine.Transition(&taskAdapter{task: tsk}, types.TaskStateApproved, "flow reached end node")
+	if err != nil {
+		return nil, fmt.Errorf("state transition failed: %w", err)
+	}
+	return newTask.(*taskAdapter).task, nil
+}
+
+// generateFlowEvents 根据流程推进结果生成节点激活、节点完成和任务通过事件
+func (m *memoryTaskManager) generateFlowEvents(tpl *template.Template, tsk *Task, flow *FlowResult) {
+	if !m.eventsEnabled() || flow == nil {
+		return
+	}
+
+	for _, step := range flow.Steps {
+		node := tpl.Nodes[step.NodeID]
+		switch step.Action {
+		case FlowActionActivated:
+			m.generateEvent(event.EventTypeNodeActivated, tsk, node, nil)
+		case FlowActionCompleted:
+			m.generateEvent(event.EventTypeNodeCompleted, tsk, node, nil)
+		}
+	}
+
+	if flow.Finished && tsk.GetState() == types.TaskStateApproved {
+		m.generateEvent(event.EventTypeTaskApproved, tsk, tpl.Nodes[flow.CurrentNode], nil)
+	}
+}
+
+// checkApprover 检查审批人是否可以审批,需要满足审批模式的顺序约束(如顺序审批)
+// 使用流程引擎时只能审批任务的当前节点,由引擎检查顺序约束;
+// 未使用流程引擎时由 WithApprovalDecider 设置的判断检查顺序约束
+func (m *memoryTaskManager) checkApprover(tpl *template.Template, tsk *Task, node *template.Node, approver string) error {
+	tsk.mu.RLock()
+	defer tsk.mu.RUnlock()
+
+	if m.engine != nil {
+		if tsk.CurrentNode != node.ID {
+			return fmt.Errorf("node %q is not the current node (current node: %q)", node.ID, tsk.CurrentNode)
+		}
+		return m.engine.CheckApprover(tpl, tsk, node.ID, approver)
+	}
+	if m.decider != nil {
+		return m.decider.CheckApprover(node, tsk.Approvers[node.ID], tsk.Approvals[node.ID], approver)
+	}
+	return nil
+}
+
+// advanceWithEngine 审批结果(同意或拒绝)记录后,通过流程引擎推进流程
+// 由节点的审批模式判断节点是否完成: 节点通过后进入下一个节点,节点被拒绝后按拒绝后行为处理,
+// 审批未完成时(如会签仍有审批人未审批、或签未全部拒绝)停留在当前节点
+// 调用方必须持有管理器锁;处理失败时使用 backup 恢复任务
+func (m *memoryTaskManager) advanceWithEngine(ctx context.Context, id string, tsk *Task, backup *Task, tpl *template.Template, node *template.Node, approvalInfo *event.ApprovalInfo) error {
+	flow, err := m.advanceFlow(ctx, tpl, tsk, node.ID)
+	if err != nil {
+		m.tasks[id] = backup
+		return err
+	}
+
+	if flow.Rejected {
+		targetNodeID, err := rejectTargetNode(tpl, tsk, node)
+		if err != nil {
+			m.tasks[id] = backup
+			return err
+		}
+
+		if targetNodeID == "" {
+			// 终止流程
+			newTask, err := m.stateMachine.Transition(&taskAdapter{task: tsk}, types.TaskStateRejected, "rejected by approver")
+			if err != nil {
+				m.tasks[id] = backup
+				return fmt.Errorf("failed to transition to rejected state: %w", err)
+			}
+			tsk = newTask.(*taskAdapter).task
+		} else {
+			// 回退或跳转到目标节点,重新激活目标节点
+			flow, err = m.reactivateNode(ctx, tpl, tsk, targetNodeID)
+			if err != nil {
+				m.tasks[id] = backup
+				return err
+			}
+		}
+	}
+
+	if flow.Finished {
+		tsk, err = m.finishFlow(tsk)
+		if err != nil {
+			m.tasks[id] = backup
+			return err
+		}
+	}
+
+	// 保存更新后的任务
+	m.tasks[id] = tsk
+
+	if m.eventsEnabled() {
+		m.generateEvent(event.EventTypeApprovalOp, tsk, node, approvalInfo)
+
+		if tsk.GetState() == types.TaskStateRejected {
+			m.generateEvent(event.EventTypeNodeCompleted, tsk, node, nil)
+			m.generateEvent(event.EventTypeTaskRejected, tsk, node, nil)
+		} else {
+			m.generateFlowEvents(tpl, tsk, flow)
+		}
+	}
+
+	return nil
+}
+
+// reactivateNode 拒绝后重新激活目标节点,并从目标节点继续推进流程
+// 清除目标节点的审批结果,并将目标节点及其之后完成的节点从已完成节点列表中移除
+func (m *memoryTaskManager) reactivateNode(ctx context.Context, tpl *template.Template, tsk *Task, nodeID string) (*FlowResult, error) {
+	tsk.mu.Lock()
+	delete(tsk.Approvals, nodeID)
+	for i, completedNodeID := range tsk.CompletedNodes {
+		if completedNodeID == nodeID {
+			tsk.CompletedNodes = tsk.CompletedNodes[:i]
+			break
+		}
+	}
+	tsk.mu.Unlock()
+	m.markNodeActivated(tsk, nodeID)
+
+	flow, err := m.advanceFlow(ctx, tpl, tsk, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 目标节点作为推进起点,引擎不会生成激活步骤
+	flow.Steps = append([]*FlowStep{{NodeID: nodeID, Action: FlowActionActivated}}, flow.Steps...)
+	return flow, nil
+}
+
+// rejectTargetNode 根据节点的拒绝后行为确定拒绝后的目标节点
+// 返回空字符串表示终止流程
+func rejectTargetNode(tpl *template.Template, tsk *Task, node *template.Node) (string, error) {
+	approvalConfig, ok := node.Config.(template.ApprovalNodeConfigAccessor)
+	if !ok {
+		return "", nil
+	}
+
+	switch approvalConfig.GetRejectBehavior() {
+	case "rollback":
+		// 回退到最近完成的上一个审批节点,没有上一个审批节点时终止流程
+		tsk.mu.RLock()
+		defer tsk.mu.RUnlock()
+		for i := len(tsk.CompletedNodes) - 1; i >= 0; i-- {
+			completedNodeID := tsk.CompletedNodes[i]
+			if completedNodeID == node.ID {
+				continue
+			}
+			if completedNode, exists := tpl.Nodes[completedNodeID]; exists && completedNode.Type == template.NodeTypeApproval {
+				return completedNodeID, nil
+			}
+		}
+		return "", nil
+	case "jump":
+		// 跳转到指定节点,未指定目标节点时终止流程
+		targetNodeID := approvalConfig.GetRejectTargetNode()
+		if targetNodeID == "" {
+			return "", nil
+		}
+		if _, exists := tpl.Nodes[targetNodeID]; !exists {
+			return "", fmt.Errorf("reject target node %q not found in template", targetNodeID)
+		}
+		return targetNodeID, nil
+	default:
+		// 默认行为: 终止流程
+		return "", nil
+	}
+}
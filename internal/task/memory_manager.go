@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -22,37 +23,95 @@ type memoryTaskManager struct {
 	stateMachine      statemachine.StateMachine
 	approverFetcherFunc func(*template.Template, *Task) error // 审批人获取函数(可选,用于任务创建时获取动态审批人)
 	eventNotifier     *event.EventNotifier // 事件通知器(可选)
+	engine            FlowEngine           // 流程引擎(可选)
+	decider           ApprovalDecider      // 审批节点完成判断(可选,未设置流程引擎时使用)
+	store             TaskStore            // 任务存储(可选,设置后任务持久化到存储)
+	clock             Clock                // 时钟(可选,用于节点激活时间和超时判断)
+	scheduler         *TimeoutScheduler    // 超时调度器(可选)
+	escalationResolver EscalationResolver  // 超时升级审批人解析函数(可选)
+	reminderInterval  time.Duration        // 同一审批人两次催办的最小间隔
+	authorizer        Authorizer           // 授权策略(为 nil 时不检查)
+	outbox            event.Outbox         // 事件发件箱(可选)
+	eventMu           sync.Mutex           // 保护 eventBuffer
+	eventBuffer       []bufferedEvent      // 基于存储的任务管理器执行操作期间缓冲的事件
+	eventBuffering    bool                 // 是否缓冲事件(任务写入存储成功后再发布)
 }
 
 // NewTaskManager 创建新的任务管理器实例(内存实现)
 // templateMgr: 模板管理器,用于获取模板信息
 // approverFetcherFunc: 审批人获取函数(可选,用于任务创建时获取动态审批人)
-func NewTaskManager(templateMgr template.TemplateManager, approverFetcherFunc func(*template.Template, *Task) error) TaskManager {
-	return &memoryTaskManager{
+// opts: 可选配置(如 WithFlowEngine、WithTaskStore)
+func NewTaskManager(templateMgr template.TemplateManager, approverFetcherFunc func(*template.Template, *Task) error, opts ...ManagerOption) TaskManager {
+	m := &memoryTaskManager{
 		tasks:              make(map[string]*Task),
 		templateMgr:        templateMgr,
 		stateMachine:       statemachine.NewStateMachine(),
 		approverFetcherFunc: approverFetcherFunc,
 		eventNotifier:      nil,
+		reminderInterval:   DefaultReminderInterval,
+		authorizer:         NewDefaultAuthorizer(),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m.build()
 }
 
 // NewTaskManagerWithNotifier 创建带事件通知器的任务管理器实例
 // templateMgr: 模板管理器,用于获取模板信息
 // approverFetcherFunc: 审批人获取函数(可选,用于任务创建时获取动态审批人)
 // notifier: 事件通知器(可选)
-func NewTaskManagerWithNotifier(templateMgr template.TemplateManager, approverFetcherFunc func(*template.Template, *Task) error, notifier *event.EventNotifier) TaskManager {
-	return &memoryTaskManager{
+// opts: 可选配置(如 WithFlowEngine、WithTaskStore)
+func NewTaskManagerWithNotifier(templateMgr template.TemplateManager, approverFetcherFunc func(*template.Template, *Task) error, notifier *event.EventNotifier, opts ...ManagerOption) TaskManager {
+	m := &memoryTaskManager{
 		tasks:              make(map[string]*Task),
 		templateMgr:        templateMgr,
 		stateMachine:       statemachine.NewStateMachine(),
 		approverFetcherFunc: approverFetcherFunc,
 		eventNotifier:      notifier,
+		reminderInterval:   DefaultReminderInterval,
+		authorizer:         NewDefaultAuthorizer(),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m.build()
 }
 
-// Create 基于模板创建审批任务实例
+// build 应用可选配置后完成任务管理器的构建
+// 设置了 TaskStore 时返回基于存储的任务管理器,设置了超时调度器时将其绑定到返回的任务管理器
+// 只设置了发件箱时使用内存任务存储,使事件在操作成功后统一写入发件箱,写入失败时返回错误
+func (m *memoryTaskManager) build() TaskManager {
+	if m.outbox != nil && m.store == nil {
+		m.store = NewMemoryTaskStore()
+	}
+
+	if m.clock == nil {
+		m.clock = realClock{}
+		if m.scheduler != nil {
+			m.clock = m.scheduler.clock
+		}
+	}
+
+	var mgr TaskManager = m
+	if m.store != nil {
+		mgr = newStoreTaskManager(m)
+	}
+	if m.scheduler != nil {
+		m.scheduler.bind(mgr, m)
+	}
+	return mgr
+}
+
+// Create 基于模板创建审批任务实例,不记录发起人
+//
+// Deprecated: 使用 CreateBy 记录发起人
 func (m *memoryTaskManager) Create(templateID string, businessID string, params json.RawMessage) (*Task, error) {
+	return m.CreateBy(templateID, businessID, "", params)
+}
+
+// CreateBy 基于模板创建审批任务实例,并记录发起人
+func (m *memoryTaskManager) CreateBy(templateID string, businessID string, initiator string, params json.RawMessage) (*Task, error) {
 	// 获取模板(使用最新版本)
 	tpl, err := m.templateMgr.Get(templateID, 0)
 	if err != nil {
@@ -69,12 +128,14 @@ func (m *memoryTaskManager) Create(templateID string, businessID string, params
 		TemplateID:     templateID,
 		TemplateVersion: tpl.Version,
 		BusinessID:     businessID,
+		Initiator:      initiator,
 		Params:         params,
 		State:          TaskStatePending,
 		CurrentNode:    findStartNode(tpl),
 		CreatedAt:      now,
 		UpdatedAt:      now,
 		SubmittedAt:    nil,
+		NodeActivatedAt: make(map[string]time.Time),
 		NodeOutputs:    make(map[string]json.RawMessage),
 		Approvers:      make(map[string][]string),
 		Approvals:      make(map[string]map[string]*Approval),
@@ -87,6 +148,11 @@ func (m *memoryTaskManager) Create(templateID string, businessID string, params
 		tsk.Params = json.RawMessage("{}")
 	}
 
+	// 授权检查(任务尚未存储,不需要持有锁)
+	if err := m.authorize(context.Background(), ActionCreate, initiator, tsk, ""); err != nil {
+		return nil, err
+	}
+
 	// 处理动态审批人(任务创建时获取)
 	// 如果提供了审批人获取函数,调用它来获取配置为 on_create 时机的动态审批人
 	if m.approverFetcherFunc != nil {
@@ -103,7 +169,7 @@ func (m *memoryTaskManager) Create(templateID string, businessID string, params
 	m.tasks[taskID] = tsk
 
 	// 生成任务创建事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeTaskCreated, tsk, nil, nil)
 	}
 
@@ -119,7 +185,7 @@ func (m *memoryTaskManager) Get(id string) (*Task, error) {
 
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return nil, fmt.Errorf("task %q not found", id)
+		return nil, fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
 	}
 
 	// 返回任务的深拷贝,确保隔离性
@@ -128,14 +194,23 @@ func (m *memoryTaskManager) Get(id string) (*Task, error) {
 
 // Submit 提交任务进入审批流程
 // 使用状态机进行状态转换,从 pending 转换为 submitted
-func (m *memoryTaskManager) Submit(id string) error {
+func (m *memoryTaskManager) Submit(id string, actor string) error {
+	return m.SubmitCtx(context.Background(), id, actor)
+}
+
+// SubmitCtx 提交任务进入审批流程,ctx 用于取消和超时控制
+func (m *memoryTaskManager) SubmitCtx(ctx context.Context, id string, actor string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(ctx, ActionSubmit, actor, tsk, ""); err != nil {
+		return err
 	}
 
 	// 验证当前状态允许提交
@@ -159,10 +234,15 @@ func (m *memoryTaskManager) Submit(id string) error {
 	tsk.SubmittedAt = &now
 	tsk.UpdatedAt = now
 
+	// 如果设置了流程引擎,由引擎从开始节点推进到第一个等待审批的节点
+	if m.engine != nil {
+		return m.submitWithEngine(ctx, id, tsk)
+	}
+
 	// 生成节点激活事件(提交后当前节点被激活)
 	// 如果当前节点是 start,需要找到下一个节点并激活
 	if tsk.CurrentNode != "" {
-		if tpl, err := m.templateMgr.Get(tsk.TemplateID, 0); err == nil {
+		if tpl, err := m.taskTemplate(tsk); err == nil {
 			currentNode := tsk.CurrentNode
 			// 如果当前节点是 start,找到下一个节点
 			if node, exists := tpl.Nodes[currentNode]; exists && node.Type == template.NodeTypeStart {
@@ -174,18 +254,19 @@ func (m *memoryTaskManager) Submit(id string) error {
 				}
 			}
 		}
+		m.markNodeActivated(tsk, tsk.CurrentNode)
 	}
 
 	// 保存更新后的任务
 	m.tasks[id] = tsk
 
 	// 生成任务提交事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeTaskSubmitted, tsk, nil, nil)
 		
 		// 生成节点激活事件
 		if tsk.CurrentNode != "" {
-			if tpl, err := m.templateMgr.Get(tsk.TemplateID, 0); err == nil {
+			if tpl, err := m.taskTemplate(tsk); err == nil {
 				if node, exists := tpl.Nodes[tsk.CurrentNode]; exists {
 					m.generateEvent(event.EventTypeNodeActivated, tsk, node, nil)
 				}
@@ -204,57 +285,7 @@ func (m *memoryTaskManager) Query(filter *TaskFilter) ([]*Task, error) {
 	var results []*Task
 
 	for _, tsk := range m.tasks {
-		tsk.mu.RLock()
-		matches := true
-
-		// 按状态过滤
-		if filter.State != types.TaskState("") && tsk.State != filter.State {
-			matches = false
-		}
-
-		// 按模板 ID 过滤
-		if filter.TemplateID != "" && tsk.TemplateID != filter.TemplateID {
-			matches = false
-		}
-
-		// 按业务 ID 过滤
-		if filter.BusinessID != "" && tsk.BusinessID != filter.BusinessID {
-			matches = false
-		}
-
-		// 按审批人过滤(查询待审批任务)
-		if filter.Approver != "" {
-			// 检查该审批人是否在任一节点的审批人列表中
-			found := false
-			if tsk.Approvers != nil {
-				for _, approvers := range tsk.Approvers {
-					for _, approver := range approvers {
-						if approver == filter.Approver {
-							found = true
-							break
-						}
-					}
-					if found {
-						break
-					}
-				}
-			}
-			if !found {
-				matches = false
-			}
-		}
-
-		// 按时间范围过滤
-		if !filter.StartTime.IsZero() && tsk.CreatedAt.Before(filter.StartTime) {
-			matches = false
-		}
-		if !filter.EndTime.IsZero() && tsk.CreatedAt.After(filter.EndTime) {
-			matches = false
-		}
-
-		tsk.mu.RUnlock()
-
-		if matches {
+		if filter.Match(tsk) {
 			// 返回任务的副本
 			results = append(results, tsk.Clone())
 		}
@@ -312,14 +343,18 @@ func (a *taskAdapter) Clone() statemachine.TransitionableTask {
 // Cancel 取消任务
 // 将任务从 pending、submitted 或 approving 状态转换为 cancelled 状态
 // 已通过、已拒绝、已取消、已超时的任务不能取消
-func (m *memoryTaskManager) Cancel(id string, reason string) error {
+func (m *memoryTaskManager) Cancel(id string, actor string, reason string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionCancel, actor, tsk, ""); err != nil {
+		return err
 	}
 
 	// 检查当前状态是否允许取消
@@ -343,7 +378,7 @@ func (m *memoryTaskManager) Cancel(id string, reason string) error {
 	m.tasks[id] = tsk
 
 	// 生成取消事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeTaskCancelled, tsk, nil, nil)
 	}
 
@@ -353,14 +388,18 @@ func (m *memoryTaskManager) Cancel(id string, reason string) error {
 // Withdraw 撤回任务
 // 将任务从 submitted 或 approving 状态撤回回 pending 状态
 // 如果任务已有审批记录,不允许撤回
-func (m *memoryTaskManager) Withdraw(id string, reason string) error {
+func (m *memoryTaskManager) Withdraw(id string, actor string, reason string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionWithdraw, actor, tsk, ""); err != nil {
+		return err
 	}
 
 	// 检查当前状态是否允许撤回
@@ -369,10 +408,11 @@ func (m *memoryTaskManager) Withdraw(id string, reason string) error {
 		return fmt.Errorf("cannot withdraw task in state %q, only submitted or approving tasks can be withdrawn", currentState)
 	}
 
-	// 检查是否有审批记录(如果有,不允许撤回)
-	records := tsk.GetRecords()
-	if len(records) > 0 {
-		return fmt.Errorf("cannot withdraw task with approval records")
+	// 检查是否有审批记录(如果有,不允许撤回),迁移模板版本生成的记录除外
+	for _, record := range tsk.GetRecords() {
+		if record.Result != RecordResultMigrate {
+			return fmt.Errorf("cannot withdraw task with approval records")
+		}
 	}
 
 	// 验证当前状态允许转换为 pending
@@ -398,7 +438,7 @@ func (m *memoryTaskManager) Withdraw(id string, reason string) error {
 	m.tasks[id] = tsk
 
 	// 生成撤回事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeTaskWithdrawn, tsk, nil, nil)
 	}
 
@@ -414,13 +454,17 @@ func (m *memoryTaskManager) Transfer(id string, nodeID string, fromApprover stri
 	// 1. 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionTransfer, fromApprover, tsk, nodeID); err != nil {
+		return err
 	}
 
 	// 2. 获取模板
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return fmt.Errorf("failed to get template %q: %w", tsk.TemplateID, err)
+		return err
 	}
 
 	// 3. 获取节点配置
@@ -526,10 +570,10 @@ func (m *memoryTaskManager) Transfer(id string, nodeID string, fromApprover stri
 	m.tasks[id] = tsk
 
 	// 13. 生成转交事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		// 获取节点信息
 		var node *template.Node
-		if tpl, err := m.templateMgr.Get(tsk.TemplateID, 0); err == nil {
+		if tpl, err := m.taskTemplate(tsk); err == nil {
 			if n, exists := tpl.Nodes[nodeID]; exists {
 				node = n
 			}
@@ -547,20 +591,24 @@ func (m *memoryTaskManager) Transfer(id string, nodeID string, fromApprover stri
 
 // AddApprover 加签
 // 在审批人列表中添加新的审批人
-func (m *memoryTaskManager) AddApprover(id string, nodeID string, approver string, reason string) error {
+func (m *memoryTaskManager) AddApprover(id string, nodeID string, actor string, approver string, reason string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 1. 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionAddApprover, actor, tsk, nodeID); err != nil {
+		return err
 	}
 
 	// 2. 获取模板
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return fmt.Errorf("failed to get template %q: %w", tsk.TemplateID, err)
+		return err
 	}
 
 	// 3. 获取节点配置
@@ -639,10 +687,10 @@ func (m *memoryTaskManager) AddApprover(id string, nodeID string, approver strin
 	m.tasks[id] = tsk
 
 	// 11. 生成加签事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		// 获取节点信息
 		var node *template.Node
-		if tpl, err := m.templateMgr.Get(tsk.TemplateID, 0); err == nil {
+		if tpl, err := m.taskTemplate(tsk); err == nil {
 			if n, exists := tpl.Nodes[nodeID]; exists {
 				node = n
 			}
@@ -660,20 +708,24 @@ func (m *memoryTaskManager) AddApprover(id string, nodeID string, approver strin
 
 // RemoveApprover 减签
 // 从审批人列表中移除指定的审批人
-func (m *memoryTaskManager) RemoveApprover(id string, nodeID string, approver string, reason string) error {
+func (m *memoryTaskManager) RemoveApprover(id string, nodeID string, actor string, approver string, reason string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 1. 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionRemoveApprover, actor, tsk, nodeID); err != nil {
+		return err
 	}
 
 	// 2. 获取模板
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return fmt.Errorf("failed to get template %q: %w", tsk.TemplateID, err)
+		return err
 	}
 
 	// 3. 获取节点配置
@@ -762,10 +814,10 @@ func (m *memoryTaskManager) RemoveApprover(id string, nodeID string, approver st
 	m.tasks[id] = tsk
 
 	// 11. 生成减签事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		// 获取节点信息
 		var node *template.Node
-		if tpl, err := m.templateMgr.Get(tsk.TemplateID, 0); err == nil {
+		if tpl, err := m.taskTemplate(tsk); err == nil {
 			if n, exists := tpl.Nodes[nodeID]; exists {
 				node = n
 			}
@@ -782,6 +834,8 @@ func (m *memoryTaskManager) RemoveApprover(id string, nodeID string, approver st
 }
 
 // HandleTimeout 处理任务超时
+// 当前节点配置了超时策略时执行到期的超时动作(提醒、升级、自动同意、自动拒绝或终止),
+// 并写入对应结果的审批记录;未配置超时策略时任务进入 timeout 状态
 func (m *memoryTaskManager) HandleTimeout(id string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -789,16 +843,43 @@ func (m *memoryTaskManager) HandleTimeout(id string) error {
 	// 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionHandleTimeout, "", tsk, ""); err != nil {
+		return err
 	}
 
 	// 检查是否超时
-	timeout, _ := m.CheckTimeout(tsk)
-	if !timeout {
+	step, due := m.dueTimeoutStep(tsk)
+	if !due {
 		// 未超时,直接返回
 		return nil
 	}
 
+	switch step.action.Type {
+	case timeoutActionNotify:
+		return m.timeoutNotify(id, tsk, step)
+	case timeoutActionEscalate:
+		return m.timeoutEscalate(id, tsk, step)
+	case timeoutActionAutoApprove, timeoutActionAutoReject:
+		tpl, err := m.taskTemplate(tsk)
+		if err != nil {
+			return err
+		}
+		return m.timeoutDecide(id, tsk, tpl, step)
+	}
+
+	// 状态机不允许从 submitted 直接转换为 timeout,需要先进入 approving
+	// (使用流程引擎时,第一个审批节点在审批前任务保持 submitted 状态)
+	if tsk.GetState() == types.TaskStateSubmitted {
+		newTask, err := m.stateMachine.Transition(&taskAdapter{task: tsk}, types.TaskStateApproving, "flow started")
+		if err != nil {
+			return fmt.Errorf("state transition failed: %w", err)
+		}
+		tsk = newTask.(*taskAdapter).task
+	}
+
 	// 验证当前状态允许转换为超时状态
 	if !m.stateMachine.CanTransition(tsk.GetState(), types.TaskStateTimeout) {
 		return errors.ErrInvalidStateTransition
@@ -813,13 +894,15 @@ func (m *memoryTaskManager) HandleTimeout(id string) error {
 
 	// 更新任务对象
 	tsk = newTask.(*taskAdapter).task
-	tsk.UpdatedAt = time.Now()
+	tsk.mu.Lock()
+	m.appendTimeoutRecord(tsk, step.nodeID, "timeout", "task timeout")
+	tsk.mu.Unlock()
 
 	// 保存更新后的任务
 	m.tasks[id] = tsk
 
 	// 生成超时事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeTaskTimeout, tsk, nil, nil)
 	}
 
@@ -829,14 +912,18 @@ func (m *memoryTaskManager) HandleTimeout(id string) error {
 // Pause 暂停任务
 // 只有 pending、submitted、approving 状态可以暂停
 // 暂停时会记录暂停前的状态,用于恢复时恢复到正确状态
-func (m *memoryTaskManager) Pause(id string, reason string) error {
+func (m *memoryTaskManager) Pause(id string, actor string, reason string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionPause, actor, tsk, ""); err != nil {
+		return err
 	}
 
 	// 检查当前状态是否允许暂停
@@ -868,7 +955,7 @@ func (m *memoryTaskManager) Pause(id string, reason string) error {
 	m.tasks[id] = tsk
 
 	// 生成暂停事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeTaskPaused, tsk, nil, nil)
 	}
 
@@ -878,14 +965,18 @@ func (m *memoryTaskManager) Pause(id string, reason string) error {
 // Resume 恢复任务
 // 只有 paused 状态可以恢复
 // 恢复时会恢复到暂停前的状态(pending、submitted 或 approving)
-func (m *memoryTaskManager) Resume(id string, reason string) error {
+func (m *memoryTaskManager) Resume(id string, actor string, reason string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionResume, actor, tsk, ""); err != nil {
+		return err
 	}
 
 	// 检查当前状态是否是 paused
@@ -921,11 +1012,14 @@ func (m *memoryTaskManager) Resume(id string, reason string) error {
 	tsk.PausedAt = nil
 	tsk.PausedState = ""
 
+	// 暂停期间到期的截止时间已被调度器丢弃,恢复后重新登记
+	m.scheduleTimeout(tsk)
+
 	// 保存更新后的任务
 	m.tasks[id] = tsk
 
 	// 生成恢复事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeTaskResumed, tsk, nil, nil)
 	}
 
@@ -935,20 +1029,24 @@ func (m *memoryTaskManager) Resume(id string, reason string) error {
 // RollbackToNode 回退到指定节点
 // 只能回退到已完成的节点
 // 回退时会清理回退节点之后的审批记录和状态
-func (m *memoryTaskManager) RollbackToNode(id string, nodeID string, reason string) error {
+func (m *memoryTaskManager) RollbackToNode(id string, nodeID string, actor string, reason string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionRollback, actor, tsk, nodeID); err != nil {
+		return err
 	}
 
 	// 获取模板
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, tsk.TemplateVersion)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return fmt.Errorf("failed to get template: %w", err)
+		return err
 	}
 
 	// 验证节点存在
@@ -991,7 +1089,7 @@ func (m *memoryTaskManager) RollbackToNode(id string, nodeID string, reason stri
 	// 1. 移除回退节点之后的审批记录
 	var filteredRecords []*Record
 	for _, record := range tsk.Records {
-		if keepNodes[record.NodeID] {
+		if keepNodes[tsk.currentNodeID(record.NodeID)] {
 			filteredRecords = append(filteredRecords, record)
 		}
 	}
@@ -1079,11 +1177,14 @@ func (m *memoryTaskManager) RollbackToNode(id string, nodeID string, reason stri
 		tsk.UpdatedAt = time.Now()
 	}
 
+	// 回退的目标节点重新激活
+	m.markNodeActivated(tsk, nodeID)
+
 	// 保存更新后的任务
 	m.tasks[id] = tsk
 
 	// 生成回退事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeTaskRollback, tsk, nil, nil)
 	}
 
@@ -1093,20 +1194,24 @@ func (m *memoryTaskManager) RollbackToNode(id string, nodeID string, reason stri
 // ReplaceApprover 替换审批人
 // 只能替换尚未审批的审批人
 // 替换后会保留原审批人的审批记录(如果有),新审批人可以继续审批
-func (m *memoryTaskManager) ReplaceApprover(id string, nodeID string, oldApprover string, newApprover string, reason string) error {
+func (m *memoryTaskManager) ReplaceApprover(id string, nodeID string, actor string, oldApprover string, newApprover string, reason string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// 1. 获取任务
 	tsk, exists := m.tasks[id]
 	if !exists {
-		return fmt.Errorf("task %q not found", id)
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionReplaceApprover, actor, tsk, nodeID); err != nil {
+		return err
 	}
 
 	// 2. 获取模板
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return fmt.Errorf("failed to get template %q: %w", tsk.TemplateID, err)
+		return err
 	}
 
 	// 3. 获取节点配置
@@ -1215,7 +1320,7 @@ func (m *memoryTaskManager) ReplaceApprover(id string, nodeID string, oldApprove
 	m.tasks[id] = tsk
 
 	// 12. 生成替换审批人事件
-	if m.eventNotifier != nil {
+	if m.eventsEnabled() {
 		m.generateEvent(event.EventTypeApproverReplaced, tsk, node, &event.ApprovalInfo{
 			NodeID:   nodeID,
 			Approver: oldApprover,
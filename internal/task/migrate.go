@@ -0,0 +1,215 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// taskTemplate 获取任务绑定的模板版本
+// 任务创建时记录模板版本号,之后的所有操作都按该版本执行,发布新版本不影响进行中的任务
+// 未记录版本号(TemplateVersion 为 0)的任务使用最新版本
+func (m *memoryTaskManager) taskTemplate(tsk *Task) (*template.Template, error) {
+	tpl, err := m.templateMgr.Get(tsk.TemplateID, tsk.TemplateVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template %q version %d: %w", tsk.TemplateID, tsk.TemplateVersion, err)
+	}
+	return tpl, nil
+}
+
+// RecordResultMigrate 任务迁移到其他模板版本时生成的审批记录的审批结果
+const RecordResultMigrate = "migrate"
+
+// MigrateTaskToVersion 将任务迁移到指定的模板版本
+// 迁移后任务的所有操作按新版本执行,已结束的任务不能迁移
+// nodeMapping 为旧节点 ID 到新节点 ID 的映射,未出现在映射中的节点保持原 ID;
+// 映射的目标节点必须存在于新版本中,且不同节点不能映射到同一节点
+// 以节点 ID 为键的运行时数据按映射更新;审批记录和催办历史保持不变,映射记录在 NodeAliases 中
+// 当前节点(映射后)必须存在于新版本中
+// 迁移生成一条审批记录,审批人为操作人(为空时为 SystemApprover),意见中记录版本和节点映射
+func (m *memoryTaskManager) MigrateTaskToVersion(id string, version int, nodeMapping map[string]string, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 获取任务
+	tsk, exists := m.tasks[id]
+	if !exists {
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionMigrate, actor, tsk, ""); err != nil {
+		return err
+	}
+
+	// 已结束的任务不能迁移
+	switch state := tsk.GetState(); state {
+	case types.TaskStateApproved, types.TaskStateRejected, types.TaskStateCancelled, types.TaskStateTimeout:
+		return fmt.Errorf("cannot migrate task %q in terminal state %q", id, state)
+	}
+
+	// 获取目标版本的模板
+	tpl, err := m.templateMgr.Get(tsk.TemplateID, version)
+	if err != nil {
+		return fmt.Errorf("failed to get template %q version %d: %w", tsk.TemplateID, version, err)
+	}
+
+	mapNode := func(nodeID string) string {
+		if mapped, exists := nodeMapping[nodeID]; exists {
+			return mapped
+		}
+		return nodeID
+	}
+
+	// 验证映射的目标节点在目标版本中存在
+	for from, to := range nodeMapping {
+		if _, exists := tpl.Nodes[to]; !exists {
+			return fmt.Errorf("node %q mapped from %q not found in template %q version %d", to, from, tsk.TemplateID, tpl.Version)
+		}
+	}
+
+	// 验证当前节点在目标版本中存在
+	currentNode := mapNode(tsk.CurrentNode)
+	if _, exists := tpl.Nodes[currentNode]; !exists {
+		return fmt.Errorf("current node %q not found in template %q version %d", currentNode, tsk.TemplateID, tpl.Version)
+	}
+
+	// 验证映射后的节点不冲突,否则以节点 ID 为键的审批结果、节点输出等数据会互相覆盖
+	if err := checkNodeMapping(tsk, nodeMapping, mapNode); err != nil {
+		return err
+	}
+
+	if actor == "" {
+		actor = SystemApprover
+	}
+	now := m.clock.Now()
+
+	tsk.mu.Lock()
+	fromVersion := tsk.TemplateVersion
+
+	// 按节点映射更新任务的运行时数据
+	tsk.CurrentNode = currentNode
+	for i, nodeID := range tsk.CompletedNodes {
+		tsk.CompletedNodes[i] = mapNode(nodeID)
+	}
+	tsk.Approvers = remapNodeKeys(tsk.Approvers, mapNode)
+	tsk.Approvals = remapNodeKeys(tsk.Approvals, mapNode)
+	tsk.Delegations = remapNodeKeys(tsk.Delegations, mapNode)
+	tsk.NodeOutputs = remapNodeKeys(tsk.NodeOutputs, mapNode)
+	tsk.NodeActivatedAt = remapNodeKeys(tsk.NodeActivatedAt, mapNode)
+
+	// 审批记录和催办历史保持原节点 ID,记录节点映射供超时动作和催办频率统计时换算
+	for from, to := range tsk.NodeAliases {
+		tsk.NodeAliases[from] = mapNode(to)
+	}
+	for from, to := range nodeMapping {
+		if from == to {
+			continue
+		}
+		if tsk.NodeAliases == nil {
+			tsk.NodeAliases = make(map[string]string)
+		}
+		tsk.NodeAliases[from] = to
+	}
+
+	// 生成迁移记录
+	comment := fmt.Sprintf("migrated from template version %d to %d", fromVersion, tpl.Version)
+	if len(nodeMapping) > 0 {
+		comment += fmt.Sprintf(", node mapping %v", nodeMapping)
+	}
+	tsk.Records = append(tsk.Records, &Record{
+		ID:          generateRecordID(),
+		TaskID:      id,
+		NodeID:      currentNode,
+		Approver:    actor,
+		Result:      RecordResultMigrate,
+		Comment:     comment,
+		CreatedAt:   now,
+		Attachments: []string{},
+	})
+
+	tsk.TemplateVersion = tpl.Version
+	tsk.UpdatedAt = now
+	tsk.mu.Unlock()
+
+	// 新版本的超时配置可能不同,重新登记截止时间
+	m.scheduleTimeout(tsk)
+
+	m.generateEvent(event.EventTypeApprovalOp, tsk, tpl.Nodes[currentNode], &event.ApprovalInfo{
+		NodeID:   currentNode,
+		Approver: actor,
+		Result:   RecordResultMigrate,
+		Comment:  comment,
+	})
+	return nil
+}
+
+// remapNodeKeys 按节点映射重建以节点 ID 为键的映射表
+func remapNodeKeys[V any](data map[string]V, mapNode func(string) string) map[string]V {
+	if data == nil {
+		return nil
+	}
+	result := make(map[string]V, len(data))
+	for nodeID, value := range data {
+		result[mapNode(nodeID)] = value
+	}
+	return result
+}
+
+// checkNodeMapping 验证任务中的节点按映射更新后不冲突
+// 映射中不同节点不能映射到同一节点;任务中的节点映射后也不能与其他节点重合
+// 调用方必须持有任务锁
+func checkNodeMapping(tsk *Task, nodeMapping map[string]string, mapNode func(string) string) error {
+	sources := make(map[string]string, len(nodeMapping))
+	for from, to := range nodeMapping {
+		if other, exists := sources[to]; exists {
+			return fmt.Errorf("nodes %q and %q both mapped to node %q", other, from, to)
+		}
+		sources[to] = from
+	}
+
+	nodes := map[string]bool{tsk.CurrentNode: true}
+	for _, nodeID := range tsk.CompletedNodes {
+		nodes[nodeID] = true
+	}
+	for _, keys := range [][]string{
+		mapKeys(tsk.Approvers), mapKeys(tsk.Approvals), mapKeys(tsk.Delegations),
+		mapKeys(tsk.NodeOutputs), mapKeys(tsk.NodeActivatedAt),
+	} {
+		for _, nodeID := range keys {
+			nodes[nodeID] = true
+		}
+	}
+
+	mapped := make(map[string]string, len(nodes))
+	for nodeID := range nodes {
+		to := mapNode(nodeID)
+		if other, exists := mapped[to]; exists {
+			return fmt.Errorf("nodes %q and %q both mapped to node %q", other, nodeID, to)
+		}
+		mapped[to] = nodeID
+	}
+	return nil
+}
+
+// mapKeys 返回以节点 ID 为键的映射表的所有键
+func mapKeys[V any](data map[string]V) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// currentNodeID 返回历史节点 ID 在当前模板版本中对应的节点 ID
+// 审批记录和催办历史中的节点 ID 在迁移后保持不变,按节点统计时需要换算
+// 调用方必须持有任务锁
+func (t *Task) currentNodeID(nodeID string) string {
+	if mapped, ok := t.NodeAliases[nodeID]; ok {
+		return mapped
+	}
+	return nodeID
+}
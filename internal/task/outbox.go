@@ -0,0 +1,101 @@
+package task
+
+import (
+	"github.com/mautops/approval-kit/internal/event"
+)
+
+// OutboxTaskStore 支持事务性发件箱的任务存储
+// 任务存储实现该接口时,任务和操作产生的事件在同一事务中写入,
+// 任务保存成功则事件一定写入发件箱,保存失败则事件也不会写入
+type OutboxTaskStore interface {
+	TaskStore
+
+	// SaveWithEvents 保存任务,并在同一事务中将事件写入发件箱
+	// 版本校验与 Save 相同
+	SaveWithEvents(tsk *Task, entries []*event.OutboxEntry) error
+}
+
+// WithOutbox 设置事件发件箱
+// 设置后任务产生的事件写入发件箱,由 event.OutboxDispatcher 投递给各处理器(至少一次)
+// 同时设置了 WithTaskStore 且存储实现了 OutboxTaskStore 时,事件与任务在同一事务中写入存储,
+// 此时存储和 outbox 应使用同一个数据库(如 sqlstore.TaskStore 和 sqlstore.Outbox);
+// 存储未实现 OutboxTaskStore(或未设置 WithTaskStore,此时使用内存任务存储)时,任务保存成功后再写入 outbox,
+// 写入失败时操作返回 ErrOutboxAppendFailed(任务已保存)
+// 可以与事件通知器同时使用,事件通知器仍然异步推送事件
+func WithOutbox(outbox event.Outbox) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.outbox = outbox
+	}
+}
+
+// eventsEnabled 判断是否需要生成事件
+func (m *memoryTaskManager) eventsEnabled() bool {
+	return m.eventNotifier != nil || m.outbox != nil
+}
+
+// bufferedEvent 基于存储的任务管理器执行操作期间缓冲的事件
+type bufferedEvent struct {
+	evt   *event.Event
+	entry *event.OutboxEntry // 发件箱条目(未设置发件箱时为 nil)
+}
+
+// publishEvent 发布事件: 推送给事件通知器,并写入发件箱
+// 基于存储的任务管理器执行操作期间事件先缓冲,任务写入存储成功后再发布
+// 设置了发件箱时总是使用基于存储的任务管理器(见 build),事件一定经过缓冲,写入失败时由操作返回错误
+func (m *memoryTaskManager) publishEvent(evt *event.Event) {
+	m.eventMu.Lock()
+	if m.eventBuffering {
+		var entry *event.OutboxEntry
+		if m.outbox != nil {
+			entry = event.NewOutboxEntry(evt, m.clock.Now())
+		}
+		m.eventBuffer = append(m.eventBuffer, bufferedEvent{evt: evt, entry: entry})
+		m.eventMu.Unlock()
+		return
+	}
+	m.eventMu.Unlock()
+
+	m.notifyEvents([]bufferedEvent{{evt: evt}})
+}
+
+// notifyEvents 将事件推送给事件通知器
+func (m *memoryTaskManager) notifyEvents(events []bufferedEvent) {
+	if m.eventNotifier == nil {
+		return
+	}
+	for _, buffered := range events {
+		// 异步推送事件
+		m.eventNotifier.Notify(buffered.evt)
+	}
+}
+
+// outboxEntries 返回缓冲事件的发件箱条目
+func outboxEntries(events []bufferedEvent) []*event.OutboxEntry {
+	var entries []*event.OutboxEntry
+	for _, buffered := range events {
+		if buffered.entry != nil {
+			entries = append(entries, buffered.entry)
+		}
+	}
+	return entries
+}
+
+// bufferEvents 开始缓冲事件
+// 调用方必须串行化操作(基于存储的任务管理器持有写锁)
+func (m *memoryTaskManager) bufferEvents() {
+	m.eventMu.Lock()
+	defer m.eventMu.Unlock()
+	m.eventBuffering = true
+	m.eventBuffer = nil
+}
+
+// takeEvents 结束缓冲并返回缓冲的事件
+// 操作失败或任务写入存储失败时调用方丢弃返回的事件
+func (m *memoryTaskManager) takeEvents() []bufferedEvent {
+	m.eventMu.Lock()
+	defer m.eventMu.Unlock()
+	events := m.eventBuffer
+	m.eventBuffering = false
+	m.eventBuffer = nil
+	return events
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -23,7 +24,9 @@ func (r *Record) Validate() error {
 		return fmt.Errorf("record Result is required")
 	}
 	// 验证审批结果类型
-	validResults := []string{"approve", "reject", "transfer", "add_approver", "remove_approver", "replace"}
+	validResults := []string{"approve", "reject", "transfer", "add_approver", "remove_approver", "replace",
+		"notify", "escalate", "auto_approve", "auto_reject", "timeout",
+		RecordResultSkipNode, RecordResultSkipApprover, RecordResultEscalateInitiator, RecordResultMigrate}
 	valid := false
 	for _, validResult := range validResults {
 		if r.Result == validResult {
@@ -32,7 +35,7 @@ func (r *Record) Validate() error {
 		}
 	}
 	if !valid {
-		return fmt.Errorf("invalid record Result: %q, must be one of: approve, reject, transfer, add_approver, remove_approver, replace", r.Result)
+		return fmt.Errorf("invalid record Result: %q, must be one of: %s", r.Result, strings.Join(validResults, ", "))
 	}
 	// 验证时间
 	if r.CreatedAt.IsZero() {
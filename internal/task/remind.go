@@ -0,0 +1,235 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// DefaultReminderInterval 同一任务中同一审批人两次催办的默认最小间隔
+const DefaultReminderInterval = time.Hour
+
+// WithReminderInterval 设置同一任务中同一审批人两次催办的最小间隔
+// 未设置时使用 DefaultReminderInterval,设置为 0 时不限制催办频率
+func WithReminderInterval(interval time.Duration) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.reminderInterval = interval
+	}
+}
+
+// Remind 催办节点中尚未审批的审批人
+// 间隔内已被催办过的审批人不会重复催办,所有审批人都在间隔内被催办过时返回 ErrReminderRateLimited
+func (m *memoryTaskManager) Remind(id string, nodeID string, actor string, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 获取任务
+	tsk, exists := m.tasks[id]
+	if !exists {
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionRemind, actor, tsk, nodeID); err != nil {
+		return err
+	}
+
+	if actor == "" {
+		return fmt.Errorf("reminder actor is required")
+	}
+
+	// 只有 submitted 或 approving 状态才能催办
+	state := tsk.GetState()
+	if state != types.TaskStateSubmitted && state != types.TaskStateApproving {
+		return fmt.Errorf("%w: task state %q cannot be reminded", errors.ErrInvalidStateTransition, state)
+	}
+
+	// 获取模板和节点
+	tpl, err := m.taskTemplate(tsk)
+	if err != nil {
+		return err
+	}
+	node, exists := tpl.Nodes[nodeID]
+	if !exists {
+		return fmt.Errorf("node %q not found in template", nodeID)
+	}
+	if node.Type != template.NodeTypeApproval {
+		return fmt.Errorf("node %q is not an approval node", nodeID)
+	}
+
+	now := m.clock.Now()
+	tsk.mu.Lock()
+	if tsk.CurrentNode != nodeID {
+		currentNode := tsk.CurrentNode
+		tsk.mu.Unlock()
+		return fmt.Errorf("node %q is not the current node %q", nodeID, currentNode)
+	}
+
+	pending := pendingApprovers(tsk, nodeID)
+	if len(pending) == 0 {
+		tsk.mu.Unlock()
+		return fmt.Errorf("%w: no pending approver on node %q", errors.ErrApproverNotFound, nodeID)
+	}
+
+	// 过滤间隔内已被催办过的审批人
+	var approvers []string
+	for _, approver := range pending {
+		if last, ok := lastReminded(tsk, approver); ok && m.reminderInterval > 0 && now.Sub(last) < m.reminderInterval {
+			continue
+		}
+		approvers = append(approvers, approver)
+	}
+	if len(approvers) == 0 {
+		tsk.mu.Unlock()
+		return fmt.Errorf("%w: approvers on node %q were reminded within %s", errors.ErrReminderRateLimited, nodeID, m.reminderInterval)
+	}
+
+	reminder := &Reminder{
+		NodeID:    nodeID,
+		Actor:     actor,
+		Approvers: approvers,
+		Message:   message,
+		CreatedAt: now,
+	}
+	tsk.Reminders = append(tsk.Reminders, reminder)
+	tsk.UpdatedAt = now
+	tsk.mu.Unlock()
+
+	m.tasks[id] = tsk
+	m.generateReminderEvent(tsk, node, reminderInfo(reminder))
+	return nil
+}
+
+// HandleReminders 发送到期的自动催办
+// 当前节点配置了自动催办且已到催办时间时,催办尚未审批的审批人;未到期时不做任何操作
+func (m *memoryTaskManager) HandleReminders(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 获取任务
+	tsk, exists := m.tasks[id]
+	if !exists {
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+
+	if err := m.authorize(context.Background(), ActionHandleReminders, "", tsk, ""); err != nil {
+		return err
+	}
+
+	state := tsk.GetState()
+	if state != types.TaskStateSubmitted && state != types.TaskStateApproving {
+		return nil
+	}
+
+	deadline, nodeID, ok := m.reminderDeadline(tsk)
+	if !ok || m.clock.Now().Before(deadline) {
+		return nil
+	}
+
+	tpl, err := m.taskTemplate(tsk)
+	if err != nil {
+		return err
+	}
+
+	now := m.clock.Now()
+	tsk.mu.Lock()
+	reminder := &Reminder{
+		NodeID:    nodeID,
+		Actor:     TimeoutApprover,
+		Approvers: pendingApprovers(tsk, nodeID),
+		Automatic: true,
+		CreatedAt: now,
+	}
+	tsk.Reminders = append(tsk.Reminders, reminder)
+	tsk.UpdatedAt = now
+	tsk.mu.Unlock()
+
+	m.tasks[id] = tsk
+	m.scheduleTimeout(tsk)
+
+	if len(reminder.Approvers) > 0 {
+		m.generateReminderEvent(tsk, tpl.Nodes[nodeID], reminderInfo(reminder))
+	}
+	return nil
+}
+
+// reminderDeadline 计算任务当前节点下一次自动催办的时间
+// 第一次催办在节点激活后 Interval,之后在上一次自动催办后 Interval;
+// 当前节点未配置自动催办或已达到最多催办次数时返回 false
+func (m *memoryTaskManager) reminderDeadline(tsk *Task) (time.Time, string, bool) {
+	tpl, err := m.taskTemplate(tsk)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+
+	tsk.mu.RLock()
+	defer tsk.mu.RUnlock()
+
+	currentNodeID := tsk.CurrentNode
+	node, exists := tpl.Nodes[currentNodeID]
+	if !exists || node.Type != template.NodeTypeApproval {
+		return time.Time{}, "", false
+	}
+	accessor, ok := node.Config.(template.ReminderPolicyAccessor)
+	if !ok {
+		return time.Time{}, "", false
+	}
+	schedule := accessor.GetReminderSchedule()
+	if schedule == nil || schedule.Interval <= 0 {
+		return time.Time{}, "", false
+	}
+
+	// 本次激活后已发送的自动催办
+	last := nodeStartTime(tsk, currentNodeID)
+	sent := 0
+	for _, reminder := range tsk.Reminders {
+		if tsk.currentNodeID(reminder.NodeID) == currentNodeID && reminder.Automatic && !reminder.CreatedAt.Before(last) {
+			sent++
+			last = reminder.CreatedAt
+		}
+	}
+	if schedule.MaxTimes > 0 && sent >= schedule.MaxTimes {
+		return time.Time{}, "", false
+	}
+	return last.Add(schedule.Interval), currentNodeID, true
+}
+
+// pendingApprovers 返回节点中尚未审批的审批人
+// 调用方必须持有任务锁
+func pendingApprovers(tsk *Task, nodeID string) []string {
+	var pending []string
+	for _, approver := range tsk.Approvers[nodeID] {
+		if _, exists := tsk.Approvals[nodeID][approver]; !exists {
+			pending = append(pending, approver)
+		}
+	}
+	return pending
+}
+
+// lastReminded 返回审批人在任务中最近一次被催办的时间
+// 调用方必须持有任务锁
+func lastReminded(tsk *Task, approver string) (time.Time, bool) {
+	for i := len(tsk.Reminders) - 1; i >= 0; i-- {
+		for _, reminded := range tsk.Reminders[i].Approvers {
+			if reminded == approver {
+				return tsk.Reminders[i].CreatedAt, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// reminderInfo 将催办记录转换为事件中的催办信息
+func reminderInfo(reminder *Reminder) *event.ReminderInfo {
+	return &event.ReminderInfo{
+		NodeID:    reminder.NodeID,
+		Actor:     reminder.Actor,
+		Approvers: append([]string(nil), reminder.Approvers...),
+		Message:   reminder.Message,
+		Automatic: reminder.Automatic,
+	}
+}
@@ -0,0 +1,31 @@
+package task
+
+import "time"
+
+// 跳过规则生成的系统审批记录的审批结果
+const (
+	// RecordResultSkipNode 审批人列表为空,节点被跳过
+	RecordResultSkipNode = "skip_node"
+
+	// RecordResultSkipApprover 审批人由系统自动同意(已在之前的节点同意过,或是任务发起人)
+	RecordResultSkipApprover = "skip_approver"
+
+	// RecordResultEscalateInitiator 审批人是任务发起人,替换为其上级
+	RecordResultEscalateInitiator = "escalate_initiator"
+)
+
+// AppendSystemRecord 添加系统生成的审批记录
+// 由流程引擎在应用跳过规则时调用,审批人为 SystemApprover,comment 说明原因
+// 调用方必须持有任务锁(流程引擎推进流程时任务管理器已持有)
+func AppendSystemRecord(tsk *Task, nodeID string, result string, comment string, at time.Time) {
+	tsk.Records = append(tsk.Records, &Record{
+		ID:          generateRecordID(),
+		TaskID:      tsk.ID,
+		NodeID:      nodeID,
+		Approver:    SystemApprover,
+		Result:      result,
+		Comment:     comment,
+		CreatedAt:   at,
+		Attachments: []string{},
+	})
+}
@@ -0,0 +1,113 @@
+package task
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// TaskStore 任务持久化存储接口
+// 负责任务的读取、保存、查询和删除,使用 Version 字段实现乐观锁
+type TaskStore interface {
+	// Get 获取任务
+	// id: 任务 ID
+	// 返回: 任务对象(副本)和错误信息,任务不存在时返回 ErrTaskNotFound
+	Get(id string) (*Task, error)
+
+	// Save 保存任务
+	// tsk.Version 必须与存储中的版本一致(新任务为 0),否则返回 ErrConcurrentModification
+	// 保存成功后 tsk.Version 更新为新的版本号
+	Save(tsk *Task) error
+
+	// Query 查询任务
+	// filter: 过滤条件
+	// 返回: 任务列表(副本)和错误信息
+	Query(filter *TaskFilter) ([]*Task, error)
+
+	// Delete 删除任务
+	// id: 任务 ID
+	// 返回: 错误信息,任务不存在时返回 ErrTaskNotFound
+	Delete(id string) error
+}
+
+// WithTaskStore 设置任务存储
+// 设置后任务保存在指定的存储中,每次操作从存储加载任务并在成功后写回
+// 未设置时任务只保存在任务管理器的内存中
+func WithTaskStore(store TaskStore) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.store = store
+	}
+}
+
+// memoryTaskStore 内存实现的任务存储
+type memoryTaskStore struct {
+	mu    sync.RWMutex
+	tasks map[string]*Task // taskID -> Task
+}
+
+// NewMemoryTaskStore 创建内存任务存储
+// 进程重启后数据丢失,适用于测试和单机场景
+func NewMemoryTaskStore() TaskStore {
+	return &memoryTaskStore{
+		tasks: make(map[string]*Task),
+	}
+}
+
+// Get 获取任务
+func (s *memoryTaskStore) Get(id string) (*Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tsk, exists := s.tasks[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+	return tsk.Clone(), nil
+}
+
+// Save 保存任务
+func (s *memoryTaskStore) Save(tsk *Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := 0
+	if stored, exists := s.tasks[tsk.ID]; exists {
+		current = stored.Version
+	}
+	if tsk.Version != current {
+		return fmt.Errorf("task %q version %d, stored version %d: %w", tsk.ID, tsk.Version, current, errors.ErrConcurrentModification)
+	}
+
+	saved := tsk.Clone()
+	saved.Version = current + 1
+	s.tasks[tsk.ID] = saved
+	tsk.Version = saved.Version
+	return nil
+}
+
+// Query 查询任务
+func (s *memoryTaskStore) Query(filter *TaskFilter) ([]*Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []*Task
+	for _, tsk := range s.tasks {
+		if filter.Match(tsk) {
+			results = append(results, tsk.Clone())
+		}
+	}
+	return results, nil
+}
+
+// Delete 删除任务
+func (s *memoryTaskStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.tasks[id]; !exists {
+		return fmt.Errorf("%w: %q", errors.ErrTaskNotFound, id)
+	}
+	delete(s.tasks, id)
+	return nil
+}
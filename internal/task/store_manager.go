@@ -0,0 +1,274 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"sync"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// storeTaskManager 基于 TaskStore 的任务管理器
+// 每次操作前从存储加载任务,复用内存任务管理器执行业务逻辑,操作成功后写回存储
+// 写回时按 Version 做乐观锁校验,其他进程已修改同一任务时返回 ErrConcurrentModification
+// 操作产生的事件在任务写回成功后才发布,设置了事件发件箱时与任务一起写回(见 OutboxTaskStore)
+type storeTaskManager struct {
+	mu    sync.Mutex // 串行化本进程内的写操作
+	inner *memoryTaskManager
+	store TaskStore
+}
+
+// newStoreTaskManager 创建基于 TaskStore 的任务管理器
+func newStoreTaskManager(inner *memoryTaskManager) TaskManager {
+	return &storeTaskManager{
+		inner: inner,
+		store: inner.store,
+	}
+}
+
+// Create 基于模板创建审批任务实例并保存到存储,不记录发起人
+//
+// Deprecated: 使用 CreateBy 记录发起人
+func (s *storeTaskManager) Create(templateID string, businessID string, params json.RawMessage) (*Task, error) {
+	return s.CreateBy(templateID, businessID, "", params)
+}
+
+// CreateBy 基于模板创建审批任务实例并保存到存储,记录发起人
+func (s *storeTaskManager) CreateBy(templateID string, businessID string, initiator string, params json.RawMessage) (*Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inner.bufferEvents()
+	created, err := s.inner.CreateBy(templateID, businessID, initiator, params)
+	events := s.inner.takeEvents()
+	if err != nil {
+		return nil, err
+	}
+	defer s.evict(created.ID)
+
+	tsk := s.loaded(created.ID)
+	if err := s.save(tsk, events); err != nil {
+		if stderrors.Is(err, errors.ErrOutboxAppendFailed) {
+			// 任务已保存,返回任务以便调用方得知任务 ID
+			return tsk.Clone(), err
+		}
+		return nil, err
+	}
+	return tsk.Clone(), nil
+}
+
+// Get 从存储获取审批任务详情
+func (s *storeTaskManager) Get(id string) (*Task, error) {
+	return s.store.Get(id)
+}
+
+// Query 从存储查询任务
+func (s *storeTaskManager) Query(filter *TaskFilter) ([]*Task, error) {
+	return s.store.Query(filter)
+}
+
+func (s *storeTaskManager) Submit(id string, actor string) error {
+	return s.update(id, func() error {
+		return s.inner.Submit(id, actor)
+	})
+}
+
+func (s *storeTaskManager) SubmitCtx(ctx context.Context, id string, actor string) error {
+	return s.update(id, func() error {
+		return s.inner.SubmitCtx(ctx, id, actor)
+	})
+}
+
+func (s *storeTaskManager) Approve(id string, nodeID string, approver string, comment string) error {
+	return s.update(id, func() error {
+		return s.inner.Approve(id, nodeID, approver, comment)
+	})
+}
+
+func (s *storeTaskManager) ApproveCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error {
+	return s.update(id, func() error {
+		return s.inner.ApproveCtx(ctx, id, nodeID, approver, comment)
+	})
+}
+
+func (s *storeTaskManager) ApproveWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error {
+	return s.update(id, func() error {
+		return s.inner.ApproveWithAttachments(id, nodeID, approver, comment, attachments)
+	})
+}
+
+func (s *storeTaskManager) ApproveWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error {
+	return s.update(id, func() error {
+		return s.inner.ApproveWithAttachmentsCtx(ctx, id, nodeID, approver, comment, attachments)
+	})
+}
+
+func (s *storeTaskManager) Reject(id string, nodeID string, approver string, comment string) error {
+	return s.update(id, func() error {
+		return s.inner.Reject(id, nodeID, approver, comment)
+	})
+}
+
+func (s *storeTaskManager) RejectCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error {
+	return s.update(id, func() error {
+		return s.inner.RejectCtx(ctx, id, nodeID, approver, comment)
+	})
+}
+
+func (s *storeTaskManager) RejectWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error {
+	return s.update(id, func() error {
+		return s.inner.RejectWithAttachments(id, nodeID, approver, comment, attachments)
+	})
+}
+
+func (s *storeTaskManager) RejectWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error {
+	return s.update(id, func() error {
+		return s.inner.RejectWithAttachmentsCtx(ctx, id, nodeID, approver, comment, attachments)
+	})
+}
+
+func (s *storeTaskManager) Cancel(id string, actor string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.Cancel(id, actor, reason)
+	})
+}
+
+func (s *storeTaskManager) Withdraw(id string, actor string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.Withdraw(id, actor, reason)
+	})
+}
+
+func (s *storeTaskManager) Transfer(id string, nodeID string, fromApprover string, toApprover string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.Transfer(id, nodeID, fromApprover, toApprover, reason)
+	})
+}
+
+func (s *storeTaskManager) AddApprover(id string, nodeID string, actor string, approver string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.AddApprover(id, nodeID, actor, approver, reason)
+	})
+}
+
+func (s *storeTaskManager) RemoveApprover(id string, nodeID string, actor string, approver string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.RemoveApprover(id, nodeID, actor, approver, reason)
+	})
+}
+
+func (s *storeTaskManager) HandleTimeout(id string) error {
+	return s.update(id, func() error {
+		return s.inner.HandleTimeout(id)
+	})
+}
+
+func (s *storeTaskManager) HandleReminders(id string) error {
+	return s.update(id, func() error {
+		return s.inner.HandleReminders(id)
+	})
+}
+
+func (s *storeTaskManager) Remind(id string, nodeID string, actor string, message string) error {
+	return s.update(id, func() error {
+		return s.inner.Remind(id, nodeID, actor, message)
+	})
+}
+
+func (s *storeTaskManager) Pause(id string, actor string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.Pause(id, actor, reason)
+	})
+}
+
+func (s *storeTaskManager) Resume(id string, actor string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.Resume(id, actor, reason)
+	})
+}
+
+func (s *storeTaskManager) RollbackToNode(id string, nodeID string, actor string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.RollbackToNode(id, nodeID, actor, reason)
+	})
+}
+
+func (s *storeTaskManager) ReplaceApprover(id string, nodeID string, actor string, oldApprover string, newApprover string, reason string) error {
+	return s.update(id, func() error {
+		return s.inner.ReplaceApprover(id, nodeID, actor, oldApprover, newApprover, reason)
+	})
+}
+
+func (s *storeTaskManager) MigrateTaskToVersion(id string, version int, nodeMapping map[string]string, actor string) error {
+	return s.update(id, func() error {
+		return s.inner.MigrateTaskToVersion(id, version, nodeMapping, actor)
+	})
+}
+
+// update 加载任务、执行操作并写回存储
+// 操作失败时不写回,存储中的任务保持不变
+func (s *storeTaskManager) update(id string, op func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tsk, err := s.store.Get(id)
+	if err != nil {
+		return err
+	}
+
+	s.inner.mu.Lock()
+	s.inner.tasks[id] = tsk
+	s.inner.mu.Unlock()
+	defer s.evict(id)
+
+	s.inner.bufferEvents()
+	err = op()
+	events := s.inner.takeEvents()
+	if err != nil {
+		return err
+	}
+	return s.save(s.loaded(id), events)
+}
+
+// save 将任务和操作产生的事件写回存储
+// 存储实现了 OutboxTaskStore 时事件与任务在同一事务中写入,否则任务保存成功后写入发件箱,
+// 写入发件箱失败时返回 ErrOutboxAppendFailed(任务已保存,事件没有写入)
+// 任务保存成功后才将事件推送给事件通知器,保存失败(如版本冲突)时丢弃事件
+func (s *storeTaskManager) save(tsk *Task, events []bufferedEvent) error {
+	entries := outboxEntries(events)
+	if outboxStore, ok := s.store.(OutboxTaskStore); ok && len(entries) > 0 {
+		if err := outboxStore.SaveWithEvents(tsk, entries); err != nil {
+			return fmt.Errorf("failed to save task %q: %w", tsk.ID, err)
+		}
+		s.inner.notifyEvents(events)
+		return nil
+	}
+
+	if err := s.store.Save(tsk); err != nil {
+		return fmt.Errorf("failed to save task %q: %w", tsk.ID, err)
+	}
+	s.inner.notifyEvents(events)
+	if len(entries) > 0 {
+		if err := s.inner.outbox.Append(entries...); err != nil {
+			return fmt.Errorf("%w: task %q saved but %d events not appended: %w", errors.ErrOutboxAppendFailed, tsk.ID, len(entries), err)
+		}
+	}
+	return nil
+}
+
+// loaded 返回内存任务管理器中当前的任务对象
+// 状态转换会替换任务对象,因此每次都需要重新读取
+func (s *storeTaskManager) loaded(id string) *Task {
+	s.inner.mu.RLock()
+	defer s.inner.mu.RUnlock()
+	return s.inner.tasks[id]
+}
+
+// evict 从内存任务管理器中移除任务,以存储中的数据为准
+func (s *storeTaskManager) evict(id string) {
+	s.inner.mu.Lock()
+	defer s.inner.mu.Unlock()
+	delete(s.inner.tasks, id)
+}
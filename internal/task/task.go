@@ -18,6 +18,7 @@ type Task struct {
 	TemplateID     string          // 模板 ID
 	TemplateVersion int            // 模板版本号
 	BusinessID     string          // 关联的业务 ID
+	Initiator      string          // 发起人 ID(通过 CreateBy 创建时记录)
 	Params         json.RawMessage // 任务参数(JSON 格式)
 
 	// 状态信息
@@ -33,11 +34,21 @@ type Task struct {
 	UpdatedAt   time.Time  // 更新时间
 	SubmittedAt *time.Time // 提交时间
 
+	// NodeActivatedAt 节点 ID -> 节点最近一次激活时间,用于计算节点超时
+	NodeActivatedAt map[string]time.Time
+
 	// 运行时数据
 	NodeOutputs map[string]json.RawMessage           // 节点 ID -> 节点输出数据
 	Approvers   map[string][]string                  // 节点 ID -> 审批人列表
 	Approvals   map[string]map[string]*Approval      // 节点 ID -> 审批人 -> 审批结果
 
+	// Delegations 节点 ID -> 代理人 -> 原审批人,审批人获取时按委托规则替换的代理关系
+	Delegations map[string]map[string]string
+
+	// NodeAliases 迁移前的节点 ID -> 当前模板版本中的节点 ID
+	// 迁移不改写审批记录和催办历史,按节点统计历史时通过 currentNodeID 换算
+	NodeAliases map[string]string
+
 	// 回退相关字段
 	CompletedNodes []string // 已完成的节点 ID 列表,用于回退操作
 
@@ -46,6 +57,12 @@ type Task struct {
 
 	// 状态变更历史
 	StateHistory []*StateChange // 状态变更历史
+
+	// 催办历史
+	Reminders []*Reminder // 催办历史,用于催办频率限制和追溯
+
+	// 持久化相关字段
+	Version int // 版本号,由 TaskStore 在每次保存时递增,用于乐观锁
 }
 
 // Approval 审批结果
@@ -61,13 +78,25 @@ type Record struct {
 	ID         string    // 记录 ID
 	TaskID     string    // 任务 ID
 	NodeID     string    // 节点 ID
-	Approver   string    // 审批人
+	Approver   string    // 审批人(实际执行审批的人)
+	OnBehalfOf string    // 原审批人(代理审批时为委托人,否则为空)
 	Result     string    // 审批结果(approve/reject/transfer)
 	Comment    string    // 审批意见
 	CreatedAt  time.Time // 审批时间
 	Attachments []string // 附件列表
 }
 
+// Reminder 催办记录
+// 每次催办(手动或自动)生成一条,记录被催办的审批人
+type Reminder struct {
+	NodeID    string    // 节点 ID
+	Actor     string    // 催办发起人(自动催办时为 system)
+	Approvers []string  // 被催办的审批人
+	Message   string    // 催办消息
+	Automatic bool      // 是否为自动催办
+	CreatedAt time.Time // 催办时间
+}
+
 // StateChange 状态变更记录
 // 记录每次状态变更的详细信息,用于追溯和审计
 type StateChange struct {
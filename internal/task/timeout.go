@@ -1,71 +1,413 @@
 package task
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/mautops/approval-kit/internal/event"
 	"github.com/mautops/approval-kit/internal/template"
 	"github.com/mautops/approval-kit/internal/types"
 )
 
+// SystemApprover 系统生成的审批记录中使用的审批人(如超时动作、跳过规则)
+const SystemApprover = "system"
+
+// TimeoutApprover 超时动作生成的审批记录和审批结果中使用的审批人
+const TimeoutApprover = SystemApprover
+
+// 超时动作类型,与 node.TimeoutActionType 的取值一致
+const (
+	timeoutActionNotify      = "notify"
+	timeoutActionEscalate    = "escalate"
+	timeoutActionAutoApprove = "auto_approve"
+	timeoutActionAutoReject  = "auto_reject"
+	timeoutActionTerminate   = "terminate"
+)
+
+// EscalationResolver 超时升级审批人解析函数
+// 超时动作为升级且未配置升级目标时调用,根据尚未审批的审批人返回升级目标(如审批人的上级)
+// tsk: 任务对象(只读)
+// nodeID: 超时的节点 ID
+// approvers: 节点中尚未审批的审批人
+type EscalationResolver func(tsk *Task, nodeID string, approvers []string) ([]string, error)
+
+// WithEscalationResolver 设置超时升级审批人解析函数
+func WithEscalationResolver(resolver EscalationResolver) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.escalationResolver = resolver
+	}
+}
+
+// timeoutStep 任务当前节点下一个待执行的超时动作
+type timeoutStep struct {
+	nodeID   string
+	node     *template.Node
+	action   template.TimeoutActionSpec
+	deadline time.Time
+}
+
 // CheckTimeout 检查任务是否超时
+// 超时时间从当前节点的激活时间开始计算,配置了超时策略时检查下一个超时动作是否到期
 // 返回: 是否超时,超时的节点 ID(如果超时)
 func (m *memoryTaskManager) CheckTimeout(tsk *Task) (bool, string) {
-	// 获取当前节点
-	tsk.mu.RLock()
-	currentNodeID := tsk.CurrentNode
-	state := tsk.State
-	tsk.mu.RUnlock()
+	step, due := m.dueTimeoutStep(tsk)
+	if !due {
+		return false, ""
+	}
+	return true, step.nodeID
+}
 
-	// 只有 submitted 或 approving 状态的任务才需要检查超时
+// dueTimeoutStep 返回已到期的超时动作
+// 只有 submitted 或 approving 状态的任务才需要检查超时
+func (m *memoryTaskManager) dueTimeoutStep(tsk *Task) (*timeoutStep, bool) {
+	state := tsk.GetState()
 	if state != types.TaskStateSubmitted && state != types.TaskStateApproving {
-		return false, ""
+		return nil, false
+	}
+
+	step, ok := m.nextTimeoutStep(tsk)
+	if !ok || m.clock.Now().Before(step.deadline) {
+		return nil, false
+	}
+	return step, true
+}
+
+// timeoutDeadline 计算任务当前节点下一个超时动作的截止时间
+// 当前节点不是审批节点、未配置超时或超时动作已全部执行时返回 false
+func (m *memoryTaskManager) timeoutDeadline(tsk *Task) (time.Time, string, bool) {
+	step, ok := m.nextTimeoutStep(tsk)
+	if !ok {
+		return time.Time{}, "", false
 	}
+	return step.deadline, step.nodeID, true
+}
 
+// nextTimeoutStep 计算任务当前节点下一个待执行的超时动作
+// 配置了超时策略时按策略的动作列表计算,已执行的动作通过本次激活后的系统审批记录数量确定;
+// 未配置超时策略时,超时后终止任务
+// 截止时间 = 节点激活时间 + 动作偏移;没有激活时间(如旧数据)时依次使用提交时间和创建时间
+func (m *memoryTaskManager) nextTimeoutStep(tsk *Task) (*timeoutStep, bool) {
 	// 获取模板
-	tpl, err := m.templateMgr.Get(tsk.TemplateID, 0)
+	tpl, err := m.taskTemplate(tsk)
 	if err != nil {
-		return false, ""
+		return nil, false
 	}
 
+	tsk.mu.RLock()
+	defer tsk.mu.RUnlock()
+
 	// 获取当前节点
+	currentNodeID := tsk.CurrentNode
 	node, exists := tpl.Nodes[currentNodeID]
-	if !exists {
-		return false, ""
+	if !exists || node.Type != template.NodeTypeApproval {
+		return nil, false
 	}
 
-	// 检查节点配置
-	if node.Type != template.NodeTypeApproval {
-		return false, ""
+	actions := timeoutActions(node)
+	if len(actions) == 0 {
+		return nil, false
 	}
 
-	// 获取审批节点配置
-	config, ok := node.Config.(template.ApprovalNodeConfigAccessor)
-	if !ok {
-		return false, ""
+	startTime := nodeStartTime(tsk, currentNodeID)
+
+	// 本次激活后已执行的超时动作数量
+	done := 0
+	for _, record := range tsk.Records {
+		if tsk.currentNodeID(record.NodeID) == currentNodeID && isTimeoutRecord(record) && !record.CreatedAt.Before(startTime) {
+			done++
+		}
+	}
+	if done >= len(actions) {
+		return nil, false
 	}
 
-	// 检查是否配置了超时
-	timeout := config.GetTimeout()
-	if timeout == nil {
-		return false, ""
+	return &timeoutStep{
+		nodeID:   currentNodeID,
+		node:     node,
+		action:   actions[done],
+		deadline: startTime.Add(actions[done].After),
+	}, true
+}
+
+// isTimeoutRecord 判断审批记录是否由超时动作生成
+// 系统审批记录还可能由跳过规则生成,按审批结果区分
+func isTimeoutRecord(record *Record) bool {
+	if record.Approver != TimeoutApprover {
+		return false
+	}
+	switch record.Result {
+	case timeoutActionNotify, timeoutActionEscalate, timeoutActionAutoApprove, timeoutActionAutoReject:
+		return true
+	}
+	return false
+}
+
+// nodeStartTime 返回节点最近一次激活的时间
+// 没有激活时间(如旧数据)时依次使用提交时间和创建时间
+// 调用方必须持有任务锁
+func nodeStartTime(tsk *Task, nodeID string) time.Time {
+	if activatedAt, ok := tsk.NodeActivatedAt[nodeID]; ok {
+		return activatedAt
+	}
+	if tsk.SubmittedAt != nil {
+		return *tsk.SubmittedAt
+	}
+	return tsk.CreatedAt
+}
+
+// timeoutActions 返回审批节点的超时动作列表
+// 未配置超时策略时,配置了 Timeout 的节点在超时后终止任务
+func timeoutActions(node *template.Node) []template.TimeoutActionSpec {
+	if accessor, ok := node.Config.(template.TimeoutPolicyAccessor); ok {
+		if actions := accessor.GetTimeoutActions(); len(actions) > 0 {
+			return actions
+		}
+	}
+
+	config, ok := node.Config.(template.ApprovalNodeConfigAccessor)
+	if !ok || config.GetTimeout() == nil {
+		return nil
 	}
+	return []template.TimeoutActionSpec{{Type: timeoutActionTerminate, After: *config.GetTimeout()}}
+}
+
+// timeoutNotify 执行提醒动作: 记录提醒并生成审批操作事件,节点继续等待审批
+// 调用方必须持有管理器锁
+func (m *memoryTaskManager) timeoutNotify(id string, tsk *Task, step *timeoutStep) error {
+	comment := fmt.Sprintf("node %q has been waiting since %s", step.nodeID, step.deadline.Add(-step.action.After).Format(time.RFC3339))
+	tsk.mu.Lock()
+	m.appendTimeoutRecord(tsk, step.nodeID, timeoutActionNotify, comment)
+	tsk.mu.Unlock()
 
-	// 检查节点是否已超时
-	// 需要找到节点激活时间或任务提交时间
+	m.tasks[id] = tsk
+	m.scheduleTimeout(tsk)
+
+	m.generateEvent(event.EventTypeApprovalOp, tsk, step.node, &event.ApprovalInfo{
+		NodeID:   step.nodeID,
+		Approver: TimeoutApprover,
+		Result:   timeoutActionNotify,
+		Comment:  comment,
+	})
+	return nil
+}
+
+// timeoutEscalate 执行升级动作: 尚未审批的审批人替换为升级目标,节点继续等待审批
+// 升级目标为空时使用升级审批人解析函数,仍无法确定升级目标时记录升级失败(见 timeoutEscalateFailed)
+// 调用方必须持有管理器锁
+func (m *memoryTaskManager) timeoutEscalate(id string, tsk *Task, step *timeoutStep) error {
 	tsk.mu.RLock()
-	submittedAt := tsk.SubmittedAt
+	var decided, pending []string
+	for _, approver := range tsk.Approvers[step.nodeID] {
+		if _, exists := tsk.Approvals[step.nodeID][approver]; exists {
+			decided = append(decided, approver)
+		} else {
+			pending = append(pending, approver)
+		}
+	}
 	tsk.mu.RUnlock()
 
-	// 如果没有提交时间,使用创建时间
-	startTime := tsk.CreatedAt
-	if submittedAt != nil {
-		startTime = *submittedAt
+	targets := step.action.EscalateTo
+	if len(targets) == 0 {
+		if m.escalationResolver == nil {
+			return m.timeoutEscalateFailed(id, tsk, step, fmt.Errorf("no target, set EscalateTo or use WithEscalationResolver"))
+		}
+		resolved, err := m.escalationResolver(tsk.Clone(), step.nodeID, pending)
+		if err != nil {
+			return m.timeoutEscalateFailed(id, tsk, step, fmt.Errorf("failed to resolve escalation targets: %w", err))
+		}
+		targets = resolved
+	}
+	if len(targets) == 0 {
+		return m.timeoutEscalateFailed(id, tsk, step, fmt.Errorf("resolved no target"))
+	}
+
+	// 已审批的审批人保留,尚未审批的审批人替换为升级目标
+	approvers := append([]string{}, decided...)
+	seen := make(map[string]bool, len(approvers))
+	for _, approver := range approvers {
+		seen[approver] = true
+	}
+	for _, target := range targets {
+		if !seen[target] {
+			seen[target] = true
+			approvers = append(approvers, target)
+		}
+	}
+
+	comment := fmt.Sprintf("escalated from [%s] to [%s]", strings.Join(pending, ", "), strings.Join(targets, ", "))
+	tsk.mu.Lock()
+	if tsk.Approvers == nil {
+		tsk.Approvers = make(map[string][]string)
+	}
+	tsk.Approvers[step.nodeID] = approvers
+	m.appendTimeoutRecord(tsk, step.nodeID, timeoutActionEscalate, comment)
+	tsk.mu.Unlock()
+
+	m.tasks[id] = tsk
+	m.scheduleTimeout(tsk)
+
+	m.generateEvent(event.EventTypeApprovalOp, tsk, step.node, &event.ApprovalInfo{
+		NodeID:   step.nodeID,
+		Approver: TimeoutApprover,
+		Result:   timeoutActionEscalate,
+		Comment:  comment,
+	})
+	return nil
+}
+
+// timeoutEscalateFailed 记录失败的升级动作,审批人保持不变,节点继续等待审批
+// 失败的升级动作同样计入已执行的超时动作,后续超时动作按计划执行
+// 调用方必须持有管理器锁
+func (m *memoryTaskManager) timeoutEscalateFailed(id string, tsk *Task, step *timeoutStep, cause error) error {
+	comment := fmt.Sprintf("escalation failed: %v", cause)
+	log.Printf("node %q of task %q timeout %s", step.nodeID, id, comment)
+
+	tsk.mu.Lock()
+	m.appendTimeoutRecord(tsk, step.nodeID, timeoutActionEscalate, comment)
+	tsk.mu.Unlock()
+
+	m.tasks[id] = tsk
+	m.scheduleTimeout(tsk)
+
+	m.generateEvent(event.EventTypeApprovalOp, tsk, step.node, &event.ApprovalInfo{
+		NodeID:   step.nodeID,
+		Approver: TimeoutApprover,
+		Result:   timeoutActionEscalate,
+		Comment:  comment,
+	})
+	return nil
+}
+
+// timeoutDecide 执行自动同意或自动拒绝动作
+// 尚未审批的审批人记录为同意(或拒绝),之后与人工审批相同: 使用流程引擎时由审批模式判断节点结果并推进流程,
+// 未使用流程引擎时直接结束任务
+// 调用方必须持有管理器锁
+func (m *memoryTaskManager) timeoutDecide(id string, tsk *Task, tpl *template.Template, step *timeoutStep) error {
+	result := "approve"
+	if step.action.Type == timeoutActionAutoReject {
+		result = "reject"
+	}
+	comment := fmt.Sprintf("%s after node timeout", step.action.Type)
+
+	var backup *Task
+	if m.engine != nil {
+		backup = tsk.Clone()
+	}
+
+	now := m.clock.Now()
+	tsk.mu.Lock()
+	if tsk.State == types.TaskStateSubmitted {
+		tsk.State = types.TaskStateApproving
+	}
+	if tsk.Approvals == nil {
+		tsk.Approvals = make(map[string]map[string]*Approval)
+	}
+	if tsk.Approvals[step.nodeID] == nil {
+		tsk.Approvals[step.nodeID] = make(map[string]*Approval)
+	}
+	for _, approver := range tsk.Approvers[step.nodeID] {
+		if _, exists := tsk.Approvals[step.nodeID][approver]; !exists {
+			tsk.Approvals[step.nodeID][approver] = &Approval{
+				Result:    result,
+				Comment:   comment,
+				CreatedAt: now,
+			}
+		}
+	}
+	m.appendTimeoutRecord(tsk, step.nodeID, step.action.Type, comment)
+	tsk.mu.Unlock()
+
+	approvalInfo := &event.ApprovalInfo{
+		NodeID:   step.nodeID,
+		Approver: TimeoutApprover,
+		Result:   step.action.Type,
+		Comment:  comment,
+	}
+	if m.engine != nil {
+		return m.advanceWithEngine(context.Background(), id, tsk, backup, tpl, step.node, approvalInfo)
 	}
 
-	// 检查是否超时
-	if time.Since(startTime) > *timeout {
-		return true, currentNodeID
+	// 未设置流程引擎时,当前节点的结果即任务的结果
+	targetState := types.TaskStateApproved
+	if result == "reject" {
+		targetState = types.TaskStateRejected
 	}
+	newTask, err := m.stateMachine.Transition(&taskAdapter{task: tsk}, targetState, comment)
+	if err != nil {
+		return fmt.Errorf("state transition failed: %w", err)
+	}
+	tsk = newTask.(*taskAdapter).task
+	if targetState == types.TaskStateApproved {
+		tsk.CompletedNodes = append(tsk.CompletedNodes, step.nodeID)
+	}
+	tsk.UpdatedAt = now
+	m.tasks[id] = tsk
+
+	m.generateEvent(event.EventTypeApprovalOp, tsk, step.node, approvalInfo)
+	m.generateEvent(event.EventTypeNodeCompleted, tsk, step.node, nil)
+	if targetState == types.TaskStateApproved {
+		m.generateEvent(event.EventTypeTaskApproved, tsk, step.node, nil)
+	} else {
+		m.generateEvent(event.EventTypeTaskRejected, tsk, step.node, nil)
+	}
+	return nil
+}
 
-	return false, ""
+// appendTimeoutRecord 添加超时动作的审批记录
+// 调用方必须持有任务锁
+func (m *memoryTaskManager) appendTimeoutRecord(tsk *Task, nodeID string, result string, comment string) {
+	now := m.clock.Now()
+	tsk.Records = append(tsk.Records, &Record{
+		ID:          generateRecordID(),
+		TaskID:      tsk.ID,
+		NodeID:      nodeID,
+		Approver:    TimeoutApprover,
+		Result:      result,
+		Comment:     comment,
+		CreatedAt:   now,
+		Attachments: []string{},
+	})
+	tsk.UpdatedAt = now
+}
+
+// markNodeActivated 记录节点激活时间,并向超时调度器登记任务当前节点的截止时间
+// 调用方不能持有任务锁
+func (m *memoryTaskManager) markNodeActivated(tsk *Task, nodeIDs ...string) {
+	now := m.clock.Now()
+	tsk.mu.Lock()
+	if tsk.NodeActivatedAt == nil {
+		tsk.NodeActivatedAt = make(map[string]time.Time)
+	}
+	for _, nodeID := range nodeIDs {
+		tsk.NodeActivatedAt[nodeID] = now
+	}
+	tsk.mu.Unlock()
+
+	m.scheduleTimeout(tsk)
+}
+
+// scheduleTimeout 向超时调度器登记任务的下一个到期时间
+// 到期时间为当前节点下一个超时动作和下一次自动催办中较早的一个
+// 未设置超时调度器或当前节点既未配置超时也未配置自动催办时不做任何操作
+// 调用方不能持有任务锁
+func (m *memoryTaskManager) scheduleTimeout(tsk *Task) {
+	if m.scheduler == nil {
+		return
+	}
+	if deadline, ok := m.nextDeadline(tsk); ok {
+		m.scheduler.schedule(tsk.ID, deadline)
+	}
+}
+
+// nextDeadline 返回任务下一个需要调度器处理的时间: 超时动作和自动催办中较早的一个
+// 调用方不能持有任务锁
+func (m *memoryTaskManager) nextDeadline(tsk *Task) (time.Time, bool) {
+	deadline, _, ok := m.timeoutDeadline(tsk)
+	if remindAt, _, remind := m.reminderDeadline(tsk); remind && (!ok || remindAt.Before(deadline)) {
+		deadline, ok = remindAt, true
+	}
+	return deadline, ok
 }
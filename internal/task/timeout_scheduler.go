@@ -0,0 +1,307 @@
+package task
+
+import (
+	"container/heap"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+const (
+	// defaultRetryBackoff 超时处理失败后首次重试的默认等待时间
+	defaultRetryBackoff = time.Second
+	// defaultMaxRetryBackoff 超时处理失败后重试的默认最大等待时间
+	defaultMaxRetryBackoff = 5 * time.Minute
+)
+
+// TimeoutScheduler 超时调度器
+// 按任务当前节点的超时截止时间和自动催办时间维护最小堆,到期后自动调用任务管理器的 HandleReminders 和 HandleTimeout
+// 任务管理器在节点激活时登记截止时间,每个任务只保留最近一次登记的截止时间
+// 到期时由 HandleReminders 和 HandleTimeout 重新检查任务状态和节点配置,已完成或已推进到其他节点的任务不会被误判超时
+// 处理失败(如存储暂时不可用)时按指数退避重新登记,直到处理成功或任务不存在
+type TimeoutScheduler struct {
+	mu       sync.Mutex
+	clock    Clock
+	onError  func(taskID string, err error)
+	entries  timeoutHeap
+	byTask   map[string]*timeoutEntry // taskID -> 堆中的条目
+	failures map[string]int           // taskID -> 连续处理失败次数
+	mgr      TaskManager              // 执行超时处理的任务管理器
+	deadline func(*Task) (time.Time, bool)
+
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	started bool
+}
+
+// SchedulerOption 超时调度器可选配置
+type SchedulerOption func(*TimeoutScheduler)
+
+// WithSchedulerClock 设置超时调度器使用的时钟
+// 未设置时使用系统时间
+func WithSchedulerClock(clock Clock) SchedulerOption {
+	return func(s *TimeoutScheduler) {
+		s.clock = clock
+	}
+}
+
+// WithSchedulerErrorHandler 设置超时处理失败时的回调
+// 未设置时忽略错误
+func WithSchedulerErrorHandler(handler func(taskID string, err error)) SchedulerOption {
+	return func(s *TimeoutScheduler) {
+		s.onError = handler
+	}
+}
+
+// WithSchedulerRetryBackoff 设置超时处理失败后的重试等待时间
+// 首次重试等待 initial,之后每次失败等待时间翻倍,最多等待 max
+// 未设置时首次等待 1 秒,最多等待 5 分钟
+func WithSchedulerRetryBackoff(initial, max time.Duration) SchedulerOption {
+	return func(s *TimeoutScheduler) {
+		s.retryBackoff = initial
+		s.maxRetryBackoff = max
+	}
+}
+
+// NewTimeoutScheduler 创建超时调度器
+// 需要通过 WithTimeoutScheduler 绑定到任务管理器后调用 Start 启动
+func NewTimeoutScheduler(opts ...SchedulerOption) *TimeoutScheduler {
+	s := &TimeoutScheduler{
+		clock:           realClock{},
+		byTask:          make(map[string]*timeoutEntry),
+		failures:        make(map[string]int),
+		retryBackoff:    defaultRetryBackoff,
+		maxRetryBackoff: defaultMaxRetryBackoff,
+		wake:            make(chan struct{}, 1),
+		stop:            make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithTimeoutScheduler 设置超时调度器
+// 设置后任务节点激活时自动登记超时截止时间,调度器启动后到期自动处理超时
+func WithTimeoutScheduler(scheduler *TimeoutScheduler) ManagerOption {
+	return func(m *memoryTaskManager) {
+		m.scheduler = scheduler
+	}
+}
+
+// bind 绑定任务管理器
+// mgr: 执行超时处理的任务管理器(使用 TaskStore 时为基于存储的任务管理器)
+// inner: 用于计算截止时间的内存任务管理器
+func (s *TimeoutScheduler) bind(mgr TaskManager, inner *memoryTaskManager) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mgr = mgr
+	s.deadline = inner.nextDeadline
+}
+
+// Start 启动超时调度器
+// 启动时从任务管理器(及其存储)加载所有 submitted 和 approving 状态的任务并登记截止时间,
+// 进程重启后已到期的任务会被立即处理
+func (s *TimeoutScheduler) Start() error {
+	s.mu.Lock()
+	if s.mgr == nil {
+		s.mu.Unlock()
+		return fmt.Errorf("timeout scheduler is not bound to a task manager")
+	}
+	if s.started {
+		s.mu.Unlock()
+		return fmt.Errorf("timeout scheduler already started")
+	}
+	s.started = true
+	mgr := s.mgr
+	s.mu.Unlock()
+
+	for _, state := range []types.TaskState{types.TaskStateSubmitted, types.TaskStateApproving} {
+		tasks, err := mgr.Query(&TaskFilter{State: state})
+		if err != nil {
+			return fmt.Errorf("failed to load %s tasks: %w", state, err)
+		}
+		for _, tsk := range tasks {
+			if deadline, ok := s.deadline(tsk); ok {
+				s.schedule(tsk.ID, deadline)
+			}
+		}
+	}
+
+	go s.run()
+	return nil
+}
+
+// Stop 停止超时调度器并等待调度协程退出
+// 未启动的调度器调用 Stop 直接返回
+func (s *TimeoutScheduler) Stop() {
+	s.mu.Lock()
+	started := s.started
+	s.mu.Unlock()
+	if !started {
+		return
+	}
+
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+	<-s.done
+}
+
+// Pending 返回当前登记的截止时间数量
+func (s *TimeoutScheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// schedule 登记任务的超时截止时间
+// 任务已登记时更新为新的截止时间
+func (s *TimeoutScheduler) schedule(taskID string, deadline time.Time) {
+	s.mu.Lock()
+	if entry, exists := s.byTask[taskID]; exists {
+		entry.deadline = deadline
+		heap.Fix(&s.entries, entry.index)
+	} else {
+		entry := &timeoutEntry{taskID: taskID, deadline: deadline}
+		heap.Push(&s.entries, entry)
+		s.byTask[taskID] = entry
+	}
+	s.mu.Unlock()
+
+	// 唤醒调度协程重新计算等待时间
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run 调度循环
+// 等待最早的截止时间到期,到期后处理超时;登记新的截止时间时重新计算等待时间
+func (s *TimeoutScheduler) run() {
+	defer close(s.done)
+
+	for {
+		var wait <-chan time.Time
+		s.mu.Lock()
+		var due []string
+		now := s.clock.Now()
+		for len(s.entries) > 0 && !now.Before(s.entries[0].deadline) {
+			entry := heap.Pop(&s.entries).(*timeoutEntry)
+			delete(s.byTask, entry.taskID)
+			due = append(due, entry.taskID)
+		}
+		if len(s.entries) > 0 {
+			wait = s.clock.After(s.entries[0].deadline.Sub(now))
+		}
+		s.mu.Unlock()
+
+		for _, taskID := range due {
+			s.fire(taskID)
+		}
+		if len(due) > 0 {
+			// 处理超时期间可能登记了新的截止时间,重新计算
+			continue
+		}
+
+		select {
+		case <-wait:
+		case <-s.wake:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// fire 处理到期任务的自动催办和超时
+// 处理失败时按退避时间重新登记
+func (s *TimeoutScheduler) fire(taskID string) {
+	var failed error
+	if err := s.mgr.HandleReminders(taskID); err != nil {
+		failed = err
+		if s.onError != nil {
+			s.onError(taskID, err)
+		}
+	}
+	if err := s.mgr.HandleTimeout(taskID); err != nil {
+		failed = err
+		if s.onError != nil {
+			s.onError(taskID, err)
+		}
+	}
+	s.retry(taskID, failed)
+}
+
+// retry 处理失败时按指数退避重新登记任务,处理成功或任务不存在时清除失败次数
+// 处理期间已登记了更早的截止时间时保留原截止时间
+func (s *TimeoutScheduler) retry(taskID string, err error) {
+	s.mu.Lock()
+	if err == nil || stderrors.Is(err, errors.ErrTaskNotFound) {
+		delete(s.failures, taskID)
+		s.mu.Unlock()
+		return
+	}
+
+	s.failures[taskID]++
+	backoff := s.retryBackoff
+	for i := 1; i < s.failures[taskID] && backoff < s.maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.maxRetryBackoff {
+		backoff = s.maxRetryBackoff
+	}
+	deadline := s.clock.Now().Add(backoff)
+	if entry, exists := s.byTask[taskID]; exists && !deadline.Before(entry.deadline) {
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+
+	s.schedule(taskID, deadline)
+}
+
+// timeoutEntry 超时截止时间条目
+type timeoutEntry struct {
+	taskID   string
+	deadline time.Time
+	index    int // 在堆中的位置,由 timeoutHeap 维护
+}
+
+// timeoutHeap 按截止时间排序的最小堆(实现 heap.Interface)
+type timeoutHeap []*timeoutEntry
+
+func (h timeoutHeap) Len() int { return len(h) }
+
+func (h timeoutHeap) Less(i, j int) bool { return h[i].deadline.Before(h[j].deadline) }
+
+func (h timeoutHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timeoutHeap) Push(x interface{}) {
+	entry := x.(*timeoutEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *timeoutHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
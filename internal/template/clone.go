@@ -69,6 +69,8 @@ func (e *Edge) Clone() *Edge {
 		From:      e.From,
 		To:        e.To,
 		Condition: e.Condition,
+		Priority:  e.Priority,
+		Default:   e.Default,
 	}
 }
 
@@ -88,6 +90,9 @@ func (c *TemplateConfig) Clone() *TemplateConfig {
 		}
 	}
 
+	// 复制跳过规则
+	clone.SkipPolicy = c.SkipPolicy.Clone()
+
 	return clone
 }
 
@@ -0,0 +1,238 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Definition 模板定义
+// 模板的声明式描述,可以保存为 JSON 或 YAML 文件并纳入版本管理;
+// 节点配置按节点类型通过 NodeConfigCodec 编解码
+type Definition struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Version     int               `json:"version,omitempty"`
+	Nodes       []*NodeDefinition `json:"nodes"`
+	Edges       []*EdgeDefinition `json:"edges"`
+	Config      *ConfigDefinition `json:"config,omitempty"`
+}
+
+// NodeDefinition 节点定义
+type NodeDefinition struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Type   NodeType        `json:"type"`
+	Order  int             `json:"order,omitempty"`
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// EdgeDefinition 边定义
+type EdgeDefinition struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Condition string `json:"condition,omitempty"`
+	Priority  int    `json:"priority,omitempty"`
+	Default   bool   `json:"default,omitempty"`
+}
+
+// ConfigDefinition 模板全局配置定义
+type ConfigDefinition struct {
+	Webhooks   []*WebhookDefinition `json:"webhooks,omitempty"`
+	SkipPolicy *SkipPolicy          `json:"skip_policy,omitempty"`
+}
+
+// WebhookDefinition Webhook 配置定义
+type WebhookDefinition struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Auth    *AuthDefinition   `json:"auth,omitempty"`
+}
+
+// AuthDefinition 认证配置定义
+type AuthDefinition struct {
+	Type  string `json:"type"`
+	Token string `json:"token,omitempty"`
+	Key   string `json:"key,omitempty"`
+}
+
+// ToDefinition 将模板转换为模板定义
+// 节点按 Order 和 ID 排序,保证相同模板的输出一致
+func ToDefinition(tpl *Template, codec NodeConfigCodec) (*Definition, error) {
+	def := &Definition{
+		ID:          tpl.ID,
+		Name:        tpl.Name,
+		Description: tpl.Description,
+		Version:     tpl.Version,
+		Nodes:       make([]*NodeDefinition, 0, len(tpl.Nodes)),
+		Edges:       make([]*EdgeDefinition, 0, len(tpl.Edges)),
+	}
+
+	for _, node := range sortedNodes(tpl.Nodes) {
+		config, err := codec.Encode(node.Config)
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", node.ID, err)
+		}
+		def.Nodes = append(def.Nodes, &NodeDefinition{
+			ID:     node.ID,
+			Name:   node.Name,
+			Type:   node.Type,
+			Order:  node.Order,
+			Config: config,
+		})
+	}
+
+	for _, edge := range tpl.Edges {
+		def.Edges = append(def.Edges, &EdgeDefinition{
+			From: edge.From, To: edge.To, Condition: edge.Condition, Priority: edge.Priority, Default: edge.Default,
+		})
+	}
+
+	if tpl.Config != nil {
+		def.Config = &ConfigDefinition{SkipPolicy: tpl.Config.SkipPolicy.Clone()}
+		for _, w := range tpl.Config.Webhooks {
+			webhook := &WebhookDefinition{URL: w.URL, Method: w.Method, Headers: w.Headers}
+			if w.Auth != nil {
+				webhook.Auth = &AuthDefinition{Type: w.Auth.Type, Token: w.Auth.Token, Key: w.Auth.Key}
+			}
+			def.Config.Webhooks = append(def.Config.Webhooks, webhook)
+		}
+	}
+
+	return def, nil
+}
+
+// FromDefinition 将模板定义转换为模板
+// 只做结构转换,不验证模板的有效性(创建模板时由 TemplateManager 验证)
+func FromDefinition(def *Definition, codec NodeConfigCodec) (*Template, error) {
+	tpl := &Template{
+		ID:          def.ID,
+		Name:        def.Name,
+		Description: def.Description,
+		Version:     def.Version,
+		Nodes:       make(map[string]*Node, len(def.Nodes)),
+		Edges:       make([]*Edge, 0, len(def.Edges)),
+	}
+
+	for _, n := range def.Nodes {
+		if _, exists := tpl.Nodes[n.ID]; exists {
+			return nil, fmt.Errorf("duplicate node %q", n.ID)
+		}
+		config, err := codec.Decode(n.Type, n.Config)
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", n.ID, err)
+		}
+		tpl.Nodes[n.ID] = &Node{ID: n.ID, Name: n.Name, Type: n.Type, Order: n.Order, Config: config}
+	}
+
+	for _, e := range def.Edges {
+		tpl.Edges = append(tpl.Edges, &Edge{
+			From: e.From, To: e.To, Condition: e.Condition, Priority: e.Priority, Default: e.Default,
+		})
+	}
+
+	if def.Config != nil {
+		tpl.Config = &TemplateConfig{SkipPolicy: def.Config.SkipPolicy.Clone()}
+		for _, w := range def.Config.Webhooks {
+			webhook := &WebhookConfig{URL: w.URL, Method: w.Method, Headers: w.Headers}
+			if w.Auth != nil {
+				webhook.Auth = &AuthConfig{Type: w.Auth.Type, Token: w.Auth.Token, Key: w.Auth.Key}
+			}
+			tpl.Config.Webhooks = append(tpl.Config.Webhooks, webhook)
+		}
+	}
+
+	return tpl, nil
+}
+
+// MarshalJSON 将模板编码为 JSON 格式的模板定义
+func MarshalJSON(tpl *Template, codec NodeConfigCodec) ([]byte, error) {
+	def, err := ToDefinition(tpl, codec)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(def, "", "  ")
+}
+
+// UnmarshalJSON 从 JSON 格式的模板定义解码模板
+// 模板定义中出现未知字段时返回错误,避免拼写错误的配置被静默忽略
+func UnmarshalJSON(data []byte, codec NodeConfigCodec) (*Template, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var def Definition
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("invalid template definition: %w", err)
+	}
+	return FromDefinition(&def, codec)
+}
+
+// MarshalYAML 将模板编码为 YAML 格式的模板定义
+// 字段名和字段顺序与 JSON 格式一致
+func MarshalYAML(tpl *Template, codec NodeConfigCodec) ([]byte, error) {
+	data, err := MarshalJSON(tpl, codec)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON 是 YAML 的子集,解析为 yaml.Node 可以保留字段顺序,再清除 JSON 的引号和括号样式
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	clearYAMLStyle(&doc)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalYAML 从 YAML 格式的模板定义解码模板
+// YAML 先转换为 JSON,再按 JSON 格式解码,两种格式的字段完全一致
+func UnmarshalYAML(data []byte, codec NodeConfigCodec) (*Template, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid template definition: %w", err)
+	}
+
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template definition: %w", err)
+	}
+	return UnmarshalJSON(jsonData, codec)
+}
+
+// clearYAMLStyle 递归清除 YAML 节点的样式,使用默认的块样式输出
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// sortedNodes 按 Order 和 ID 排序返回节点列表
+func sortedNodes(nodes map[string]*Node) []*Node {
+	result := make([]*Node, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, node)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Order != result[j].Order {
+			return result[i].Order < result[j].Order
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
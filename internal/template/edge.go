@@ -1,10 +1,64 @@
 package template
 
+import "fmt"
+
 // Edge 表示节点间的连接
 // 定义审批流程中节点之间的流转关系,支持条件分支
 type Edge struct {
 	From      string // 源节点 ID
 	To        string // 目标节点 ID
-	Condition string // 条件表达式(可选,用于条件节点)
+	Condition string // 条件表达式(可选,用于排他网关,语法与表达式条件相同)
+	Priority  int    // 排他网关中条件的评估顺序,数值小的先评估,相同时按边的顺序
+	Default   bool   // 是否为排他网关的默认出边(没有条件匹配时使用)
 }
 
+
+// isExclusiveGateway 返回节点是否为排他网关
+func isExclusiveGateway(node *Node) bool {
+	if node == nil {
+		return false
+	}
+	gateway, ok := node.Config.(ExclusiveGatewayAccessor)
+	return ok && gateway.IsExclusiveGateway()
+}
+
+// gatewayEdgeProblem 排他网关出边的问题
+type gatewayEdgeProblem struct {
+	edgeIndex int // 问题所在边的下标(与具体边无关时为 -1)
+	message   string
+}
+
+// checkGatewayEdges 检查排他网关的出边
+// 必须有且仅有一条默认出边,默认出边不能有条件,其他出边必须有有效的条件
+func checkGatewayEdges(tpl *Template, node *Node) []gatewayEdgeProblem {
+	gateway := node.Config.(ExclusiveGatewayAccessor)
+
+	var problems []gatewayEdgeProblem
+	defaults := 0
+	for i, edge := range tpl.Edges {
+		if edge == nil || edge.From != node.ID {
+			continue
+		}
+		switch {
+		case edge.Default:
+			defaults++
+			if edge.Condition != "" {
+				problems = append(problems, gatewayEdgeProblem{i, "default edge of exclusive gateway must not have a condition"})
+			}
+		case edge.Condition == "":
+			problems = append(problems, gatewayEdgeProblem{i, "edge of exclusive gateway requires a condition or must be the default edge"})
+		default:
+			if err := gateway.ValidateEdgeCondition(edge.Condition); err != nil {
+				problems = append(problems, gatewayEdgeProblem{i, err.Error()})
+			}
+		}
+	}
+
+	switch {
+	case defaults == 0:
+		problems = append(problems, gatewayEdgeProblem{-1, "exclusive gateway requires a default edge"})
+	case defaults > 1:
+		problems = append(problems, gatewayEdgeProblem{-1, fmt.Sprintf("exclusive gateway has %d default edges, want 1", defaults)})
+	}
+	return problems
+}
@@ -1,25 +1,37 @@
 package template
 
 import (
+	stderrors "errors"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
 	"github.com/mautops/approval-kit/internal/errors"
 )
 
-// memoryTemplateManager 内存实现的模板管理器
+// memoryTemplateManager 模板管理器
+// 负责模板的验证和版本号分配,模板数据保存在 TemplateStore 中(默认为内存存储)
 type memoryTemplateManager struct {
-	mu        sync.RWMutex
-	templates map[string]map[int]*Template // templateID -> version -> Template
+	mu               sync.Mutex    // 串行化本进程内的写操作
+	store            TemplateStore // 模板存储
+	strictValidation bool          // 创建和更新时是否执行深度验证
 }
 
-// NewTemplateManager 创建新的模板管理器实例(内存实现)
-func NewTemplateManager() TemplateManager {
-	return &memoryTemplateManager{
-		templates: make(map[string]map[int]*Template),
+// maxUpdateAttempts 更新模板时分配版本号的最大尝试次数
+// 多个服务实例共享存储时,同时更新同一模板可能分配到相同的版本号
+const maxUpdateAttempts = 3
+
+// NewTemplateManager 创建新的模板管理器实例
+// opts: 可选配置(如 WithTemplateStore),未设置存储时使用内存存储
+func NewTemplateManager(opts ...ManagerOption) TemplateManager {
+	m := &memoryTemplateManager{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.store == nil {
+		m.store = NewMemoryTemplateStore()
 	}
+	return m
 }
 
 // Create 创建新的审批模板
@@ -29,28 +41,17 @@ func (m *memoryTemplateManager) Create(tpl *Template) error {
 	}
 
 	// 验证模板
-	if err := tpl.Validate(); err != nil {
-		return errors.ErrInvalidTemplate
+	if err := m.validate(tpl); err != nil {
+		return err
 	}
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// 检查是否已存在
-	if versions, exists := m.templates[tpl.ID]; exists {
-		if _, versionExists := versions[tpl.Version]; versionExists {
-			return fmt.Errorf("template %q version %d already exists", tpl.ID, tpl.Version)
-		}
-	}
-
-	// 创建模板副本(避免外部修改)
-	templateCopy := tpl.Clone()
-
-	// 存储模板
-	if m.templates[tpl.ID] == nil {
-		m.templates[tpl.ID] = make(map[int]*Template)
+	// 存储模板副本(避免外部修改),版本已存在时存储返回错误
+	if err := m.store.SaveVersion(tpl.Clone()); err != nil {
+		return fmt.Errorf("failed to create template %q: %w", tpl.ID, err)
 	}
-	m.templates[tpl.ID][tpl.Version] = templateCopy
 
 	return nil
 }
@@ -71,78 +72,53 @@ func (m *memoryTemplateManager) Update(id string, tpl *Template) error {
 	tpl.ID = id
 
 	// 验证模板
-	if err := tpl.Validate(); err != nil {
-		return errors.ErrInvalidTemplate
+	if err := m.validate(tpl); err != nil {
+		return err
 	}
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// 检查模板是否存在
-	versions, exists := m.templates[id]
-	if !exists {
-		return fmt.Errorf("template %q not found", id)
-	}
-
-	// 自动递增版本号(忽略传入的版本号)
-	maxVersion := 0
-	for v := range versions {
-		if v > maxVersion {
-			maxVersion = v
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		// 检查模板是否存在
+		var versions []int
+		versions, err = m.store.ListVersions(id)
+		if err != nil {
+			return err
 		}
-	}
-	newVersion := maxVersion + 1
 
-	// 创建模板副本
-	templateCopy := tpl.Clone()
-	templateCopy.ID = id
-	templateCopy.Version = newVersion
-	templateCopy.UpdatedAt = time.Now()
+		// 自动递增版本号(忽略传入的版本号)
+		newVersion := versions[len(versions)-1] + 1
 
-	// 如果 CreatedAt 为零值,设置为当前时间
-	if templateCopy.CreatedAt.IsZero() {
-		templateCopy.CreatedAt = time.Now()
-	}
+		// 创建模板副本
+		templateCopy := tpl.Clone()
+		templateCopy.ID = id
+		templateCopy.Version = newVersion
+		templateCopy.UpdatedAt = time.Now()
+
+		// 如果 CreatedAt 为零值,设置为当前时间
+		if templateCopy.CreatedAt.IsZero() {
+			templateCopy.CreatedAt = time.Now()
+		}
 
-	// 存储新版本
-	m.templates[id][newVersion] = templateCopy
+		// 存储新版本,其他实例已保存相同版本号时重新分配
+		err = m.store.SaveVersion(templateCopy)
+		if !stderrors.Is(err, errors.ErrTemplateVersionExists) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update template %q: %w", id, err)
+	}
 
 	return nil
 }
 
 // Get 获取指定版本的审批模板
+// version 为 0 时返回最新版本
 func (m *memoryTemplateManager) Get(id string, version int) (*Template, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	versions, exists := m.templates[id]
-	if !exists {
-		return nil, fmt.Errorf("template %q not found", id)
-	}
-
-	// 如果 version 为 0,返回最新版本
-	if version == 0 {
-		var latestVersion int
-		var latestTemplate *Template
-		for v, t := range versions {
-			if v > latestVersion {
-				latestVersion = v
-				latestTemplate = t
-			}
-		}
-		if latestTemplate == nil {
-			return nil, fmt.Errorf("template %q has no versions", id)
-		}
-		return latestTemplate.Clone(), nil
-	}
-
-	// 返回指定版本
-	tpl, exists := versions[version]
-	if !exists {
-		return nil, fmt.Errorf("template %q version %d not found", id, version)
-	}
-
-	return tpl.Clone(), nil
+	return m.store.Get(id, version)
 }
 
 // Delete 删除审批模板
@@ -151,38 +127,23 @@ func (m *memoryTemplateManager) Delete(id string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// 检查模板是否存在
-	_, exists := m.templates[id]
-	if !exists {
-		return fmt.Errorf("template %q not found", id)
-	}
-
-	// 删除所有版本
-	delete(m.templates, id)
-
-	return nil
+	return m.store.Delete(id)
 }
 
 // ListVersions 列出模板的所有版本号
 // 返回版本号列表,按版本号升序排列
 func (m *memoryTemplateManager) ListVersions(id string) ([]int, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	return m.store.ListVersions(id)
+}
 
-	// 检查模板是否存在
-	versions, exists := m.templates[id]
-	if !exists {
-		return nil, fmt.Errorf("template %q not found", id)
+// validate 验证模板
+// 启用严格验证时使用 ValidateTemplate 深度验证,返回包含所有问题的 *ValidationError
+func (m *memoryTemplateManager) validate(tpl *Template) error {
+	if m.strictValidation {
+		return ValidateTemplate(tpl).Err()
 	}
-
-	// 收集所有版本号
-	versionList := make([]int, 0, len(versions))
-	for v := range versions {
-		versionList = append(versionList, v)
+	if err := tpl.Validate(); err != nil {
+		return errors.ErrInvalidTemplate
 	}
-
-	// 按版本号升序排序(使用标准库排序)
-	sort.Ints(versionList)
-
-	return versionList, nil
+	return nil
 }
@@ -43,6 +43,60 @@ type ApprovalNodeConfigAccessor interface {
 	GetRejectTargetNode() string
 }
 
+// TimeoutPolicyAccessor 超时策略访问接口
+// 用于在不导入 node 包的情况下访问审批节点配置的超时策略
+type TimeoutPolicyAccessor interface {
+	// GetTimeoutActions 返回按触发偏移升序排列的超时动作,未配置超时策略时返回 nil
+	GetTimeoutActions() []TimeoutActionSpec
+}
+
+// TimeoutActionSpec 超时动作描述
+type TimeoutActionSpec struct {
+	Type       string        // 动作类型: "notify", "escalate", "auto_approve", "auto_reject", "terminate"
+	After      time.Duration // 相对节点激活时间的触发偏移
+	EscalateTo []string      // 升级目标审批人(仅 escalate 使用)
+}
+
+// ReminderPolicyAccessor 自动催办配置访问接口
+// 审批节点配置实现此接口时,任务管理器按配置自动催办尚未审批的审批人
+type ReminderPolicyAccessor interface {
+	// GetReminderSchedule 返回自动催办计划,未配置自动催办时返回 nil
+	GetReminderSchedule() *ReminderSchedule
+}
+
+// ReminderSchedule 自动催办计划
+type ReminderSchedule struct {
+	Interval time.Duration // 催办间隔,从节点激活时间开始计算
+	MaxTimes int           // 最多自动催办次数,0 表示不限次数
+}
+
+// ConditionNodeConfigAccessor 条件节点配置访问接口
+// 用于在不导入 node 包的情况下访问条件节点的分支目标
+type ConditionNodeConfigAccessor interface {
+	NodeConfig
+	// GetTrueNodeID 返回条件为 true 时跳转的节点 ID
+	GetTrueNodeID() string
+	// GetFalseNodeID 返回条件为 false 时跳转的节点 ID
+	GetFalseNodeID() string
+}
+
+// ExclusiveGatewayAccessor 排他网关配置访问接口
+// 用于在不导入 node 包的情况下识别排他网关并检查出边上的条件表达式
+type ExclusiveGatewayAccessor interface {
+	NodeConfig
+	// IsExclusiveGateway 返回节点是否按出边条件选择分支
+	IsExclusiveGateway() bool
+	// ValidateEdgeCondition 检查出边上的条件表达式
+	ValidateEdgeCondition(condition string) error
+}
+
+// ExpressionAccessor 条件表达式检查接口
+// 用于在不导入 node 包的情况下在基础模板验证中编译节点配置中的条件表达式
+type ExpressionAccessor interface {
+	// ValidateExpressions 编译节点配置中的所有条件表达式
+	ValidateExpressions() error
+}
+
 // OperationPermissionsAccessor 操作权限访问接口
 // 用于在不导入 node 包的情况下访问操作权限配置
 type OperationPermissionsAccessor interface {
@@ -0,0 +1,100 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// SkipAction 跳过规则的处理方式
+type SkipAction string
+
+const (
+	// SkipActionNone 不处理(默认)
+	SkipActionNone SkipAction = ""
+
+	// SkipActionAutoApprove 审批人视为同意,由系统记录审批结果
+	SkipActionAutoApprove SkipAction = "auto_approve"
+
+	// SkipActionEscalate 审批人替换为其上级(需要流程引擎配置组织架构目录)
+	SkipActionEscalate SkipAction = "escalate"
+
+	// SkipActionSkip 跳过节点,节点视为通过
+	SkipActionSkip SkipAction = "skip"
+)
+
+// SkipPolicy 审批人去重和跳过规则
+// 流程引擎在审批节点激活时应用: 可以在审批节点配置中设置,也可以在模板全局配置中为所有审批节点设置,
+// 节点配置优先于模板全局配置(整体替换,不按字段合并)
+// 应用规则时生成的审批记录的审批人为系统(task.SystemApprover),审批意见说明原因
+type SkipPolicy struct {
+	// DuplicateApprover 审批人已在之前完成的节点同意过时的处理: SkipActionNone 或 SkipActionAutoApprove
+	DuplicateApprover SkipAction `json:"duplicate_approver,omitempty"`
+
+	// InitiatorApprover 审批人是任务发起人时的处理: SkipActionNone、SkipActionAutoApprove 或 SkipActionEscalate
+	InitiatorApprover SkipAction `json:"initiator_approver,omitempty"`
+
+	// EmptyApprovers 获取到的审批人列表为空时的处理: SkipActionNone(返回错误)或 SkipActionSkip
+	EmptyApprovers SkipAction `json:"empty_approvers,omitempty"`
+}
+
+// SkipPolicyAccessor 跳过规则访问接口
+// 用于在不导入 node 包的情况下访问审批节点配置的跳过规则
+type SkipPolicyAccessor interface {
+	// GetSkipPolicy 返回节点的跳过规则,未配置时返回 nil
+	GetSkipPolicy() *SkipPolicy
+}
+
+// Validate 验证跳过规则的有效性
+func (p *SkipPolicy) Validate() error {
+	rules := []struct {
+		name    string
+		action  SkipAction
+		allowed []SkipAction
+	}{
+		{"duplicate_approver", p.DuplicateApprover, []SkipAction{SkipActionAutoApprove}},
+		{"initiator_approver", p.InitiatorApprover, []SkipAction{SkipActionAutoApprove, SkipActionEscalate}},
+		{"empty_approvers", p.EmptyApprovers, []SkipAction{SkipActionSkip}},
+	}
+	for _, rule := range rules {
+		if rule.action == SkipActionNone {
+			continue
+		}
+		valid := false
+		for _, allowed := range rule.allowed {
+			if rule.action == allowed {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("%w: invalid %s skip action: %q", errors.ErrInvalidTemplate, rule.name, rule.action)
+		}
+	}
+	return nil
+}
+
+// Clone 创建跳过规则的副本
+func (p *SkipPolicy) Clone() *SkipPolicy {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	return &clone
+}
+
+// EffectiveSkipPolicy 返回审批节点生效的跳过规则
+// 节点配置了跳过规则时使用节点的规则,否则使用模板全局配置的规则,都未配置时返回 nil
+func EffectiveSkipPolicy(tpl *Template, node *Node) *SkipPolicy {
+	if node != nil {
+		if accessor, ok := node.Config.(SkipPolicyAccessor); ok {
+			if policy := accessor.GetSkipPolicy(); policy != nil {
+				return policy
+			}
+		}
+	}
+	if tpl != nil && tpl.Config != nil {
+		return tpl.Config.SkipPolicy
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// TemplateStore 模板持久化存储接口
+// 模板的每个版本一经保存不可修改,更新模板即保存一个新版本
+type TemplateStore interface {
+	// SaveVersion 保存模板的一个版本
+	// tpl: 模板对象,按 tpl.ID 和 tpl.Version 保存
+	// 返回: 错误信息,版本已存在时返回 ErrTemplateVersionExists
+	SaveVersion(tpl *Template) error
+
+	// Get 获取指定版本的模板
+	// id: 模板 ID
+	// version: 模板版本号,0 表示获取最新版本
+	// 返回: 模板对象(副本)和错误信息,模板或版本不存在时返回 ErrTemplateNotFound
+	Get(id string, version int) (*Template, error)
+
+	// ListVersions 列出模板的所有版本号
+	// id: 模板 ID
+	// 返回: 版本号列表(按版本号升序排列)和错误信息,模板不存在时返回 ErrTemplateNotFound
+	ListVersions(id string) ([]int, error)
+
+	// Delete 删除模板的所有版本
+	// id: 模板 ID
+	// 返回: 错误信息,模板不存在时返回 ErrTemplateNotFound
+	Delete(id string) error
+}
+
+// NodeConfigCodec 节点配置编解码器
+// 节点配置是接口类型,持久化时需要按节点类型编解码为具体的配置结构
+type NodeConfigCodec interface {
+	// Encode 将节点配置编码为 JSON
+	Encode(config NodeConfig) (json.RawMessage, error)
+
+	// Decode 按节点类型将 JSON 解码为节点配置
+	Decode(nodeType NodeType, data json.RawMessage) (NodeConfig, error)
+}
+
+// ManagerOption 模板管理器可选配置
+type ManagerOption func(*memoryTemplateManager)
+
+// WithTemplateStore 设置模板存储
+// 设置后模板的所有版本保存在指定的存储中,多个服务实例可以共享同一份模板定义
+// 未设置时模板只保存在内存中
+func WithTemplateStore(store TemplateStore) ManagerOption {
+	return func(m *memoryTemplateManager) {
+		m.store = store
+	}
+}
+
+// WithStrictValidation 启用严格验证
+// 启用后创建和更新模板时使用 ValidateTemplate 深度验证,存在错误级别的问题时返回 *ValidationError
+// 未启用时只执行 Template.Validate 的基本验证
+func WithStrictValidation() ManagerOption {
+	return func(m *memoryTemplateManager) {
+		m.strictValidation = true
+	}
+}
+
+// memoryTemplateStore 内存实现的模板存储
+type memoryTemplateStore struct {
+	mu        sync.RWMutex
+	templates map[string]map[int]*Template // templateID -> version -> Template
+}
+
+// NewMemoryTemplateStore 创建内存模板存储
+func NewMemoryTemplateStore() TemplateStore {
+	return &memoryTemplateStore{
+		templates: make(map[string]map[int]*Template),
+	}
+}
+
+// SaveVersion 保存模板的一个版本
+func (s *memoryTemplateStore) SaveVersion(tpl *Template) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.templates[tpl.ID][tpl.Version]; exists {
+		return fmt.Errorf("template %q version %d: %w", tpl.ID, tpl.Version, errors.ErrTemplateVersionExists)
+	}
+	if s.templates[tpl.ID] == nil {
+		s.templates[tpl.ID] = make(map[int]*Template)
+	}
+	s.templates[tpl.ID][tpl.Version] = tpl.Clone()
+	return nil
+}
+
+// Get 获取指定版本的模板
+func (s *memoryTemplateStore) Get(id string, version int) (*Template, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions, exists := s.templates[id]
+	if !exists || len(versions) == 0 {
+		return nil, fmt.Errorf("template %q: %w", id, errors.ErrTemplateNotFound)
+	}
+
+	// 如果 version 为 0,返回最新版本
+	if version == 0 {
+		for v := range versions {
+			if v > version {
+				version = v
+			}
+		}
+	}
+
+	tpl, exists := versions[version]
+	if !exists {
+		return nil, fmt.Errorf("template %q version %d: %w", id, version, errors.ErrTemplateNotFound)
+	}
+	return tpl.Clone(), nil
+}
+
+// ListVersions 列出模板的所有版本号
+func (s *memoryTemplateStore) ListVersions(id string) ([]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions, exists := s.templates[id]
+	if !exists {
+		return nil, fmt.Errorf("template %q: %w", id, errors.ErrTemplateNotFound)
+	}
+
+	versionList := make([]int, 0, len(versions))
+	for v := range versions {
+		versionList = append(versionList, v)
+	}
+	sort.Ints(versionList)
+	return versionList, nil
+}
+
+// Delete 删除模板的所有版本
+func (s *memoryTemplateStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.templates[id]; !exists {
+		return fmt.Errorf("template %q: %w", id, errors.ErrTemplateNotFound)
+	}
+	delete(s.templates, id)
+	return nil
+}
@@ -33,6 +33,9 @@ type TemplateConfig struct {
 	// Webhook 配置
 	Webhooks []*WebhookConfig
 
+	// SkipPolicy 所有审批节点默认的审批人去重和跳过规则(可选),审批节点配置的规则优先
+	SkipPolicy *SkipPolicy
+
 	// 其他全局配置可以在这里扩展
 }
 
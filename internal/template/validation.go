@@ -11,6 +11,10 @@ import (
 // 1. ID 和 Name 不能为空
 // 2. 必须有且仅有一个开始节点
 // 3. 所有边引用的节点必须存在
+// 4. 条件节点中的表达式可以编译
+// 5. 排他网关有且仅有一条默认出边,其他出边有有效的条件
+// 6. 全局配置(如果设置)有效: 跳过规则和 Webhook 配置
+// 需要检查节点配置和流程结构时使用 ValidateTemplate
 func (t *Template) Validate() error {
 	// 验证 ID
 	if t.ID == "" {
@@ -56,6 +60,35 @@ func (t *Template) Validate() error {
 		}
 	}
 
+	// 编译条件节点中的表达式
+	for _, node := range sortedNodes(t.Nodes) {
+		if accessor, ok := node.Config.(ExpressionAccessor); ok {
+			if err := accessor.ValidateExpressions(); err != nil {
+				return fmt.Errorf("%w: node %q: %v", errors.ErrInvalidTemplate, node.ID, err)
+			}
+		}
+	}
+
+	// 验证排他网关的出边
+	for _, node := range sortedNodes(t.Nodes) {
+		if !isExclusiveGateway(node) {
+			continue
+		}
+		if problems := checkGatewayEdges(t, node); len(problems) > 0 {
+			if problems[0].edgeIndex >= 0 {
+				return fmt.Errorf("%w: node %q: edge[%d]: %s", errors.ErrInvalidTemplate, node.ID, problems[0].edgeIndex, problems[0].message)
+			}
+			return fmt.Errorf("%w: node %q: %s", errors.ErrInvalidTemplate, node.ID, problems[0].message)
+		}
+	}
+
+	// 验证全局配置
+	if t.Config != nil {
+		if err := t.Config.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,369 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// Severity 验证问题的严重程度
+type Severity string
+
+const (
+	// SeverityError 错误: 模板无法正确执行
+	SeverityError Severity = "error"
+
+	// SeverityWarning 警告: 模板可以执行,但结构可能不符合预期
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationIssue 模板验证发现的问题
+type ValidationIssue struct {
+	Severity  Severity // 严重程度
+	NodeID    string   // 问题所在节点 ID(与节点无关时为空)
+	EdgeIndex int      // 问题所在边在 Edges 中的下标(与边无关时为 -1)
+	Message   string   // 问题描述
+}
+
+// String 返回带位置信息的问题描述
+func (i *ValidationIssue) String() string {
+	var location string
+	switch {
+	case i.EdgeIndex >= 0:
+		location = fmt.Sprintf("edge[%d]: ", i.EdgeIndex)
+	case i.NodeID != "":
+		location = fmt.Sprintf("node %q: ", i.NodeID)
+	}
+	return fmt.Sprintf("%s: %s%s", i.Severity, location, i.Message)
+}
+
+// ValidationResult 模板验证结果
+type ValidationResult struct {
+	Issues []*ValidationIssue // 发现的所有问题,按检查顺序排列
+}
+
+// Valid 返回模板是否没有错误级别的问题
+func (r *ValidationResult) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+// Errors 返回错误级别的问题
+func (r *ValidationResult) Errors() []*ValidationIssue {
+	return r.filter(SeverityError)
+}
+
+// Warnings 返回警告级别的问题
+func (r *ValidationResult) Warnings() []*ValidationIssue {
+	return r.filter(SeverityWarning)
+}
+
+// Err 存在错误级别的问题时返回 *ValidationError,否则返回 nil
+func (r *ValidationResult) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return &ValidationError{Issues: r.Issues}
+}
+
+func (r *ValidationResult) filter(severity Severity) []*ValidationIssue {
+	var result []*ValidationIssue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// ValidationError 模板验证错误
+// 包含验证发现的所有问题,可以通过 errors.Is(err, ErrInvalidTemplate) 判断
+type ValidationError struct {
+	Issues []*ValidationIssue
+}
+
+// Error 返回所有错误级别问题的描述
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.Severity == SeverityError {
+			messages = append(messages, issue.String())
+		}
+	}
+	return fmt.Sprintf("%v: %s", errors.ErrInvalidTemplate, strings.Join(messages, "; "))
+}
+
+// Unwrap 返回 ErrInvalidTemplate
+func (e *ValidationError) Unwrap() error {
+	return errors.ErrInvalidTemplate
+}
+
+// ValidateTemplate 深度验证模板
+// 与 Template.Validate 不同,不会在第一个问题处停止,而是返回发现的所有问题:
+// 1. 基本信息: ID 和 Name 不能为空,必须有且仅有一个开始节点,至少有一个结束节点
+// 2. 边: 引用的节点必须存在
+// 3. 节点配置: 审批节点和条件节点必须有配置且 NodeConfig.Validate() 通过,
+// 条件分支目标和拒绝后跳转目标必须存在,排他网关有且仅有一条默认出边且其他出边有有效的条件
+// 4. 流程结构: 从开始节点可以到达结束节点,非结束节点必须有后续节点,
+// 所有节点都应从开始节点可达,流程中不应有环
+// 5. 全局配置: 跳过规则和 Webhook 配置(如果设置)有效
+func ValidateTemplate(tpl *Template) *ValidationResult {
+	v := &templateValidator{tpl: tpl, result: &ValidationResult{}}
+	v.validate()
+	return v.result
+}
+
+// templateValidator 模板深度验证器
+type templateValidator struct {
+	tpl    *Template
+	result *ValidationResult
+}
+
+func (v *templateValidator) add(severity Severity, nodeID string, edgeIndex int, format string, args ...interface{}) {
+	v.result.Issues = append(v.result.Issues, &ValidationIssue{
+		Severity:  severity,
+		NodeID:    nodeID,
+		EdgeIndex: edgeIndex,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *templateValidator) validate() {
+	tpl := v.tpl
+	if tpl.ID == "" {
+		v.add(SeverityError, "", -1, "template ID is required")
+	}
+	if tpl.Name == "" {
+		v.add(SeverityError, "", -1, "template Name is required")
+	}
+	if len(tpl.Nodes) == 0 {
+		v.add(SeverityError, "", -1, "template must have at least one node")
+		return
+	}
+
+	nodes := v.validateNodes()
+	v.validateEdges()
+	v.validateConfigs(nodes)
+	v.validateFlow(nodes)
+
+	if tpl.Config != nil {
+		if err := tpl.Config.Validate(); err != nil {
+			v.add(SeverityError, "", -1, "invalid template config: %v", err)
+		}
+	}
+}
+
+// validateNodes 验证节点定义,返回按 Order 和 ID 排序的有效节点
+func (v *templateValidator) validateNodes() []*Node {
+	valid := make(map[string]*Node, len(v.tpl.Nodes))
+	for id, node := range v.tpl.Nodes {
+		if node == nil {
+			v.add(SeverityError, id, -1, "node definition is nil")
+			continue
+		}
+		if node.ID != id {
+			v.add(SeverityError, id, -1, "node ID %q does not match its key", node.ID)
+		}
+		valid[id] = node
+	}
+	nodes := sortedNodes(valid)
+
+	startCount, endCount := 0, 0
+	for _, node := range nodes {
+		switch node.Type {
+		case NodeTypeStart:
+			startCount++
+		case NodeTypeEnd:
+			endCount++
+		}
+	}
+	if startCount != 1 {
+		v.add(SeverityError, "", -1, "template must have exactly one start node, found %d", startCount)
+	}
+	if endCount == 0 {
+		v.add(SeverityError, "", -1, "template must have at least one end node")
+	}
+	return nodes
+}
+
+// validateEdges 验证所有边引用的节点存在
+func (v *templateValidator) validateEdges() {
+	for i, edge := range v.tpl.Edges {
+		if edge == nil {
+			v.add(SeverityError, "", i, "edge definition is nil")
+			continue
+		}
+		if !v.nodeExists(edge.From) {
+			v.add(SeverityError, "", i, "references non-existent node: %q", edge.From)
+		}
+		if !v.nodeExists(edge.To) {
+			v.add(SeverityError, "", i, "references non-existent node: %q", edge.To)
+		}
+	}
+}
+
+// validateConfigs 验证节点配置及配置中引用的节点
+func (v *templateValidator) validateConfigs(nodes []*Node) {
+	for _, node := range nodes {
+		if node.Config == nil {
+			if node.Type == NodeTypeApproval || node.Type == NodeTypeCondition {
+				v.add(SeverityError, node.ID, -1, "%s node requires a config", node.Type)
+			}
+			continue
+		}
+
+		if node.Config.NodeType() != node.Type {
+			v.add(SeverityError, node.ID, -1, "config is for node type %q, want %q", node.Config.NodeType(), node.Type)
+		}
+		if err := node.Config.Validate(); err != nil {
+			v.add(SeverityError, node.ID, -1, "invalid config: %v", err)
+		}
+
+		if accessor, ok := node.Config.(ConditionNodeConfigAccessor); ok {
+			for _, target := range []string{accessor.GetTrueNodeID(), accessor.GetFalseNodeID()} {
+				if target != "" && !v.nodeExists(target) {
+					v.add(SeverityError, node.ID, -1, "condition branch references non-existent node: %q", target)
+				}
+			}
+		}
+		if isExclusiveGateway(node) {
+			for _, problem := range checkGatewayEdges(v.tpl, node) {
+				v.add(SeverityError, node.ID, problem.edgeIndex, "%s", problem.message)
+			}
+		}
+		if accessor, ok := node.Config.(ApprovalNodeConfigAccessor); ok {
+			if target := accessor.GetRejectTargetNode(); target != "" && !v.nodeExists(target) {
+				v.add(SeverityError, node.ID, -1, "reject target references non-existent node: %q", target)
+			}
+		}
+	}
+}
+
+// validateFlow 验证流程结构: 出边、可达性和环
+func (v *templateValidator) validateFlow(nodes []*Node) {
+	successors := v.successors(nodes)
+
+	for _, node := range nodes {
+		switch {
+		case node.Type == NodeTypeEnd:
+			if len(successors[node.ID]) > 0 {
+				v.add(SeverityWarning, node.ID, -1, "end node has outgoing edges that are never followed")
+			}
+		case len(successors[node.ID]) == 0:
+			v.add(SeverityError, node.ID, -1, "node has no outgoing edge, flow cannot continue")
+		case node.Type != NodeTypeCondition && len(successors[node.ID]) > 1:
+			v.add(SeverityError, node.ID, -1, "node has %d outgoing edges, use a condition node to choose a branch", len(successors[node.ID]))
+		}
+	}
+
+	start := findNodeOfType(nodes, NodeTypeStart)
+	if start == nil {
+		return
+	}
+
+	// 从开始节点出发的可达性
+	reachable := map[string]bool{start.ID: true}
+	queue := []string{start.ID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range successors[current] {
+			if !reachable[next] {
+				reachable[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	endReachable := false
+	for _, node := range nodes {
+		if !reachable[node.ID] {
+			v.add(SeverityWarning, node.ID, -1, "node is not reachable from start node %q", start.ID)
+		} else if node.Type == NodeTypeEnd {
+			endReachable = true
+		}
+	}
+	if !endReachable {
+		v.add(SeverityError, start.ID, -1, "no end node is reachable from start node")
+	}
+
+	v.detectCycles(start.ID, successors)
+}
+
+// detectCycles 从开始节点深度优先搜索,发现回边时报告环
+func (v *templateValidator) detectCycles(startID string, successors map[string][]string) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(nodeID string)
+	visit = func(nodeID string) {
+		state[nodeID] = visiting
+		path = append(path, nodeID)
+		for _, next := range successors[nodeID] {
+			switch state[next] {
+			case visiting:
+				// 截取环上的节点: 路径中从 next 到当前节点的部分
+				start := len(path) - 1
+				for path[start] != next {
+					start--
+				}
+				cycle := append(append([]string{}, path[start:]...), next)
+				v.add(SeverityWarning, nodeID, -1, "flow contains a cycle: %s", strings.Join(cycle, " -> "))
+			case 0:
+				visit(next)
+			}
+		}
+		path = path[:len(path)-1]
+		state[nodeID] = visited
+	}
+	visit(startID)
+}
+
+// successors 返回每个节点的后续节点
+// 条件节点的后续节点为条件分支目标和出边目标,其他节点为出边目标
+func (v *templateValidator) successors(nodes []*Node) map[string][]string {
+	result := make(map[string][]string)
+	add := func(from, to string) {
+		if !v.nodeExists(to) {
+			return
+		}
+		for _, existing := range result[from] {
+			if existing == to {
+				return
+			}
+		}
+		result[from] = append(result[from], to)
+	}
+
+	for _, node := range nodes {
+		if accessor, ok := node.Config.(ConditionNodeConfigAccessor); ok {
+			add(node.ID, accessor.GetTrueNodeID())
+			add(node.ID, accessor.GetFalseNodeID())
+		}
+	}
+	for _, edge := range v.tpl.Edges {
+		if edge != nil && v.nodeExists(edge.From) {
+			add(edge.From, edge.To)
+		}
+	}
+	return result
+}
+
+func (v *templateValidator) nodeExists(nodeID string) bool {
+	node, exists := v.tpl.Nodes[nodeID]
+	return exists && node != nil
+}
+
+// findNodeOfType 返回第一个指定类型的节点
+func findNodeOfType(nodes []*Node, nodeType NodeType) *Node {
+	for _, node := range nodes {
+		if node.Type == nodeType {
+			return node
+		}
+	}
+	return nil
+}
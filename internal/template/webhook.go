@@ -0,0 +1,61 @@
+package template
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/mautops/approval-kit/internal/errors"
+)
+
+// Webhook 认证类型
+const (
+	// AuthTypeToken 在 Authorization 请求头中携带 Bearer Token
+	AuthTypeToken = "token"
+
+	// AuthTypeSignature 使用 Key 对时间戳和请求体计算 HMAC-SHA256 签名
+	AuthTypeSignature = "signature"
+)
+
+// Validate 验证 Webhook 配置的有效性
+// URL 必须是 http 或 https 地址;认证类型为 token 时 Token 不能为空,为 signature 时 Key 不能为空
+func (w *WebhookConfig) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: invalid webhook URL %q", errors.ErrInvalidTemplate, w.URL)
+	}
+
+	if w.Auth == nil {
+		return nil
+	}
+	switch w.Auth.Type {
+	case AuthTypeToken:
+		if w.Auth.Token == "" {
+			return fmt.Errorf("%w: webhook %q token auth requires a token", errors.ErrInvalidTemplate, w.URL)
+		}
+	case AuthTypeSignature:
+		if w.Auth.Key == "" {
+			return fmt.Errorf("%w: webhook %q signature auth requires a key", errors.ErrInvalidTemplate, w.URL)
+		}
+	default:
+		return fmt.Errorf("%w: webhook %q has invalid auth type %q", errors.ErrInvalidTemplate, w.URL, w.Auth.Type)
+	}
+	return nil
+}
+
+// Validate 验证模板全局配置的有效性: 跳过规则和 Webhook 配置
+func (c *TemplateConfig) Validate() error {
+	if c.SkipPolicy != nil {
+		if err := c.SkipPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+	for i, webhook := range c.Webhooks {
+		if webhook == nil {
+			return fmt.Errorf("%w: webhooks[%d] is nil", errors.ErrInvalidTemplate, i)
+		}
+		if err := webhook.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,347 @@
+// Package approvalkit 审批流核心库的对外入口
+// 负责组装模板管理器、任务管理器、流程引擎和事件通知器,
+// 外部项目通过 New 和函数式选项即可构建完整可用的审批流
+package approvalkit
+
+import (
+	"time"
+
+	internalNode "github.com/mautops/approval-kit/internal/node"
+	internalTask "github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/pkg/event"
+	"github.com/mautops/approval-kit/pkg/node"
+	"github.com/mautops/approval-kit/pkg/task"
+	"github.com/mautops/approval-kit/pkg/template"
+)
+
+// Kit 审批工具包
+// 持有模板管理器和任务管理器,任务管理器默认启用流程引擎,按模板的边逐个节点推进审批流程
+type Kit struct {
+	templates    template.TemplateManager
+	tasks        task.TaskManager
+	notifier     *event.EventNotifier
+	ownsNotifier bool // 事件通知器是否由 Kit 创建(Close 时停止)
+}
+
+// Option Kit 可选配置
+type Option func(*options)
+
+// options Kit 配置项
+type options struct {
+	templateMgr        template.TemplateManager
+	templateStore      template.TemplateStore
+	notifier           *event.EventNotifier
+	handlers           []event.EventHandler
+	subscriptions      []*event.Subscription
+	queueSize          int
+	eventWorkers       int
+	templateWebhooks   bool
+	modeHandlers       []node.ApprovalModeHandler
+	conditionFuncs     map[string]node.CustomConditionFunc
+	executors          []node.NodeExecutor
+	httpClient         node.HTTPClient
+	taskStore          task.TaskStore
+	outbox             event.Outbox
+	scheduler          *task.TimeoutScheduler
+	escalationResolver task.EscalationResolver
+	reminderInterval   *time.Duration
+	delegations        task.DelegationStore
+	directory          node.Directory
+	authorizer         task.Authorizer
+	disableEngine      bool
+}
+
+// WithTemplateManager 使用指定的模板管理器
+// 未设置时使用内存模板管理器
+func WithTemplateManager(templateMgr template.TemplateManager) Option {
+	return func(o *options) {
+		o.templateMgr = templateMgr
+	}
+}
+
+// WithTemplateStore 使用指定的模板存储创建模板管理器
+// 与 WithTemplateManager 同时使用时 WithTemplateManager 优先
+func WithTemplateStore(store template.TemplateStore) Option {
+	return func(o *options) {
+		o.templateStore = store
+	}
+}
+
+// WithNotifier 使用指定的事件通知器
+// 通知器的生命周期由调用方管理,Kit.Close 不会停止该通知器
+func WithNotifier(notifier *event.EventNotifier) Option {
+	return func(o *options) {
+		o.notifier = notifier
+	}
+}
+
+// WithEventHandlers 注册事件处理器
+// Kit 会创建事件通知器并在 Close 时停止;与 WithNotifier 同时使用时 WithNotifier 优先
+func WithEventHandlers(handlers ...event.EventHandler) Option {
+	return func(o *options) {
+		o.handlers = append(o.handlers, handlers...)
+	}
+}
+
+// WithEventSubscription 注册只接收满足过滤条件的事件的处理器
+// 与 WithEventHandlers 相同,由 Kit 创建的事件通知器推送,与 WithNotifier 同时使用时不生效
+func WithEventSubscription(handler event.EventHandler, filter *event.EventFilter) Option {
+	return func(o *options) {
+		o.subscriptions = append(o.subscriptions, &event.Subscription{Handler: handler, Filter: filter})
+	}
+}
+
+// WithTemplateWebhooks 将任务事件推送到任务所用模板的 TemplateConfig.Webhooks 中声明的地址
+// 按每个 Webhook 的认证配置携带 Bearer Token 或 HMAC-SHA256 签名;
+// 与 WithEventHandlers 相同,由 Kit 创建的事件通知器推送,与 WithNotifier 同时使用时不生效
+// 使用事件发件箱时,改为向 OutboxDispatcher 注册 event.NewTemplateWebhookHandler
+func WithTemplateWebhooks() Option {
+	return func(o *options) {
+		o.templateWebhooks = true
+	}
+}
+
+// WithEventQueueSize 设置 Kit 创建的事件通知器的队列大小
+func WithEventQueueSize(queueSize int) Option {
+	return func(o *options) {
+		o.queueSize = queueSize
+	}
+}
+
+// WithEventWorkers 设置 Kit 创建的事件通知器的推送 worker 数量
+func WithEventWorkers(workers int) Option {
+	return func(o *options) {
+		o.eventWorkers = workers
+	}
+}
+
+// WithApprovalModeHandler 注册审批模式处理器
+// 可以注册自定义审批模式,或替换内置审批模式的处理器(按 handler.Mode() 注册)
+func WithApprovalModeHandler(handler node.ApprovalModeHandler) Option {
+	return func(o *options) {
+		o.modeHandlers = append(o.modeHandlers, handler)
+	}
+}
+
+// WithConditionFunction 注册自定义条件函数
+// 条件类型为 "custom" 的条件按 CustomConditionConfig.Name 调用对应的函数,
+// 以数据形式定义的模板也可以使用业务相关的判断(如 isVIPCustomer)
+func WithConditionFunction(name string, fn node.CustomConditionFunc) Option {
+	return func(o *options) {
+		if o.conditionFuncs == nil {
+			o.conditionFuncs = make(map[string]node.CustomConditionFunc)
+		}
+		o.conditionFuncs[name] = fn
+	}
+}
+
+// WithNodeExecutor 注册节点执行器,替换对应节点类型的默认执行器
+func WithNodeExecutor(executor node.NodeExecutor) Option {
+	return func(o *options) {
+		o.executors = append(o.executors, executor)
+	}
+}
+
+// WithHTTPClient 设置获取动态审批人和评估 HTTP 决策条件时使用的 HTTP 客户端
+// 未设置时使用默认 HTTP 客户端
+func WithHTTPClient(client node.HTTPClient) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithTaskStore 使用指定的任务存储持久化任务
+// 未设置时任务只保存在内存中,进程重启后丢失
+func WithTaskStore(store task.TaskStore) Option {
+	return func(o *options) {
+		o.taskStore = store
+	}
+}
+
+// WithOutbox 将任务产生的事件写入事件发件箱,由 event.OutboxDispatcher 投递(至少一次)
+// 任务存储实现了 task.OutboxTaskStore 时(如 sqlstore.TaskStore),事件与任务在同一事务中写入;
+// 否则任务保存成功后再写入发件箱,写入失败时操作返回 task.ErrOutboxAppendFailed
+// 投递器的生命周期由调用方管理
+func WithOutbox(outbox event.Outbox) Option {
+	return func(o *options) {
+		o.outbox = outbox
+	}
+}
+
+// WithTimeoutScheduler 使用超时调度器自动处理节点超时
+// 调度器的生命周期由调用方管理: New 之后调用 Start 启动,不再使用时调用 Stop
+func WithTimeoutScheduler(scheduler *task.TimeoutScheduler) Option {
+	return func(o *options) {
+		o.scheduler = scheduler
+	}
+}
+
+// WithEscalationResolver 设置超时升级审批人解析函数
+// 超时策略的升级动作未配置升级目标时,通过该函数解析升级目标(如审批人的上级)
+func WithEscalationResolver(resolver task.EscalationResolver) Option {
+	return func(o *options) {
+		o.escalationResolver = resolver
+	}
+}
+
+// WithReminderInterval 设置同一任务中同一审批人两次催办的最小间隔
+// 未设置时为 1 小时,设置为 0 时不限制催办频率
+func WithReminderInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.reminderInterval = &interval
+	}
+}
+
+// WithDelegationStore 使用委托规则存储
+// 任务创建时和节点激活时获取的审批人中有生效委托规则的,替换为代理人,
+// 代理人审批生成的审批记录通过 OnBehalfOf 保留原审批人
+func WithDelegationStore(store task.DelegationStore) Option {
+	return func(o *options) {
+		o.delegations = store
+	}
+}
+
+// WithDirectory 使用组织架构目录
+// 角色、部门负责人、上级审批人配置未注入目录时,通过该目录查询审批人
+func WithDirectory(directory node.Directory) Option {
+	return func(o *options) {
+		o.directory = directory
+	}
+}
+
+// WithAuthorizer 设置任务操作的授权策略
+// 未设置时使用默认策略: 只有节点的审批人可以审批或拒绝,只有发起人可以提交、撤回或取消,
+// 需要管理员时使用 task.NewDefaultAuthorizer(task.WithAdmins(admins...))
+func WithAuthorizer(authorizer task.Authorizer) Option {
+	return func(o *options) {
+		o.authorizer = authorizer
+	}
+}
+
+// WithoutFlowEngine 不启用流程引擎
+// 任务管理器使用单节点审批的行为: 当前节点审批完成即整个任务完成
+// 节点审批人只在创建任务时获取,默认授权策略下没有审批人的节点不能审批,
+// 由调用方校验审批人时使用 WithAuthorizer(task.NewDefaultAuthorizer(task.AllowUnassignedApprovers()))
+func WithoutFlowEngine() Option {
+	return func(o *options) {
+		o.disableEngine = true
+	}
+}
+
+// New 创建审批工具包
+// 默认使用内存模板管理器和内存任务管理器,启用流程引擎,不推送事件
+func New(opts ...Option) *Kit {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.templateMgr == nil {
+		var templateOpts []template.ManagerOption
+		if o.templateStore != nil {
+			templateOpts = append(templateOpts, template.WithTemplateStore(o.templateStore))
+		}
+		o.templateMgr = template.NewTemplateManager(templateOpts...)
+	}
+	if o.httpClient == nil {
+		o.httpClient = node.NewDefaultHTTPClient()
+	}
+
+	kit := &Kit{templates: o.templateMgr}
+
+	// 事件通知器
+	if o.templateWebhooks {
+		o.handlers = append(o.handlers, event.NewTemplateWebhookHandler(o.templateMgr, nil))
+	}
+	kit.notifier = o.notifier
+	if kit.notifier == nil && (len(o.handlers) > 0 || len(o.subscriptions) > 0) {
+		notifierOpts := []event.NotifierOption{event.WithNotifierWorkers(o.eventWorkers)}
+		for _, sub := range o.subscriptions {
+			notifierOpts = append(notifierOpts, event.WithSubscription(sub.Handler, sub.Filter))
+		}
+		kit.notifier = event.NewEventNotifier(o.handlers, o.queueSize, notifierOpts...)
+		kit.ownsNotifier = true
+	}
+
+	// 流程引擎
+	// 不启用流程引擎时仍用于任务创建时获取审批人
+	registry := internalNode.NewApprovalModeHandlerRegistry()
+	for _, handler := range o.modeHandlers {
+		registry.RegisterHandler(handler.Mode(), handler)
+	}
+	conditions := internalNode.NewConditionEvaluatorRegistry()
+	for name, fn := range o.conditionFuncs {
+		conditions.RegisterFunction(name, fn)
+	}
+	engineOpts := []internalNode.FlowEngineOption{
+		internalNode.WithApprovalModeRegistry(registry),
+		internalNode.WithConditionEvaluatorRegistry(conditions),
+		internalNode.WithHTTPClient(o.httpClient),
+		internalNode.WithDelegationStore(o.delegations),
+		internalNode.WithDirectory(o.directory),
+	}
+	for _, executor := range o.executors {
+		engineOpts = append(engineOpts, internalNode.WithNodeExecutor(executor))
+	}
+	engine := internalNode.NewFlowEngine(engineOpts...)
+
+	var managerOpts []internalTask.ManagerOption
+	if !o.disableEngine {
+		managerOpts = append(managerOpts, internalTask.WithFlowEngine(engine))
+	} else {
+		managerOpts = append(managerOpts, internalTask.WithApprovalDecider(internalNode.NewApprovalDecider(registry)))
+	}
+
+	// 任务存储
+	if o.taskStore != nil {
+		managerOpts = append(managerOpts, internalTask.WithTaskStore(o.taskStore))
+	}
+
+	// 事件发件箱
+	if o.outbox != nil {
+		managerOpts = append(managerOpts, internalTask.WithOutbox(o.outbox))
+	}
+
+	// 超时调度器
+	if o.scheduler != nil {
+		managerOpts = append(managerOpts, internalTask.WithTimeoutScheduler(o.scheduler))
+	}
+
+	// 超时升级审批人解析
+	if o.escalationResolver != nil {
+		managerOpts = append(managerOpts, internalTask.WithEscalationResolver(o.escalationResolver))
+	}
+
+	// 授权策略
+	if o.authorizer != nil {
+		managerOpts = append(managerOpts, internalTask.WithAuthorizer(o.authorizer))
+	}
+
+	// 催办频率限制
+	if o.reminderInterval != nil {
+		managerOpts = append(managerOpts, internalTask.WithReminderInterval(*o.reminderInterval))
+	}
+
+	// 任务创建时获取审批人(获取时机为 on_create 的审批节点)
+	kit.tasks = internalTask.NewTaskManagerWithNotifier(o.templateMgr, engine.FetchApproversOnCreate, kit.notifier, managerOpts...)
+
+	return kit
+}
+
+// Templates 返回模板管理器
+func (k *Kit) Templates() template.TemplateManager {
+	return k.templates
+}
+
+// Tasks 返回任务管理器
+func (k *Kit) Tasks() task.TaskManager {
+	return k.tasks
+}
+
+// Close 释放 Kit 持有的资源
+// 停止由 Kit 创建的事件通知器(通过 WithNotifier 传入的通知器由调用方负责停止)
+func (k *Kit) Close() {
+	if k.ownsNotifier && k.notifier != nil {
+		k.notifier.Stop()
+	}
+}
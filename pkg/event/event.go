@@ -51,6 +51,9 @@ const (
 
 	// EventTypeNodeCompleted 节点完成事件
 	EventTypeNodeCompleted EventType = internalEvent.EventTypeNodeCompleted
+
+	// EventTypeApproverReminded 审批人催办事件
+	EventTypeApproverReminded EventType = internalEvent.EventTypeApproverReminded
 )
 
 // Event 事件定义
@@ -70,6 +73,10 @@ type NodeInfo = internalEvent.NodeInfo
 // 与 internal/event.ApprovalInfo 结构相同,但位于 pkg 目录,可以被外部导入
 type ApprovalInfo = internalEvent.ApprovalInfo
 
+// ReminderInfo 催办信息
+// 与 internal/event.ReminderInfo 结构相同,但位于 pkg 目录,可以被外部导入
+type ReminderInfo = internalEvent.ReminderInfo
+
 // BusinessInfo 业务信息
 // 与 internal/event.BusinessInfo 结构相同,但位于 pkg 目录,可以被外部导入
 type BusinessInfo = internalEvent.BusinessInfo
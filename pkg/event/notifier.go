@@ -0,0 +1,52 @@
+package event
+
+import (
+	internalEvent "github.com/mautops/approval-kit/internal/event"
+)
+
+// EventNotifier 事件通知器
+// 与 internal/event.EventNotifier 结构相同,但位于 pkg 目录,可以被外部导入
+type EventNotifier = internalEvent.EventNotifier
+
+// EventFilter 事件过滤条件
+// 与 internal/event.EventFilter 结构相同,但位于 pkg 目录,可以被外部导入
+type EventFilter = internalEvent.EventFilter
+
+// Subscription 事件订阅
+// 与 internal/event.Subscription 结构相同,但位于 pkg 目录,可以被外部导入
+type Subscription = internalEvent.Subscription
+
+// NotifierOption 事件通知器可选配置
+// 与 internal/event.NotifierOption 相同,但位于 pkg 目录,可以被外部导入
+type NotifierOption = internalEvent.NotifierOption
+
+// WebhookConfig Webhook 配置
+// 与 internal/event.WebhookConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type WebhookConfig = internalEvent.WebhookConfig
+
+// NewEventNotifier 创建新的事件通知器
+// handlers: 事件处理器列表,订阅所有事件
+// queueSize: 事件队列大小(小于等于 0 时使用默认值)
+func NewEventNotifier(handlers []EventHandler, queueSize int, opts ...NotifierOption) *EventNotifier {
+	internalHandlers := make([]internalEvent.EventHandler, 0, len(handlers))
+	for _, handler := range handlers {
+		internalHandlers = append(internalHandlers, handler)
+	}
+	return internalEvent.NewEventNotifier(internalHandlers, queueSize, opts...)
+}
+
+// WithSubscription 注册带过滤条件的事件处理器
+// filter 为 nil 时订阅所有事件
+func WithSubscription(handler EventHandler, filter *EventFilter) NotifierOption {
+	return internalEvent.WithSubscription(handler, filter)
+}
+
+// WithNotifierWorkers 设置推送 worker 数量(默认 8)
+func WithNotifierWorkers(workers int) NotifierOption {
+	return internalEvent.WithNotifierWorkers(workers)
+}
+
+// NewWebhookHandler 创建新的 Webhook 事件处理器
+func NewWebhookHandler(config *WebhookConfig) EventHandler {
+	return internalEvent.NewWebhookHandler(config)
+}
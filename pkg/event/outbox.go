@@ -0,0 +1,92 @@
+package event
+
+import (
+	"time"
+
+	internalEvent "github.com/mautops/approval-kit/internal/event"
+)
+
+// DeliveryStatus 事件对单个处理器的投递状态
+type DeliveryStatus = internalEvent.DeliveryStatus
+
+// 投递状态
+const (
+	DeliveryStatusPending   = internalEvent.DeliveryStatusPending
+	DeliveryStatusDelivered = internalEvent.DeliveryStatusDelivered
+	DeliveryStatusDead      = internalEvent.DeliveryStatusDead
+)
+
+// OutboxEntry 发件箱中的事件
+// 与 internal/event.OutboxEntry 结构相同,但位于 pkg 目录,可以被外部导入
+type OutboxEntry = internalEvent.OutboxEntry
+
+// Delivery 事件对单个处理器的投递状态
+// 与 internal/event.Delivery 结构相同,但位于 pkg 目录,可以被外部导入
+type Delivery = internalEvent.Delivery
+
+// OutboxDelivery 发件箱条目及其对某个处理器的投递状态
+type OutboxDelivery = internalEvent.OutboxDelivery
+
+// Outbox 事件发件箱接口
+// 与 internal/event.Outbox 接口相同,但位于 pkg 目录,可以被外部导入
+type Outbox = internalEvent.Outbox
+
+// MemoryOutbox 内存实现的事件发件箱
+type MemoryOutbox = internalEvent.MemoryOutbox
+
+// OutboxDispatcher 发件箱事件投递器
+// 与 internal/event.OutboxDispatcher 相同,但位于 pkg 目录,可以被外部导入
+type OutboxDispatcher = internalEvent.OutboxDispatcher
+
+// DispatcherOption 发件箱投递器可选配置
+type DispatcherOption = internalEvent.DispatcherOption
+
+// NewOutboxEntry 创建发件箱条目
+func NewOutboxEntry(evt *Event, at time.Time) *OutboxEntry {
+	return internalEvent.NewOutboxEntry(evt, at)
+}
+
+// NewMemoryOutbox 创建内存事件发件箱
+func NewMemoryOutbox() *MemoryOutbox {
+	return internalEvent.NewMemoryOutbox()
+}
+
+// NewOutboxDispatcher 创建发件箱事件投递器
+func NewOutboxDispatcher(outbox Outbox, opts ...DispatcherOption) (*OutboxDispatcher, error) {
+	return internalEvent.NewOutboxDispatcher(outbox, opts...)
+}
+
+// WithOutboxHandler 注册事件处理器,name 用于跟踪投递状态和重放死信
+func WithOutboxHandler(name string, handler EventHandler) DispatcherOption {
+	return internalEvent.WithOutboxHandler(name, handler)
+}
+
+// WithDispatchInterval 设置轮询发件箱的间隔
+func WithDispatchInterval(interval time.Duration) DispatcherOption {
+	return internalEvent.WithDispatchInterval(interval)
+}
+
+// WithDispatchBatchSize 设置每个处理器每次轮询最多投递的事件数量
+func WithDispatchBatchSize(size int) DispatcherOption {
+	return internalEvent.WithDispatchBatchSize(size)
+}
+
+// WithMaxAttempts 设置每个处理器的最大投递次数,达到后进入死信列表
+func WithMaxAttempts(attempts int) DispatcherOption {
+	return internalEvent.WithMaxAttempts(attempts)
+}
+
+// WithRetryBackoff 设置第一次重试前的等待时间,之后每次失败加倍
+func WithRetryBackoff(backoff time.Duration) DispatcherOption {
+	return internalEvent.WithRetryBackoff(backoff)
+}
+
+// WithDispatcherClock 设置投递器使用的当前时间函数
+func WithDispatcherClock(now func() time.Time) DispatcherOption {
+	return internalEvent.WithDispatcherClock(now)
+}
+
+// WithDispatchErrorHandler 设置投递失败时的回调
+func WithDispatchErrorHandler(handler func(delivery *Delivery, err error)) DispatcherOption {
+	return internalEvent.WithDispatchErrorHandler(handler)
+}
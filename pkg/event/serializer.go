@@ -0,0 +1,42 @@
+package event
+
+import (
+	"net/http"
+
+	internalEvent "github.com/mautops/approval-kit/internal/event"
+)
+
+// SchemaVersion 事件 JSON 结构的版本
+const SchemaVersion = internalEvent.SchemaVersion
+
+// Message 序列化后的事件消息
+// 与 internal/event.Message 结构相同,但位于 pkg 目录,可以被外部导入
+type Message = internalEvent.Message
+
+// Serializer 事件序列化器
+// 与 internal/event.Serializer 接口相同,但位于 pkg 目录,可以被外部导入
+type Serializer = internalEvent.Serializer
+
+// JSONSerializer 默认的 JSON 序列化器
+type JSONSerializer = internalEvent.JSONSerializer
+
+// CloudEventsSerializer CloudEvents 1.0 序列化器(HTTP 协议绑定)
+// 与 internal/event.CloudEventsSerializer 结构相同,但位于 pkg 目录,可以被外部导入
+type CloudEventsSerializer = internalEvent.CloudEventsSerializer
+
+// CloudEvents 内容模式
+const (
+	CloudEventsStructured = internalEvent.CloudEventsStructured
+	CloudEventsBinary     = internalEvent.CloudEventsBinary
+)
+
+// CloudEvents 默认属性
+const (
+	DefaultCloudEventsSource     = internalEvent.DefaultCloudEventsSource
+	DefaultCloudEventsTypePrefix = internalEvent.DefaultCloudEventsTypePrefix
+)
+
+// DecodeWebhookEvent 从 Webhook 请求中解析事件,serializer 为 nil 时使用 JSONSerializer
+func DecodeWebhookEvent(r *http.Request, body []byte, serializer Serializer) (*Event, error) {
+	return internalEvent.DecodeWebhookEvent(r, body, serializer)
+}
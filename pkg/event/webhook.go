@@ -0,0 +1,64 @@
+package event
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	internalEvent "github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/pkg/template"
+)
+
+// WebhookAuth Webhook 认证配置
+// 与 internal/event.WebhookAuth 结构相同,但位于 pkg 目录,可以被外部导入
+type WebhookAuth = internalEvent.WebhookAuth
+
+// Webhook 认证类型
+const (
+	WebhookAuthToken     = internalEvent.WebhookAuthToken
+	WebhookAuthSignature = internalEvent.WebhookAuthSignature
+)
+
+// Webhook 请求头
+const (
+	HeaderEventID   = internalEvent.HeaderEventID
+	HeaderTimestamp = internalEvent.HeaderTimestamp
+	HeaderSignature = internalEvent.HeaderSignature
+)
+
+// ErrInvalidSignature 表示 Webhook 请求签名无效或已过期,可以通过 errors.Is 判断
+var ErrInvalidSignature = errors.ErrInvalidSignature
+
+// DefaultSignatureTolerance 验证签名时允许的默认时间偏差
+const DefaultSignatureTolerance = internalEvent.DefaultSignatureTolerance
+
+// TemplateWebhookOption 按模板路由的 Webhook 事件处理器可选配置
+type TemplateWebhookOption = internalEvent.TemplateWebhookOption
+
+// NewTemplateWebhookHandler 创建按模板路由的 Webhook 事件处理器
+// 事件推送到任务所用模板版本的 TemplateConfig.Webhooks 中声明的所有地址
+func NewTemplateWebhookHandler(templates template.TemplateManager, client *http.Client, opts ...TemplateWebhookOption) EventHandler {
+	return internalEvent.NewTemplateWebhookHandler(templates, client, opts...)
+}
+
+// WithWebhookSerializer 设置事件序列化器(如 CloudEventsSerializer)
+func WithWebhookSerializer(serializer Serializer) TemplateWebhookOption {
+	return internalEvent.WithWebhookSerializer(serializer)
+}
+
+// SignWebhook 计算 Webhook 请求签名
+func SignWebhook(key string, timestamp int64, body []byte) string {
+	return internalEvent.SignWebhook(key, timestamp, body)
+}
+
+// VerifyWebhookSignature 验证 Webhook 请求签名
+// 签名无效或签名时间与 now 相差超过 tolerance 时返回 ErrInvalidSignature
+func VerifyWebhookSignature(key string, timestamp string, body []byte, signature string, tolerance time.Duration, now time.Time) error {
+	return internalEvent.VerifyWebhookSignature(key, timestamp, body, signature, tolerance, now)
+}
+
+// VerifyWebhookRequest 验证 Webhook 请求签名并返回请求体
+// 供接收方在 HTTP 处理函数中使用
+func VerifyWebhookRequest(r *http.Request, key string, tolerance time.Duration) ([]byte, error) {
+	return internalEvent.VerifyWebhookRequest(r, key, tolerance)
+}
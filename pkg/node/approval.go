@@ -0,0 +1,135 @@
+package node
+
+import (
+	internalNode "github.com/mautops/approval-kit/internal/node"
+)
+
+// ApprovalMode 表示审批节点的审批模式
+// 与 internal/node.ApprovalMode 类型相同,但位于 pkg 目录,可以被外部导入
+type ApprovalMode = internalNode.ApprovalMode
+
+// 审批模式常量
+const (
+	// ApprovalModeSingle 单人审批
+	ApprovalModeSingle ApprovalMode = internalNode.ApprovalModeSingle
+
+	// ApprovalModeUnanimous 多人会签: 多个审批人需全部同意
+	ApprovalModeUnanimous ApprovalMode = internalNode.ApprovalModeUnanimous
+
+	// ApprovalModeOr 多人或签: 多个审批人中任意一人同意即可
+	ApprovalModeOr ApprovalMode = internalNode.ApprovalModeOr
+
+	// ApprovalModeProportional 比例会签: 多个审批人中达到指定比例同意即可
+	ApprovalModeProportional ApprovalMode = internalNode.ApprovalModeProportional
+
+	// ApprovalModeSequential 顺序审批: 多个审批人按顺序依次审批
+	ApprovalModeSequential ApprovalMode = internalNode.ApprovalModeSequential
+)
+
+// ApprovalNodeConfig 审批节点配置
+// 与 internal/node.ApprovalNodeConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type ApprovalNodeConfig = internalNode.ApprovalNodeConfig
+
+// ProportionalThreshold 比例会签阈值配置
+// 与 internal/node.ProportionalThreshold 结构相同,但位于 pkg 目录,可以被外部导入
+type ProportionalThreshold = internalNode.ProportionalThreshold
+
+// OperationPermissions 操作权限配置
+// 与 internal/node.OperationPermissions 结构相同,但位于 pkg 目录,可以被外部导入
+type OperationPermissions = internalNode.OperationPermissions
+
+// RejectBehavior 拒绝后行为
+// 与 internal/node.RejectBehavior 类型相同,但位于 pkg 目录,可以被外部导入
+type RejectBehavior = internalNode.RejectBehavior
+
+// 拒绝后行为常量
+const (
+	// RejectBehaviorTerminate 拒绝后终止流程
+	RejectBehaviorTerminate RejectBehavior = internalNode.RejectBehaviorTerminate
+
+	// RejectBehaviorRollback 拒绝后回退到上一节点
+	RejectBehaviorRollback RejectBehavior = internalNode.RejectBehaviorRollback
+
+	// RejectBehaviorJump 拒绝后跳转到指定节点
+	RejectBehaviorJump RejectBehavior = internalNode.RejectBehaviorJump
+)
+
+// ApproverConfig 审批人配置接口
+// 与 internal/node.ApproverConfig 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type ApproverConfig = internalNode.ApproverConfig
+
+// ApproverTiming 审批人获取时机
+// 与 internal/node.ApproverTiming 类型相同,但位于 pkg 目录,可以被外部导入
+type ApproverTiming = internalNode.ApproverTiming
+
+// 审批人获取时机常量
+const (
+	// ApproverTimingOnCreate 任务创建时获取
+	ApproverTimingOnCreate ApproverTiming = internalNode.ApproverTimingOnCreate
+
+	// ApproverTimingOnActivate 节点激活时获取
+	ApproverTimingOnActivate ApproverTiming = internalNode.ApproverTimingOnActivate
+)
+
+// FixedApproverConfig 固定审批人配置
+// 与 internal/node.FixedApproverConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type FixedApproverConfig = internalNode.FixedApproverConfig
+
+// DynamicApproverConfig 动态审批人配置
+// 与 internal/node.DynamicApproverConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type DynamicApproverConfig = internalNode.DynamicApproverConfig
+
+// ApprovalModeHandler 审批模式处理器接口
+// 与 internal/node.ApprovalModeHandler 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type ApprovalModeHandler = internalNode.ApprovalModeHandler
+
+// ApproverOrderChecker 审批顺序检查接口(可选)
+// 与 internal/node.ApproverOrderChecker 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type ApproverOrderChecker = internalNode.ApproverOrderChecker
+
+// ApprovalModeHandlerRegistry 审批模式处理器注册表
+// 与 internal/node.ApprovalModeHandlerRegistry 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type ApprovalModeHandlerRegistry = internalNode.ApprovalModeHandlerRegistry
+
+// ApprovalResult 审批结果
+// 与 internal/node.ApprovalResult 结构相同,但位于 pkg 目录,可以被外部导入
+type ApprovalResult = internalNode.ApprovalResult
+
+// NewApprovalModeHandlerRegistry 创建新的审批模式处理器注册表(已注册内置审批模式)
+func NewApprovalModeHandlerRegistry() ApprovalModeHandlerRegistry {
+	return internalNode.NewApprovalModeHandlerRegistry()
+}
+
+// TimeoutPolicy 超时策略
+// 与 internal/node.TimeoutPolicy 结构相同,但位于 pkg 目录,可以被外部导入
+type TimeoutPolicy = internalNode.TimeoutPolicy
+
+// TimeoutAction 超时动作
+// 与 internal/node.TimeoutAction 结构相同,但位于 pkg 目录,可以被外部导入
+type TimeoutAction = internalNode.TimeoutAction
+
+// TimeoutActionType 超时动作类型
+// 与 internal/node.TimeoutActionType 类型相同,但位于 pkg 目录,可以被外部导入
+type TimeoutActionType = internalNode.TimeoutActionType
+
+// 超时动作类型常量
+const (
+	// TimeoutActionNotify 发送提醒,节点继续等待审批
+	TimeoutActionNotify TimeoutActionType = internalNode.TimeoutActionNotify
+
+	// TimeoutActionEscalate 升级审批: 尚未审批的审批人替换为升级目标
+	TimeoutActionEscalate TimeoutActionType = internalNode.TimeoutActionEscalate
+
+	// TimeoutActionAutoApprove 自动同意
+	TimeoutActionAutoApprove TimeoutActionType = internalNode.TimeoutActionAutoApprove
+
+	// TimeoutActionAutoReject 自动拒绝
+	TimeoutActionAutoReject TimeoutActionType = internalNode.TimeoutActionAutoReject
+
+	// TimeoutActionTerminate 任务进入 timeout 终态
+	TimeoutActionTerminate TimeoutActionType = internalNode.TimeoutActionTerminate
+)
+
+// ReminderPolicy 自动催办配置
+// 与 internal/node.ReminderPolicy 结构相同,但位于 pkg 目录,可以被外部导入
+type ReminderPolicy = internalNode.ReminderPolicy
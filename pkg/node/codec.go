@@ -0,0 +1,33 @@
+package node
+
+import (
+	internalNode "github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/pkg/template"
+)
+
+// NewNodeConfigCodec 创建节点配置编解码器
+// 支持审批节点、条件节点以及通过 RegisterNodeConfig 注册的节点配置,用于模板持久化和模板定义文件
+func NewNodeConfigCodec() template.NodeConfigCodec {
+	return internalNode.NewNodeConfigCodec()
+}
+
+// RegisterNodeConfig 注册节点配置类型
+// nodeType: 节点类型,模板定义中节点的 type 字段
+// factory: 创建空配置的函数,配置需要能够通过 encoding/json 编解码
+func RegisterNodeConfig(nodeType template.NodeType, factory func() template.NodeConfig) {
+	internalNode.RegisterNodeConfig(nodeType, factory)
+}
+
+// RegisterApproverConfig 注册审批人配置类型
+// approverType: 审批人配置类型标识,模板定义中 approver 的 type 字段
+// factory: 创建空配置的函数,配置需要能够通过 encoding/json 编解码
+func RegisterApproverConfig(approverType string, factory func() ApproverConfig) {
+	internalNode.RegisterApproverConfig(approverType, factory)
+}
+
+// RegisterConditionConfig 注册条件配置类型
+// conditionType: 条件类型,与配置的 ConditionType() 一致
+// factory: 创建空配置的函数,配置需要能够通过 encoding/json 编解码
+func RegisterConditionConfig(conditionType string, factory func() ConditionConfig) {
+	internalNode.RegisterConditionConfig(conditionType, factory)
+}
@@ -0,0 +1,80 @@
+package node
+
+import (
+	internalNode "github.com/mautops/approval-kit/internal/node"
+)
+
+// ConditionNodeConfig 条件节点配置
+// 与 internal/node.ConditionNodeConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type ConditionNodeConfig = internalNode.ConditionNodeConfig
+
+// 条件节点模式
+const (
+	// ConditionModeBranch 二分支模式(默认): 根据条件结果跳转到 TrueNodeID 或 FalseNodeID
+	ConditionModeBranch = internalNode.ConditionModeBranch
+
+	// ConditionModeExclusive 排他网关模式: 按优先级评估出边条件,都不满足时使用默认出边
+	ConditionModeExclusive = internalNode.ConditionModeExclusive
+)
+
+// Condition 条件定义
+// 与 internal/node.Condition 结构相同,但位于 pkg 目录,可以被外部导入
+type Condition = internalNode.Condition
+
+// ConditionConfig 条件配置接口
+// 与 internal/node.ConditionConfig 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type ConditionConfig = internalNode.ConditionConfig
+
+// NumericConditionConfig 数值比较条件配置
+// 与 internal/node.NumericConditionConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type NumericConditionConfig = internalNode.NumericConditionConfig
+
+// StringConditionConfig 字符串匹配条件配置
+// 与 internal/node.StringConditionConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type StringConditionConfig = internalNode.StringConditionConfig
+
+// EnumConditionConfig 枚举判断条件配置
+// 与 internal/node.EnumConditionConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type EnumConditionConfig = internalNode.EnumConditionConfig
+
+// CompositeConditionConfig 组合条件配置
+// 与 internal/node.CompositeConditionConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type CompositeConditionConfig = internalNode.CompositeConditionConfig
+
+// ExprConditionConfig 表达式条件配置
+// 与 internal/node.ExprConditionConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type ExprConditionConfig = internalNode.ExprConditionConfig
+
+// CustomConditionConfig 自定义条件配置
+// 与 internal/node.CustomConditionConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type CustomConditionConfig = internalNode.CustomConditionConfig
+
+// HTTPConditionConfig HTTP 决策条件配置
+// 与 internal/node.HTTPConditionConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type HTTPConditionConfig = internalNode.HTTPConditionConfig
+
+// CustomConditionFunc 自定义条件函数
+// 与 internal/node.CustomConditionFunc 相同,但位于 pkg 目录,可以被外部导入
+type CustomConditionFunc = internalNode.CustomConditionFunc
+
+// ConditionEvaluator 条件评估器接口
+// 与 internal/node.ConditionEvaluator 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type ConditionEvaluator = internalNode.ConditionEvaluator
+
+// ConditionEvaluatorRegistry 条件评估器注册表
+// 与 internal/node.ConditionEvaluatorRegistry 结构相同,但位于 pkg 目录,可以被外部导入
+type ConditionEvaluatorRegistry = internalNode.ConditionEvaluatorRegistry
+
+// NewConditionEvaluatorRegistry 创建新的条件评估器注册表(已注册内置条件评估器)
+func NewConditionEvaluatorRegistry() *ConditionEvaluatorRegistry {
+	return internalNode.NewConditionEvaluatorRegistry()
+}
+
+// JSONPath 编译后的 JSONPath 路径
+// 与 internal/node.JSONPath 结构相同,但位于 pkg 目录,可以被外部导入
+type JSONPath = internalNode.JSONPath
+
+// CompileJSONPath 编译 JSONPath 路径,如 "$.items[0].price"、"items[*].sku"
+func CompileJSONPath(path string) (*JSONPath, error) {
+	return internalNode.CompileJSONPath(path)
+}
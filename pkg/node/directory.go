@@ -0,0 +1,41 @@
+package node
+
+import (
+	internalNode "github.com/mautops/approval-kit/internal/node"
+)
+
+// Directory 组织架构目录接口
+// 与 internal/node.Directory 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type Directory = internalNode.Directory
+
+// DirectoryUser 组织架构中的用户
+// 与 internal/node.DirectoryUser 结构相同,但位于 pkg 目录,可以被外部导入
+type DirectoryUser = internalNode.DirectoryUser
+
+// Department 组织架构中的部门
+// 与 internal/node.Department 结构相同,但位于 pkg 目录,可以被外部导入
+type Department = internalNode.Department
+
+// MemoryDirectory 内存实现的组织架构目录
+// 与 internal/node.MemoryDirectory 相同,但位于 pkg 目录,可以被外部导入
+type MemoryDirectory = internalNode.MemoryDirectory
+
+// NewMemoryDirectory 创建内存组织架构目录
+func NewMemoryDirectory() *MemoryDirectory {
+	return internalNode.NewMemoryDirectory()
+}
+
+// RoleApproverConfig 角色审批人配置
+// 与 internal/node.RoleApproverConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type RoleApproverConfig = internalNode.RoleApproverConfig
+
+// DepartmentHeadApproverConfig 部门负责人审批人配置
+// 与 internal/node.DepartmentHeadApproverConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type DepartmentHeadApproverConfig = internalNode.DepartmentHeadApproverConfig
+
+// ManagerApproverConfig 上级审批人配置
+// 与 internal/node.ManagerApproverConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type ManagerApproverConfig = internalNode.ManagerApproverConfig
+
+// DefaultInitiatorParam 上级审批人配置默认读取发起人的任务参数路径
+const DefaultInitiatorParam = internalNode.DefaultInitiatorParam
@@ -0,0 +1,26 @@
+package node
+
+import (
+	internalNode "github.com/mautops/approval-kit/internal/node"
+)
+
+// NodeExecutor 节点执行器接口
+// 与 internal/node.NodeExecutor 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type NodeExecutor = internalNode.NodeExecutor
+
+// NodeContext 节点执行上下文
+// 与 internal/node.NodeContext 结构相同,但位于 pkg 目录,可以被外部导入
+type NodeContext = internalNode.NodeContext
+
+// NodeResult 节点执行结果
+// 与 internal/node.NodeResult 结构相同,但位于 pkg 目录,可以被外部导入
+type NodeResult = internalNode.NodeResult
+
+// ContextCache 上下文缓存
+// 与 internal/node.ContextCache 结构相同,但位于 pkg 目录,可以被外部导入
+type ContextCache = internalNode.ContextCache
+
+// NewContextCache 创建新的上下文缓存
+func NewContextCache() *ContextCache {
+	return internalNode.NewContextCache()
+}
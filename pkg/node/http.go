@@ -0,0 +1,26 @@
+package node
+
+import (
+	internalNode "github.com/mautops/approval-kit/internal/node"
+)
+
+// HTTPClient HTTP 客户端接口
+// 与 internal/node.HTTPClient 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type HTTPClient = internalNode.HTTPClient
+
+// HTTPAPIConfig HTTP API 配置
+// 与 internal/node.HTTPAPIConfig 结构相同,但位于 pkg 目录,可以被外部导入
+type HTTPAPIConfig = internalNode.HTTPAPIConfig
+
+// ParamMapping 参数映射
+// 与 internal/node.ParamMapping 结构相同,但位于 pkg 目录,可以被外部导入
+type ParamMapping = internalNode.ParamMapping
+
+// ResponseMapping 响应映射
+// 与 internal/node.ResponseMapping 结构相同,但位于 pkg 目录,可以被外部导入
+type ResponseMapping = internalNode.ResponseMapping
+
+// NewDefaultHTTPClient 创建默认的 HTTP 客户端
+func NewDefaultHTTPClient() HTTPClient {
+	return internalNode.NewDefaultHTTPClient()
+}
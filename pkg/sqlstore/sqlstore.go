@@ -0,0 +1,74 @@
+// Package sqlstore 基于 database/sql 的持久化存储
+// 与 internal/sqlstore 相同,但位于 pkg 目录,可以被外部导入
+package sqlstore
+
+import (
+	"database/sql"
+
+	internalSQLStore "github.com/mautops/approval-kit/internal/sqlstore"
+	"github.com/mautops/approval-kit/pkg/template"
+)
+
+// Dialect SQL 方言
+type Dialect = internalSQLStore.Dialect
+
+const (
+	// DialectSQLite SQLite,使用 ? 占位符
+	DialectSQLite = internalSQLStore.DialectSQLite
+
+	// DialectMySQL MySQL,使用 ? 占位符
+	DialectMySQL = internalSQLStore.DialectMySQL
+
+	// DialectPostgres PostgreSQL,使用 $1、$2 占位符
+	DialectPostgres = internalSQLStore.DialectPostgres
+)
+
+// Option 存储可选配置
+type Option = internalSQLStore.Option
+
+// TaskStore 基于 database/sql 的任务存储
+type TaskStore = internalSQLStore.TaskStore
+
+// TemplateStore 基于 database/sql 的模板存储
+type TemplateStore = internalSQLStore.TemplateStore
+
+// DelegationStore 基于 database/sql 的委托规则存储
+type DelegationStore = internalSQLStore.DelegationStore
+
+// Outbox 基于 database/sql 的事件发件箱
+type Outbox = internalSQLStore.Outbox
+
+// WithDialect 设置 SQL 方言
+func WithDialect(dialect Dialect) Option {
+	return internalSQLStore.WithDialect(dialect)
+}
+
+// WithNodeConfigCodec 设置模板存储使用的节点配置编解码器
+func WithNodeConfigCodec(codec template.NodeConfigCodec) Option {
+	return internalSQLStore.WithNodeConfigCodec(codec)
+}
+
+// Migrate 执行表结构迁移
+func Migrate(db *sql.DB, opts ...Option) error {
+	return internalSQLStore.Migrate(db, opts...)
+}
+
+// NewTaskStore 创建 SQL 任务存储,创建时执行表结构迁移
+func NewTaskStore(db *sql.DB, opts ...Option) (*TaskStore, error) {
+	return internalSQLStore.NewTaskStore(db, opts...)
+}
+
+// NewTemplateStore 创建 SQL 模板存储,创建时执行表结构迁移
+func NewTemplateStore(db *sql.DB, opts ...Option) (*TemplateStore, error) {
+	return internalSQLStore.NewTemplateStore(db, opts...)
+}
+
+// NewDelegationStore 创建 SQL 委托规则存储,创建时执行表结构迁移
+func NewDelegationStore(db *sql.DB, opts ...Option) (*DelegationStore, error) {
+	return internalSQLStore.NewDelegationStore(db, opts...)
+}
+
+// NewOutbox 创建 SQL 事件发件箱,创建时执行表结构迁移
+func NewOutbox(db *sql.DB, opts ...Option) (*Outbox, error) {
+	return internalSQLStore.NewOutbox(db, opts...)
+}
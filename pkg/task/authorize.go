@@ -0,0 +1,74 @@
+package task
+
+import (
+	"github.com/mautops/approval-kit/internal/errors"
+	internalTask "github.com/mautops/approval-kit/internal/task"
+)
+
+// Action 任务操作类型
+// 与 internal/task.Action 类型相同,但位于 pkg 目录,可以被外部导入
+type Action = internalTask.Action
+
+const (
+	ActionCreate          = internalTask.ActionCreate
+	ActionSubmit          = internalTask.ActionSubmit
+	ActionApprove         = internalTask.ActionApprove
+	ActionReject          = internalTask.ActionReject
+	ActionCancel          = internalTask.ActionCancel
+	ActionWithdraw        = internalTask.ActionWithdraw
+	ActionTransfer        = internalTask.ActionTransfer
+	ActionAddApprover     = internalTask.ActionAddApprover
+	ActionRemoveApprover  = internalTask.ActionRemoveApprover
+	ActionReplaceApprover = internalTask.ActionReplaceApprover
+	ActionPause           = internalTask.ActionPause
+	ActionResume          = internalTask.ActionResume
+	ActionRollback        = internalTask.ActionRollback
+	ActionRemind          = internalTask.ActionRemind
+	ActionMigrate         = internalTask.ActionMigrate
+	ActionHandleTimeout   = internalTask.ActionHandleTimeout
+	ActionHandleReminders = internalTask.ActionHandleReminders
+)
+
+// AuthorizationRequest 授权检查请求
+// 与 internal/task.AuthorizationRequest 结构相同,但位于 pkg 目录,可以被外部导入
+type AuthorizationRequest = internalTask.AuthorizationRequest
+
+// Authorizer 授权检查接口
+// 与 internal/task.Authorizer 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type Authorizer = internalTask.Authorizer
+
+// AuthorizerFunc 函数形式的授权检查
+// 与 internal/task.AuthorizerFunc 类型相同,但位于 pkg 目录,可以被外部导入
+type AuthorizerFunc = internalTask.AuthorizerFunc
+
+// ForbiddenError 操作人无权执行操作
+// 与 internal/task.ForbiddenError 结构相同,但位于 pkg 目录,可以被外部导入
+type ForbiddenError = internalTask.ForbiddenError
+
+// ErrForbidden 表示操作人无权执行操作,可以通过 errors.Is 判断
+var ErrForbidden = errors.ErrForbidden
+
+// DefaultAuthorizerOption 默认授权策略的配置选项
+// 与 internal/task.DefaultAuthorizerOption 类型相同,但位于 pkg 目录,可以被外部导入
+type DefaultAuthorizerOption = internalTask.DefaultAuthorizerOption
+
+// NewDefaultAuthorizer 创建默认授权策略
+// 审批人才能审批;发起人或管理员才能提交、撤回、取消、暂停、恢复;管理员才能减签、替换审批人、回退和迁移
+func NewDefaultAuthorizer(opts ...DefaultAuthorizerOption) Authorizer {
+	return internalTask.NewDefaultAuthorizer(opts...)
+}
+
+// WithAdmins 设置默认授权策略的管理员
+func WithAdmins(admins ...string) DefaultAuthorizerOption {
+	return internalTask.WithAdmins(admins...)
+}
+
+// AllowUnassignedApprovers 允许任何人审批或拒绝尚未获取审批人的节点
+func AllowUnassignedApprovers() DefaultAuthorizerOption {
+	return internalTask.AllowUnassignedApprovers()
+}
+
+// AllowAllAuthorizer 不做任何限制的授权策略
+func AllowAllAuthorizer() Authorizer {
+	return internalTask.AllowAllAuthorizer()
+}
@@ -0,0 +1,18 @@
+package task
+
+import (
+	internalTask "github.com/mautops/approval-kit/internal/task"
+)
+
+// DelegationRule 委托规则
+// 与 internal/task.DelegationRule 结构相同,但位于 pkg 目录,可以被外部导入
+type DelegationRule = internalTask.DelegationRule
+
+// DelegationStore 委托规则存储接口
+// 与 internal/task.DelegationStore 接口相同,但位于 pkg 目录,可以被外部导入
+type DelegationStore = internalTask.DelegationStore
+
+// NewMemoryDelegationStore 创建内存委托规则存储
+func NewMemoryDelegationStore() DelegationStore {
+	return internalTask.NewMemoryDelegationStore()
+}
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"encoding/json"
 )
 
@@ -8,13 +9,21 @@ import (
 // 负责审批任务的创建、查询、提交、审批等操作
 // 与 internal/task.TaskManager 接口定义完全一致,但位于 pkg 目录,可以被外部导入
 type TaskManager interface {
-	// Create 基于模板创建审批任务实例
+	// Create 基于模板创建审批任务实例,不记录发起人
 	// templateID: 模板 ID
 	// businessID: 关联的业务 ID
 	// params: 任务参数(JSON 格式),用于条件判断和动态审批人获取
 	// 返回: 任务对象和错误信息
+	// 注意: 默认授权策略下未记录发起人的任务只有管理员可以提交、撤回、取消、暂停和恢复
+	//
+	// Deprecated: 使用 CreateBy 记录发起人
 	Create(templateID string, businessID string, params json.RawMessage) (*Task, error)
 
+	// CreateBy 基于模板创建审批任务实例,并记录发起人
+	// initiator: 发起人 ID,记录在 Task.Initiator 中,用于撤回、取消等操作的授权检查
+	// 其他参数和行为与 Create 相同
+	CreateBy(templateID string, businessID string, initiator string, params json.RawMessage) (*Task, error)
+
 	// Get 获取审批任务详情
 	// id: 任务 ID
 	// 返回: 任务对象和错误信息
@@ -22,9 +31,15 @@ type TaskManager interface {
 
 	// Submit 提交任务进入审批流程
 	// id: 任务 ID
-	// 返回: 错误信息
+	// actor: 操作人 ID,默认授权策略下必须是发起人或管理员
+	// 返回: 错误信息,操作人无权提交时返回 ErrForbidden
 	// 注意: 提交会触发状态转换,从 pending 转换为 submitted
-	Submit(id string) error
+	Submit(id string, actor string) error
+
+	// SubmitCtx 提交任务进入审批流程
+	// ctx: 请求上下文,传递给流程引擎(如动态审批人获取),取消或超时后停止推进并返回错误
+	// 其他参数和行为与 Submit 相同
+	SubmitCtx(ctx context.Context, id string, actor string) error
 
 	// Approve 审批人进行同意操作
 	// id: 任务 ID
@@ -34,6 +49,11 @@ type TaskManager interface {
 	// 返回: 错误信息
 	Approve(id string, nodeID string, approver string, comment string) error
 
+	// ApproveCtx 审批人进行同意操作
+	// ctx: 请求上下文,传递给流程引擎(如动态审批人获取),取消或超时后停止推进并返回错误
+	// 其他参数和行为与 Approve 相同
+	ApproveCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error
+
 	// ApproveWithAttachments 审批人进行同意操作(带附件)
 	// id: 任务 ID
 	// nodeID: 节点 ID
@@ -43,6 +63,11 @@ type TaskManager interface {
 	// 返回: 错误信息
 	ApproveWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error
 
+	// ApproveWithAttachmentsCtx 审批人进行同意操作(带附件)
+	// ctx: 请求上下文,传递给流程引擎(如动态审批人获取),取消或超时后停止推进并返回错误
+	// 其他参数和行为与 ApproveWithAttachments 相同
+	ApproveWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error
+
 	// Reject 审批人进行拒绝操作
 	// id: 任务 ID
 	// nodeID: 节点 ID
@@ -51,6 +76,11 @@ type TaskManager interface {
 	// 返回: 错误信息
 	Reject(id string, nodeID string, approver string, comment string) error
 
+	// RejectCtx 审批人进行拒绝操作
+	// ctx: 请求上下文,传递给流程引擎(如动态审批人获取),取消或超时后停止推进并返回错误
+	// 其他参数和行为与 Reject 相同
+	RejectCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error
+
 	// RejectWithAttachments 审批人进行拒绝操作(带附件)
 	// id: 任务 ID
 	// nodeID: 节点 ID
@@ -60,19 +90,26 @@ type TaskManager interface {
 	// 返回: 错误信息
 	RejectWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error
 
+	// RejectWithAttachmentsCtx 审批人进行拒绝操作(带附件)
+	// ctx: 请求上下文,传递给流程引擎(如动态审批人获取),取消或超时后停止推进并返回错误
+	// 其他参数和行为与 RejectWithAttachments 相同
+	RejectWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error
+
 	// Cancel 取消任务
 	// id: 任务 ID
+	// actor: 操作人 ID,默认授权策略下必须是发起人或管理员
 	// reason: 取消原因
-	// 返回: 错误信息
-	Cancel(id string, reason string) error
+	// 返回: 错误信息,操作人无权取消时返回 ErrForbidden
+	Cancel(id string, actor string, reason string) error
 
 	// Withdraw 撤回任务
 	// id: 任务 ID
+	// actor: 操作人 ID,默认授权策略下必须是发起人或管理员
 	// reason: 撤回原因
-	// 返回: 错误信息
+	// 返回: 错误信息,操作人无权撤回时返回 ErrForbidden
 	// 注意: 撤回会将任务从 submitted 或 approving 状态撤回回 pending 状态
 	// 如果任务已有审批记录,不允许撤回
-	Withdraw(id string, reason string) error
+	Withdraw(id string, actor string, reason string) error
 
 	// Transfer 转交审批
 	// id: 任务 ID
@@ -87,20 +124,22 @@ type TaskManager interface {
 	// AddApprover 加签
 	// id: 任务 ID
 	// nodeID: 节点 ID
+	// actor: 操作人 ID,默认授权策略下必须是节点的审批人或管理员
 	// approver: 新审批人 ID
 	// reason: 加签原因
-	// 返回: 错误信息
+	// 返回: 错误信息,操作人无权加签时返回 ErrForbidden
 	// 注意: 加签需要节点配置允许加签
-	AddApprover(id string, nodeID string, approver string, reason string) error
+	AddApprover(id string, nodeID string, actor string, approver string, reason string) error
 
 	// RemoveApprover 减签
 	// id: 任务 ID
 	// nodeID: 节点 ID
+	// actor: 操作人 ID,默认授权策略下必须是管理员
 	// approver: 要移除的审批人 ID
 	// reason: 减签原因
-	// 返回: 错误信息
+	// 返回: 错误信息,操作人无权减签时返回 ErrForbidden
 	// 注意: 减签需要节点配置允许减签,且审批人必须在审批人列表中
-	RemoveApprover(id string, nodeID string, approver string, reason string) error
+	RemoveApprover(id string, nodeID string, actor string, approver string, reason string) error
 
 	// Query 查询任务列表
 	// filter: 查询过滤器
@@ -113,40 +152,69 @@ type TaskManager interface {
 	// 注意: 如果任务已超时,将任务状态转换为 timeout
 	HandleTimeout(id string) error
 
+	// HandleReminders 发送到期的自动催办
+	// id: 任务 ID
+	// 返回: 错误信息
+	// 注意: 当前节点配置了自动催办且已到催办时间时,催办节点中尚未审批的审批人
+	HandleReminders(id string) error
+
+	// Remind 催办审批人
+	// id: 任务 ID
+	// nodeID: 节点 ID(必须是当前节点)
+	// actor: 催办发起人
+	// message: 催办消息
+	// 返回: 错误信息
+	// 注意: 催办节点中尚未审批的审批人,生成 approver_reminded 事件并记录催办历史
+	// 同一审批人在催办间隔内只会被催办一次,所有审批人都在间隔内被催办过时返回 ErrReminderRateLimited
+	Remind(id string, nodeID string, actor string, message string) error
+
 	// Pause 暂停任务
 	// id: 任务 ID
+	// actor: 操作人 ID,默认授权策略下必须是发起人或管理员
 	// reason: 暂停原因
-	// 返回: 错误信息
+	// 返回: 错误信息,操作人无权暂停时返回 ErrForbidden
 	// 注意: 只有 pending、submitted、approving 状态可以暂停
 	// 暂停时会记录暂停前的状态,用于恢复时恢复到正确状态
-	Pause(id string, reason string) error
+	Pause(id string, actor string, reason string) error
 
 	// Resume 恢复任务
 	// id: 任务 ID
+	// actor: 操作人 ID,默认授权策略下必须是发起人或管理员
 	// reason: 恢复原因
-	// 返回: 错误信息
+	// 返回: 错误信息,操作人无权恢复时返回 ErrForbidden
 	// 注意: 只有 paused 状态可以恢复
 	// 恢复时会恢复到暂停前的状态(pending、submitted 或 approving)
-	Resume(id string, reason string) error
+	Resume(id string, actor string, reason string) error
 
 	// RollbackToNode 回退到指定节点
 	// id: 任务 ID
 	// nodeID: 目标节点 ID
+	// actor: 操作人 ID,默认授权策略下必须是管理员
 	// reason: 回退原因
-	// 返回: 错误信息
+	// 返回: 错误信息,操作人无权回退时返回 ErrForbidden
 	// 注意: 只能回退到已完成的节点
 	// 回退时会清理回退节点之后的审批记录和状态
-	RollbackToNode(id string, nodeID string, reason string) error
+	RollbackToNode(id string, nodeID string, actor string, reason string) error
 
 	// ReplaceApprover 替换审批人
 	// id: 任务 ID
 	// nodeID: 节点 ID
+	// actor: 操作人 ID,默认授权策略下必须是管理员
 	// oldApprover: 原审批人 ID
 	// newApprover: 新审批人 ID
 	// reason: 替换原因
-	// 返回: 错误信息
+	// 返回: 错误信息,操作人无权替换时返回 ErrForbidden
 	// 注意: 只能替换尚未审批的审批人
 	// 替换后会保留原审批人的审批记录(如果有),新审批人可以继续审批
-	ReplaceApprover(id string, nodeID string, oldApprover string, newApprover string, reason string) error
-}
+	ReplaceApprover(id string, nodeID string, actor string, oldApprover string, newApprover string, reason string) error
 
+	// MigrateTaskToVersion 将任务迁移到指定的模板版本
+	// id: 任务 ID
+	// version: 目标模板版本号
+	// nodeMapping: 旧节点 ID 到新节点 ID 的映射(可选),未出现在映射中的节点保持原 ID
+	// 返回: 错误信息
+	// 注意: 任务创建后固定使用创建时的模板版本,发布新版本不影响进行中的任务,需要升级时显式调用此方法
+	// actor: 操作人 ID,默认授权策略下必须是管理员,记录在迁移生成的审批记录中
+	// 任务当前节点(映射后)必须存在于目标版本中,已结束的任务不能迁移
+	MigrateTaskToVersion(id string, version int, nodeMapping map[string]string, actor string) error
+}
@@ -0,0 +1,46 @@
+package task
+
+import (
+	"time"
+
+	internalTask "github.com/mautops/approval-kit/internal/task"
+)
+
+// Clock 时钟接口
+// 与 internal/task.Clock 接口相同,但位于 pkg 目录,可以被外部导入
+type Clock = internalTask.Clock
+
+// TimeoutScheduler 超时调度器
+// 与 internal/task.TimeoutScheduler 相同,但位于 pkg 目录,可以被外部导入
+type TimeoutScheduler = internalTask.TimeoutScheduler
+
+// SchedulerOption 超时调度器可选配置
+// 与 internal/task.SchedulerOption 相同,但位于 pkg 目录,可以被外部导入
+type SchedulerOption = internalTask.SchedulerOption
+
+// NewTimeoutScheduler 创建超时调度器
+func NewTimeoutScheduler(opts ...SchedulerOption) *TimeoutScheduler {
+	return internalTask.NewTimeoutScheduler(opts...)
+}
+
+// WithSchedulerClock 设置超时调度器使用的时钟
+func WithSchedulerClock(clock Clock) SchedulerOption {
+	return internalTask.WithSchedulerClock(clock)
+}
+
+// WithSchedulerErrorHandler 设置超时处理失败时的回调
+func WithSchedulerErrorHandler(handler func(taskID string, err error)) SchedulerOption {
+	return internalTask.WithSchedulerErrorHandler(handler)
+}
+
+// WithSchedulerRetryBackoff 设置超时处理失败后的重试等待时间
+func WithSchedulerRetryBackoff(initial, max time.Duration) SchedulerOption {
+	return internalTask.WithSchedulerRetryBackoff(initial, max)
+}
+
+// TimeoutApprover 超时动作生成的审批记录中使用的审批人
+const TimeoutApprover = internalTask.TimeoutApprover
+
+// EscalationResolver 超时升级审批人解析函数
+// 与 internal/task.EscalationResolver 相同,但位于 pkg 目录,可以被外部导入
+type EscalationResolver = internalTask.EscalationResolver
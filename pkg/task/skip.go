@@ -0,0 +1,15 @@
+package task
+
+import (
+	internalTask "github.com/mautops/approval-kit/internal/task"
+)
+
+// SystemApprover 系统生成的审批记录中使用的审批人(如超时动作、跳过规则)
+const SystemApprover = internalTask.SystemApprover
+
+// 跳过规则生成的系统审批记录的审批结果
+const (
+	RecordResultSkipNode          = internalTask.RecordResultSkipNode
+	RecordResultSkipApprover      = internalTask.RecordResultSkipApprover
+	RecordResultEscalateInitiator = internalTask.RecordResultEscalateInitiator
+)
@@ -0,0 +1,22 @@
+package task
+
+import (
+	"github.com/mautops/approval-kit/internal/errors"
+	internalTask "github.com/mautops/approval-kit/internal/task"
+)
+
+// TaskStore 任务持久化存储接口
+// 与 internal/task.TaskStore 接口相同,但位于 pkg 目录,可以被外部导入
+type TaskStore = internalTask.TaskStore
+
+// NewMemoryTaskStore 创建内存任务存储
+func NewMemoryTaskStore() TaskStore {
+	return internalTask.NewMemoryTaskStore()
+}
+
+// OutboxTaskStore 支持事务性发件箱的任务存储
+// 与 internal/task.OutboxTaskStore 接口相同,但位于 pkg 目录,可以被外部导入
+type OutboxTaskStore = internalTask.OutboxTaskStore
+
+// ErrOutboxAppendFailed 表示任务已保存,但操作产生的事件写入发件箱失败,可以通过 errors.Is 判断
+var ErrOutboxAppendFailed = errors.ErrOutboxAppendFailed
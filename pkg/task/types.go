@@ -18,3 +18,8 @@ type Record = internalTask.Record
 // 与 internal/task.StateChange 结构相同,但位于 pkg 目录,可以被外部导入
 type StateChange = internalTask.StateChange
 
+
+// Reminder 催办记录
+// 每次催办(手动或自动)生成一条,记录被催办的审批人
+// 与 internal/task.Reminder 结构相同,但位于 pkg 目录,可以被外部导入
+type Reminder = internalTask.Reminder
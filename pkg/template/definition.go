@@ -0,0 +1,55 @@
+package template
+
+import (
+	internalTemplate "github.com/mautops/approval-kit/internal/template"
+)
+
+// Definition 模板定义
+// 模板的声明式描述,可以保存为 JSON 或 YAML 文件并纳入版本管理
+// 与 internal/template.Definition 结构相同,但位于 pkg 目录,可以被外部导入
+type Definition = internalTemplate.Definition
+
+// NodeDefinition 节点定义
+type NodeDefinition = internalTemplate.NodeDefinition
+
+// EdgeDefinition 边定义
+type EdgeDefinition = internalTemplate.EdgeDefinition
+
+// ConfigDefinition 模板全局配置定义
+type ConfigDefinition = internalTemplate.ConfigDefinition
+
+// WebhookDefinition Webhook 配置定义
+type WebhookDefinition = internalTemplate.WebhookDefinition
+
+// AuthDefinition 认证配置定义
+type AuthDefinition = internalTemplate.AuthDefinition
+
+// ToDefinition 将模板转换为模板定义
+func ToDefinition(tpl *Template, codec NodeConfigCodec) (*Definition, error) {
+	return internalTemplate.ToDefinition(tpl, codec)
+}
+
+// FromDefinition 将模板定义转换为模板
+func FromDefinition(def *Definition, codec NodeConfigCodec) (*Template, error) {
+	return internalTemplate.FromDefinition(def, codec)
+}
+
+// MarshalJSON 将模板编码为 JSON 格式的模板定义
+func MarshalJSON(tpl *Template, codec NodeConfigCodec) ([]byte, error) {
+	return internalTemplate.MarshalJSON(tpl, codec)
+}
+
+// UnmarshalJSON 从 JSON 格式的模板定义解码模板
+func UnmarshalJSON(data []byte, codec NodeConfigCodec) (*Template, error) {
+	return internalTemplate.UnmarshalJSON(data, codec)
+}
+
+// MarshalYAML 将模板编码为 YAML 格式的模板定义
+func MarshalYAML(tpl *Template, codec NodeConfigCodec) ([]byte, error) {
+	return internalTemplate.MarshalYAML(tpl, codec)
+}
+
+// UnmarshalYAML 从 YAML 格式的模板定义解码模板
+func UnmarshalYAML(data []byte, codec NodeConfigCodec) (*Template, error) {
+	return internalTemplate.UnmarshalYAML(data, codec)
+}
@@ -1,5 +1,9 @@
 package template
 
+import (
+	internalTemplate "github.com/mautops/approval-kit/internal/template"
+)
+
 // TemplateManager 模板管理接口
 // 负责审批模板的创建、更新、查询和删除操作
 // 遵循接口隔离原则,提供小而专注的接口
@@ -35,3 +39,8 @@ type TemplateManager interface {
 	ListVersions(id string) ([]int, error)
 }
 
+// NewTemplateManager 创建新的模板管理器
+// opts: 可选配置(如 WithTemplateStore),未设置存储时使用内存存储
+func NewTemplateManager(opts ...ManagerOption) TemplateManager {
+	return internalTemplate.NewTemplateManager(opts...)
+}
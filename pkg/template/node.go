@@ -25,6 +25,11 @@ type ApprovalNodeConfigAccessor = internalTemplate.ApprovalNodeConfigAccessor
 // 与 internal/template.OperationPermissionsAccessor 接口定义完全一致,但位于 pkg 目录,可以被外部导入
 type OperationPermissionsAccessor = internalTemplate.OperationPermissionsAccessor
 
+// ExclusiveGatewayAccessor 排他网关配置访问接口
+// 用于在不导入 node 包的情况下识别排他网关并检查出边上的条件表达式
+// 与 internal/template.ExclusiveGatewayAccessor 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type ExclusiveGatewayAccessor = internalTemplate.ExclusiveGatewayAccessor
+
 // NodeFromInternal 将 internal.Node 转换为 pkg.Node
 func NodeFromInternal(n *internalTemplate.Node) *Node {
 	return (*Node)(n)
@@ -0,0 +1,31 @@
+package template
+
+import (
+	internalTemplate "github.com/mautops/approval-kit/internal/template"
+)
+
+// SkipAction 跳过规则的处理方式
+// 与 internal/template.SkipAction 相同,但位于 pkg 目录,可以被外部导入
+type SkipAction = internalTemplate.SkipAction
+
+// 跳过规则的处理方式
+const (
+	SkipActionNone        = internalTemplate.SkipActionNone
+	SkipActionAutoApprove = internalTemplate.SkipActionAutoApprove
+	SkipActionEscalate    = internalTemplate.SkipActionEscalate
+	SkipActionSkip        = internalTemplate.SkipActionSkip
+)
+
+// SkipPolicy 审批人去重和跳过规则
+// 与 internal/template.SkipPolicy 结构相同,但位于 pkg 目录,可以被外部导入
+type SkipPolicy = internalTemplate.SkipPolicy
+
+// SkipPolicyAccessor 跳过规则访问接口
+// 与 internal/template.SkipPolicyAccessor 接口定义完全一致,但位于 pkg 目录,可以被外部导入
+type SkipPolicyAccessor = internalTemplate.SkipPolicyAccessor
+
+// EffectiveSkipPolicy 返回审批节点生效的跳过规则
+// 节点配置的规则优先于模板全局配置的规则
+func EffectiveSkipPolicy(tpl *Template, node *Node) *SkipPolicy {
+	return internalTemplate.EffectiveSkipPolicy(tpl, node)
+}
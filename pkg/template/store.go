@@ -0,0 +1,32 @@
+package template
+
+import (
+	internalTemplate "github.com/mautops/approval-kit/internal/template"
+)
+
+// TemplateStore 模板持久化存储接口
+// 与 internal/template.TemplateStore 接口相同,但位于 pkg 目录,可以被外部导入
+type TemplateStore = internalTemplate.TemplateStore
+
+// NodeConfigCodec 节点配置编解码器
+// 与 internal/template.NodeConfigCodec 接口相同,但位于 pkg 目录,可以被外部导入
+type NodeConfigCodec = internalTemplate.NodeConfigCodec
+
+// ManagerOption 模板管理器可选配置
+type ManagerOption = internalTemplate.ManagerOption
+
+// WithTemplateStore 设置模板存储
+func WithTemplateStore(store TemplateStore) ManagerOption {
+	return internalTemplate.WithTemplateStore(store)
+}
+
+// NewMemoryTemplateStore 创建内存模板存储
+func NewMemoryTemplateStore() TemplateStore {
+	return internalTemplate.NewMemoryTemplateStore()
+}
+
+// WithStrictValidation 启用严格验证
+// 启用后创建和更新模板时使用 ValidateTemplate 深度验证,存在错误级别的问题时返回 *ValidationError
+func WithStrictValidation() ManagerOption {
+	return internalTemplate.WithStrictValidation()
+}
@@ -0,0 +1,35 @@
+package template
+
+import (
+	internalTemplate "github.com/mautops/approval-kit/internal/template"
+)
+
+// Severity 验证问题的严重程度
+// 与 internal/template.Severity 类型相同,但位于 pkg 目录,可以被外部导入
+type Severity = internalTemplate.Severity
+
+// 验证问题严重程度常量
+const (
+	// SeverityError 错误: 模板无法正确执行
+	SeverityError Severity = internalTemplate.SeverityError
+
+	// SeverityWarning 警告: 模板可以执行,但结构可能不符合预期
+	SeverityWarning Severity = internalTemplate.SeverityWarning
+)
+
+// ValidationIssue 模板验证发现的问题
+// 与 internal/template.ValidationIssue 结构相同,但位于 pkg 目录,可以被外部导入
+type ValidationIssue = internalTemplate.ValidationIssue
+
+// ValidationResult 模板验证结果
+// 与 internal/template.ValidationResult 结构相同,但位于 pkg 目录,可以被外部导入
+type ValidationResult = internalTemplate.ValidationResult
+
+// ValidationError 模板验证错误
+// 与 internal/template.ValidationError 结构相同,但位于 pkg 目录,可以被外部导入
+type ValidationError = internalTemplate.ValidationError
+
+// ValidateTemplate 深度验证模板,返回发现的所有问题
+func ValidateTemplate(tpl *Template) *ValidationResult {
+	return internalTemplate.ValidateTemplate(tpl)
+}
@@ -0,0 +1,11 @@
+package template
+
+import (
+	internalTemplate "github.com/mautops/approval-kit/internal/template"
+)
+
+// Webhook 认证类型
+const (
+	AuthTypeToken     = internalTemplate.AuthTypeToken
+	AuthTypeSignature = internalTemplate.AuthTypeSignature
+)
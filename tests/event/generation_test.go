@@ -28,7 +28,7 @@ func TestEventGenerationOnTaskCreate(t *testing.T) {
 	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -79,12 +79,12 @@ func TestEventGenerationOnTaskSubmit(t *testing.T) {
 	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -152,15 +152,15 @@ func TestEventGenerationOnApproval(t *testing.T) {
 				t.Fatalf("Create template failed: %v", err)
 			}
 
-			taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
+			taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier, testAuthorizer())
 
 			params := json.RawMessage(`{"amount": 1000}`)
-			tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+			tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 			if err != nil {
 				t.Fatalf("Create() failed: %v", err)
 			}
 
-			err = taskMgr.Submit(tsk.ID)
+			err = taskMgr.Submit(tsk.ID, "initiator-001")
 			if err != nil {
 				t.Fatalf("Submit() failed: %v", err)
 			}
@@ -215,15 +215,15 @@ func TestEventGenerationOnTaskApproved(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
+	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -265,15 +265,15 @@ func TestEventGenerationOnTaskRejected(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
+	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -318,12 +318,12 @@ func TestEventGenerationOnTaskCancelled(t *testing.T) {
 	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Cancel(tsk.ID, "cancelled by user")
+	err = taskMgr.Cancel(tsk.ID, "initiator-001", "cancelled by user")
 	if err != nil {
 		t.Fatalf("Cancel() failed: %v", err)
 	}
@@ -363,12 +363,12 @@ func TestEventGenerationOnNodeActivated(t *testing.T) {
 	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -409,15 +409,15 @@ func TestEventGenerationOnNodeCompleted(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
+	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -470,15 +470,15 @@ func TestEventGenerationAllEventTypes(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
+	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -523,7 +523,7 @@ func TestEventDataCompleteness(t *testing.T) {
 	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier)
 
 	params := json.RawMessage(`{"amount": 1000, "reason": "test"}`)
-	tsk, err := taskMgr.Create("tpl-event-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-event-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -590,6 +590,11 @@ func TestEventDataCompleteness(t *testing.T) {
 	}
 }
 
+// testAuthorizer 事件测试使用的授权策略,允许审批尚未获取审批人的节点(测试未启用流程引擎)
+func testAuthorizer() task.ManagerOption {
+	return task.WithAuthorizer(task.NewDefaultAuthorizer(task.AllowUnassignedApprovers()))
+}
+
 // createTestTemplateForEvent 创建用于事件测试的简单模板
 func createTestTemplateForEvent() *template.Template {
 	return &template.Template{
@@ -1,6 +1,7 @@
 package event_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -48,7 +49,7 @@ func TestEventHandlerHandle(t *testing.T) {
 		},
 	}
 
-	err := handler.Handle(evt)
+	err := handler.Handle(context.Background(), evt)
 	if err != nil {
 		t.Fatalf("Handle() failed: %v", err)
 	}
@@ -1,6 +1,7 @@
 package event_test
 
 import (
+	"context"
 	"sync"
 
 	"github.com/mautops/approval-kit/internal/event"
@@ -12,7 +13,7 @@ type mockEventHandler struct {
 	mu     sync.Mutex
 }
 
-func (m *mockEventHandler) Handle(evt *event.Event) error {
+func (m *mockEventHandler) Handle(ctx context.Context, evt *event.Event) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.events = append(m.events, evt)
@@ -1,6 +1,7 @@
 package event_test
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -120,7 +121,7 @@ type idempotentEventHandler struct {
 	mu           sync.Mutex
 }
 
-func (i *idempotentEventHandler) Handle(evt *event.Event) error {
+func (i *idempotentEventHandler) Handle(ctx context.Context, evt *event.Event) error {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
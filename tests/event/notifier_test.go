@@ -1,6 +1,7 @@
 package event_test
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -165,7 +166,7 @@ type slowEventHandler struct {
 	delay  time.Duration
 }
 
-func (s *slowEventHandler) Handle(evt *event.Event) error {
+func (s *slowEventHandler) Handle(ctx context.Context, evt *event.Event) error {
 	time.Sleep(s.delay)
 	s.mu.Lock()
 	defer s.mu.Unlock()
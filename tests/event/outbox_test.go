@@ -0,0 +1,213 @@
+package event_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+)
+
+// flakyHandler 前 failures 次处理失败的事件处理器
+type flakyHandler struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	events   []*event.Event
+}
+
+func (h *flakyHandler) Handle(ctx context.Context, evt *event.Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls++
+	if h.calls <= h.failures {
+		return fmt.Errorf("handler unavailable")
+	}
+	h.events = append(h.events, evt)
+	return nil
+}
+
+// newOutboxEvent 创建发件箱测试事件
+func newOutboxEvent(taskID string) *event.Event {
+	return &event.Event{
+		ID:   taskID + "-task_approved",
+		Type: event.EventTypeTaskApproved,
+		Time: time.Now(),
+		Task: &event.TaskInfo{ID: taskID, State: "approved"},
+	}
+}
+
+// TestOutboxDispatcherDelivers 测试投递器按写入顺序投递给每个处理器,并分别记录投递状态
+func TestOutboxDispatcherDelivers(t *testing.T) {
+	outbox := event.NewMemoryOutbox()
+	now := time.Now()
+	first, second := event.NewOutboxEntry(newOutboxEvent("task-001"), now), event.NewOutboxEntry(newOutboxEvent("task-002"), now)
+	if err := outbox.Append(first, second); err != nil {
+		t.Fatalf("Append() failed: %v", err)
+	}
+
+	billing, audit := &mockEventHandler{}, &mockEventHandler{}
+	dispatcher, err := event.NewOutboxDispatcher(outbox,
+		event.WithOutboxHandler("billing", billing),
+		event.WithOutboxHandler("audit", audit),
+	)
+	if err != nil {
+		t.Fatalf("NewOutboxDispatcher() failed: %v", err)
+	}
+
+	delivered, err := dispatcher.DispatchOnce(context.Background())
+	if err != nil || delivered != 4 {
+		t.Fatalf("DispatchOnce() = %d, %v, want 4", delivered, err)
+	}
+	if events := billing.GetEvents(); len(events) != 2 || events[0].Task.ID != "task-001" || events[1].Task.ID != "task-002" {
+		t.Errorf("billing events = %+v", events)
+	}
+
+	deliveries, err := outbox.Deliveries(first.ID)
+	if err != nil {
+		t.Fatalf("Deliveries() failed: %v", err)
+	}
+	if len(deliveries) != 2 || deliveries[0].Handler != "audit" || deliveries[1].Handler != "billing" {
+		t.Fatalf("deliveries = %+v", deliveries)
+	}
+	for _, d := range deliveries {
+		if d.Status != event.DeliveryStatusDelivered || d.Attempts != 1 {
+			t.Errorf("delivery = %+v, want delivered after 1 attempt", d)
+		}
+	}
+
+	// 已投递的事件不会重复投递
+	if delivered, err := dispatcher.DispatchOnce(context.Background()); err != nil || delivered != 0 {
+		t.Errorf("second DispatchOnce() = %d, %v, want 0", delivered, err)
+	}
+}
+
+// TestOutboxDispatcherRetryAndDeadLetter 测试投递失败按退避时间重试,达到上限后进入死信列表并可以重放
+func TestOutboxDispatcherRetryAndDeadLetter(t *testing.T) {
+	outbox := event.NewMemoryOutbox()
+	now := time.Now()
+	entry := event.NewOutboxEntry(newOutboxEvent("task-001"), now)
+	if err := outbox.Append(entry); err != nil {
+		t.Fatalf("Append() failed: %v", err)
+	}
+
+	handler := &flakyHandler{failures: 3}
+	var failed []*event.Delivery
+	dispatcher, err := event.NewOutboxDispatcher(outbox,
+		event.WithOutboxHandler("billing", handler),
+		event.WithMaxAttempts(3),
+		event.WithRetryBackoff(time.Minute),
+		event.WithDispatcherClock(func() time.Time { return now }),
+		event.WithDispatchErrorHandler(func(delivery *event.Delivery, err error) {
+			failed = append(failed, delivery)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewOutboxDispatcher() failed: %v", err)
+	}
+
+	// 第一次失败后 1 分钟重试,第二次失败后 2 分钟重试
+	for i, wait := range []time.Duration{time.Minute, 2 * time.Minute} {
+		if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+			t.Fatalf("DispatchOnce() failed: %v", err)
+		}
+		deliveries, _ := outbox.Deliveries(entry.ID)
+		if len(deliveries) != 1 || deliveries[0].Attempts != i+1 || deliveries[0].Status != event.DeliveryStatusPending ||
+			!deliveries[0].NextAttemptAt.Equal(now.Add(wait)) || deliveries[0].LastError != "handler unavailable" {
+			t.Fatalf("deliveries after attempt %d = %+v", i+1, deliveries)
+		}
+
+		// 未到重试时间不投递
+		if _, err := dispatcher.DispatchOnce(context.Background()); err != nil || handler.calls != i+1 {
+			t.Fatalf("DispatchOnce() before backoff: calls = %d, err = %v", handler.calls, err)
+		}
+		now = now.Add(wait)
+	}
+
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("DispatchOnce() failed: %v", err)
+	}
+	deadLetters, err := outbox.DeadLetters()
+	if err != nil {
+		t.Fatalf("DeadLetters() failed: %v", err)
+	}
+	if len(deadLetters) != 1 || deadLetters[0].Entry.ID != entry.ID || deadLetters[0].Delivery.Attempts != 3 ||
+		deadLetters[0].Entry.Event.Task.ID != "task-001" {
+		t.Fatalf("dead letters = %+v", deadLetters)
+	}
+	if len(failed) != 3 || failed[2].Status != event.DeliveryStatusDead {
+		t.Errorf("error handler deliveries = %+v", failed)
+	}
+
+	// 死信不再投递,重放后重新投递
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil || handler.calls != 3 {
+		t.Fatalf("DispatchOnce() dead letter: calls = %d, err = %v", handler.calls, err)
+	}
+	if err := outbox.Replay(entry.ID, "billing"); err != nil {
+		t.Fatalf("Replay() failed: %v", err)
+	}
+	if delivered, err := dispatcher.DispatchOnce(context.Background()); err != nil || delivered != 1 {
+		t.Fatalf("DispatchOnce() after replay = %d, %v, want 1", delivered, err)
+	}
+	if deadLetters, _ := outbox.DeadLetters(); len(deadLetters) != 0 {
+		t.Errorf("dead letters after replay = %+v", deadLetters)
+	}
+
+	if err := outbox.Replay(entry.ID, "billing"); !stderrors.Is(err, errors.ErrDeliveryNotFound) {
+		t.Errorf("Replay() delivered event error = %v, want ErrDeliveryNotFound", err)
+	}
+}
+
+// TestOutboxDispatcherStartStop 测试后台投递和停止
+func TestOutboxDispatcherStartStop(t *testing.T) {
+	outbox := event.NewMemoryOutbox()
+	handler := &mockEventHandler{}
+	dispatcher, err := event.NewOutboxDispatcher(outbox,
+		event.WithOutboxHandler("billing", handler),
+		event.WithDispatchInterval(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("NewOutboxDispatcher() failed: %v", err)
+	}
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	if err := dispatcher.Start(); err == nil {
+		t.Error("Start() twice should fail")
+	}
+
+	if err := outbox.Append(event.NewOutboxEntry(newOutboxEvent("task-001"), time.Now())); err != nil {
+		t.Fatalf("Append() failed: %v", err)
+	}
+	dispatcher.Wake()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(handler.GetEvents()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	dispatcher.Stop()
+	if len(handler.GetEvents()) != 1 {
+		t.Errorf("events = %d, want 1", len(handler.GetEvents()))
+	}
+}
+
+// TestNewOutboxDispatcherValidation 测试投递器配置校验
+func TestNewOutboxDispatcherValidation(t *testing.T) {
+	outbox := event.NewMemoryOutbox()
+	if _, err := event.NewOutboxDispatcher(nil); err == nil {
+		t.Error("NewOutboxDispatcher() without outbox should fail")
+	}
+	if _, err := event.NewOutboxDispatcher(outbox,
+		event.WithOutboxHandler("billing", &mockEventHandler{}),
+		event.WithOutboxHandler("billing", &mockEventHandler{}),
+	); err == nil {
+		t.Error("NewOutboxDispatcher() with duplicate handlers should fail")
+	}
+	if _, err := event.NewOutboxDispatcher(outbox, event.WithMaxAttempts(0)); err == nil {
+		t.Error("NewOutboxDispatcher() with zero max attempts should fail")
+	}
+}
@@ -1,6 +1,7 @@
 package event_test
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -112,7 +113,7 @@ type failingEventHandler struct {
 	events      []*event.Event
 }
 
-func (f *failingEventHandler) Handle(evt *event.Event) error {
+func (f *failingEventHandler) Handle(ctx context.Context, evt *event.Event) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -132,7 +133,7 @@ type alwaysFailingEventHandler struct {
 	attemptCount int
 }
 
-func (a *alwaysFailingEventHandler) Handle(evt *event.Event) error {
+func (a *alwaysFailingEventHandler) Handle(ctx context.Context, evt *event.Event) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -0,0 +1,159 @@
+package event_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/event"
+)
+
+// newSerializerEvent 创建包含全部信息的测试事件
+func newSerializerEvent() *event.Event {
+	return &event.Event{
+		ID:       "task-001-approval_operation-1",
+		Type:     event.EventTypeApprovalOp,
+		Time:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Task:     &event.TaskInfo{ID: "task-001", TemplateID: "expense", TemplateVersion: 2, BusinessID: "biz-001", State: "approving"},
+		Node:     &event.NodeInfo{ID: "manager", Name: "Manager", Type: "approval"},
+		Approval: &event.ApprovalInfo{NodeID: "manager", Approver: "user-002", OnBehalfOf: "user-001", Result: "approve", Comment: "ok"},
+		Business: &event.BusinessInfo{ID: "biz-001", Data: json.RawMessage(`{"amount":100}`)},
+	}
+}
+
+// TestJSONSerializerSchema 测试 JSON 结构的字段名和版本
+func TestJSONSerializerSchema(t *testing.T) {
+	evt := newSerializerEvent()
+	msg, err := event.JSONSerializer{}.Serialize(evt)
+	if err != nil {
+		t.Fatalf("Serialize() failed: %v", err)
+	}
+	if msg.Headers["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type = %q", msg.Headers["Content-Type"])
+	}
+
+	var wire map[string]interface{}
+	if err := json.Unmarshal(msg.Body, &wire); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if wire["schema_version"] != event.SchemaVersion || wire["type"] != "approval_operation" {
+		t.Errorf("wire = %v", wire)
+	}
+	task := wire["task"].(map[string]interface{})
+	if task["template_id"] != "expense" || task["template_version"] != float64(2) || task["business_id"] != "biz-001" {
+		t.Errorf("task = %v", task)
+	}
+	approval := wire["approval"].(map[string]interface{})
+	if approval["on_behalf_of"] != "user-001" || approval["node_id"] != "manager" {
+		t.Errorf("approval = %v", approval)
+	}
+	if _, exists := wire["reminder"]; exists {
+		t.Error("reminder should be omitted when empty")
+	}
+
+	got, err := event.JSONSerializer{}.Deserialize(msg.Headers, msg.Body)
+	if err != nil {
+		t.Fatalf("Deserialize() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, evt) {
+		t.Errorf("Deserialize() = %+v, want %+v", got, evt)
+	}
+
+	// 不支持的主版本
+	if _, err := (event.JSONSerializer{}).Deserialize(nil, []byte(`{"schema_version":"2.0","id":"evt"}`)); err == nil {
+		t.Error("Deserialize() should reject schema version 2.0")
+	}
+}
+
+// TestCloudEventsSerializer 测试 CloudEvents 结构化模式和二进制模式
+func TestCloudEventsSerializer(t *testing.T) {
+	evt := newSerializerEvent()
+
+	structured := event.CloudEventsSerializer{Source: "/approval/expense", DataSchema: "https://example.com/schemas/event/1.0"}
+	msg, err := structured.Serialize(evt)
+	if err != nil {
+		t.Fatalf("Serialize() structured failed: %v", err)
+	}
+	if msg.Headers["Content-Type"] != "application/cloudevents+json" {
+		t.Errorf("Content-Type = %q", msg.Headers["Content-Type"])
+	}
+	var ce map[string]interface{}
+	if err := json.Unmarshal(msg.Body, &ce); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	want := map[string]interface{}{
+		"specversion":     "1.0",
+		"id":              evt.ID,
+		"source":          "/approval/expense",
+		"type":            "io.approvalkit.approval_operation",
+		"subject":         "task-001",
+		"time":            "2026-01-02T03:04:05Z",
+		"datacontenttype": "application/json",
+		"dataschema":      "https://example.com/schemas/event/1.0",
+	}
+	for k, v := range want {
+		if ce[k] != v {
+			t.Errorf("cloud event %s = %v, want %v", k, ce[k], v)
+		}
+	}
+	if data, ok := ce["data"].(map[string]interface{}); !ok || data["schema_version"] != event.SchemaVersion {
+		t.Errorf("cloud event data = %v", ce["data"])
+	}
+	if got, err := structured.Deserialize(msg.Headers, msg.Body); err != nil || !reflect.DeepEqual(got, evt) {
+		t.Errorf("Deserialize() structured = %+v, %v", got, err)
+	}
+
+	binary := event.CloudEventsSerializer{Mode: event.CloudEventsBinary}
+	msg, err = binary.Serialize(evt)
+	if err != nil {
+		t.Fatalf("Serialize() binary failed: %v", err)
+	}
+	if msg.Headers["ce-specversion"] != "1.0" || msg.Headers["ce-id"] != evt.ID || msg.Headers["ce-source"] != event.DefaultCloudEventsSource ||
+		msg.Headers["ce-type"] != "io.approvalkit.approval_operation" || msg.Headers["Content-Type"] != "application/json" {
+		t.Errorf("binary headers = %v", msg.Headers)
+	}
+	if got, err := binary.Deserialize(msg.Headers, msg.Body); err != nil || !reflect.DeepEqual(got, evt) {
+		t.Errorf("Deserialize() binary = %+v, %v", got, err)
+	}
+
+	if _, err := (event.CloudEventsSerializer{Mode: "batched"}).Serialize(evt); err == nil {
+		t.Error("Serialize() should reject unsupported mode")
+	}
+}
+
+// TestWebhookHandlerCloudEventsBinary 测试 Webhook 使用 CloudEvents 二进制模式推送,接收方解析事件
+func TestWebhookHandlerCloudEventsBinary(t *testing.T) {
+	serializer := event.CloudEventsSerializer{Mode: event.CloudEventsBinary}
+	received := make(chan *event.Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("ReadAll() failed: %v", err)
+		}
+		evt, err := event.DecodeWebhookEvent(r, body, serializer)
+		if err != nil {
+			t.Errorf("DecodeWebhookEvent() failed: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Ce-Type") != "io.approvalkit.approval_operation" {
+			t.Errorf("Ce-Type = %q", r.Header.Get("Ce-Type"))
+		}
+		received <- evt
+	}))
+	defer server.Close()
+
+	handler := event.NewWebhookHandler(&event.WebhookConfig{URL: server.URL, Serializer: serializer})
+	evt := newSerializerEvent()
+	if err := handler.Handle(context.Background(), evt); err != nil {
+		t.Fatalf("Handle() failed: %v", err)
+	}
+	if got := <-received; !reflect.DeepEqual(got, evt) {
+		t.Errorf("received = %+v, want %+v", got, evt)
+	}
+}
@@ -0,0 +1,208 @@
+package event_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/event"
+)
+
+// newFilterEvent 创建用于过滤测试的事件
+func newFilterEvent(taskID string, eventType event.EventType, templateID, nodeID string) *event.Event {
+	return &event.Event{
+		ID:   fmt.Sprintf("%s-%s", taskID, eventType),
+		Type: eventType,
+		Time: time.Now(),
+		Task: &event.TaskInfo{ID: taskID, TemplateID: templateID},
+		Node: &event.NodeInfo{ID: nodeID},
+	}
+}
+
+// waitEvents 等待处理器收到指定数量的事件
+func waitEvents(t *testing.T, handler *mockEventHandler, want int) []*event.Event {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if events := handler.GetEvents(); len(events) >= want {
+			return events
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return handler.GetEvents()
+}
+
+// TestEventFilterMatch 测试事件过滤条件
+func TestEventFilterMatch(t *testing.T) {
+	evt := newFilterEvent("task-001", event.EventTypeApprovalOp, "expense", "manager")
+
+	tests := []struct {
+		name   string
+		filter *event.EventFilter
+		want   bool
+	}{
+		{"nil filter", nil, true},
+		{"empty filter", &event.EventFilter{}, true},
+		{"type matched", &event.EventFilter{Types: []event.EventType{event.EventTypeTaskApproved, event.EventTypeApprovalOp}}, true},
+		{"type not matched", &event.EventFilter{Types: []event.EventType{event.EventTypeTaskApproved}}, false},
+		{"template matched", &event.EventFilter{TemplateIDs: []string{"expense"}}, true},
+		{"template not matched", &event.EventFilter{TemplateIDs: []string{"leave"}}, false},
+		{"node matched", &event.EventFilter{NodeIDs: []string{"manager"}}, true},
+		{"node not matched", &event.EventFilter{NodeIDs: []string{"finance"}}, false},
+		{"predicate rejected", &event.EventFilter{Predicate: func(*event.Event) bool { return false }}, false},
+		{"all matched", &event.EventFilter{
+			Types:       []event.EventType{event.EventTypeApprovalOp},
+			TemplateIDs: []string{"expense"},
+			NodeIDs:     []string{"manager"},
+			Predicate:   func(e *event.Event) bool { return e.Task.ID == "task-001" },
+		}, true},
+		{"one field not matched", &event.EventFilter{
+			Types:       []event.EventType{event.EventTypeApprovalOp},
+			TemplateIDs: []string{"leave"},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(evt); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 没有节点信息的事件不满足节点过滤条件
+	if (&event.EventFilter{NodeIDs: []string{"manager"}}).Match(&event.Event{Type: event.EventTypeTaskCreated}) {
+		t.Error("Match() should reject events without node when NodeIDs is set")
+	}
+}
+
+// TestEventNotifierSubscription 测试处理器只收到订阅的事件
+func TestEventNotifierSubscription(t *testing.T) {
+	all := &mockEventHandler{}
+	approved := &mockEventHandler{}
+	expenseManager := &mockEventHandler{}
+
+	notifier := event.NewEventNotifier([]event.EventHandler{all}, 10,
+		event.WithSubscription(approved, &event.EventFilter{Types: []event.EventType{event.EventTypeTaskApproved}}),
+		event.WithSubscription(expenseManager, &event.EventFilter{TemplateIDs: []string{"expense"}, NodeIDs: []string{"manager"}}),
+	)
+	defer notifier.Stop()
+
+	notifier.Notify(newFilterEvent("task-001", event.EventTypeApprovalOp, "expense", "manager"))
+	notifier.Notify(newFilterEvent("task-001", event.EventTypeTaskApproved, "expense", "end"))
+	notifier.Notify(newFilterEvent("task-002", event.EventTypeApprovalOp, "leave", "manager"))
+
+	if got := len(waitEvents(t, all, 3)); got != 3 {
+		t.Errorf("all handler got %d events, want 3", got)
+	}
+	if events := waitEvents(t, approved, 1); len(events) != 1 || events[0].Type != event.EventTypeTaskApproved {
+		t.Errorf("approved handler got %v", events)
+	}
+	if events := waitEvents(t, expenseManager, 1); len(events) != 1 || events[0].ID != "task-001-approval_operation" {
+		t.Errorf("expense manager handler got %v", events)
+	}
+}
+
+// pushGauge 统计同时进行的推送数量
+type pushGauge struct {
+	mu        sync.Mutex
+	active    int
+	maxActive int
+}
+
+// orderedEventHandler 记录每个任务收到的事件序号的处理器
+type orderedEventHandler struct {
+	mu           sync.Mutex
+	gauge        *pushGauge
+	events       map[string][]int // 任务 ID -> 收到的事件序号
+	total        int
+	seqByEventID map[string]int
+}
+
+func (h *orderedEventHandler) Handle(ctx context.Context, evt *event.Event) error {
+	h.gauge.mu.Lock()
+	h.gauge.active++
+	if h.gauge.active > h.gauge.maxActive {
+		h.gauge.maxActive = h.gauge.active
+	}
+	h.gauge.mu.Unlock()
+
+	time.Sleep(time.Duration(rand.Intn(3)) * time.Millisecond)
+
+	h.gauge.mu.Lock()
+	h.gauge.active--
+	h.gauge.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events[evt.Task.ID] = append(h.events[evt.Task.ID], h.seqByEventID[evt.ID])
+	h.total++
+	return nil
+}
+
+// TestEventNotifierOrdering 测试同一任务的事件按顺序推送,且同时推送数量不超过 worker 数量
+func TestEventNotifierOrdering(t *testing.T) {
+	const (
+		workers       = 2
+		tasks         = 5
+		eventsPerTask = 20
+	)
+	handlers := []*orderedEventHandler{}
+	options := []event.NotifierOption{event.WithNotifierWorkers(workers)}
+	seqByEventID := make(map[string]int)
+	gauge := &pushGauge{}
+	for i := 0; i < 2; i++ {
+		h := &orderedEventHandler{gauge: gauge, events: make(map[string][]int), seqByEventID: seqByEventID}
+		handlers = append(handlers, h)
+		options = append(options, event.WithSubscription(h, nil))
+	}
+
+	evts := make([]*event.Event, 0, tasks*eventsPerTask)
+	for seq := 0; seq < eventsPerTask; seq++ {
+		for i := 0; i < tasks; i++ {
+			evt := newFilterEvent(fmt.Sprintf("task-%03d", i), event.EventTypeApprovalOp, "expense", "manager")
+			evt.ID = fmt.Sprintf("%s-%d", evt.ID, seq)
+			seqByEventID[evt.ID] = seq
+			evts = append(evts, evt)
+		}
+	}
+
+	notifier := event.NewEventNotifier(nil, len(evts), options...)
+	defer notifier.Stop()
+	for _, evt := range evts {
+		notifier.Notify(evt)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for _, h := range handlers {
+		for {
+			h.mu.Lock()
+			total := h.total
+			h.mu.Unlock()
+			if total == len(evts) || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	for idx, h := range handlers {
+		h.mu.Lock()
+		if h.total != len(evts) {
+			t.Fatalf("handler %d got %d events, want %d", idx, h.total, len(evts))
+		}
+		for taskID, seqs := range h.events {
+			for i, seq := range seqs {
+				if seq != i {
+					t.Fatalf("handler %d task %s events out of order: %v", idx, taskID, seqs)
+				}
+			}
+		}
+		h.mu.Unlock()
+	}
+	if gauge.maxActive > workers {
+		t.Errorf("max concurrent pushes = %d, want <= %d", gauge.maxActive, workers)
+	}
+}
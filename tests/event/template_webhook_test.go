@@ -0,0 +1,199 @@
+package event_test
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// webhookReceiver 记录收到的 Webhook 请求的测试服务器
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	events   []*event.Event
+	errs     []error
+}
+
+// newWebhookReceiver 创建 Webhook 接收服务器,key 不为空时验证签名
+func newWebhookReceiver(t *testing.T, key string) *webhookReceiver {
+	t.Helper()
+	r := &webhookReceiver{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, req)
+
+		var body []byte
+		var err error
+		if key != "" {
+			body, err = event.VerifyWebhookRequest(req, key, 0)
+		} else {
+			body, err = io.ReadAll(req.Body)
+		}
+		if err != nil {
+			r.errs = append(r.errs, err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var evt event.Event
+		if err := json.Unmarshal(body, &evt); err != nil {
+			r.errs = append(r.errs, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.events = append(r.events, &evt)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// received 返回收到的事件数量
+func (r *webhookReceiver) received() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// createWebhookTemplate 创建配置了 Webhook 的模板
+func createWebhookTemplate(t *testing.T, templateMgr template.TemplateManager, id string, webhooks ...*template.WebhookConfig) {
+	t.Helper()
+	tpl := &template.Template{
+		ID:   id,
+		Name: id,
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"end":   {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges:   []*template.Edge{{From: "start", To: "end"}},
+		Config:  &template.TemplateConfig{Webhooks: webhooks},
+		Version: 1,
+	}
+	if err := templateMgr.Create(tpl); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+}
+
+// newTemplateEvent 创建使用指定模板版本的任务事件
+func newTemplateEvent(templateID string, version int) *event.Event {
+	return &event.Event{
+		ID:   "task-001-task_approved-1",
+		Type: event.EventTypeTaskApproved,
+		Time: time.Now(),
+		Task: &event.TaskInfo{ID: "task-001", TemplateID: templateID, TemplateVersion: version, State: "approved"},
+	}
+}
+
+// TestTemplateWebhookHandlerRouting 测试事件只推送到任务所用模板声明的 Webhook,并按认证配置签名
+func TestTemplateWebhookHandlerRouting(t *testing.T) {
+	signed := newWebhookReceiver(t, "signing-key")
+	token := newWebhookReceiver(t, "")
+	other := newWebhookReceiver(t, "")
+
+	templateMgr := template.NewTemplateManager()
+	createWebhookTemplate(t, templateMgr, "expense",
+		&template.WebhookConfig{URL: signed.URL, Auth: &template.AuthConfig{Type: template.AuthTypeSignature, Key: "signing-key"}},
+		&template.WebhookConfig{URL: token.URL, Headers: map[string]string{"X-Tenant": "t-001"}, Auth: &template.AuthConfig{Type: template.AuthTypeToken, Token: "secret"}},
+	)
+	createWebhookTemplate(t, templateMgr, "leave", &template.WebhookConfig{URL: other.URL})
+	createWebhookTemplate(t, templateMgr, "silent")
+
+	handler := event.NewTemplateWebhookHandler(templateMgr, nil)
+	if err := handler.Handle(context.Background(), newTemplateEvent("expense", 1)); err != nil {
+		t.Fatalf("Handle() failed: %v", err)
+	}
+	if err := handler.Handle(context.Background(), newTemplateEvent("silent", 1)); err != nil {
+		t.Fatalf("Handle() template without webhooks failed: %v", err)
+	}
+
+	if signed.received() != 1 || len(signed.errs) != 0 {
+		t.Fatalf("signed receiver: events = %d, errors = %v", signed.received(), signed.errs)
+	}
+	if signed.events[0].Task.ID != "task-001" || signed.requests[0].Header.Get(event.HeaderEventID) != "task-001-task_approved-1" {
+		t.Errorf("signed event = %+v, headers = %v", signed.events[0].Task, signed.requests[0].Header)
+	}
+	if token.received() != 1 {
+		t.Fatalf("token receiver: events = %d", token.received())
+	}
+	if got := token.requests[0].Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want Bearer secret", got)
+	}
+	if got := token.requests[0].Header.Get("X-Tenant"); got != "t-001" {
+		t.Errorf("X-Tenant = %q, want t-001", got)
+	}
+	if token.requests[0].Header.Get(event.HeaderSignature) != "" {
+		t.Error("token webhook should not be signed")
+	}
+	if other.received() != 0 {
+		t.Errorf("other template receiver got %d events, want 0", other.received())
+	}
+}
+
+// TestTemplateWebhookHandlerFailure 测试推送失败或模板不存在时返回错误
+func TestTemplateWebhookHandlerFailure(t *testing.T) {
+	// 签名密钥不一致,接收方拒绝请求
+	receiver := newWebhookReceiver(t, "receiver-key")
+	templateMgr := template.NewTemplateManager()
+	createWebhookTemplate(t, templateMgr, "expense",
+		&template.WebhookConfig{URL: receiver.URL, Auth: &template.AuthConfig{Type: template.AuthTypeSignature, Key: "wrong-key"}})
+
+	handler := event.NewTemplateWebhookHandler(templateMgr, nil)
+	if err := handler.Handle(context.Background(), newTemplateEvent("expense", 1)); err == nil {
+		t.Error("Handle() should fail when the receiver rejects the signature")
+	}
+	if len(receiver.errs) != 1 || !stderrors.Is(receiver.errs[0], errors.ErrInvalidSignature) {
+		t.Errorf("receiver errors = %v, want ErrInvalidSignature", receiver.errs)
+	}
+
+	if err := handler.Handle(context.Background(), newTemplateEvent("missing", 1)); !stderrors.Is(err, errors.ErrTemplateNotFound) {
+		t.Errorf("Handle() missing template error = %v, want ErrTemplateNotFound", err)
+	}
+}
+
+// TestVerifyWebhookSignature 测试签名验证拒绝篡改和过期的请求
+func TestVerifyWebhookSignature(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"ID":"evt-001"}`)
+	ts := now.Unix()
+	signature := event.SignWebhook("key", ts, body)
+	timestamp := strconv.FormatInt(ts, 10)
+
+	if err := event.VerifyWebhookSignature("key", timestamp, body, signature, time.Minute, now); err != nil {
+		t.Fatalf("VerifyWebhookSignature() failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		timestamp string
+		body      []byte
+		now       time.Time
+	}{
+		{"wrong key", "other", timestamp, body, now},
+		{"tampered body", "key", timestamp, []byte(`{"ID":"evt-002"}`), now},
+		{"tampered timestamp", "key", strconv.FormatInt(ts+1, 10), body, now},
+		{"replayed", "key", timestamp, body, now.Add(2 * time.Minute)},
+		{"invalid timestamp", "key", "yesterday", body, now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := event.VerifyWebhookSignature(tt.key, tt.timestamp, tt.body, signature, time.Minute, tt.now)
+			if !stderrors.Is(err, errors.ErrInvalidSignature) {
+				t.Errorf("VerifyWebhookSignature() error = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package event_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -65,7 +66,7 @@ func TestWebhookHandler(t *testing.T) {
 	}
 
 	// 处理事件
-	err := handler.Handle(evt)
+	err := handler.Handle(context.Background(), evt)
 	if err != nil {
 		t.Fatalf("Handle() failed: %v", err)
 	}
@@ -126,7 +127,7 @@ func TestWebhookHandlerWithAuth(t *testing.T) {
 	}
 
 	// 处理事件
-	err := handler.Handle(evt)
+	err := handler.Handle(context.Background(), evt)
 	if err != nil {
 		t.Fatalf("Handle() failed: %v", err)
 	}
@@ -173,7 +174,7 @@ func TestWebhookHandlerError(t *testing.T) {
 	}
 
 	// 处理事件(应该返回错误)
-	err := handler.Handle(evt)
+	err := handler.Handle(context.Background(), evt)
 	if err == nil {
 		t.Error("Handle() should return error for 500 status")
 	}
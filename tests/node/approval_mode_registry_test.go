@@ -0,0 +1,139 @@
+package node_test
+
+import (
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+)
+
+// quorumModeHandler 自定义审批模式: 任意两人同意即通过,任意一人拒绝即拒绝
+type quorumModeHandler struct{}
+
+func (h *quorumModeHandler) Mode() node.ApprovalMode {
+	return "quorum"
+}
+
+func (h *quorumModeHandler) CheckCompletion(approvers []string, approvals map[string]*task.Approval, config *node.ApprovalNodeConfig) (bool, *node.ApprovalResult) {
+	approved := 0
+	for _, approver := range approvers {
+		approval, exists := approvals[approver]
+		if !exists {
+			continue
+		}
+		if approval.Result == "reject" {
+			return true, &node.ApprovalResult{Completed: true, Result: "reject"}
+		}
+		approved++
+	}
+	if approved >= 2 {
+		return true, &node.ApprovalResult{Completed: true, Result: "approve"}
+	}
+	return false, nil
+}
+
+// TestApprovalModeRegistryCustomMode 测试注册自定义审批模式
+func TestApprovalModeRegistryCustomMode(t *testing.T) {
+	registry := node.NewApprovalModeHandlerRegistry()
+	config := &node.ApprovalNodeConfig{
+		Mode:           "quorum",
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002", "user-003"}},
+	}
+
+	// 未注册时自定义模式无效
+	if err := config.ValidateWithRegistry(registry); err == nil {
+		t.Error("ValidateWithRegistry() should fail for unregistered mode")
+	}
+
+	registry.RegisterHandler("quorum", &quorumModeHandler{})
+	if registry.GetHandler("quorum") == nil {
+		t.Fatal("GetHandler() should return registered custom handler")
+	}
+	if err := config.ValidateWithRegistry(registry); err != nil {
+		t.Errorf("ValidateWithRegistry() failed for registered mode: %v", err)
+	}
+
+	// Validate 只接受内置模式
+	if err := config.Validate(); err == nil {
+		t.Error("Validate() should fail for custom mode")
+	}
+}
+
+// TestApprovalModeRegistryConcurrent 测试注册表并发注册和查找
+func TestApprovalModeRegistryConcurrent(t *testing.T) {
+	registry := node.NewApprovalModeHandlerRegistry()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			registry.RegisterHandler("quorum", &quorumModeHandler{})
+		}()
+		go func() {
+			defer wg.Done()
+			registry.GetHandler(node.ApprovalModeSingle)
+		}()
+	}
+	wg.Wait()
+
+	if registry.GetHandler("quorum") == nil {
+		t.Error("GetHandler() should return registered custom handler")
+	}
+}
+
+// TestSequentialModeCheckOrder 测试顺序审批模式的审批顺序检查
+func TestSequentialModeCheckOrder(t *testing.T) {
+	handler := node.NewApprovalModeHandlerRegistry().GetHandler(node.ApprovalModeSequential)
+	checker, ok := handler.(node.ApproverOrderChecker)
+	if !ok {
+		t.Fatal("sequential mode handler should implement ApproverOrderChecker")
+	}
+
+	approvers := []string{"user-001", "user-002", "user-003"}
+	approvals := map[string]*task.Approval{}
+
+	if err := checker.CheckOrder(approvers, approvals, "user-002"); !stderrors.Is(err, errors.ErrApprovalOutOfOrder) {
+		t.Errorf("CheckOrder() for user-002 before user-001 = %v, want ErrApprovalOutOfOrder", err)
+	}
+	if err := checker.CheckOrder(approvers, approvals, "user-001"); err != nil {
+		t.Errorf("CheckOrder() for user-001 failed: %v", err)
+	}
+
+	approvals["user-001"] = &task.Approval{Result: "approve", CreatedAt: time.Now()}
+	if err := checker.CheckOrder(approvers, approvals, "user-002"); err != nil {
+		t.Errorf("CheckOrder() for user-002 after user-001 failed: %v", err)
+	}
+	if err := checker.CheckOrder(approvers, approvals, "user-003"); err == nil {
+		t.Error("CheckOrder() for user-003 before user-002 should fail")
+	}
+}
+
+// TestProportionalModeRejectWhenThresholdUnreachable 测试比例会签无法达到阈值时拒绝
+func TestProportionalModeRejectWhenThresholdUnreachable(t *testing.T) {
+	handler := node.NewApprovalModeHandlerRegistry().GetHandler(node.ApprovalModeProportional)
+	config := &node.ApprovalNodeConfig{
+		Mode:                  node.ApprovalModeProportional,
+		ProportionalThreshold: &node.ProportionalThreshold{Required: 2, Total: 3},
+	}
+	approvers := []string{"user-001", "user-002", "user-003"}
+
+	// 一人拒绝,剩余两人仍可能达到阈值
+	approvals := map[string]*task.Approval{
+		"user-001": {Result: "reject", CreatedAt: time.Now()},
+	}
+	if completed, _ := handler.CheckCompletion(approvers, approvals, config); completed {
+		t.Error("CheckCompletion() should wait while threshold is still reachable")
+	}
+
+	// 两人拒绝,无法达到阈值
+	approvals["user-002"] = &task.Approval{Result: "reject", CreatedAt: time.Now()}
+	completed, result := handler.CheckCompletion(approvers, approvals, config)
+	if !completed || result == nil || result.Result != "reject" {
+		t.Errorf("CheckCompletion() = %v, %+v, want completed with reject", completed, result)
+	}
+}
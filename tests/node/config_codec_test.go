@@ -0,0 +1,156 @@
+package node_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// TestNodeConfigCodecRoundTrip 测试节点配置编码后解码保持一致
+func TestNodeConfigCodecRoundTrip(t *testing.T) {
+	timeout := 24 * time.Hour
+	tests := []struct {
+		name     string
+		nodeType template.NodeType
+		config   template.NodeConfig
+	}{
+		{
+			name:     "approval with fixed approvers",
+			nodeType: template.NodeTypeApproval,
+			config: &node.ApprovalNodeConfig{
+				Mode:                  node.ApprovalModeProportional,
+				ApproverConfig:        &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002"}},
+				Timeout:               &timeout,
+				RejectBehavior:        node.RejectBehaviorJump,
+				RejectTargetNode:      "start",
+				Permissions:           node.OperationPermissions{AllowTransfer: true},
+				RequireCommentField:   true,
+				ProportionalThreshold: &node.ProportionalThreshold{Required: 1, Total: 2},
+			},
+		},
+		{
+			name:     "approval with dynamic approvers",
+			nodeType: template.NodeTypeApproval,
+			config: &node.ApprovalNodeConfig{
+				Mode: node.ApprovalModeSingle,
+				ApproverConfig: &node.DynamicApproverConfig{
+					API: &node.HTTPAPIConfig{
+						URL:             "https://example.com/approvers",
+						Method:          "GET",
+						ParamMapping:    &node.ParamMapping{Source: "task_params", Path: "dept", Target: "dept"},
+						ResponseMapping: &node.ResponseMapping{Path: "data.approvers", Format: "json"},
+					},
+					Timing: node.ApproverTimingOnCreate,
+				},
+			},
+		},
+		{
+			name:     "approval with timeout policy",
+			nodeType: template.NodeTypeApproval,
+			config: &node.ApprovalNodeConfig{
+				Mode:           node.ApprovalModeSingle,
+				ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001"}},
+				TimeoutPolicy: &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+					{Type: node.TimeoutActionNotify, After: 24 * time.Hour},
+					{Type: node.TimeoutActionEscalate, After: 48 * time.Hour, EscalateTo: []string{"director-001"}},
+					{Type: node.TimeoutActionAutoReject, After: 72 * time.Hour},
+				}},
+				ReminderPolicy: &node.ReminderPolicy{Interval: 4 * time.Hour, MaxTimes: 3},
+			},
+		},
+		{
+			name:     "approval with skip policy",
+			nodeType: template.NodeTypeApproval,
+			config: &node.ApprovalNodeConfig{
+				Mode:           node.ApprovalModeUnanimous,
+				ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002"}},
+				SkipPolicy: &template.SkipPolicy{
+					DuplicateApprover: template.SkipActionAutoApprove,
+					InitiatorApprover: template.SkipActionEscalate,
+					EmptyApprovers:    template.SkipActionSkip,
+				},
+			},
+		},
+		{
+			name:     "approval with manager chain approvers",
+			nodeType: template.NodeTypeApproval,
+			config: &node.ApprovalNodeConfig{
+				Mode:           node.ApprovalModeSequential,
+				ApproverConfig: &node.ManagerApproverConfig{UserParam: "applicant", Levels: 2, Chain: true, Timing: node.ApproverTimingOnCreate},
+			},
+		},
+		{
+			name:     "approval with department head approvers",
+			nodeType: template.NodeTypeApproval,
+			config: &node.ApprovalNodeConfig{
+				Mode:           node.ApprovalModeOr,
+				ApproverConfig: &node.DepartmentHeadApproverConfig{DepartmentParam: "dept"},
+			},
+		},
+		{
+			name:     "approval with role approvers",
+			nodeType: template.NodeTypeApproval,
+			config: &node.ApprovalNodeConfig{
+				Mode:           node.ApprovalModeOr,
+				ApproverConfig: &node.RoleApproverConfig{Role: "finance-approver"},
+			},
+		},
+		{
+			name:     "composite condition",
+			nodeType: template.NodeTypeCondition,
+			config: &node.ConditionNodeConfig{
+				Condition: &node.Condition{
+					Type: "composite",
+					Config: &node.CompositeConditionConfig{
+						Operator: "and",
+						Conditions: []*node.Condition{
+							{Type: "numeric", Config: &node.NumericConditionConfig{Field: "amount", Operator: "gt", Value: 1000, Source: "task_params"}},
+							{Type: "enum", Config: &node.EnumConditionConfig{Field: "level", Operator: "in", Values: []string{"a", "b"}, Source: "task_params"}},
+						},
+					},
+				},
+				TrueNodeID:  "high",
+				FalseNodeID: "low",
+			},
+		},
+	}
+
+	codec := node.NewNodeConfigCodec()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := codec.Encode(tt.config)
+			if err != nil {
+				t.Fatalf("Encode() failed: %v", err)
+			}
+			got, err := codec.Decode(tt.nodeType, data)
+			if err != nil {
+				t.Fatalf("Decode() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.config) {
+				t.Errorf("Decode() = %+v, want %+v", got, tt.config)
+			}
+		})
+	}
+}
+
+// TestNodeConfigCodecNilAndUnknown 测试空配置和不支持的节点类型
+func TestNodeConfigCodecNilAndUnknown(t *testing.T) {
+	codec := node.NewNodeConfigCodec()
+
+	data, err := codec.Encode(nil)
+	if err != nil || data != nil {
+		t.Errorf("Encode(nil) = %s, %v, want nil", data, err)
+	}
+	if config, err := codec.Decode(template.NodeTypeStart, nil); err != nil || config != nil {
+		t.Errorf("Decode(start, nil) = %v, %v, want nil", config, err)
+	}
+	if _, err := codec.Decode(template.NodeTypeEnd, []byte(`{"a":1}`)); err == nil {
+		t.Error("Decode() should fail for node type without config")
+	}
+	if _, err := codec.Decode(template.NodeTypeApproval, []byte(`{"mode":"single","approver":{"type":"unknown","config":{}}}`)); err == nil {
+		t.Error("Decode() should fail for unknown approver config type")
+	}
+}
@@ -0,0 +1,76 @@
+package node_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// notifyNodeConfig 自定义节点配置
+type notifyNodeConfig struct {
+	Channel string `json:"channel"`
+}
+
+func (c *notifyNodeConfig) NodeType() template.NodeType { return "notify" }
+func (c *notifyNodeConfig) Validate() error             { return nil }
+
+// roleApproverConfig 自定义审批人配置
+type roleApproverConfig struct {
+	Role string `json:"role"`
+}
+
+func (c *roleApproverConfig) GetApprovers(ctx context.Context, nc *node.NodeContext) ([]string, error) {
+	return []string{c.Role}, nil
+}
+
+func (c *roleApproverConfig) GetTiming() node.ApproverTiming {
+	return node.ApproverTimingOnActivate
+}
+
+// TestRegisterNodeConfig 测试注册自定义节点配置类型
+func TestRegisterNodeConfig(t *testing.T) {
+	node.RegisterNodeConfig("notify", func() template.NodeConfig { return &notifyNodeConfig{} })
+
+	codec := node.NewNodeConfigCodec()
+	config := &notifyNodeConfig{Channel: "email"}
+	data, err := codec.Encode(config)
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	got, err := codec.Decode("notify", data)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("Decode() = %+v, want %+v", got, config)
+	}
+}
+
+// TestRegisterApproverConfig 测试注册自定义审批人配置类型
+func TestRegisterApproverConfig(t *testing.T) {
+	codec := node.NewNodeConfigCodec()
+	config := &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeSingle,
+		ApproverConfig: &roleApproverConfig{Role: "finance"},
+	}
+
+	if _, err := codec.Encode(config); err == nil {
+		t.Fatal("Encode() should fail for unregistered approver config")
+	}
+
+	node.RegisterApproverConfig("role", func() node.ApproverConfig { return &roleApproverConfig{} })
+	data, err := codec.Encode(config)
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	got, err := codec.Decode(template.NodeTypeApproval, data)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("Decode() = %+v, want %+v", got, config)
+	}
+}
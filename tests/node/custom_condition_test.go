@@ -0,0 +1,158 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// isVIPCustomer 测试使用的自定义条件函数: 任务参数中的客户等级不低于参数中的最低等级
+func isVIPCustomer(ctx *node.NodeContext, args json.RawMessage) (bool, error) {
+	var params struct {
+		Level int `json:"customer_level"`
+	}
+	if err := json.Unmarshal(ctx.Task.Params, &params); err != nil {
+		return false, err
+	}
+	var config struct {
+		MinLevel int `json:"min_level"`
+	}
+	if err := json.Unmarshal(args, &config); err != nil {
+		return false, err
+	}
+	return params.Level >= config.MinLevel, nil
+}
+
+// customCondition 创建自定义条件
+func customCondition(name string, args string) *node.Condition {
+	config := &node.CustomConditionConfig{Name: name}
+	if args != "" {
+		config.Args = json.RawMessage(args)
+	}
+	return &node.Condition{Type: "custom", Config: config}
+}
+
+// TestCustomConditionEvaluate 测试自定义条件调用注册的函数
+func TestCustomConditionEvaluate(t *testing.T) {
+	registry := node.NewConditionEvaluatorRegistry()
+	registry.RegisterFunction("isVIPCustomer", isVIPCustomer)
+	registry.RegisterFunction("broken", func(*node.NodeContext, json.RawMessage) (bool, error) {
+		return false, errors.New("crm unavailable")
+	})
+
+	evaluator := registry.GetEvaluator("custom")
+	if evaluator == nil || !evaluator.Supports("custom") {
+		t.Fatal("registry should have an evaluator for custom conditions")
+	}
+
+	ctx := &node.NodeContext{Task: newFlowTask(`{"customer_level": 3}`)}
+	tests := []struct {
+		name      string
+		condition *node.Condition
+		want      bool
+		wantErr   string
+	}{
+		{"matched", customCondition("isVIPCustomer", `{"min_level": 3}`), true, ""},
+		{"not matched", customCondition("isVIPCustomer", `{"min_level": 5}`), false, ""},
+		{"function error", customCondition("broken", ""), false, "crm unavailable"},
+		{"not registered", customCondition("isBlacklisted", ""), false, `not registered: "isBlacklisted"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluator.Evaluate(tt.condition, ctx)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Evaluate() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 自定义条件可以作为组合条件的子条件
+	composite := &node.Condition{Type: "composite", Config: &node.CompositeConditionConfig{
+		Operator:   "and",
+		Conditions: []*node.Condition{customCondition("isVIPCustomer", `{"min_level": 1}`), exprCondition("customer_level < 5")},
+	}}
+	if got, err := registry.GetEvaluator("composite").Evaluate(composite, ctx); err != nil || !got {
+		t.Errorf("composite Evaluate() = %v, %v, want true", got, err)
+	}
+}
+
+// TestCustomConditionConfigValidate 测试自定义条件配置验证和编码
+func TestCustomConditionConfigValidate(t *testing.T) {
+	if err := customCondition("isVIPCustomer", `{"min_level": 3}`).Validate(); err != nil {
+		t.Errorf("Validate() failed: %v", err)
+	}
+	if err := customCondition("", "").Validate(); err == nil {
+		t.Error("Validate() should require Name")
+	}
+	if err := customCondition("isVIPCustomer", `{"min_level":`).Validate(); err == nil {
+		t.Error("Validate() should reject invalid Args")
+	}
+
+	codec := node.NewNodeConfigCodec()
+	config := &node.ConditionNodeConfig{
+		Condition:   customCondition("isVIPCustomer", `{"min_level":3}`),
+		TrueNodeID:  "manager",
+		FalseNodeID: "end",
+	}
+	data, err := codec.Encode(config)
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	got, err := codec.Decode(template.NodeTypeCondition, data)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("Decode() = %+v, want %+v", got, config)
+	}
+}
+
+// TestFlowEngineCustomCondition 测试流程引擎使用注册表中的自定义条件函数选择分支
+func TestFlowEngineCustomCondition(t *testing.T) {
+	tpl := createMultiStageTemplate()
+	tpl.Nodes["condition"].Config.(*node.ConditionNodeConfig).Condition = customCondition("isVIPCustomer", `{"min_level": 3}`)
+
+	// 默认注册表没有注册函数
+	if _, err := node.NewFlowEngine().Advance(context.Background(), tpl, newFlowTask(`{"customer_level": 3}`), "start"); err == nil {
+		t.Error("Advance() should fail when the custom function is not registered")
+	}
+
+	registry := node.NewConditionEvaluatorRegistry()
+	registry.RegisterFunction("isVIPCustomer", isVIPCustomer)
+	engine := node.NewFlowEngine(node.WithConditionEvaluatorRegistry(registry))
+
+	for params, want := range map[string]string{
+		`{"customer_level": 5}`: "manager",
+		`{"customer_level": 1}`: "end",
+	} {
+		tsk := newFlowTask(params)
+		if _, err := engine.Advance(context.Background(), tpl, tsk, "start"); err != nil {
+			t.Fatalf("Advance(%s) failed: %v", params, err)
+		}
+		if tsk.CurrentNode != want {
+			t.Errorf("Advance(%s) CurrentNode = %q, want %q", params, tsk.CurrentNode, want)
+		}
+	}
+
+	// 单独使用条件节点执行器
+	executor := node.NewConditionNodeExecutorWithRegistry(registry)
+	result, err := executor.Execute(&node.NodeContext{Task: newFlowTask(`{"customer_level": 4}`), Node: tpl.Nodes["condition"]})
+	if err != nil || result.NextNodeID != "manager" {
+		t.Errorf("Execute() = %+v, %v, want next node manager", result, err)
+	}
+}
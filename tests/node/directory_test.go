@@ -0,0 +1,233 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"reflect"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// newTestDirectory 创建测试用组织架构
+// 部门: company(ceo) -> rd(cto) -> backend(lead)
+// 用户: dev-001 属于 backend,直属上级为部门负责人 lead;
+// dev-002 属于 backend,直属上级指定为 mentor
+func newTestDirectory(t *testing.T) *node.MemoryDirectory {
+	t.Helper()
+	directory := node.NewMemoryDirectory()
+	departments := []*node.Department{
+		{ID: "company", Name: "Company", Head: "ceo"},
+		{ID: "rd", Name: "R&D", Head: "cto", Parent: "company"},
+		{ID: "backend", Name: "Backend", Head: "lead", Parent: "rd"},
+	}
+	for _, department := range departments {
+		if err := directory.AddDepartment(department); err != nil {
+			t.Fatalf("AddDepartment() failed: %v", err)
+		}
+	}
+	users := []*node.DirectoryUser{
+		{ID: "ceo", Department: "company"},
+		{ID: "cto", Department: "rd", Roles: []string{"finance-approver"}},
+		{ID: "lead", Department: "backend"},
+		{ID: "mentor", Department: "backend", Manager: "lead", Roles: []string{"finance-approver"}},
+		{ID: "dev-001", Department: "backend"},
+		{ID: "dev-002", Department: "backend", Manager: "mentor"},
+	}
+	for _, user := range users {
+		if err := directory.AddUser(user); err != nil {
+			t.Fatalf("AddUser() failed: %v", err)
+		}
+	}
+	return directory
+}
+
+// TestMemoryDirectory 测试内存组织架构目录的查询
+func TestMemoryDirectory(t *testing.T) {
+	directory := newTestDirectory(t)
+	ctx := context.Background()
+
+	if err := directory.AddUser(&node.DirectoryUser{}); err == nil {
+		t.Error("AddUser() without ID should fail")
+	}
+	if _, err := directory.GetUser(ctx, "unknown"); !stderrors.Is(err, errors.ErrUserNotFound) {
+		t.Errorf("GetUser() unknown = %v, want ErrUserNotFound", err)
+	}
+	if _, err := directory.GetDepartment(ctx, "unknown"); !stderrors.Is(err, errors.ErrDepartmentNotFound) {
+		t.Errorf("GetDepartment() unknown = %v, want ErrDepartmentNotFound", err)
+	}
+
+	users, err := directory.GetUsersByRole(ctx, "finance-approver")
+	if err != nil || !reflect.DeepEqual(users, []string{"cto", "mentor"}) {
+		t.Errorf("GetUsersByRole() = %v, %v, want [cto mentor]", users, err)
+	}
+
+	// 未指定上级时沿部门层级查找负责人,部门负责人的上级是上级部门的负责人
+	managers := map[string]string{
+		"dev-001": "lead",
+		"dev-002": "mentor",
+		"lead":    "cto",
+		"cto":     "ceo",
+		"ceo":     "",
+	}
+	for userID, want := range managers {
+		got, err := directory.GetManager(ctx, userID)
+		if err != nil || got != want {
+			t.Errorf("GetManager(%q) = %q, %v, want %q", userID, got, err, want)
+		}
+	}
+}
+
+// TestDirectoryApproverConfigs 测试基于组织架构的审批人配置
+func TestDirectoryApproverConfigs(t *testing.T) {
+	directory := newTestDirectory(t)
+	nc := &node.NodeContext{
+		Params:    json.RawMessage(`{"initiator": "dev-001", "applicant": {"id": "dev-002", "dept": "rd"}}`),
+		Directory: directory,
+	}
+
+	tests := []struct {
+		name    string
+		config  node.ApproverConfig
+		want    []string
+		wantErr bool
+	}{
+		{"role", &node.RoleApproverConfig{Role: "finance-approver"}, []string{"cto", "mentor"}, false},
+		{"role without users", &node.RoleApproverConfig{Role: "auditor"}, nil, true},
+		{"fixed department head", &node.DepartmentHeadApproverConfig{Department: "backend"}, []string{"lead"}, false},
+		{"department head from params", &node.DepartmentHeadApproverConfig{DepartmentParam: "applicant.dept"}, []string{"cto"}, false},
+		{"department param missing", &node.DepartmentHeadApproverConfig{DepartmentParam: "dept"}, nil, true},
+		{"direct manager of initiator", &node.ManagerApproverConfig{}, []string{"lead"}, false},
+		{"second level manager", &node.ManagerApproverConfig{Levels: 2}, []string{"cto"}, false},
+		{"manager chain", &node.ManagerApproverConfig{UserParam: "applicant.id", Levels: 3, Chain: true}, []string{"mentor", "lead", "cto"}, false},
+		{"levels above top of chain", &node.ManagerApproverConfig{Levels: 10}, []string{"ceo"}, false},
+		{"injected directory", &node.ManagerApproverConfig{Directory: directory}, []string{"lead"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.GetApprovers(context.Background(), nc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetApprovers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetApprovers() = %v, want %v", got, tt.want)
+			}
+			if tt.config.GetTiming() != node.ApproverTimingOnActivate {
+				t.Errorf("GetTiming() = %q, want on_activate by default", tt.config.GetTiming())
+			}
+		})
+	}
+
+	// 任务有发起人时优先于任务参数中的 initiator
+	withInitiator := &node.NodeContext{Params: nc.Params, Directory: directory, Task: &task.Task{Initiator: "dev-002"}}
+	if got, err := (&node.ManagerApproverConfig{}).GetApprovers(context.Background(), withInitiator); err != nil || !reflect.DeepEqual(got, []string{"mentor"}) {
+		t.Errorf("GetApprovers() with task initiator = %v, %v, want [mentor]", got, err)
+	}
+
+	// 没有目录时返回错误
+	if _, err := (&node.RoleApproverConfig{Role: "finance-approver"}).GetApprovers(context.Background(), &node.NodeContext{}); err == nil {
+		t.Error("GetApprovers() without directory should fail")
+	}
+}
+
+// TestDirectoryApproverConfigValidate 测试审批节点配置验证时检查组织架构审批人配置
+func TestDirectoryApproverConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  node.ApproverConfig
+		wantErr bool
+	}{
+		{"role", &node.RoleApproverConfig{Role: "finance-approver"}, false},
+		{"role missing", &node.RoleApproverConfig{}, true},
+		{"department head", &node.DepartmentHeadApproverConfig{DepartmentParam: "dept"}, false},
+		{"department head missing", &node.DepartmentHeadApproverConfig{}, true},
+		{"department head ambiguous", &node.DepartmentHeadApproverConfig{Department: "rd", DepartmentParam: "dept"}, true},
+		{"manager", &node.ManagerApproverConfig{Levels: 2}, false},
+		{"manager negative levels", &node.ManagerApproverConfig{Levels: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &node.ApprovalNodeConfig{Mode: node.ApprovalModeOr, ApproverConfig: tt.config}
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !stderrors.Is(err, errors.ErrInvalidTemplate) {
+				t.Errorf("Validate() error = %v, want ErrInvalidTemplate", err)
+			}
+		})
+	}
+}
+
+// TestFlowEngineDirectoryApprovers 测试流程引擎使用组织架构目录获取审批人
+// 直属上级在任务创建时获取,部门负责人在节点激活时获取
+func TestFlowEngineDirectoryApprovers(t *testing.T) {
+	tpl := &template.Template{
+		ID: "directory-template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.ManagerApproverConfig{Timing: node.ApproverTimingOnCreate},
+				},
+			},
+			"head": {
+				ID:   "head",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.DepartmentHeadApproverConfig{DepartmentParam: "dept"},
+				},
+			},
+			"end": {ID: "end", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "head"},
+			{From: "head", To: "end"},
+		},
+	}
+	tsk := &task.Task{
+		ID:          "task-001",
+		TemplateID:  "directory-template",
+		Params:      json.RawMessage(`{"initiator": "dev-002", "dept": "rd"}`),
+		CurrentNode: "start",
+		Approvers:   make(map[string][]string),
+		Approvals:   make(map[string]map[string]*task.Approval),
+	}
+
+	engine := node.NewFlowEngine(node.WithDirectory(newTestDirectory(t)))
+	if err := engine.FetchApproversOnCreate(tpl, tsk); err != nil {
+		t.Fatalf("FetchApproversOnCreate() failed: %v", err)
+	}
+	if got := tsk.Approvers["manager"]; !reflect.DeepEqual(got, []string{"mentor"}) {
+		t.Errorf("Approvers[manager] after create = %v, want [mentor]", got)
+	}
+	if _, exists := tsk.Approvers["head"]; exists {
+		t.Errorf("Approvers[head] should be resolved on activation, got %v", tsk.Approvers["head"])
+	}
+
+	if _, err := engine.Advance(context.Background(), tpl, tsk, "start"); err != nil {
+		t.Fatalf("Advance() from start failed: %v", err)
+	}
+	approveNode(tsk, "manager", "mentor")
+	result, err := engine.Advance(context.Background(), tpl, tsk, "manager")
+	if err != nil {
+		t.Fatalf("Advance() from manager failed: %v", err)
+	}
+	if result.CurrentNode != "head" {
+		t.Fatalf("CurrentNode = %q, want head", result.CurrentNode)
+	}
+	if got := tsk.Approvers["head"]; !reflect.DeepEqual(got, []string{"cto"}) {
+		t.Errorf("Approvers[head] after activation = %v, want [cto]", got)
+	}
+}
@@ -1,6 +1,7 @@
 package node_test
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -88,7 +89,7 @@ func TestDynamicApproverConfigGetApprovers(t *testing.T) {
 		Cache:   node.NewContextCache(),
 	}
 
-	approvers, err := config.GetApprovers(ctx)
+	approvers, err := config.GetApprovers(context.Background(), ctx)
 	if err != nil {
 		t.Fatalf("DynamicApproverConfig.GetApprovers() failed: %v", err)
 	}
@@ -140,7 +141,7 @@ func TestDynamicApproverConfigGetApproversError(t *testing.T) {
 		Cache:   node.NewContextCache(),
 	}
 
-	_, err := config.GetApprovers(ctx)
+	_, err := config.GetApprovers(context.Background(), ctx)
 	if err == nil {
 		t.Error("DynamicApproverConfig.GetApprovers() should return error when API call fails")
 	}
@@ -184,7 +185,7 @@ func TestDynamicApproverConfigGetApproversInvalidResponse(t *testing.T) {
 		Cache:   node.NewContextCache(),
 	}
 
-	_, err := config.GetApprovers(ctx)
+	_, err := config.GetApprovers(context.Background(), ctx)
 	if err == nil {
 		t.Error("DynamicApproverConfig.GetApprovers() should return error when response is invalid")
 	}
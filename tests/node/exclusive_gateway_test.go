@@ -0,0 +1,209 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	apperrors "github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// createAmountTierTemplate 创建按金额分级审批的排他网关模板
+// amount < 1000 直接结束,1000-10000 经理审批,10000-100000 总监审批,其他(> 100000)默认由 CEO 审批
+func createAmountTierTemplate() *template.Template {
+	approval := func(id string) *template.Node {
+		return &template.Node{
+			ID:   id,
+			Name: id,
+			Type: template.NodeTypeApproval,
+			Config: &node.ApprovalNodeConfig{
+				Mode:           node.ApprovalModeSingle,
+				ApproverConfig: &node.FixedApproverConfig{Approvers: []string{id + "-001"}},
+			},
+		}
+	}
+	return &template.Template{
+		ID:   "amount-tier-template",
+		Name: "Amount Tier Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"tier": {
+				ID:     "tier",
+				Name:   "Amount Tier",
+				Type:   template.NodeTypeCondition,
+				Config: &node.ConditionNodeConfig{Mode: node.ConditionModeExclusive},
+			},
+			"manager":  approval("manager"),
+			"director": approval("director"),
+			"ceo":      approval("ceo"),
+			"end":      {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "tier"},
+			{From: "tier", To: "ceo", Default: true},
+			{From: "tier", To: "director", Condition: "amount < 100000", Priority: 3},
+			{From: "tier", To: "manager", Condition: "amount < 10000", Priority: 2},
+			{From: "tier", To: "end", Condition: "amount < 1000", Priority: 1},
+			{From: "manager", To: "end"},
+			{From: "director", To: "end"},
+			{From: "ceo", To: "end"},
+		},
+		Version: 1,
+	}
+}
+
+// TestExclusiveGatewayRouting 测试排他网关按优先级选择第一个满足条件的出边
+func TestExclusiveGatewayRouting(t *testing.T) {
+	tpl := createAmountTierTemplate()
+	if err := tpl.Validate(); err != nil {
+		t.Fatalf("Validate() failed: %v", err)
+	}
+	if err := template.ValidateTemplate(tpl).Err(); err != nil {
+		t.Fatalf("ValidateTemplate() failed: %v", err)
+	}
+
+	engine := node.NewFlowEngine()
+	tests := []struct {
+		params string
+		want   string
+	}{
+		{`{"amount": 500}`, "end"},
+		{`{"amount": 1000}`, "manager"},
+		{`{"amount": 9999}`, "manager"},
+		{`{"amount": 10000}`, "director"},
+		{`{"amount": 500000}`, "ceo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.params, func(t *testing.T) {
+			tsk := newFlowTask(tt.params)
+			result, err := engine.Advance(context.Background(), tpl, tsk, "start")
+			if err != nil {
+				t.Fatalf("Advance() failed: %v", err)
+			}
+			if tsk.CurrentNode != tt.want {
+				t.Errorf("CurrentNode = %q, want %q", tsk.CurrentNode, tt.want)
+			}
+			if tt.want == "end" && !result.Finished {
+				t.Error("flow should finish when routed to end")
+			}
+			if !strings.Contains(string(tsk.NodeOutputs["tier"]), `"next_node_id":"`+tt.want+`"`) {
+				t.Errorf("gateway output = %s, want next_node_id %q", tsk.NodeOutputs["tier"], tt.want)
+			}
+		})
+	}
+
+	// 优先级相同时按边的顺序评估
+	for _, edge := range tpl.Edges {
+		edge.Priority = 0
+	}
+	tsk := newFlowTask(`{"amount": 500}`)
+	if _, err := engine.Advance(context.Background(), tpl, tsk, "start"); err != nil {
+		t.Fatalf("Advance() failed: %v", err)
+	}
+	if tsk.CurrentNode != "director" {
+		t.Errorf("CurrentNode = %q, want director", tsk.CurrentNode)
+	}
+}
+
+// TestExclusiveGatewayValidation 测试排他网关的模板验证
+func TestExclusiveGatewayValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(tpl *template.Template)
+		wantErr string
+	}{
+		{
+			name: "no default edge",
+			modify: func(tpl *template.Template) {
+				tpl.Edges[1].Default = false
+				tpl.Edges[1].Condition = "amount >= 100000"
+			},
+			wantErr: "exclusive gateway requires a default edge",
+		},
+		{
+			name:    "multiple default edges",
+			modify:  func(tpl *template.Template) { tpl.Edges[2].Default, tpl.Edges[2].Condition = true, "" },
+			wantErr: "exclusive gateway has 2 default edges",
+		},
+		{
+			name:    "default edge with condition",
+			modify:  func(tpl *template.Template) { tpl.Edges[1].Condition = "amount > 0" },
+			wantErr: "default edge of exclusive gateway must not have a condition",
+		},
+		{
+			name:    "edge without condition",
+			modify:  func(tpl *template.Template) { tpl.Edges[3].Condition = "" },
+			wantErr: "requires a condition or must be the default edge",
+		},
+		{
+			name:    "invalid condition",
+			modify:  func(tpl *template.Template) { tpl.Edges[3].Condition = "amount <" },
+			wantErr: `invalid edge condition "amount <"`,
+		},
+		{
+			name: "branch fields in exclusive mode",
+			modify: func(tpl *template.Template) {
+				tpl.Nodes["tier"].Config.(*node.ConditionNodeConfig).TrueNodeID = "manager"
+			},
+			wantErr: "exclusive mode uses edge conditions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := createAmountTierTemplate()
+			tt.modify(tpl)
+
+			err := template.ValidateTemplate(tpl).Err()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateTemplate() error = %v, want %q", err, tt.wantErr)
+			}
+			if !errors.Is(err, apperrors.ErrInvalidTemplate) {
+				t.Errorf("ValidateTemplate() error should wrap ErrInvalidTemplate")
+			}
+		})
+	}
+
+	// Template.Validate 同样拒绝没有默认出边的排他网关
+	tpl := createAmountTierTemplate()
+	tpl.Edges[1].Default, tpl.Edges[1].Condition = false, "amount >= 100000"
+	if err := tpl.Validate(); err == nil || !strings.Contains(err.Error(), "requires a default edge") {
+		t.Errorf("Validate() error = %v, want default edge error", err)
+	}
+
+	config := &node.ConditionNodeConfig{Mode: "parallel"}
+	if err := config.Validate(); err == nil {
+		t.Error("Validate() should reject unknown mode")
+	}
+}
+
+// TestExclusiveGatewayDefinition 测试排他网关模板定义的编解码
+func TestExclusiveGatewayDefinition(t *testing.T) {
+	codec := node.NewNodeConfigCodec()
+	data, err := template.MarshalJSON(createAmountTierTemplate(), codec)
+	if err != nil {
+		t.Fatalf("MarshalJSON() failed: %v", err)
+	}
+	for _, want := range []string{`"mode": "exclusive"`, `"priority": 3`, `"default": true`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("definition JSON does not contain %s:\n%s", want, data)
+		}
+	}
+
+	tpl, err := template.UnmarshalJSON(data, codec)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() failed: %v", err)
+	}
+	if err := template.ValidateTemplate(tpl).Err(); err != nil {
+		t.Fatalf("ValidateTemplate() failed: %v", err)
+	}
+	tsk := newFlowTask(`{"amount": 20000}`)
+	if _, err := node.NewFlowEngine().Advance(context.Background(), tpl, tsk, "start"); err != nil {
+		t.Fatalf("Advance() failed: %v", err)
+	}
+	if tsk.CurrentNode != "director" {
+		t.Errorf("CurrentNode = %q, want director", tsk.CurrentNode)
+	}
+}
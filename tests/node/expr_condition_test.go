@@ -0,0 +1,224 @@
+package node_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// newExprContext 创建表达式条件测试使用的节点执行上下文
+func newExprContext(params string) *node.NodeContext {
+	submittedAt := time.Unix(1700000100, 0)
+	return &node.NodeContext{
+		Task: &task.Task{
+			ID:              "task-001",
+			TemplateID:      "expense",
+			TemplateVersion: 2,
+			BusinessID:      "biz-001",
+			Initiator:       "user-001",
+			State:           types.TaskStateApproving,
+			CurrentNode:     "route",
+			CreatedAt:       time.Unix(1700000000, 0),
+			SubmittedAt:     &submittedAt,
+			Params:          json.RawMessage(params),
+			NodeOutputs: map[string]json.RawMessage{
+				"risk_check": json.RawMessage(`{"score": 85, "tags": ["vip", "repeat"]}`),
+			},
+		},
+	}
+}
+
+// exprCondition 创建表达式条件
+func exprCondition(expression string) *node.Condition {
+	return &node.Condition{Type: "expr", Config: &node.ExprConditionConfig{Expression: expression}}
+}
+
+// TestExprConditionEvaluate 测试表达式条件评估
+func TestExprConditionEvaluate(t *testing.T) {
+	registry := node.NewConditionEvaluatorRegistry()
+	evaluator := registry.GetEvaluator("expr")
+	if evaluator == nil {
+		t.Fatal("registry should have an evaluator for expr conditions")
+	}
+
+	ctx := newExprContext(`{"amount": 12000, "dept": "rd", "urgent": false, "title": "  Server Purchase ", "items": [{"price": 100}, {"price": 250}], "meta": {"region": "cn"}}`)
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"amount > 10000 && dept in ['rd','ops'] || params.urgent", true},
+		{"amount > 20000 && dept in ['rd','ops'] || params.urgent", false},
+		{"params.amount * 2 - 4000 == 20000", true},
+		{"amount % 5000 == 2000 and not urgent", true},
+		{"dept not in ['rd', 'ops']", false},
+		{"(amount + 1) / 2 >= 6000.5", true},
+		{"-amount < 0", true},
+		{"lower(trim(title)) == 'server purchase'", true},
+		{"startsWith(trim(title), 'Server') && endsWith(trim(title), 'Purchase') && contains(title, 'ver P')", true},
+		{"upper(dept) + '-' + meta.region == 'RD-cn'", true},
+		{"matches(dept, '^r[a-z]$')", true},
+		{"len(items) == 2 && items[1].price > items[0].price && len('审批') == 2", true},
+		{"'region' in meta && 'ver' in title", true},
+		{"outputs.risk_check.score >= 80 && 'vip' in outputs.risk_check.tags", true},
+		{"outputs['risk_check'].score < 80", false},
+		{"task.template_id == 'expense' && task.template_version == 2 && task.state == 'approving'", true},
+		{"task.initiator == 'user-001' && task.business_id == 'biz-001' && task.id == 'task-001' && task.current_node == 'route'", true},
+		{"task.submitted_at - task.created_at == 100", true},
+		// 不存在的字段为 null,null 在逻辑运算中视为 false
+		{"params.missing || missing_flag", false},
+		{"missing == null && outputs.unknown_node.score == null", true},
+		{"!params.missing", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			condition := exprCondition(tt.expression)
+			if err := condition.Validate(); err != nil {
+				t.Fatalf("Validate() failed: %v", err)
+			}
+			got, err := evaluator.Evaluate(condition, ctx)
+			if err != nil {
+				t.Fatalf("Evaluate() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExprConditionRuntimeError 测试求值时的类型错误
+func TestExprConditionRuntimeError(t *testing.T) {
+	evaluator := node.NewExprConditionEvaluator()
+	ctx := newExprContext(`{"amount": "12000", "zero": 0, "dept": "rd"}`)
+
+	for _, expression := range []string{
+		"amount > 10000",
+		"10 / zero > 1",
+		"dept",
+		"dept && true",
+		"amount.value == 1",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := evaluator.Evaluate(exprCondition(expression), ctx); err == nil {
+				t.Errorf("Evaluate(%q) should fail", expression)
+			}
+		})
+	}
+}
+
+// TestExprConditionValidate 测试表达式的编译期检查
+func TestExprConditionValidate(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{"", "Expression is required"},
+		{"amount >", "end of expression"},
+		{"amount > 1)", `unexpected ")"`},
+		{"'unterminated", "unterminated string"},
+		{"amount # 1", "unexpected character"},
+		{"size(dept) > 1", `unknown function "size"`},
+		{"contains(dept) ", "takes 2 argument(s), got 1"},
+		{"lower(1) == 'a'", "argument 1 must be string, got number"},
+		{"task.owner == 'user-001'", `unknown task field "owner"`},
+		{"task.state > 1", "two numbers or two strings, got string and number"},
+		{"'a' + 1 == 'a1'", "operator + requires two numbers or two strings"},
+		{"amount - 'a' > 0", "operator - requires numbers"},
+		{"1 == 'a'", "cannot compare number with string"},
+		{"1 in 'abc'", "requires string on the left"},
+		{"dept in 10", "requires list, string or map on the right"},
+		{"amount && 1", "requires bool operands"},
+		{"!'a'", "operator ! requires bool"},
+		{"amount * 2", "must evaluate to bool, got number"},
+		{"len(dept)", "must evaluate to bool, got number"},
+		{"matches(dept, '[') ", "invalid regular expression"},
+		{"task.id.value == 1", "cannot access field of string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			err := exprCondition(tt.expression).Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// 组合条件中的表达式同样检查
+	composite := &node.Condition{Type: "composite", Config: &node.CompositeConditionConfig{
+		Operator:   "and",
+		Conditions: []*node.Condition{exprCondition("amount > 1"), exprCondition("unknown()")},
+	}}
+	if err := composite.Validate(); err == nil || !strings.Contains(err.Error(), `unknown function "unknown"`) {
+		t.Errorf("composite Validate() error = %v, want unknown function", err)
+	}
+}
+
+// TestExprConditionTemplate 测试模板验证时检查表达式,以及表达式条件的编码
+func TestExprConditionTemplate(t *testing.T) {
+	newTemplate := func(expression string) *template.Template {
+		return &template.Template{
+			ID:   "expense",
+			Name: "Expense",
+			Nodes: map[string]*template.Node{
+				"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+				"route": {ID: "route", Name: "Route", Type: template.NodeTypeCondition, Config: &node.ConditionNodeConfig{
+					Condition:   exprCondition(expression),
+					TrueNodeID:  "director",
+					FalseNodeID: "end",
+				}},
+				"director": {ID: "director", Name: "Director", Type: template.NodeTypeEnd},
+				"end":      {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+			},
+			Edges: []*template.Edge{
+				{From: "start", To: "route"},
+				{From: "route", To: "director"},
+				{From: "route", To: "end"},
+			},
+		}
+	}
+
+	if result := template.ValidateTemplate(newTemplate("amount > 10000 && dept in ['rd','ops'] || params.urgent")); !result.Valid() {
+		t.Fatalf("ValidateTemplate() errors = %v", result.Errors())
+	}
+	result := template.ValidateTemplate(newTemplate("amount > 10000 &&"))
+	if result.Valid() {
+		t.Fatal("ValidateTemplate() should reject an invalid expression")
+	}
+
+	// 基础模板验证同样编译表达式,包括组合条件中的子条件
+	if err := newTemplate("amount > 10000 &&").Validate(); err == nil {
+		t.Error("Validate() should reject an invalid expression")
+	}
+	nested := newTemplate("amount > 1")
+	nested.Nodes["route"].Config.(*node.ConditionNodeConfig).Condition = &node.Condition{Type: "composite", Config: &node.CompositeConditionConfig{
+		Operator:   "or",
+		Conditions: []*node.Condition{exprCondition("amount > 1"), exprCondition("unknown()")},
+	}}
+	if err := nested.Validate(); err == nil || !strings.Contains(err.Error(), `unknown function "unknown"`) {
+		t.Errorf("Validate() error = %v, want unknown function", err)
+	}
+	if err := newTemplate("amount > 1").Validate(); err != nil {
+		t.Errorf("Validate() failed: %v", err)
+	}
+
+	codec := node.NewNodeConfigCodec()
+	config := newTemplate("task.template_version >= 2").Nodes["route"].Config
+	data, err := codec.Encode(config)
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	got, err := codec.Decode(template.NodeTypeCondition, data)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("Decode() = %+v, want %+v", got, config)
+	}
+}
@@ -1,6 +1,7 @@
 package node_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -25,7 +26,7 @@ func TestFixedApproverConfig(t *testing.T) {
 	}
 
 	// 获取审批人
-	approvers, err := config.GetApprovers(ctx)
+	approvers, err := config.GetApprovers(context.Background(), ctx)
 	if err != nil {
 		t.Fatalf("FixedApproverConfig.GetApprovers() failed: %v", err)
 	}
@@ -54,7 +55,7 @@ func TestFixedApproverConfigIsolation(t *testing.T) {
 	}
 
 	// 获取审批人
-	approvers, err := config.GetApprovers(ctx)
+	approvers, err := config.GetApprovers(context.Background(), ctx)
 	if err != nil {
 		t.Fatalf("FixedApproverConfig.GetApprovers() failed: %v", err)
 	}
@@ -63,7 +64,7 @@ func TestFixedApproverConfigIsolation(t *testing.T) {
 	approvers[0] = "modified-user"
 
 	// 再次获取,验证原配置未被修改
-	approvers2, err := config.GetApprovers(ctx)
+	approvers2, err := config.GetApprovers(context.Background(), ctx)
 	if err != nil {
 		t.Fatalf("FixedApproverConfig.GetApprovers() failed on second call: %v", err)
 	}
@@ -102,7 +103,7 @@ func TestFixedApproverConfigEmpty(t *testing.T) {
 		Cache:   node.NewContextCache(),
 	}
 
-	approvers, err := config.GetApprovers(ctx)
+	approvers, err := config.GetApprovers(context.Background(), ctx)
 	if err != nil {
 		t.Fatalf("FixedApproverConfig.GetApprovers() failed: %v", err)
 	}
@@ -0,0 +1,275 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// createMultiStageTemplate 创建多级审批模板
+// start -> condition -> manager -> finance -> end, amount <= 1000 时条件节点直接跳转到 end
+func createMultiStageTemplate() *template.Template {
+	return &template.Template{
+		ID:   "multi-stage-template",
+		Name: "Multi Stage Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"condition": {
+				ID:   "condition",
+				Name: "Amount Check",
+				Type: template.NodeTypeCondition,
+				Config: &node.ConditionNodeConfig{
+					Condition: &node.Condition{
+						Type: "numeric",
+						Config: &node.NumericConditionConfig{
+							Field:    "amount",
+							Operator: "gt",
+							Value:    1000,
+							Source:   "task_params",
+						},
+					},
+					TrueNodeID:  "manager",
+					FalseNodeID: "end",
+				},
+			},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+				},
+			},
+			"finance": {
+				ID:   "finance",
+				Name: "Finance Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeUnanimous,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"finance-001", "finance-002"}},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "condition"},
+			{From: "condition", To: "manager"},
+			{From: "condition", To: "end"},
+			{From: "manager", To: "finance"},
+			{From: "finance", To: "end"},
+		},
+		Version: 1,
+	}
+}
+
+// newFlowTask 创建用于流程推进测试的任务
+func newFlowTask(params string) *task.Task {
+	return &task.Task{
+		ID:          "task-001",
+		TemplateID:  "multi-stage-template",
+		Params:      json.RawMessage(params),
+		CurrentNode: "start",
+		Approvers:   make(map[string][]string),
+		Approvals:   make(map[string]map[string]*task.Approval),
+	}
+}
+
+// approveNode 记录审批人的同意结果
+func approveNode(tsk *task.Task, nodeID string, approver string) {
+	if tsk.Approvals[nodeID] == nil {
+		tsk.Approvals[nodeID] = make(map[string]*task.Approval)
+	}
+	tsk.Approvals[nodeID][approver] = &task.Approval{Result: "approve", CreatedAt: time.Now()}
+}
+
+// TestFlowEngineImplementsInterface 测试流程引擎实现 task.FlowEngine 接口
+func TestFlowEngineImplementsInterface(t *testing.T) {
+	var _ task.FlowEngine = node.NewFlowEngine()
+}
+
+// TestFlowEngineAdvanceMultiStage 测试流程引擎逐个节点推进多级审批
+func TestFlowEngineAdvanceMultiStage(t *testing.T) {
+	engine := node.NewFlowEngine()
+	tpl := createMultiStageTemplate()
+	tsk := newFlowTask(`{"amount": 5000}`)
+
+	// 从开始节点推进,经过条件节点后停在经理审批节点
+	result, err := engine.Advance(context.Background(), tpl, tsk, "start")
+	if err != nil {
+		t.Fatalf("Advance() from start failed: %v", err)
+	}
+	if result.CurrentNode != "manager" || tsk.CurrentNode != "manager" {
+		t.Errorf("CurrentNode = %q (task %q), want %q", result.CurrentNode, tsk.CurrentNode, "manager")
+	}
+	if result.Finished || result.Rejected {
+		t.Errorf("Finished = %v, Rejected = %v, want both false", result.Finished, result.Rejected)
+	}
+	if got := tsk.CompletedNodes; len(got) != 2 || got[0] != "start" || got[1] != "condition" {
+		t.Errorf("CompletedNodes = %v, want [start condition]", got)
+	}
+	if _, exists := tsk.NodeOutputs["condition"]; !exists {
+		t.Error("NodeOutputs should contain condition node output")
+	}
+	if got := tsk.Approvers["manager"]; len(got) != 1 || got[0] != "manager-001" {
+		t.Errorf("Approvers[manager] = %v, want [manager-001]", got)
+	}
+
+	// 经理审批未完成时停留在当前节点
+	result, err = engine.Advance(context.Background(), tpl, tsk, "manager")
+	if err != nil {
+		t.Fatalf("Advance() on pending node failed: %v", err)
+	}
+	if result.CurrentNode != "manager" || len(result.Steps) != 0 {
+		t.Errorf("pending node: CurrentNode = %q, steps = %d, want manager and 0 steps", result.CurrentNode, len(result.Steps))
+	}
+
+	// 经理同意后进入财务审批节点
+	approveNode(tsk, "manager", "manager-001")
+	result, err = engine.Advance(context.Background(), tpl, tsk, "manager")
+	if err != nil {
+		t.Fatalf("Advance() after manager approval failed: %v", err)
+	}
+	if result.CurrentNode != "finance" {
+		t.Errorf("CurrentNode = %q, want %q", result.CurrentNode, "finance")
+	}
+	wantSteps := []task.FlowStep{
+		{NodeID: "manager", Action: task.FlowActionCompleted},
+		{NodeID: "finance", Action: task.FlowActionActivated},
+	}
+	if len(result.Steps) != len(wantSteps) {
+		t.Fatalf("Steps count = %d, want %d", len(result.Steps), len(wantSteps))
+	}
+	for i, want := range wantSteps {
+		if *result.Steps[i] != want {
+			t.Errorf("Steps[%d] = %+v, want %+v", i, *result.Steps[i], want)
+		}
+	}
+
+	// 会签模式: 一人同意仍需等待
+	approveNode(tsk, "finance", "finance-001")
+	result, err = engine.Advance(context.Background(), tpl, tsk, "finance")
+	if err != nil {
+		t.Fatalf("Advance() after first finance approval failed: %v", err)
+	}
+	if result.CurrentNode != "finance" || result.Finished {
+		t.Errorf("CurrentNode = %q, Finished = %v, want finance and false", result.CurrentNode, result.Finished)
+	}
+
+	// 全部同意后到达结束节点
+	approveNode(tsk, "finance", "finance-002")
+	result, err = engine.Advance(context.Background(), tpl, tsk, "finance")
+	if err != nil {
+		t.Fatalf("Advance() after all finance approvals failed: %v", err)
+	}
+	if !result.Finished || result.CurrentNode != "end" {
+		t.Errorf("Finished = %v, CurrentNode = %q, want true and end", result.Finished, result.CurrentNode)
+	}
+	want := []string{"start", "condition", "manager", "finance", "end"}
+	if len(tsk.CompletedNodes) != len(want) {
+		t.Fatalf("CompletedNodes = %v, want %v", tsk.CompletedNodes, want)
+	}
+	for i := range want {
+		if tsk.CompletedNodes[i] != want[i] {
+			t.Errorf("CompletedNodes[%d] = %q, want %q", i, tsk.CompletedNodes[i], want[i])
+		}
+	}
+}
+
+// TestFlowEngineAdvanceConditionBranch 测试条件节点选择分支后直接到达结束节点
+func TestFlowEngineAdvanceConditionBranch(t *testing.T) {
+	engine := node.NewFlowEngine()
+	tpl := createMultiStageTemplate()
+	tsk := newFlowTask(`{"amount": 100}`)
+
+	result, err := engine.Advance(context.Background(), tpl, tsk, "start")
+	if err != nil {
+		t.Fatalf("Advance() failed: %v", err)
+	}
+	if !result.Finished || tsk.CurrentNode != "end" {
+		t.Errorf("Finished = %v, CurrentNode = %q, want true and end", result.Finished, tsk.CurrentNode)
+	}
+	if _, exists := tsk.Approvers["manager"]; exists {
+		t.Error("approvers of skipped branch should not be resolved")
+	}
+}
+
+// TestFlowEngineAdvanceRejected 测试审批节点被拒绝时停止推进
+func TestFlowEngineAdvanceRejected(t *testing.T) {
+	engine := node.NewFlowEngine()
+	tpl := createMultiStageTemplate()
+	tsk := newFlowTask(`{"amount": 5000}`)
+
+	if _, err := engine.Advance(context.Background(), tpl, tsk, "start"); err != nil {
+		t.Fatalf("Advance() failed: %v", err)
+	}
+
+	tsk.Approvals["manager"] = map[string]*task.Approval{
+		"manager-001": {Result: "reject", CreatedAt: time.Now()},
+	}
+	result, err := engine.Advance(context.Background(), tpl, tsk, "manager")
+	if err != nil {
+		t.Fatalf("Advance() failed: %v", err)
+	}
+	if !result.Rejected || result.CurrentNode != "manager" {
+		t.Errorf("Rejected = %v, CurrentNode = %q, want true and manager", result.Rejected, result.CurrentNode)
+	}
+	for _, nodeID := range tsk.CompletedNodes {
+		if nodeID == "manager" {
+			t.Error("rejected node should not be marked as completed")
+		}
+	}
+}
+
+// TestFlowEngineAdvanceErrors 测试流程推进的错误场景
+func TestFlowEngineAdvanceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(tpl *template.Template)
+		nodeID string
+	}{
+		{
+			name:   "node not found",
+			modify: func(tpl *template.Template) {},
+			nodeID: "missing",
+		},
+		{
+			name: "no outgoing edge",
+			modify: func(tpl *template.Template) {
+				tpl.Edges = tpl.Edges[1:]
+			},
+			nodeID: "start",
+		},
+		{
+			name: "multiple outgoing edges without condition",
+			modify: func(tpl *template.Template) {
+				tpl.Edges = append(tpl.Edges, &template.Edge{From: "start", To: "end"})
+			},
+			nodeID: "start",
+		},
+		{
+			name: "cycle between automatic nodes",
+			modify: func(tpl *template.Template) {
+				tpl.Nodes["condition"].Config.(*node.ConditionNodeConfig).FalseNodeID = "start"
+			},
+			nodeID: "start",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := createMultiStageTemplate()
+			tt.modify(tpl)
+
+			_, err := node.NewFlowEngine().Advance(context.Background(), tpl, newFlowTask(`{"amount": 100}`), tt.nodeID)
+			if err == nil {
+				t.Error("Advance() should fail")
+			}
+		})
+	}
+}
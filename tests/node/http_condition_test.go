@@ -0,0 +1,213 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// decisionCondition 创建调用风控决策服务的 HTTP 条件
+func decisionCondition(fallback *bool) *node.Condition {
+	return &node.Condition{
+		Type: "http",
+		Config: &node.HTTPConditionConfig{
+			API: &node.HTTPAPIConfig{
+				URL:             "http://risk.example.com/decide",
+				Method:          "POST",
+				ParamMapping:    &node.ParamMapping{Source: "task_params", Path: "amount", Target: "amount"},
+				ResponseMapping: &node.ResponseMapping{Path: "allow"},
+			},
+			Fallback: fallback,
+		},
+	}
+}
+
+// createDecisionTemplate 创建使用 HTTP 决策条件的多级审批模板
+// 决策服务允许时进入 manager,否则直接结束
+func createDecisionTemplate(fallback *bool) *template.Template {
+	tpl := createMultiStageTemplate()
+	tpl.Nodes["condition"].Config.(*node.ConditionNodeConfig).Condition = decisionCondition(fallback)
+	return tpl
+}
+
+// TestHTTPConditionDecision 测试 HTTP 条件按决策服务的结果选择分支并记录原始响应
+func TestHTTPConditionDecision(t *testing.T) {
+	tests := []struct {
+		response string
+		want     string
+	}{
+		{`{"allow": true, "reason": "low risk"}`, "manager"},
+		{`{"allow": false, "reason": "blacklisted"}`, "end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			client := &recordingHTTPClient{body: tt.response}
+			engine := node.NewFlowEngine(node.WithHTTPClient(client))
+			tsk := newFlowTask(`{"amount": 5000}`)
+
+			if _, err := engine.Advance(context.Background(), createDecisionTemplate(nil), tsk, "start"); err != nil {
+				t.Fatalf("Advance() failed: %v", err)
+			}
+			if tsk.CurrentNode != tt.want {
+				t.Errorf("CurrentNode = %q, want %q", tsk.CurrentNode, tt.want)
+			}
+
+			// 请求体为映射后的参数
+			body, _ := io.ReadAll(client.requests[0].Body)
+			if string(body) != `{"amount":5000}` {
+				t.Errorf("request body = %s, want {\"amount\":5000}", body)
+			}
+
+			// 原始响应记录在条件节点输出中
+			var output struct {
+				ConditionResult bool            `json:"condition_result"`
+				NextNodeID      string          `json:"next_node_id"`
+				Response        json.RawMessage `json:"response"`
+			}
+			if err := json.Unmarshal(tsk.NodeOutputs["condition"], &output); err != nil {
+				t.Fatalf("Unmarshal output failed: %v", err)
+			}
+			if output.NextNodeID != tt.want {
+				t.Errorf("output next_node_id = %q, want %q", output.NextNodeID, tt.want)
+			}
+			var got, want interface{}
+			json.Unmarshal(output.Response, &got)
+			json.Unmarshal([]byte(tt.response), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("output response = %s, want %s", output.Response, tt.response)
+			}
+		})
+	}
+}
+
+// TestHTTPConditionFallback 测试决策服务不可用时使用 Fallback
+func TestHTTPConditionFallback(t *testing.T) {
+	unreachable := &retryMockHTTPClient{doFunc: func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}}
+	engine := node.NewFlowEngine(node.WithHTTPClient(unreachable))
+
+	// 未设置 Fallback 时返回错误
+	if _, err := engine.Advance(context.Background(), createDecisionTemplate(nil), newFlowTask(`{"amount": 5000}`), "start"); err == nil ||
+		!strings.Contains(err.Error(), "decision service unavailable") {
+		t.Errorf("Advance() error = %v, want decision service unavailable", err)
+	}
+
+	fallback := false
+	tsk := newFlowTask(`{"amount": 5000}`)
+	if _, err := engine.Advance(context.Background(), createDecisionTemplate(&fallback), tsk, "start"); err != nil {
+		t.Fatalf("Advance() failed: %v", err)
+	}
+	if tsk.CurrentNode != "end" {
+		t.Errorf("CurrentNode = %q, want end", tsk.CurrentNode)
+	}
+	output := string(tsk.NodeOutputs["condition"])
+	if !strings.Contains(output, `"fallback":true`) || !strings.Contains(output, "connection refused") {
+		t.Errorf("output = %s, want fallback record", output)
+	}
+
+	// 请求被取消时不使用 Fallback
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := engine.Advance(ctx, createDecisionTemplate(&fallback), newFlowTask(`{"amount": 5000}`), "start"); err == nil {
+		t.Error("Advance() should fail when the context is canceled")
+	}
+}
+
+// TestHTTPConditionRetryResendsBody 测试决策服务请求失败重试时重新发送请求体
+func TestHTTPConditionRetryResendsBody(t *testing.T) {
+	var bodies []string
+	client := &retryMockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"allow": true}`))}, nil
+	}}
+	engine := node.NewFlowEngine(node.WithHTTPClient(client))
+	tsk := newFlowTask(`{"amount": 5000}`)
+
+	if _, err := engine.Advance(context.Background(), createDecisionTemplate(nil), tsk, "start"); err != nil {
+		t.Fatalf("Advance() failed: %v", err)
+	}
+	if tsk.CurrentNode != "manager" {
+		t.Errorf("CurrentNode = %q, want manager", tsk.CurrentNode)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"amount":5000}` {
+		t.Errorf("request bodies = %q, want second attempt body {\"amount\":5000}", bodies)
+	}
+}
+
+// TestHTTPConditionEvaluateErrors 测试 HTTP 条件的配置和响应错误
+func TestHTTPConditionEvaluateErrors(t *testing.T) {
+	evaluator := node.NewHTTPConditionEvaluator()
+	if !evaluator.Supports("http") {
+		t.Fatal("Supports(\"http\") = false, want true")
+	}
+
+	fallback := true
+	tests := []struct {
+		name     string
+		response string
+		wantErr  string
+	}{
+		{"no client", "", "HTTPClient is required"},
+		{"not a bool", `{"allow": "yes"}`, "is not a bool"},
+		{"missing field", `{"reason": "x"}`, `failed to get value by path "allow"`},
+		{"invalid JSON", `allow`, "invalid JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := decisionCondition(&fallback)
+			ctx := &node.NodeContext{Task: newFlowTask(`{"amount": 100}`), Params: []byte(`{"amount": 100}`)}
+			if tt.response != "" {
+				ctx.HTTPClient = &recordingHTTPClient{body: tt.response}
+			}
+			// 响应内容错误不是服务不可用,不使用 Fallback
+			_, err := evaluator.Evaluate(condition, ctx)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Evaluate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	invalid := &node.HTTPConditionConfig{API: &node.HTTPAPIConfig{URL: "http://risk.example.com/decide"}}
+	if err := invalid.Validate(); err == nil || !strings.Contains(err.Error(), "ResponseMapping is required") {
+		t.Errorf("Validate() error = %v, want ResponseMapping is required", err)
+	}
+	if err := (&node.HTTPConditionConfig{}).Validate(); err == nil {
+		t.Error("Validate() should require API")
+	}
+}
+
+// TestHTTPConditionCodec 测试 HTTP 条件配置的编解码
+func TestHTTPConditionCodec(t *testing.T) {
+	fallback := false
+	condition := decisionCondition(&fallback)
+	condition.Config.(*node.HTTPConditionConfig).OutputKey = "risk"
+
+	data, err := json.Marshal(condition)
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	var decoded node.Condition
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	config, ok := decoded.Config.(*node.HTTPConditionConfig)
+	if !ok {
+		t.Fatalf("decoded config type = %T, want *HTTPConditionConfig", decoded.Config)
+	}
+	if config.Fallback == nil || *config.Fallback || config.OutputKey != "risk" || config.API.ResponseMapping.Path != "allow" {
+		t.Errorf("decoded config = %+v, want fallback false, output key risk", config)
+	}
+}
@@ -0,0 +1,229 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+)
+
+// jsonPathData JSONPath 测试使用的数据
+const jsonPathData = `{
+	"items": [
+		{"sku": "A-1", "price": 120, "tags": ["new"]},
+		{"sku": "B-2", "price": 80},
+		{"sku": "C-3", "price": 300, "tags": ["vip", "bulk"]}
+	],
+	"data": {"list": [{"user_id": "a"}, {"user_id": "b"}]},
+	"a.b": {"c": 1},
+	"it's": "quoted",
+	"limit": 100
+}`
+
+// decodeJSONPathData 解码测试数据
+func decodeJSONPathData(t *testing.T) interface{} {
+	t.Helper()
+	var data interface{}
+	if err := json.Unmarshal([]byte(jsonPathData), &data); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	return data
+}
+
+// TestJSONPathGet 测试 JSONPath 读取值
+func TestJSONPathGet(t *testing.T) {
+	data := decodeJSONPathData(t)
+
+	tests := []struct {
+		path string
+		want string // 期望值的 JSON
+	}{
+		{"limit", `100`},
+		{"$.items[0].price", `120`},
+		{"items[-1].sku", `"C-3"`},
+		{"$['items'][1]['sku']", `"B-2"`},
+		{`a\.b.c`, `1`},
+		{`$["a.b"].c`, `1`},
+		{`$['it\'s']`, `"quoted"`},
+		{"items[*].sku", `["A-1","B-2","C-3"]`},
+		{"items.*.price", `[120,80,300]`},
+		{"data.list[*].user_id", `["a","b"]`},
+		{"items[0:2].sku", `["A-1","B-2"]`},
+		{"items[::-1].sku", `["C-3","B-2","A-1"]`},
+		{"items[0,2].sku", `["A-1","C-3"]`},
+		{"$..user_id", `["a","b"]`},
+		{"$..tags[0]", `["new","vip"]`},
+		{"items[?(@.price > 100)].sku", `["A-1","C-3"]`},
+		{"items[?@.price > 100 && 'vip' in @.tags].sku", `["C-3"]`},
+		{"items[?(@.price < $.limit)].sku", `["B-2"]`},
+		{"items[?(startsWith(@.sku, 'B'))].price", `[80]`},
+		{"items[?(@.missing)].sku", `[]`},
+		{"data.list[5].user_id", ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := node.CompileJSONPath(tt.path)
+			if err != nil {
+				t.Fatalf("CompileJSONPath() failed: %v", err)
+			}
+			got, err := path.Get(data)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("Get() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get() failed: %v", err)
+			}
+			var want interface{}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("Unmarshal want failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Get() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+// TestJSONPathCompileError 测试无效路径
+func TestJSONPathCompileError(t *testing.T) {
+	for _, path := range []string{
+		"",
+		"items[",
+		"items[0",
+		"items[abc]",
+		"items.",
+		"$['unterminated]",
+		"items[?(@.price >)]",
+		"items[?(@.price * 2)]",
+		`trailing\`,
+	} {
+		t.Run(path, func(t *testing.T) {
+			if _, err := node.CompileJSONPath(path); err == nil {
+				t.Errorf("CompileJSONPath(%q) should fail", path)
+			}
+		})
+	}
+
+	// 条件字段和 API 映射路径在验证时检查
+	field := &node.Condition{Type: "numeric", Config: &node.NumericConditionConfig{Field: "items[", Operator: "gt"}}
+	if err := field.Validate(); err == nil {
+		t.Error("Condition.Validate() should reject invalid field path")
+	}
+	api := &node.HTTPAPIConfig{
+		URL:             "http://example.com/api",
+		ResponseMapping: &node.ResponseMapping{Path: "data.list[?(@.id"},
+	}
+	if err := api.Validate(); err == nil {
+		t.Error("HTTPAPIConfig.Validate() should reject invalid response path")
+	}
+	api = &node.HTTPAPIConfig{
+		URL:          "http://example.com/api",
+		ParamMapping: &node.ParamMapping{Source: "node_outputs", Path: "$..score", Target: "score"},
+	}
+	if err := api.Validate(); err == nil {
+		t.Error("HTTPAPIConfig.Validate() should require a node ID in node_outputs path")
+	}
+
+	// @ 只能用于 JSONPath 过滤条件
+	if err := exprCondition("@.price > 1").Validate(); err == nil {
+		t.Error("expr condition should reject @ outside JSONPath filters")
+	}
+}
+
+// TestConditionJSONPathField 测试数值、字符串和枚举条件使用 JSONPath 字段
+func TestConditionJSONPathField(t *testing.T) {
+	registry := node.NewConditionEvaluatorRegistry()
+	ctx := &node.NodeContext{Task: &task.Task{Params: json.RawMessage(jsonPathData)}}
+
+	tests := []struct {
+		name      string
+		condition *node.Condition
+		want      bool
+	}{
+		{"numeric", &node.Condition{Type: "numeric", Config: &node.NumericConditionConfig{
+			Field: "$.items[2].price", Operator: "gte", Value: 300, Source: "task_params",
+		}}, true},
+		{"string", &node.Condition{Type: "string", Config: &node.StringConditionConfig{
+			Field: `$['it\'s']`, Operator: "eq", Value: "quoted", Source: "task_params",
+		}}, true},
+		{"enum", &node.Condition{Type: "enum", Config: &node.EnumConditionConfig{
+			Field: "data.list[1].user_id", Operator: "in", Values: []string{"b", "c"}, Source: "task_params",
+		}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.GetEvaluator(tt.condition.Type).Evaluate(tt.condition, ctx)
+			if err != nil {
+				t.Fatalf("Evaluate() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	missing := &node.Condition{Type: "numeric", Config: &node.NumericConditionConfig{
+		Field: "items[7].price", Operator: "gt", Value: 0, Source: "task_params",
+	}}
+	if _, err := registry.GetEvaluator("numeric").Evaluate(missing, ctx); err == nil || !strings.Contains(err.Error(), "field not found") {
+		t.Errorf("Evaluate() error = %v, want field not found", err)
+	}
+
+	// 非确定路径返回数组,字符串条件要求字段值为字符串
+	list := &node.Condition{Type: "string", Config: &node.StringConditionConfig{
+		Field: "items[?(@.price < 100)].sku", Operator: "eq", Value: "B-2", Source: "task_params",
+	}}
+	if _, err := registry.GetEvaluator("string").Evaluate(list, ctx); err == nil {
+		t.Error("Evaluate() should fail when the path matches a list")
+	}
+}
+
+// recordingHTTPClient 记录请求并返回固定响应的 HTTP 客户端
+type recordingHTTPClient struct {
+	body     string
+	requests []*http.Request
+}
+
+func (c *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(c.body))}, nil
+}
+
+// TestDynamicApproverJSONPath 测试参数映射和响应映射使用 JSONPath
+func TestDynamicApproverJSONPath(t *testing.T) {
+	client := &recordingHTTPClient{body: `{"data":{"list":[{"user_id":"a"},{"user_id":"b"}]}}`}
+	config := &node.DynamicApproverConfig{
+		API: &node.HTTPAPIConfig{
+			URL:             "http://example.com/api/approvers",
+			Method:          "GET",
+			ParamMapping:    &node.ParamMapping{Source: "node_outputs", Path: "risk_check.items[?(@.price > 100)].sku", Target: "skus"},
+			ResponseMapping: &node.ResponseMapping{Path: "data.list[*].user_id", Format: "json"},
+		},
+		HTTPClient: client,
+	}
+	ctx := &node.NodeContext{
+		Task:    &task.Task{ID: "task-001"},
+		Outputs: map[string]json.RawMessage{"risk_check": json.RawMessage(jsonPathData)},
+		Cache:   node.NewContextCache(),
+	}
+
+	approvers, err := config.GetApprovers(context.Background(), ctx)
+	if err != nil {
+		t.Fatalf("GetApprovers() failed: %v", err)
+	}
+	if !reflect.DeepEqual(approvers, []string{"a", "b"}) {
+		t.Errorf("GetApprovers() = %v, want [a b]", approvers)
+	}
+	if got := client.requests[0].URL.Query().Get("skus"); got != `["A-1","C-3"]` {
+		t.Errorf("query skus = %q, want [\"A-1\",\"C-3\"]", got)
+	}
+}
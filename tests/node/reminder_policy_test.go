@@ -0,0 +1,50 @@
+package node_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+)
+
+// TestReminderPolicyValidate 测试自动催办配置验证
+func TestReminderPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *node.ReminderPolicy
+		wantErr bool
+	}{
+		{name: "unlimited", policy: &node.ReminderPolicy{Interval: time.Hour}, wantErr: false},
+		{name: "limited", policy: &node.ReminderPolicy{Interval: 4 * time.Hour, MaxTimes: 3}, wantErr: false},
+		{name: "zero interval", policy: &node.ReminderPolicy{}, wantErr: true},
+		{name: "negative max times", policy: &node.ReminderPolicy{Interval: time.Hour, MaxTimes: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !stderrors.Is(err, errors.ErrInvalidTemplate) {
+				t.Errorf("Validate() error = %v, want ErrInvalidTemplate", err)
+			}
+		})
+	}
+}
+
+// TestReminderPolicyGetReminderSchedule 测试自动催办计划
+func TestReminderPolicyGetReminderSchedule(t *testing.T) {
+	config := &node.ApprovalNodeConfig{Mode: node.ApprovalModeSingle}
+	if schedule := config.GetReminderSchedule(); schedule != nil {
+		t.Errorf("GetReminderSchedule() = %+v, want nil without policy", schedule)
+	}
+
+	config.ReminderPolicy = &node.ReminderPolicy{Interval: 2 * time.Hour, MaxTimes: 5}
+	schedule := config.GetReminderSchedule()
+	if schedule == nil || schedule.Interval != 2*time.Hour || schedule.MaxTimes != 5 {
+		t.Errorf("GetReminderSchedule() = %+v", schedule)
+	}
+}
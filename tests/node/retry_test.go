@@ -1,7 +1,9 @@
 package node_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -62,7 +64,7 @@ func TestDynamicApproverConfigRetry(t *testing.T) {
 	}
 
 	// 调用 GetApprovers,应该会重试并最终成功
-	approvers, err := config.GetApprovers(ctx)
+	approvers, err := config.GetApprovers(context.Background(), ctx)
 	if err != nil {
 		t.Fatalf("GetApprovers() failed after retries: %v", err)
 	}
@@ -121,7 +123,7 @@ func TestDynamicApproverConfigRetryMaxAttempts(t *testing.T) {
 	}
 
 	// 调用 GetApprovers,应该达到最大重试次数后失败
-	_, err := config.GetApprovers(ctx)
+	_, err := config.GetApprovers(context.Background(), ctx)
 	if err == nil {
 		t.Error("GetApprovers() should fail after max retries")
 	}
@@ -191,7 +193,7 @@ func TestDynamicApproverConfigRetryBackoff(t *testing.T) {
 	}
 
 	// 调用 GetApprovers
-	_, err := config.GetApprovers(ctx)
+	_, err := config.GetApprovers(context.Background(), ctx)
 	if err != nil {
 		t.Fatalf("GetApprovers() failed: %v", err)
 	}
@@ -205,6 +207,57 @@ func TestDynamicApproverConfigRetryBackoff(t *testing.T) {
 	}
 }
 
+// TestDynamicApproverConfigRetryContextCanceled 测试上下文取消后停止重试
+func TestDynamicApproverConfigRetryContextCanceled(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	mockClient := &retryMockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			attempts++
+			// 第一次请求失败后取消上下文
+			cancel()
+			return nil, http.ErrHandlerTimeout
+		},
+	}
+
+	config := &node.DynamicApproverConfig{
+		API: &node.HTTPAPIConfig{
+			URL:    "http://example.com/api/approvers",
+			Method: "POST",
+			ResponseMapping: &node.ResponseMapping{
+				Path:   "approvers",
+				Format: "json",
+			},
+		},
+		Timing:     node.ApproverTimingOnActivate,
+		HTTPClient: mockClient,
+	}
+
+	ctx := &node.NodeContext{
+		Task: &task.Task{
+			ID:    "task-001",
+			State: types.TaskStateApproving,
+		},
+		Node: &template.Node{
+			ID:   "approval-001",
+			Name: "Approval Node",
+			Type: template.NodeTypeApproval,
+		},
+		Params:  json.RawMessage(`{}`),
+		Outputs: make(map[string]json.RawMessage),
+		Cache:   node.NewContextCache(),
+	}
+
+	_, err := config.GetApprovers(reqCtx, ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetApprovers() error = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt after cancel, got %d", attempts)
+	}
+}
+
 // retryMockHTTPClient 用于测试重试机制的 mock HTTPClient
 type retryMockHTTPClient struct {
 	doFunc func(*http.Request) (*http.Response, error)
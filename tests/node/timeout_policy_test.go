@@ -0,0 +1,122 @@
+package node_test
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+)
+
+// TestTimeoutPolicyValidate 测试超时策略验证
+func TestTimeoutPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *node.TimeoutPolicy
+		wantErr bool
+	}{
+		{
+			name: "notify escalate auto reject",
+			policy: &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+				{Type: node.TimeoutActionAutoReject, After: 72 * time.Hour},
+				{Type: node.TimeoutActionNotify, After: 24 * time.Hour},
+				{Type: node.TimeoutActionEscalate, After: 48 * time.Hour},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "no actions",
+			policy:  &node.TimeoutPolicy{},
+			wantErr: true,
+		},
+		{
+			name: "non-positive offset",
+			policy: &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+				{Type: node.TimeoutActionNotify, After: 0},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate offset",
+			policy: &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+				{Type: node.TimeoutActionNotify, After: time.Hour},
+				{Type: node.TimeoutActionEscalate, After: time.Hour},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "action after terminal action",
+			policy: &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+				{Type: node.TimeoutActionAutoApprove, After: time.Hour},
+				{Type: node.TimeoutActionNotify, After: 2 * time.Hour},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid action type",
+			policy: &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+				{Type: "unknown", After: time.Hour},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !stderrors.Is(err, errors.ErrInvalidTemplate) {
+				t.Errorf("Validate() error = %v, want ErrInvalidTemplate", err)
+			}
+		})
+	}
+}
+
+// TestTimeoutPolicyGetTimeoutActions 测试超时动作按触发偏移排序
+func TestTimeoutPolicyGetTimeoutActions(t *testing.T) {
+	config := &node.ApprovalNodeConfig{Mode: node.ApprovalModeSingle}
+	if actions := config.GetTimeoutActions(); actions != nil {
+		t.Errorf("GetTimeoutActions() = %v, want nil without policy", actions)
+	}
+
+	config.TimeoutPolicy = &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+		{Type: node.TimeoutActionAutoApprove, After: 48 * time.Hour},
+		{Type: node.TimeoutActionNotify, After: 24 * time.Hour},
+	}}
+	actions := config.GetTimeoutActions()
+	if len(actions) != 2 {
+		t.Fatalf("len(GetTimeoutActions()) = %d, want 2", len(actions))
+	}
+	if actions[0].Type != "notify" || actions[1].Type != "auto_approve" {
+		t.Errorf("GetTimeoutActions() types = [%s %s], want [notify auto_approve]", actions[0].Type, actions[1].Type)
+	}
+}
+
+// TestTimeoutPolicyJSON 测试超时动作的 JSON 字段名
+func TestTimeoutPolicyJSON(t *testing.T) {
+	policy := &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+		{Type: node.TimeoutActionNotify, After: time.Hour},
+		{Type: node.TimeoutActionEscalate, After: 2 * time.Hour, EscalateTo: []string{"director-001"}},
+	}}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	want := `{"actions":[{"type":"notify","after":"1h0m0s"},{"type":"escalate","after":"2h0m0s","escalate_to":["director-001"]}]}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded node.TimeoutPolicy
+	if err := json.Unmarshal([]byte(`{"actions":[{"type":"escalate","after":"48h","escalate_to":["director-001"]}]}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	action := decoded.Actions[0]
+	if action.Type != node.TimeoutActionEscalate || action.After != 48*time.Hour || len(action.EscalateTo) != 1 {
+		t.Errorf("decoded action = %+v", action)
+	}
+}
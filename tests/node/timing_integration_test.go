@@ -1,6 +1,7 @@
 package node_test
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -134,7 +135,7 @@ func TestApproverTimingOnActivateIntegration(t *testing.T) {
 	}
 
 	// 调用 GetApprovers (节点激活时)
-	approvers, err := config.GetApprovers(ctx)
+	approvers, err := config.GetApprovers(context.Background(), ctx)
 	if err != nil {
 		t.Fatalf("GetApprovers() failed: %v", err)
 	}
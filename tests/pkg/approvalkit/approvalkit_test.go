@@ -0,0 +1,376 @@
+package approvalkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/pkg/approvalkit"
+	"github.com/mautops/approval-kit/pkg/event"
+	"github.com/mautops/approval-kit/pkg/node"
+	"github.com/mautops/approval-kit/pkg/task"
+	"github.com/mautops/approval-kit/pkg/template"
+	"github.com/mautops/approval-kit/pkg/types"
+)
+
+// collectingHandler 收集事件的事件处理器
+type collectingHandler struct {
+	mu     sync.Mutex
+	events []*event.Event
+}
+
+func (h *collectingHandler) Handle(ctx context.Context, evt *event.Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, evt)
+	return nil
+}
+
+func (h *collectingHandler) has(eventType event.EventType) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, evt := range h.events {
+		if evt.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// quorumModeHandler 自定义审批模式: 任意两人同意即通过
+type quorumModeHandler struct{}
+
+func (h *quorumModeHandler) Mode() node.ApprovalMode {
+	return "quorum"
+}
+
+func (h *quorumModeHandler) CheckCompletion(approvers []string, approvals map[string]*task.Approval, config *node.ApprovalNodeConfig) (bool, *node.ApprovalResult) {
+	approved := 0
+	for _, approver := range approvers {
+		if approval, exists := approvals[approver]; exists && approval.Result == "approve" {
+			approved++
+		}
+	}
+	if approved >= 2 {
+		return true, &node.ApprovalResult{Completed: true, Result: "approve"}
+	}
+	return false, nil
+}
+
+// createExpenseTemplate 仅使用 pkg 包构建的报销审批模板
+// start -> manager -> finance(quorum) -> end
+func createExpenseTemplate() *template.Template {
+	return &template.Template{
+		ID:   "expense",
+		Name: "Expense Approval",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+				},
+			},
+			"finance": {
+				ID:   "finance",
+				Name: "Finance Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           "quorum",
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"finance-001", "finance-002", "finance-003"}},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "finance"},
+			{From: "finance", To: "end"},
+		},
+		Version: 1,
+	}
+}
+
+// TestKitMultiStageApproval 测试通过 Kit 构建并执行多级审批流程
+func TestKitMultiStageApproval(t *testing.T) {
+	handler := &collectingHandler{}
+	finance := &collectingHandler{}
+	kit := approvalkit.New(
+		approvalkit.WithEventHandlers(handler),
+		approvalkit.WithEventSubscription(finance, &event.EventFilter{
+			Types:   []event.EventType{event.EventTypeApprovalOp},
+			NodeIDs: []string{"finance"},
+		}),
+		approvalkit.WithEventWorkers(2),
+		approvalkit.WithApprovalModeHandler(&quorumModeHandler{}),
+	)
+	defer kit.Close()
+
+	if err := kit.Templates().Create(createExpenseTemplate()); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	tasks := kit.Tasks()
+	tsk, err := tasks.CreateBy("expense", "expense-001", "initiator-001", json.RawMessage(`{"amount": 3000}`))
+	if err != nil {
+		t.Fatalf("Create task failed: %v", err)
+	}
+	if err := tasks.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	steps := []struct {
+		nodeID   string
+		approver string
+		wantNode string
+	}{
+		{"manager", "manager-001", "finance"},
+		{"finance", "finance-001", "finance"},
+		{"finance", "finance-003", "end"},
+	}
+	for _, step := range steps {
+		if err := tasks.Approve(tsk.ID, step.nodeID, step.approver, "ok"); err != nil {
+			t.Fatalf("Approve(%s, %s) failed: %v", step.nodeID, step.approver, err)
+		}
+		got, _ := tasks.Get(tsk.ID)
+		if got.CurrentNode != step.wantNode {
+			t.Errorf("after %s approved: CurrentNode = %q, want %q", step.approver, got.CurrentNode, step.wantNode)
+		}
+	}
+
+	got, _ := tasks.Get(tsk.ID)
+	if got.State != types.TaskStateApproved {
+		t.Errorf("State = %q, want %q", got.State, types.TaskStateApproved)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !handler.has(event.EventTypeTaskApproved) {
+		if time.Now().After(deadline) {
+			t.Fatal("task approved event not received")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 同一任务的事件按顺序推送: 最后一个审批操作事件在任务通过事件之前
+	handler.mu.Lock()
+	lastOp, approvedAt := -1, -1
+	for i, evt := range handler.events {
+		switch evt.Type {
+		case event.EventTypeApprovalOp:
+			lastOp = i
+		case event.EventTypeTaskApproved:
+			approvedAt = i
+		}
+	}
+	handler.mu.Unlock()
+	if lastOp < 0 || lastOp > approvedAt {
+		t.Errorf("approval_operation at %d should precede task_approved at %d", lastOp, approvedAt)
+	}
+
+	// 订阅财务节点审批操作的处理器只收到两次财务审批
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		finance.mu.Lock()
+		received := len(finance.events)
+		finance.mu.Unlock()
+		if received >= 2 {
+			break
+		}
+	}
+	finance.mu.Lock()
+	defer finance.mu.Unlock()
+	if len(finance.events) != 2 {
+		t.Fatalf("finance subscriber got %d events, want 2", len(finance.events))
+	}
+	for _, evt := range finance.events {
+		if evt.Type != event.EventTypeApprovalOp || evt.Node.ID != "finance" {
+			t.Errorf("finance subscriber got %s on node %s", evt.Type, evt.Node.ID)
+		}
+	}
+}
+
+// TestKitWithoutFlowEngine 测试不启用流程引擎时保持单节点审批行为
+func TestKitWithoutFlowEngine(t *testing.T) {
+	kit := approvalkit.New(
+		approvalkit.WithoutFlowEngine(),
+		approvalkit.WithAuthorizer(task.NewDefaultAuthorizer(task.AllowUnassignedApprovers())),
+	)
+	defer kit.Close()
+
+	if err := kit.Templates().Create(createExpenseTemplate()); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	tasks := kit.Tasks()
+	tsk, err := tasks.CreateBy("expense", "expense-002", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("Create task failed: %v", err)
+	}
+	if err := tasks.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if err := tasks.Approve(tsk.ID, "manager", "manager-001", "ok"); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+
+	got, _ := tasks.Get(tsk.ID)
+	if got.State != types.TaskStateApproved {
+		t.Errorf("State = %q, want %q", got.State, types.TaskStateApproved)
+	}
+}
+
+// TestKitWithTemplateManager 测试使用外部传入的模板管理器和事件通知器
+func TestKitWithTemplateManager(t *testing.T) {
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(createExpenseTemplate()); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+	notifier := event.NewEventNotifier(nil, 10)
+	defer notifier.Stop()
+
+	kit := approvalkit.New(
+		approvalkit.WithTemplateManager(templateMgr),
+		approvalkit.WithNotifier(notifier),
+	)
+	defer kit.Close()
+
+	if kit.Templates() != templateMgr {
+		t.Error("Templates() should return the provided template manager")
+	}
+	if _, err := kit.Tasks().CreateBy("expense", "expense-003", "initiator-001", nil); err != nil {
+		t.Errorf("Create task with provided template manager failed: %v", err)
+	}
+}
+
+// TestKitWithTaskStore 测试使用任务存储时任务写入存储
+func TestKitWithTaskStore(t *testing.T) {
+	store := task.NewMemoryTaskStore()
+	kit := approvalkit.New(approvalkit.WithTaskStore(store))
+	defer kit.Close()
+
+	if err := kit.Templates().Create(createExpenseTemplate()); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+	tsk, err := kit.Tasks().CreateBy("expense", "expense-004", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("Create task failed: %v", err)
+	}
+	if err := kit.Tasks().Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	stored, err := store.Get(tsk.ID)
+	if err != nil {
+		t.Fatalf("store.Get() failed: %v", err)
+	}
+	if stored.CurrentNode != "manager" || stored.Version != 2 {
+		t.Errorf("stored CurrentNode = %q, Version = %d, want manager and 2", stored.CurrentNode, stored.Version)
+	}
+}
+
+// TestKitWithDirectory 测试通过组织架构目录获取发起人的直属上级作为审批人
+func TestKitWithDirectory(t *testing.T) {
+	directory := node.NewMemoryDirectory()
+	if err := directory.AddUser(&node.DirectoryUser{ID: "employee-001", Manager: "manager-001"}); err != nil {
+		t.Fatalf("AddUser failed: %v", err)
+	}
+
+	kit := approvalkit.New(approvalkit.WithDirectory(directory))
+	defer kit.Close()
+
+	tpl := &template.Template{
+		ID:   "leave",
+		Name: "Leave Approval",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.ManagerApproverConfig{Timing: node.ApproverTimingOnCreate},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "end"},
+		},
+		Version: 1,
+	}
+	if err := kit.Templates().Create(tpl); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	tasks := kit.Tasks()
+	tsk, err := tasks.CreateBy("leave", "leave-001", "employee-001", json.RawMessage(`{"initiator": "employee-001"}`))
+	if err != nil {
+		t.Fatalf("Create task failed: %v", err)
+	}
+	if got := tsk.Approvers["manager"]; len(got) != 1 || got[0] != "manager-001" {
+		t.Fatalf("Approvers[manager] = %v, want [manager-001]", got)
+	}
+	if err := tasks.Submit(tsk.ID, "employee-001"); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if err := tasks.Approve(tsk.ID, "manager", "manager-001", "ok"); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+
+	got, _ := tasks.Get(tsk.ID)
+	if got.State != types.TaskStateApproved {
+		t.Errorf("State = %q, want %q", got.State, types.TaskStateApproved)
+	}
+}
+
+// TestKitConditionFunction 测试通过 Kit 注册自定义条件函数
+func TestKitConditionFunction(t *testing.T) {
+	kit := approvalkit.New(approvalkit.WithConditionFunction("isVIPCustomer", func(ctx *node.NodeContext, args json.RawMessage) (bool, error) {
+		var params struct {
+			VIP bool `json:"vip"`
+		}
+		err := json.Unmarshal(ctx.Task.Params, &params)
+		return params.VIP, err
+	}))
+	defer kit.Close()
+
+	tpl := createExpenseTemplate()
+	tpl.Nodes["vip"] = &template.Node{ID: "vip", Name: "VIP Check", Type: template.NodeTypeCondition, Config: &node.ConditionNodeConfig{
+		Condition:   &node.Condition{Type: "custom", Config: &node.CustomConditionConfig{Name: "isVIPCustomer"}},
+		TrueNodeID:  "end",
+		FalseNodeID: "manager",
+	}}
+	tpl.Edges = []*template.Edge{
+		{From: "start", To: "vip"},
+		{From: "vip", To: "end"},
+		{From: "vip", To: "manager"},
+		{From: "manager", To: "finance"},
+		{From: "finance", To: "end"},
+	}
+	if err := kit.Templates().Create(tpl); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	for params, want := range map[string]types.TaskState{
+		`{"vip": true}`:  types.TaskStateApproved,
+		`{"vip": false}`: types.TaskStateSubmitted, // 等待经理审批
+	} {
+		tsk, err := kit.Tasks().CreateBy("expense", "expense-vip", "initiator-001", json.RawMessage(params))
+		if err != nil {
+			t.Fatalf("Create task failed: %v", err)
+		}
+		if err := kit.Tasks().Submit(tsk.ID, "initiator-001"); err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+		got, _ := kit.Tasks().Get(tsk.ID)
+		if got.State != want {
+			t.Errorf("params %s: State = %q, want %q", params, got.State, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package event_test
 
 import (
+	"context"
 	"testing"
 
 	pkgEvent "github.com/mautops/approval-kit/pkg/event"
@@ -27,8 +28,8 @@ type internalEventHandlerAdapter struct {
 	impl internalEvent.EventHandler
 }
 
-func (a *internalEventHandlerAdapter) Handle(evt *pkgEvent.Event) error {
+func (a *internalEventHandlerAdapter) Handle(ctx context.Context, evt *pkgEvent.Event) error {
 	internalEvt := pkgEvent.EventToInternal(evt)
-	return a.impl.Handle(internalEvt)
+	return a.impl.Handle(ctx, internalEvt)
 }
 
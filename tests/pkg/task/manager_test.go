@@ -1,6 +1,7 @@
 package task_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -37,6 +38,14 @@ func (a *internalTaskManagerAdapter) Create(templateID string, businessID string
 	return pkgTask.FromInternal(task), nil
 }
 
+func (a *internalTaskManagerAdapter) CreateBy(templateID string, businessID string, initiator string, params json.RawMessage) (*pkgTask.Task, error) {
+	task, err := a.impl.CreateBy(templateID, businessID, initiator, params)
+	if err != nil {
+		return nil, err
+	}
+	return pkgTask.FromInternal(task), nil
+}
+
 func (a *internalTaskManagerAdapter) Get(id string) (*pkgTask.Task, error) {
 	task, err := a.impl.Get(id)
 	if err != nil {
@@ -45,44 +54,64 @@ func (a *internalTaskManagerAdapter) Get(id string) (*pkgTask.Task, error) {
 	return pkgTask.FromInternal(task), nil
 }
 
-func (a *internalTaskManagerAdapter) Submit(id string) error {
-	return a.impl.Submit(id)
+func (a *internalTaskManagerAdapter) Submit(id string, actor string) error {
+	return a.impl.Submit(id, actor)
+}
+
+func (a *internalTaskManagerAdapter) SubmitCtx(ctx context.Context, id string, actor string) error {
+	return a.impl.SubmitCtx(ctx, id, actor)
 }
 
 func (a *internalTaskManagerAdapter) Approve(id string, nodeID string, approver string, comment string) error {
 	return a.impl.Approve(id, nodeID, approver, comment)
 }
 
+func (a *internalTaskManagerAdapter) ApproveCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error {
+	return a.impl.ApproveCtx(ctx, id, nodeID, approver, comment)
+}
+
 func (a *internalTaskManagerAdapter) ApproveWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error {
 	return a.impl.ApproveWithAttachments(id, nodeID, approver, comment, attachments)
 }
 
+func (a *internalTaskManagerAdapter) ApproveWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error {
+	return a.impl.ApproveWithAttachmentsCtx(ctx, id, nodeID, approver, comment, attachments)
+}
+
 func (a *internalTaskManagerAdapter) Reject(id string, nodeID string, approver string, comment string) error {
 	return a.impl.Reject(id, nodeID, approver, comment)
 }
 
+func (a *internalTaskManagerAdapter) RejectCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error {
+	return a.impl.RejectCtx(ctx, id, nodeID, approver, comment)
+}
+
 func (a *internalTaskManagerAdapter) RejectWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error {
 	return a.impl.RejectWithAttachments(id, nodeID, approver, comment, attachments)
 }
 
-func (a *internalTaskManagerAdapter) Cancel(id string, reason string) error {
-	return a.impl.Cancel(id, reason)
+func (a *internalTaskManagerAdapter) RejectWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error {
+	return a.impl.RejectWithAttachmentsCtx(ctx, id, nodeID, approver, comment, attachments)
 }
 
-func (a *internalTaskManagerAdapter) Withdraw(id string, reason string) error {
-	return a.impl.Withdraw(id, reason)
+func (a *internalTaskManagerAdapter) Cancel(id string, actor string, reason string) error {
+	return a.impl.Cancel(id, actor, reason)
+}
+
+func (a *internalTaskManagerAdapter) Withdraw(id string, actor string, reason string) error {
+	return a.impl.Withdraw(id, actor, reason)
 }
 
 func (a *internalTaskManagerAdapter) Transfer(id string, nodeID string, fromApprover string, toApprover string, reason string) error {
 	return a.impl.Transfer(id, nodeID, fromApprover, toApprover, reason)
 }
 
-func (a *internalTaskManagerAdapter) AddApprover(id string, nodeID string, approver string, reason string) error {
-	return a.impl.AddApprover(id, nodeID, approver, reason)
+func (a *internalTaskManagerAdapter) AddApprover(id string, nodeID string, actor string, approver string, reason string) error {
+	return a.impl.AddApprover(id, nodeID, actor, approver, reason)
 }
 
-func (a *internalTaskManagerAdapter) RemoveApprover(id string, nodeID string, approver string, reason string) error {
-	return a.impl.RemoveApprover(id, nodeID, approver, reason)
+func (a *internalTaskManagerAdapter) RemoveApprover(id string, nodeID string, actor string, approver string, reason string) error {
+	return a.impl.RemoveApprover(id, nodeID, actor, approver, reason)
 }
 
 func (a *internalTaskManagerAdapter) Query(filter *pkgTask.TaskFilter) ([]*pkgTask.Task, error) {
@@ -102,19 +131,31 @@ func (a *internalTaskManagerAdapter) HandleTimeout(id string) error {
 	return a.impl.HandleTimeout(id)
 }
 
-func (a *internalTaskManagerAdapter) Pause(id string, reason string) error {
-	return a.impl.Pause(id, reason)
+func (a *internalTaskManagerAdapter) HandleReminders(id string) error {
+	return a.impl.HandleReminders(id)
+}
+
+func (a *internalTaskManagerAdapter) Remind(id string, nodeID string, actor string, message string) error {
+	return a.impl.Remind(id, nodeID, actor, message)
+}
+
+func (a *internalTaskManagerAdapter) Pause(id string, actor string, reason string) error {
+	return a.impl.Pause(id, actor, reason)
+}
+
+func (a *internalTaskManagerAdapter) Resume(id string, actor string, reason string) error {
+	return a.impl.Resume(id, actor, reason)
 }
 
-func (a *internalTaskManagerAdapter) Resume(id string, reason string) error {
-	return a.impl.Resume(id, reason)
+func (a *internalTaskManagerAdapter) RollbackToNode(id string, nodeID string, actor string, reason string) error {
+	return a.impl.RollbackToNode(id, nodeID, actor, reason)
 }
 
-func (a *internalTaskManagerAdapter) RollbackToNode(id string, nodeID string, reason string) error {
-	return a.impl.RollbackToNode(id, nodeID, reason)
+func (a *internalTaskManagerAdapter) ReplaceApprover(id string, nodeID string, actor string, oldApprover string, newApprover string, reason string) error {
+	return a.impl.ReplaceApprover(id, nodeID, actor, oldApprover, newApprover, reason)
 }
 
-func (a *internalTaskManagerAdapter) ReplaceApprover(id string, nodeID string, oldApprover string, newApprover string, reason string) error {
-	return a.impl.ReplaceApprover(id, nodeID, oldApprover, newApprover, reason)
+func (a *internalTaskManagerAdapter) MigrateTaskToVersion(id string, version int, nodeMapping map[string]string, actor string) error {
+	return a.impl.MigrateTaskToVersion(id, version, nodeMapping, actor)
 }
 
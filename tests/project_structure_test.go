@@ -25,6 +25,9 @@ func TestProjectStructure(t *testing.T) {
 		"pkg/event",
 		"pkg/statemachine",
 		"pkg/types",
+		"pkg/node",
+		"pkg/approvalkit",
+		"pkg/sqlstore",
 	}
 
 	requiredFiles := []string{
@@ -0,0 +1,69 @@
+package sqlstore_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/sqlstore"
+	"github.com/mautops/approval-kit/internal/task"
+)
+
+// TestSQLDelegationStore 测试委托规则的保存、查询和删除
+func TestSQLDelegationStore(t *testing.T) {
+	store, err := sqlstore.NewDelegationStore(openDB(t))
+	if err != nil {
+		t.Fatalf("NewDelegationStore() failed: %v", err)
+	}
+
+	now := time.Now()
+	global := &task.DelegationRule{ID: "rule-001", Delegator: "alice", Delegate: "bob", StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), Reason: "年假"}
+	scoped := &task.DelegationRule{ID: "rule-002", Delegator: "alice", Delegate: "carol", TemplateID: "tpl-001", StartAt: now.Add(-2 * time.Hour), EndAt: now.Add(time.Hour)}
+	for _, rule := range []*task.DelegationRule{global, scoped} {
+		if err := store.Save(rule); err != nil {
+			t.Fatalf("Save(%s) failed: %v", rule.ID, err)
+		}
+	}
+
+	rules, err := store.List("alice")
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(rules) != 2 || rules[0].ID != "rule-002" || rules[1].Reason != "年假" || !rules[1].StartAt.Equal(global.StartAt) {
+		t.Errorf("List() = %+v", rules)
+	}
+
+	// 指定模板的规则优先
+	rule, err := store.FindActive("alice", "tpl-001", now)
+	if err != nil || rule == nil || rule.Delegate != "carol" {
+		t.Errorf("FindActive(tpl-001) = %+v, %v, want carol", rule, err)
+	}
+	rule, err = store.FindActive("alice", "tpl-002", now)
+	if err != nil || rule == nil || rule.Delegate != "bob" {
+		t.Errorf("FindActive(tpl-002) = %+v, %v, want bob", rule, err)
+	}
+	rule, err = store.FindActive("alice", "tpl-002", now.Add(2*time.Hour))
+	if err != nil || rule != nil {
+		t.Errorf("FindActive() after expiry = %+v, %v, want nil", rule, err)
+	}
+
+	// 相同 ID 替换
+	global.Delegate = "dave"
+	if err := store.Save(global); err != nil {
+		t.Fatalf("Save() replace failed: %v", err)
+	}
+	if rule, _ := store.FindActive("alice", "tpl-002", now); rule == nil || rule.Delegate != "dave" {
+		t.Errorf("FindActive() after replace = %+v, want dave", rule)
+	}
+
+	if err := store.Delete("rule-001"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if err := store.Delete("rule-001"); !stderrors.Is(err, errors.ErrDelegationNotFound) {
+		t.Errorf("Delete() missing = %v, want ErrDelegationNotFound", err)
+	}
+	if rules, _ := store.List(""); len(rules) != 1 {
+		t.Errorf("List() after delete = %+v", rules)
+	}
+}
@@ -0,0 +1,173 @@
+package sqlstore_test
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/sqlstore"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// newOutbox 创建 SQL 事件发件箱
+func newOutbox(t *testing.T, db *sql.DB) *sqlstore.Outbox {
+	t.Helper()
+	outbox, err := sqlstore.NewOutbox(db)
+	if err != nil {
+		t.Fatalf("NewOutbox() failed: %v", err)
+	}
+	return outbox
+}
+
+// recordingHandler 记录事件类型的事件处理器,fail 为 true 时处理失败
+type recordingHandler struct {
+	types []event.EventType
+	fail  bool
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, evt *event.Event) error {
+	if h.fail {
+		return fmt.Errorf("handler unavailable")
+	}
+	h.types = append(h.types, evt.Type)
+	return nil
+}
+
+// TestSQLOutboxTaskEvents 测试任务事件与任务在同一事务中写入并投递
+func TestSQLOutboxTaskEvents(t *testing.T) {
+	db := openDB(t)
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(&template.Template{
+		ID:   "outbox-template",
+		Name: "Outbox Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "end"},
+		},
+		Version: 1,
+	}); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	outbox := newOutbox(t, db)
+	taskMgr := task.NewTaskManager(templateMgr, nil,
+		task.WithFlowEngine(node.NewFlowEngine()),
+		task.WithTaskStore(newTaskStore(t, db)),
+		task.WithOutbox(outbox))
+
+	tsk, err := taskMgr.CreateBy("outbox-template", "biz-001", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	// 操作失败时不写入事件
+	if err := taskMgr.Approve(tsk.ID, "manager", "user-009", "ok"); err == nil {
+		t.Fatal("Approve() by non-approver should fail")
+	}
+	if err := taskMgr.Approve(tsk.ID, "manager", "manager-001", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+
+	billing := &recordingHandler{}
+	audit := &recordingHandler{fail: true}
+	dispatcher, err := event.NewOutboxDispatcher(outbox,
+		event.WithOutboxHandler("billing", billing),
+		event.WithOutboxHandler("audit", audit),
+		event.WithMaxAttempts(1))
+	if err != nil {
+		t.Fatalf("NewOutboxDispatcher() failed: %v", err)
+	}
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatalf("DispatchOnce() failed: %v", err)
+	}
+
+	if len(billing.types) == 0 || billing.types[0] != event.EventTypeTaskCreated || billing.types[len(billing.types)-1] != event.EventTypeTaskApproved {
+		t.Fatalf("billing events = %v, want task_created ... task_approved", billing.types)
+	}
+	// 投递失败的处理器进入死信列表,其他处理器不受影响
+	deadLetters, err := outbox.DeadLetters()
+	if err != nil {
+		t.Fatalf("DeadLetters() failed: %v", err)
+	}
+	if len(deadLetters) != len(billing.types) {
+		t.Fatalf("dead letters = %d, want %d", len(deadLetters), len(billing.types))
+	}
+	last := deadLetters[len(deadLetters)-1]
+	if last.Delivery.Handler != "audit" || last.Delivery.LastError != "handler unavailable" ||
+		last.Entry.Event.Type != event.EventTypeTaskApproved || last.Entry.Event.Task.ID != tsk.ID {
+		t.Fatalf("last dead letter = %+v, %+v", last.Entry, last.Delivery)
+	}
+	deliveries, err := outbox.Deliveries(last.Entry.ID)
+	if err != nil {
+		t.Fatalf("Deliveries() failed: %v", err)
+	}
+	if len(deliveries) != 2 || deliveries[0].Status != event.DeliveryStatusDead || deliveries[1].Status != event.DeliveryStatusDelivered {
+		t.Fatalf("deliveries = %+v", deliveries)
+	}
+
+	// 重放死信
+	audit.fail = false
+	if err := outbox.Replay(last.Entry.ID, "audit"); err != nil {
+		t.Fatalf("Replay() failed: %v", err)
+	}
+	if err := outbox.Replay(last.Entry.ID, "audit"); !stderrors.Is(err, errors.ErrDeliveryNotFound) {
+		t.Errorf("Replay() pending delivery error = %v, want ErrDeliveryNotFound", err)
+	}
+	if delivered, err := dispatcher.DispatchOnce(context.Background()); err != nil || delivered != 1 {
+		t.Fatalf("DispatchOnce() after replay = %d, %v, want 1", delivered, err)
+	}
+	if len(audit.types) != 1 || audit.types[0] != event.EventTypeTaskApproved {
+		t.Errorf("audit events = %v, want [task_approved]", audit.types)
+	}
+}
+
+// TestSQLTaskStoreSaveWithEventsRollback 测试任务保存失败时事件不写入发件箱
+func TestSQLTaskStoreSaveWithEventsRollback(t *testing.T) {
+	db := openDB(t)
+	store := newTaskStore(t, db)
+	outbox := newOutbox(t, db)
+
+	tsk := newTestTask("task-001")
+	entry := event.NewOutboxEntry(&event.Event{ID: "evt-001", Type: event.EventTypeTaskCreated, Task: &event.TaskInfo{ID: tsk.ID}}, time.Now())
+	if err := store.SaveWithEvents(tsk, []*event.OutboxEntry{entry}); err != nil {
+		t.Fatalf("SaveWithEvents() failed: %v", err)
+	}
+
+	stale := newTestTask("task-001")
+	stale.Version = 5
+	staleEntry := event.NewOutboxEntry(&event.Event{ID: "evt-002", Type: event.EventTypeTaskApproved, Task: &event.TaskInfo{ID: tsk.ID}}, time.Now())
+	if err := store.SaveWithEvents(stale, []*event.OutboxEntry{staleEntry}); !stderrors.Is(err, errors.ErrConcurrentModification) {
+		t.Fatalf("SaveWithEvents() stale error = %v, want ErrConcurrentModification", err)
+	}
+
+	pending, err := outbox.Pending("billing", time.Now(), 0)
+	if err != nil {
+		t.Fatalf("Pending() failed: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Entry.ID != entry.ID || pending[0].Entry.Event.ID != "evt-001" ||
+		pending[0].Delivery.Status != event.DeliveryStatusPending || pending[0].Delivery.Attempts != 0 {
+		t.Fatalf("pending = %+v", pending)
+	}
+}
@@ -0,0 +1,346 @@
+package sqlstore_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/sqlstore"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+
+	_ "modernc.org/sqlite"
+)
+
+// openDB 打开临时 SQLite 数据库
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "approval.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() failed: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTaskStore 创建 SQL 任务存储
+func newTaskStore(t *testing.T, db *sql.DB) *sqlstore.TaskStore {
+	t.Helper()
+	store, err := sqlstore.NewTaskStore(db)
+	if err != nil {
+		t.Fatalf("NewTaskStore() failed: %v", err)
+	}
+	return store
+}
+
+// newTestTask 创建包含全部运行时数据的任务
+func newTestTask(id string) *task.Task {
+	now := time.Now()
+	submittedAt := now.Add(time.Minute)
+	return &task.Task{
+		ID:              id,
+		TemplateID:      "tpl-001",
+		TemplateVersion: 2,
+		BusinessID:      "biz-001",
+		Initiator:       "initiator-001",
+		Params:          json.RawMessage(`{"amount":100}`),
+		State:           types.TaskStateApproving,
+		CurrentNode:     "approval",
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		SubmittedAt:     &submittedAt,
+		NodeActivatedAt: map[string]time.Time{"approval": submittedAt},
+		NodeOutputs:     map[string]json.RawMessage{"condition": json.RawMessage(`{"result":true}`)},
+		Approvers:       map[string][]string{"approval": {"user-001", "user-002"}},
+		Delegations:     map[string]map[string]string{"approval": {"user-002": "user-009"}},
+		NodeAliases:     map[string]string{"approval-old": "approval"},
+		Approvals: map[string]map[string]*task.Approval{
+			"approval": {"user-001": {Result: "approve", Comment: "ok", CreatedAt: now}},
+		},
+		CompletedNodes: []string{"start"},
+		Records: []*task.Record{
+			{ID: "record-001", TaskID: id, NodeID: "approval", Approver: "user-001", OnBehalfOf: "user-000", Result: "approve", Comment: "ok", CreatedAt: now, Attachments: []string{"a.pdf"}},
+		},
+		StateHistory: []*task.StateChange{
+			{From: types.TaskStatePending, To: types.TaskStateSubmitted, Reason: "submit", Time: now},
+			{From: types.TaskStateSubmitted, To: types.TaskStateApproving, Reason: "approve", Time: now},
+		},
+		Reminders: []*task.Reminder{
+			{NodeID: "approval", Actor: "initiator-001", Approvers: []string{"user-002"}, Message: "please approve", CreatedAt: now},
+		},
+	}
+}
+
+// TestSQLTaskStoreRoundTrip 测试保存后读取的任务数据完整
+func TestSQLTaskStoreRoundTrip(t *testing.T) {
+	store := newTaskStore(t, openDB(t))
+	tsk := newTestTask("task-001")
+
+	if err := store.Save(tsk); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if tsk.Version != 1 {
+		t.Errorf("Version after first save = %d, want 1", tsk.Version)
+	}
+
+	got, err := store.Get("task-001")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got.TemplateVersion != 2 || got.Initiator != "initiator-001" || got.State != types.TaskStateApproving || got.CurrentNode != "approval" || got.Version != 1 {
+		t.Errorf("Get() = %+v, fields mismatch", got)
+	}
+	if string(got.Params) != `{"amount":100}` || string(got.NodeOutputs["condition"]) != `{"result":true}` {
+		t.Errorf("Params = %s, NodeOutputs = %v", got.Params, got.NodeOutputs)
+	}
+	if !got.CreatedAt.Equal(tsk.CreatedAt) || got.SubmittedAt == nil || !got.SubmittedAt.Equal(*tsk.SubmittedAt) || got.PausedAt != nil {
+		t.Errorf("time fields mismatch: %+v", got)
+	}
+	if activatedAt, ok := got.NodeActivatedAt["approval"]; !ok || !activatedAt.Equal(tsk.NodeActivatedAt["approval"]) {
+		t.Errorf("NodeActivatedAt = %v", got.NodeActivatedAt)
+	}
+	if len(got.Approvers["approval"]) != 2 || got.Approvals["approval"]["user-001"].Comment != "ok" {
+		t.Errorf("Approvers = %v, Approvals = %v", got.Approvers, got.Approvals)
+	}
+	if len(got.CompletedNodes) != 1 || got.CompletedNodes[0] != "start" {
+		t.Errorf("CompletedNodes = %v", got.CompletedNodes)
+	}
+	if len(got.Records) != 1 || got.Records[0].Attachments[0] != "a.pdf" || !got.Records[0].CreatedAt.Equal(tsk.Records[0].CreatedAt) {
+		t.Errorf("Records = %+v", got.Records)
+	}
+	if got.Records[0].OnBehalfOf != "user-000" || got.Delegations["approval"]["user-002"] != "user-009" {
+		t.Errorf("OnBehalfOf = %q, Delegations = %v", got.Records[0].OnBehalfOf, got.Delegations)
+	}
+	if got.NodeAliases["approval-old"] != "approval" {
+		t.Errorf("NodeAliases = %v", got.NodeAliases)
+	}
+	if len(got.StateHistory) != 2 || got.StateHistory[1].To != types.TaskStateApproving {
+		t.Errorf("StateHistory = %+v", got.StateHistory)
+	}
+	if len(got.Reminders) != 1 || got.Reminders[0].Approvers[0] != "user-002" || !got.Reminders[0].CreatedAt.Equal(tsk.Reminders[0].CreatedAt) {
+		t.Errorf("Reminders = %+v", got.Reminders)
+	}
+}
+
+// TestSQLTaskStoreOptimisticLock 测试版本不一致时保存失败
+func TestSQLTaskStoreOptimisticLock(t *testing.T) {
+	store := newTaskStore(t, openDB(t))
+	if err := store.Save(newTestTask("task-001")); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	// 重复创建同一任务
+	if err := store.Save(newTestTask("task-001")); !stderrors.Is(err, errors.ErrConcurrentModification) {
+		t.Errorf("Save() duplicate = %v, want ErrConcurrentModification", err)
+	}
+
+	// 多个实例同时创建同一任务,只有一个成功
+	path := filepath.Join(t.TempDir(), "approval.db")
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(10000)")
+		if err != nil {
+			t.Fatalf("sql.Open() failed: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		replica := newTaskStore(t, db)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- replica.Save(newTestTask("task-002"))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	saved := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			saved++
+		case !stderrors.Is(err, errors.ErrConcurrentModification):
+			t.Errorf("Save() concurrent = %v, want ErrConcurrentModification", err)
+		}
+	}
+	if saved != 1 {
+		t.Errorf("concurrent Save() succeeded %d times, want 1", saved)
+	}
+
+	first, _ := store.Get("task-001")
+	second, _ := store.Get("task-001")
+
+	first.State = types.TaskStateApproved
+	first.Records = append(first.Records, &task.Record{ID: "record-002", NodeID: "approval", Approver: "user-002", Result: "approve", CreatedAt: time.Now()})
+	if err := store.Save(first); err != nil {
+		t.Fatalf("Save() first failed: %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Version = %d, want 2", first.Version)
+	}
+
+	second.State = types.TaskStateRejected
+	if err := store.Save(second); !stderrors.Is(err, errors.ErrConcurrentModification) {
+		t.Errorf("Save() stale = %v, want ErrConcurrentModification", err)
+	}
+
+	got, _ := store.Get("task-001")
+	if got.State != types.TaskStateApproved || len(got.Records) != 2 {
+		t.Errorf("State = %q, Records = %d, want approved and 2", got.State, len(got.Records))
+	}
+}
+
+// TestSQLTaskStoreQueryAndDelete 测试查询和删除
+func TestSQLTaskStoreQueryAndDelete(t *testing.T) {
+	store := newTaskStore(t, openDB(t))
+	for _, id := range []string{"task-001", "task-002", "task-003"} {
+		tsk := newTestTask(id)
+		if id == "task-003" {
+			tsk.State = types.TaskStatePending
+			tsk.Approvers = map[string][]string{"approval": {"user-003"}}
+		}
+		if err := store.Save(tsk); err != nil {
+			t.Fatalf("Save(%s) failed: %v", id, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter *task.TaskFilter
+		want   int
+	}{
+		{name: "all", filter: &task.TaskFilter{}, want: 3},
+		{name: "by state", filter: &task.TaskFilter{State: types.TaskStateApproving}, want: 2},
+		{name: "by approver", filter: &task.TaskFilter{Approver: "user-003"}, want: 1},
+		{name: "by template and business", filter: &task.TaskFilter{TemplateID: "tpl-001", BusinessID: "biz-002"}, want: 0},
+		{name: "by time range", filter: &task.TaskFilter{StartTime: time.Now().Add(-time.Hour), EndTime: time.Now().Add(time.Hour)}, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Query(tt.filter)
+			if err != nil {
+				t.Fatalf("Query() failed: %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("Query() returned %d tasks, want %d", len(got), tt.want)
+			}
+			// 审批记录和状态变更历史批量加载后归属正确的任务
+			for _, tsk := range got {
+				if len(tsk.Records) != 1 || tsk.Records[0].TaskID != tsk.ID || len(tsk.StateHistory) != 2 {
+					t.Errorf("task %s: Records = %+v, StateHistory = %d", tsk.ID, tsk.Records, len(tsk.StateHistory))
+				}
+			}
+		})
+	}
+
+	if err := store.Delete("task-001"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := store.Get("task-001"); !stderrors.Is(err, errors.ErrTaskNotFound) {
+		t.Errorf("Get() after delete = %v, want ErrTaskNotFound", err)
+	}
+	if err := store.Delete("task-001"); !stderrors.Is(err, errors.ErrTaskNotFound) {
+		t.Errorf("Delete() again = %v, want ErrTaskNotFound", err)
+	}
+}
+
+// TestSQLTaskStoreMigrateIdempotent 测试重复执行迁移
+func TestSQLTaskStoreMigrateIdempotent(t *testing.T) {
+	db := openDB(t)
+	newTaskStore(t, db)
+	if err := sqlstore.Migrate(db); err != nil {
+		t.Errorf("Migrate() again failed: %v", err)
+	}
+}
+
+// TestSQLTaskStoreMigrateConcurrently 测试多个实例同时对同一数据库执行迁移
+func TestSQLTaskStoreMigrateConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "approval.db")
+	const replicas = 4
+
+	var wg sync.WaitGroup
+	errs := make(chan error, replicas)
+	for i := 0; i < replicas; i++ {
+		db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(10000)")
+		if err != nil {
+			t.Fatalf("sql.Open() failed: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- sqlstore.Migrate(db)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Migrate() failed: %v", err)
+		}
+	}
+}
+
+// TestSQLTaskStoreSurvivesRestart 测试任务管理器重建后从存储继续审批
+func TestSQLTaskStoreSurvivesRestart(t *testing.T) {
+	db := openDB(t)
+	templateMgr := template.NewTemplateManager()
+	tpl := &template.Template{
+		ID:   "restart-template",
+		Name: "Restart Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "end"},
+		},
+		Version: 1,
+	}
+	if err := templateMgr.Create(tpl); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	newManager := func() task.TaskManager {
+		return task.NewTaskManager(templateMgr, nil,
+			task.WithFlowEngine(node.NewFlowEngine()),
+			task.WithTaskStore(newTaskStore(t, db)))
+	}
+
+	tsk, err := newManager().CreateBy("restart-template", "biz-001", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := newManager().Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	if err := newManager().Approve(tsk.ID, "manager", "manager-001", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+
+	got, err := newManager().Get(tsk.ID)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got.State != types.TaskStateApproved || len(got.Records) != 1 || len(got.StateHistory) == 0 {
+		t.Errorf("State = %q, Records = %d, StateHistory = %d", got.State, len(got.Records), len(got.StateHistory))
+	}
+}
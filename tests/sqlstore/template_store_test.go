@@ -0,0 +1,167 @@
+package sqlstore_test
+
+import (
+	"database/sql"
+	stderrors "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/sqlstore"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// newTemplateStore 创建 SQL 模板存储
+func newTemplateStore(t *testing.T, db *sql.DB) *sqlstore.TemplateStore {
+	t.Helper()
+	store, err := sqlstore.NewTemplateStore(db)
+	if err != nil {
+		t.Fatalf("NewTemplateStore() failed: %v", err)
+	}
+	return store
+}
+
+// newTestTemplate 创建包含审批节点、条件节点和 Webhook 配置的模板
+func newTestTemplate() *template.Template {
+	now := time.Now()
+	return &template.Template{
+		ID:          "expense",
+		Name:        "Expense",
+		Description: "Expense approval",
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"check": {
+				ID:   "check",
+				Name: "Amount Check",
+				Type: template.NodeTypeCondition,
+				Config: &node.ConditionNodeConfig{
+					Condition:   &node.Condition{Type: "numeric", Config: &node.NumericConditionConfig{Field: "amount", Operator: "gt", Value: 1000, Source: "task_params"}},
+					TrueNodeID:  "manager",
+					FalseNodeID: "end",
+				},
+			},
+			"manager": {
+				ID:    "manager",
+				Name:  "Manager",
+				Type:  template.NodeTypeApproval,
+				Order: 1,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "check"},
+			{From: "check", To: "manager", Condition: "true"},
+			{From: "check", To: "end", Condition: "false"},
+			{From: "manager", To: "end"},
+		},
+		Config: &template.TemplateConfig{
+			Webhooks: []*template.WebhookConfig{
+				{URL: "https://example.com/hook", Method: "POST", Auth: &template.AuthConfig{Type: "token", Token: "secret"}},
+			},
+		},
+	}
+}
+
+// TestSQLTemplateStoreRoundTrip 测试保存后读取的模板数据完整
+func TestSQLTemplateStoreRoundTrip(t *testing.T) {
+	store := newTemplateStore(t, openDB(t))
+	tpl := newTestTemplate()
+	if err := store.SaveVersion(tpl); err != nil {
+		t.Fatalf("SaveVersion() failed: %v", err)
+	}
+
+	got, err := store.Get("expense", 1)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got.Name != tpl.Name || got.Description != tpl.Description || !got.CreatedAt.Equal(tpl.CreatedAt) {
+		t.Errorf("Get() = %+v, basic fields mismatch", got)
+	}
+	if !reflect.DeepEqual(got.Nodes, tpl.Nodes) {
+		t.Errorf("Nodes mismatch: got %+v", got.Nodes)
+	}
+	if !reflect.DeepEqual(got.Edges, tpl.Edges) {
+		t.Errorf("Edges mismatch: got %+v", got.Edges)
+	}
+	if !reflect.DeepEqual(got.Config, tpl.Config) {
+		t.Errorf("Config mismatch: got %+v", got.Config)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("loaded template is invalid: %v", err)
+	}
+}
+
+// TestSQLTemplateStoreImmutableVersions 测试已保存的版本不可覆盖
+func TestSQLTemplateStoreImmutableVersions(t *testing.T) {
+	store := newTemplateStore(t, openDB(t))
+	if err := store.SaveVersion(newTestTemplate()); err != nil {
+		t.Fatalf("SaveVersion() failed: %v", err)
+	}
+
+	changed := newTestTemplate()
+	changed.Name = "Changed"
+	if err := store.SaveVersion(changed); !stderrors.Is(err, errors.ErrTemplateVersionExists) {
+		t.Errorf("SaveVersion() existing version = %v, want ErrTemplateVersionExists", err)
+	}
+	if got, _ := store.Get("expense", 1); got.Name != "Expense" {
+		t.Errorf("Name = %q, version 1 should not be changed", got.Name)
+	}
+
+	if _, err := store.Get("expense", 2); !stderrors.Is(err, errors.ErrTemplateNotFound) {
+		t.Errorf("Get() missing version = %v, want ErrTemplateNotFound", err)
+	}
+	if err := store.Delete("expense"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := store.ListVersions("expense"); !stderrors.Is(err, errors.ErrTemplateNotFound) {
+		t.Errorf("ListVersions() after delete = %v, want ErrTemplateNotFound", err)
+	}
+}
+
+// TestSQLTemplateStoreSharedByReplicas 测试多个模板管理器共享同一数据库
+func TestSQLTemplateStoreSharedByReplicas(t *testing.T) {
+	db := openDB(t)
+	replicaA := template.NewTemplateManager(template.WithTemplateStore(newTemplateStore(t, db)))
+	replicaB := template.NewTemplateManager(template.WithTemplateStore(newTemplateStore(t, db)))
+
+	if err := replicaA.Create(newTestTemplate()); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	updated := newTestTemplate()
+	updated.Name = "Expense v2"
+	if err := replicaB.Update("expense", updated); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	if err := replicaA.Update("expense", newTestTemplate()); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+
+	versions, err := replicaA.ListVersions("expense")
+	if err != nil {
+		t.Fatalf("ListVersions() failed: %v", err)
+	}
+	if !reflect.DeepEqual(versions, []int{1, 2, 3}) {
+		t.Errorf("ListVersions() = %v, want [1 2 3]", versions)
+	}
+
+	v2, err := replicaA.Get("expense", 2)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if v2.Name != "Expense v2" {
+		t.Errorf("version 2 Name = %q, want %q", v2.Name, "Expense v2")
+	}
+	latest, _ := replicaB.Get("expense", 0)
+	if latest.Version != 3 {
+		t.Errorf("latest Version = %d, want 3", latest.Version)
+	}
+}
@@ -30,19 +30,19 @@ func TestAddApproverWithoutPermission(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-002", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-002", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 尝试加签(应该失败,因为没有权限)
-	err = taskMgr.AddApprover(tsk.ID, "approval-node", "user-2", "add approver reason")
+	err = taskMgr.AddApprover(tsk.ID, "approval-node", "admin-001", "user-2", "add approver reason")
 	if err == nil {
 		t.Error("AddApprover() should fail when permission is not allowed")
 	}
@@ -54,7 +54,7 @@ func TestAddApproverNotFound(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	// 尝试加签不存在的任务
-	err := taskMgr.AddApprover("non-existent", "approval-node", "user-2", "add approver reason")
+	err := taskMgr.AddApprover("non-existent", "approval-node", "admin-001", "user-2", "add approver reason")
 	if err == nil {
 		t.Error("AddApprover() should fail when task does not exist")
 	}
@@ -0,0 +1,402 @@
+package task_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// quorumModeHandler 自定义审批模式: 任意两人同意即通过
+type quorumModeHandler struct{}
+
+func (h *quorumModeHandler) Mode() node.ApprovalMode {
+	return "quorum"
+}
+
+func (h *quorumModeHandler) CheckCompletion(approvers []string, approvals map[string]*task.Approval, config *node.ApprovalNodeConfig) (bool, *node.ApprovalResult) {
+	approved := 0
+	for _, approver := range approvers {
+		if approval, exists := approvals[approver]; exists && approval.Result == "approve" {
+			approved++
+		}
+	}
+	if approved >= 2 {
+		return true, &node.ApprovalResult{Completed: true, Result: "approve"}
+	}
+	return false, nil
+}
+
+// createModeTemplate 创建包含经理审批和指定审批模式的会审节点的模板
+// start -> manager -> review -> end
+func createModeTemplate(reviewConfig *node.ApprovalNodeConfig) *template.Template {
+	return &template.Template{
+		ID:   "mode-template",
+		Name: "Approval Mode Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+				},
+			},
+			"review": {ID: "review", Name: "Review", Type: template.NodeTypeApproval, Config: reviewConfig},
+			"end":    {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "review"},
+			{From: "review", To: "end"},
+		},
+		Version: 1,
+	}
+}
+
+// newModeTemplateManager 创建包含会审模板的模板管理器
+func newModeTemplateManager(t *testing.T, reviewConfig *node.ApprovalNodeConfig) template.TemplateManager {
+	t.Helper()
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(createModeTemplate(reviewConfig)); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	return templateMgr
+}
+
+// submitModeTask 创建启用流程引擎的任务管理器,并创建、提交任务
+func submitModeTask(t *testing.T, reviewConfig *node.ApprovalNodeConfig, engineOpts ...node.FlowEngineOption) (task.TaskManager, string) {
+	templateMgr := newModeTemplateManager(t, reviewConfig)
+	taskMgr := task.NewTaskManager(templateMgr, nil, task.WithFlowEngine(node.NewFlowEngine(engineOpts...)))
+
+	tsk, err := taskMgr.CreateBy("mode-template", "biz-001", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	return taskMgr, tsk.ID
+}
+
+// assertTask 验证任务的状态和当前节点
+func assertTask(t *testing.T, taskMgr task.TaskManager, id string, wantState types.TaskState, wantNode string) {
+	t.Helper()
+	tsk, err := taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if tsk.State != wantState || tsk.CurrentNode != wantNode {
+		t.Errorf("State = %q, CurrentNode = %q, want %q and %q", tsk.State, tsk.CurrentNode, wantState, wantNode)
+	}
+}
+
+// TestFlowEngineOrMode 测试或签模式: 一人拒绝继续等待,一人同意即通过
+func TestFlowEngineOrMode(t *testing.T) {
+	taskMgr, id := submitModeTask(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeOr,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002", "user-003"}},
+	})
+
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() by manager failed: %v", err)
+	}
+
+	if err := taskMgr.Reject(id, "review", "user-001", "no"); err != nil {
+		t.Fatalf("Reject() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+
+	if err := taskMgr.Approve(id, "review", "user-002", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+}
+
+// TestFlowEngineProportionalMode 测试比例会签模式: 达到阈值即通过
+func TestFlowEngineProportionalMode(t *testing.T) {
+	taskMgr, id := submitModeTask(t, &node.ApprovalNodeConfig{
+		Mode:                  node.ApprovalModeProportional,
+		ApproverConfig:        &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002", "user-003"}},
+		ProportionalThreshold: &node.ProportionalThreshold{Required: 2, Total: 3},
+	})
+
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() by manager failed: %v", err)
+	}
+	if err := taskMgr.Approve(id, "review", "user-001", ""); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+
+	if err := taskMgr.Approve(id, "review", "user-003", ""); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+}
+
+// TestFlowEngineSequentialMode 测试顺序审批模式: 必须按顺序审批
+func TestFlowEngineSequentialMode(t *testing.T) {
+	taskMgr, id := submitModeTask(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeSequential,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002"}},
+	})
+
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() by manager failed: %v", err)
+	}
+
+	err := taskMgr.Approve(id, "review", "user-002", "")
+	if !stderrors.Is(err, errors.ErrApprovalOutOfOrder) {
+		t.Fatalf("Approve() out of order = %v, want ErrApprovalOutOfOrder", err)
+	}
+	tsk, _ := taskMgr.Get(id)
+	if len(tsk.Approvals["review"]) != 0 {
+		t.Error("out of order approval should not be recorded")
+	}
+
+	if err := taskMgr.Approve(id, "review", "user-001", ""); err != nil {
+		t.Fatalf("Approve() by user-001 failed: %v", err)
+	}
+	if err := taskMgr.Approve(id, "review", "user-002", ""); err != nil {
+		t.Fatalf("Approve() by user-002 failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+}
+
+// TestFlowEngineCustomMode 测试注入自定义审批模式处理器
+func TestFlowEngineCustomMode(t *testing.T) {
+	registry := node.NewApprovalModeHandlerRegistry()
+	registry.RegisterHandler("quorum", &quorumModeHandler{})
+
+	taskMgr, id := submitModeTask(t, &node.ApprovalNodeConfig{
+		Mode:           "quorum",
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002", "user-003"}},
+	}, node.WithApprovalModeRegistry(registry))
+
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() by manager failed: %v", err)
+	}
+	if err := taskMgr.Approve(id, "review", "user-001", ""); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+
+	if err := taskMgr.Approve(id, "review", "user-002", ""); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+}
+
+// TestFlowEngineRejectBehaviors 测试启用流程引擎后的拒绝后行为
+func TestFlowEngineRejectBehaviors(t *testing.T) {
+	tests := []struct {
+		name      string
+		behavior  node.RejectBehavior
+		target    string
+		wantState types.TaskState
+		wantNode  string
+	}{
+		{name: "terminate", behavior: node.RejectBehaviorTerminate, wantState: types.TaskStateRejected, wantNode: "review"},
+		{name: "rollback to previous approval node", behavior: node.RejectBehaviorRollback, wantState: types.TaskStateApproving, wantNode: "manager"},
+		{name: "jump to target node", behavior: node.RejectBehaviorJump, target: "manager", wantState: types.TaskStateApproving, wantNode: "manager"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskMgr, id := submitModeTask(t, &node.ApprovalNodeConfig{
+				Mode:             node.ApprovalModeSingle,
+				ApproverConfig:   &node.FixedApproverConfig{Approvers: []string{"user-001"}},
+				RejectBehavior:   tt.behavior,
+				RejectTargetNode: tt.target,
+			})
+
+			if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+				t.Fatalf("Approve() by manager failed: %v", err)
+			}
+			if err := taskMgr.Reject(id, "review", "user-001", "no"); err != nil {
+				t.Fatalf("Reject() failed: %v", err)
+			}
+			assertTask(t, taskMgr, id, tt.wantState, tt.wantNode)
+
+			if tt.wantState != types.TaskStateApproving {
+				return
+			}
+
+			// 重新激活的节点需要重新审批,之后流程继续向后推进
+			tsk, _ := taskMgr.Get(id)
+			if len(tsk.Approvals["manager"]) != 0 {
+				t.Error("approvals of reactivated node should be cleared")
+			}
+			if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+				t.Fatalf("Approve() by manager again failed: %v", err)
+			}
+			assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+			if err := taskMgr.Approve(id, "review", "user-001", ""); err != nil {
+				t.Fatalf("Approve() by user-001 failed: %v", err)
+			}
+			assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+		})
+	}
+}
+
+// submitModeTaskWithoutEngine 创建未启用流程引擎的任务管理器,并创建、提交只有会审节点的任务
+// 审批人在任务创建时写入任务
+func submitModeTaskWithoutEngine(t *testing.T, reviewConfig *node.ApprovalNodeConfig, approvers []string, opts ...task.ManagerOption) (task.TaskManager, string) {
+	t.Helper()
+	tpl := createModeTemplate(reviewConfig)
+	delete(tpl.Nodes, "manager")
+	tpl.Edges = []*template.Edge{{From: "start", To: "review"}, {From: "review", To: "end"}}
+
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(tpl); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	fetcher := func(_ *template.Template, tsk *task.Task) error {
+		tsk.Approvers["review"] = approvers
+		return nil
+	}
+	taskMgr := task.NewTaskManager(templateMgr, fetcher, opts...)
+
+	tsk, err := taskMgr.CreateBy("mode-template", "biz-001", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	return taskMgr, tsk.ID
+}
+
+// failingApprovalDecider 判断节点完成时总是失败的 ApprovalDecider
+type failingApprovalDecider struct{}
+
+// CheckApprover 不限制审批顺序
+func (failingApprovalDecider) CheckApprover(node *template.Node, approvers []string, approvals map[string]*task.Approval, approver string) error {
+	return nil
+}
+
+// DecideApproval 返回错误
+func (failingApprovalDecider) DecideApproval(node *template.Node, approvers []string, approvals map[string]*task.Approval) (bool, string, error) {
+	return false, "", fmt.Errorf("decider unavailable")
+}
+
+// TestApprovalModeWithoutEngine 测试未启用流程引擎时按审批模式判断节点是否完成
+func TestApprovalModeWithoutEngine(t *testing.T) {
+	approvers := []string{"user-001", "user-002", "user-003"}
+
+	t.Run("proportional", func(t *testing.T) {
+		taskMgr, id := submitModeTaskWithoutEngine(t, &node.ApprovalNodeConfig{
+			Mode:                  node.ApprovalModeProportional,
+			ApproverConfig:        &node.FixedApproverConfig{Approvers: approvers},
+			ProportionalThreshold: &node.ProportionalThreshold{Required: 2, Total: 3},
+		}, approvers, task.WithApprovalDecider(node.NewApprovalDecider(nil)))
+
+		if err := taskMgr.Approve(id, "review", "user-001", ""); err != nil {
+			t.Fatalf("Approve() failed: %v", err)
+		}
+		assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+		if err := taskMgr.ApproveWithAttachments(id, "review", "user-002", "", []string{"a.pdf"}); err != nil {
+			t.Fatalf("ApproveWithAttachments() failed: %v", err)
+		}
+		assertTask(t, taskMgr, id, types.TaskStateApproved, "review")
+	})
+
+	t.Run("or", func(t *testing.T) {
+		taskMgr, id := submitModeTaskWithoutEngine(t, &node.ApprovalNodeConfig{
+			Mode:           node.ApprovalModeOr,
+			ApproverConfig: &node.FixedApproverConfig{Approvers: approvers},
+		}, approvers, task.WithApprovalDecider(node.NewApprovalDecider(nil)))
+
+		// 或签模式一人拒绝继续等待,全部拒绝才拒绝
+		if err := taskMgr.Reject(id, "review", "user-001", "no"); err != nil {
+			t.Fatalf("Reject() failed: %v", err)
+		}
+		assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+		if err := taskMgr.Reject(id, "review", "user-002", "no"); err != nil {
+			t.Fatalf("Reject() failed: %v", err)
+		}
+		if err := taskMgr.RejectWithAttachments(id, "review", "user-003", "no", []string{"b.pdf"}); err != nil {
+			t.Fatalf("RejectWithAttachments() failed: %v", err)
+		}
+		assertTask(t, taskMgr, id, types.TaskStateRejected, "review")
+	})
+
+	t.Run("sequential", func(t *testing.T) {
+		taskMgr, id := submitModeTaskWithoutEngine(t, &node.ApprovalNodeConfig{
+			Mode:           node.ApprovalModeSequential,
+			ApproverConfig: &node.FixedApproverConfig{Approvers: approvers},
+		}, approvers, task.WithApprovalDecider(node.NewApprovalDecider(nil)))
+
+		// 顺序审批不能跳过前面的审批人
+		err := taskMgr.Approve(id, "review", "user-002", "")
+		if !stderrors.Is(err, errors.ErrApprovalOutOfOrder) {
+			t.Fatalf("Approve() out of order error = %v, want ErrApprovalOutOfOrder", err)
+		}
+		for _, approver := range approvers {
+			if err := taskMgr.Approve(id, "review", approver, ""); err != nil {
+				t.Fatalf("Approve() by %s failed: %v", approver, err)
+			}
+		}
+		assertTask(t, taskMgr, id, types.TaskStateApproved, "review")
+	})
+
+	t.Run("without decider", func(t *testing.T) {
+		taskMgr, id := submitModeTaskWithoutEngine(t, &node.ApprovalNodeConfig{
+			Mode:           node.ApprovalModeOr,
+			ApproverConfig: &node.FixedApproverConfig{Approvers: approvers},
+		}, approvers)
+
+		// 未设置 ApprovalDecider 时任一审批人拒绝即拒绝
+		if err := taskMgr.Reject(id, "review", "user-001", "no"); err != nil {
+			t.Fatalf("Reject() failed: %v", err)
+		}
+		assertTask(t, taskMgr, id, types.TaskStateRejected, "review")
+	})
+
+	t.Run("decider error restores task", func(t *testing.T) {
+		taskMgr, id := submitModeTaskWithoutEngine(t, &node.ApprovalNodeConfig{
+			Mode:           node.ApprovalModeSingle,
+			ApproverConfig: &node.FixedApproverConfig{Approvers: approvers},
+		}, approvers, task.WithApprovalDecider(failingApprovalDecider{}))
+
+		if err := taskMgr.Approve(id, "review", "user-001", ""); err == nil {
+			t.Fatal("Approve() should fail when the decider fails")
+		}
+		if err := taskMgr.Reject(id, "review", "user-001", "no"); err == nil {
+			t.Fatal("Reject() should fail when the decider fails")
+		}
+		tsk, err := taskMgr.Get(id)
+		if err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		if tsk.State != types.TaskStateSubmitted || len(tsk.Approvals["review"]) != 0 || len(tsk.Records) != 0 {
+			t.Errorf("task should be restored, got State = %q, Approvals = %v, Records = %d", tsk.State, tsk.Approvals["review"], len(tsk.Records))
+		}
+	})
+
+	t.Run("custom mode with decider", func(t *testing.T) {
+		registry := node.NewApprovalModeHandlerRegistry()
+		registry.RegisterHandler("quorum", &quorumModeHandler{})
+		taskMgr, id := submitModeTaskWithoutEngine(t, &node.ApprovalNodeConfig{
+			Mode:           "quorum",
+			ApproverConfig: &node.FixedApproverConfig{Approvers: approvers},
+		}, approvers, task.WithApprovalDecider(node.NewApprovalDecider(registry)))
+
+		if err := taskMgr.Approve(id, "review", "user-001", ""); err != nil {
+			t.Fatalf("Approve() failed: %v", err)
+		}
+		assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+		if err := taskMgr.Approve(id, "review", "user-002", ""); err != nil {
+			t.Fatalf("Approve() failed: %v", err)
+		}
+		assertTask(t, taskMgr, id, types.TaskStateApproved, "review")
+	})
+}
@@ -12,7 +12,7 @@ import (
 // TestTaskManagerApprove 测试审批操作
 func TestTaskManagerApprove(t *testing.T) {
 	templateManager := template.NewTemplateManager()
-	tm := task.NewTaskManager(templateManager, nil)
+	tm := task.NewTaskManager(templateManager, nil, testAuthorizer())
 
 	// 创建模板
 	tmpl := createTestTemplateWithApprovalNode()
@@ -22,13 +22,13 @@ func TestTaskManagerApprove(t *testing.T) {
 	}
 
 	// 创建任务
-	tsk, err := tm.Create(tmpl.ID, "business-001", nil)
+	tsk, err := tm.CreateBy(tmpl.ID, "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = tm.Submit(tsk.ID)
+	err = tm.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -102,7 +102,7 @@ func TestTaskManagerApproveInvalidState(t *testing.T) {
 	}
 
 	// 创建任务(状态为 pending)
-	tsk, err := tm.Create(tmpl.ID, "business-001", nil)
+	tsk, err := tm.CreateBy(tmpl.ID, "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
@@ -119,7 +119,7 @@ func TestTaskManagerApproveInvalidState(t *testing.T) {
 // TestTaskManagerReject 测试拒绝操作
 func TestTaskManagerReject(t *testing.T) {
 	templateManager := template.NewTemplateManager()
-	tm := task.NewTaskManager(templateManager, nil)
+	tm := task.NewTaskManager(templateManager, nil, testAuthorizer())
 
 	// 创建模板
 	tmpl := createTestTemplateWithApprovalNode()
@@ -129,13 +129,13 @@ func TestTaskManagerReject(t *testing.T) {
 	}
 
 	// 创建任务
-	tsk, err := tm.Create(tmpl.ID, "business-001", nil)
+	tsk, err := tm.CreateBy(tmpl.ID, "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = tm.Submit(tsk.ID)
+	err = tm.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -170,7 +170,7 @@ func TestTaskManagerReject(t *testing.T) {
 // TestTaskManagerApproveRequireComment 测试审批意见必填
 func TestTaskManagerApproveRequireComment(t *testing.T) {
 	templateManager := template.NewTemplateManager()
-	tm := task.NewTaskManager(templateManager, nil)
+	tm := task.NewTaskManager(templateManager, nil, testAuthorizer())
 
 	// 创建包含必填审批意见配置的模板
 	tmpl := &template.Template{
@@ -212,13 +212,13 @@ func TestTaskManagerApproveRequireComment(t *testing.T) {
 	}
 
 	// 创建任务
-	tsk, err := tm.Create("tpl-require-comment", "business-001", nil)
+	tsk, err := tm.CreateBy("tpl-require-comment", "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = tm.Submit(tsk.ID)
+	err = tm.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -249,13 +249,13 @@ func TestTaskManagerApproveNodeNotFound(t *testing.T) {
 	}
 
 	// 创建任务
-	tsk, err := tm.Create(tmpl.ID, "business-001", nil)
+	tsk, err := tm.CreateBy(tmpl.ID, "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = tm.Submit(tsk.ID)
+	err = tm.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -280,7 +280,7 @@ func TestTaskManagerApproveTemplateNotFound(t *testing.T) {
 	}
 
 	// 创建任务
-	tsk, err := tm.Create(tmpl.ID, "business-001", nil)
+	tsk, err := tm.CreateBy(tmpl.ID, "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
@@ -292,7 +292,7 @@ func TestTaskManagerApproveTemplateNotFound(t *testing.T) {
 	}
 
 	// 提交任务
-	err = tm.Submit(tsk.ID)
+	err = tm.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -336,13 +336,13 @@ func TestTaskManagerApproveNonApprovalNode(t *testing.T) {
 	}
 
 	// 创建任务
-	tsk, err := tm.Create("tpl-non-approval", "business-001", nil)
+	tsk, err := tm.CreateBy("tpl-non-approval", "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = tm.Submit(tsk.ID)
+	err = tm.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -362,7 +362,7 @@ func TestTaskManagerApproveNonApprovalNode(t *testing.T) {
 // TestTaskManagerApproveInApprovingState 测试在 approving 状态下审批
 func TestTaskManagerApproveInApprovingState(t *testing.T) {
 	templateManager := template.NewTemplateManager()
-	tm := task.NewTaskManager(templateManager, nil)
+	tm := task.NewTaskManager(templateManager, nil, testAuthorizer())
 
 	// 创建模板(使用多人会签模式,允许多个审批人)
 	// 使用 record_query_test.go 中定义的 createTestTemplateWithMultipleApprovers
@@ -374,24 +374,24 @@ func TestTaskManagerApproveInApprovingState(t *testing.T) {
 	}
 
 	// 创建任务
-	tsk, err := tm.Create(tmpl.ID, "business-001", nil)
+	tsk, err := tm.CreateBy(tmpl.ID, "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = tm.Submit(tsk.ID)
+	err = tm.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
 
 	// 手动设置审批人列表(因为固定审批人需要在节点激活时设置)
-	err = tm.AddApprover(tsk.ID, "approval-001", "user-001", "setup approver")
+	err = tm.AddApprover(tsk.ID, "approval-001", "admin-001", "user-001", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
-	err = tm.AddApprover(tsk.ID, "approval-001", "user-002", "setup approver")
+	err = tm.AddApprover(tsk.ID, "approval-001", "admin-001", "user-002", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
@@ -511,13 +511,13 @@ func TestTaskManagerApproveNodeConfigNotAccessor(t *testing.T) {
 	}
 
 	// 创建任务
-	tsk, err := tm.Create("tpl-nil-config", "business-001", nil)
+	tsk, err := tm.CreateBy("tpl-nil-config", "business-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = tm.Submit(tsk.ID)
+	err = tm.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -0,0 +1,243 @@
+package task_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// newAuthorizeTaskManager 创建使用指定选项的任务管理器和由 initiator-001 发起的任务
+func newAuthorizeTaskManager(t *testing.T, opts ...task.ManagerOption) (task.TaskManager, string) {
+	t.Helper()
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(createTestTemplate()); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	taskMgr := task.NewTaskManager(templateMgr, nil, opts...)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("CreateBy() failed: %v", err)
+	}
+	if tsk.Initiator != "initiator-001" {
+		t.Errorf("Initiator = %q, want initiator-001", tsk.Initiator)
+	}
+	return taskMgr, tsk.ID
+}
+
+// assertForbidden 验证错误为 ErrForbidden
+func assertForbidden(t *testing.T, err error, wantAction task.Action) {
+	t.Helper()
+	if !stderrors.Is(err, errors.ErrForbidden) {
+		t.Fatalf("error = %v, want ErrForbidden", err)
+	}
+	var forbiddenErr *task.ForbiddenError
+	if !stderrors.As(err, &forbiddenErr) || forbiddenErr.Action != wantAction {
+		t.Errorf("error = %#v, want *ForbiddenError with action %q", err, wantAction)
+	}
+}
+
+// TestDefaultAuthorizerCancelWithdraw 测试只有发起人或管理员可以取消和撤回
+func TestDefaultAuthorizerCancelWithdraw(t *testing.T) {
+	taskMgr, id := newAuthorizeTaskManager(t)
+
+	assertForbidden(t, taskMgr.Cancel(id, "user-001", "not mine"), task.ActionCancel)
+	assertForbidden(t, taskMgr.Cancel(id, "", "anonymous"), task.ActionCancel)
+	if err := taskMgr.Submit(id, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	assertForbidden(t, taskMgr.Withdraw(id, "user-001", "not mine"), task.ActionWithdraw)
+	assertTask(t, taskMgr, id, types.TaskStateSubmitted, "approval-001")
+
+	if err := taskMgr.Withdraw(id, "initiator-001", "withdraw"); err != nil {
+		t.Fatalf("Withdraw() by initiator failed: %v", err)
+	}
+
+	// 管理员可以取消他人发起的任务
+	adminMgr, adminID := newAuthorizeTaskManager(t, task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))))
+	if err := adminMgr.Cancel(adminID, "admin-001", "cleanup"); err != nil {
+		t.Fatalf("Cancel() by admin failed: %v", err)
+	}
+	assertTask(t, adminMgr, adminID, types.TaskStateCancelled, "start")
+}
+
+// TestDefaultAuthorizerWithoutInitiator 测试未记录发起人的任务只有管理员可以操作
+func TestDefaultAuthorizerWithoutInitiator(t *testing.T) {
+	taskMgr, _ := newAuthorizeTaskManager(t, task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))))
+	tsk, err := taskMgr.Create("tpl-001", "biz-002", nil)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if tsk.Initiator != "" {
+		t.Errorf("Initiator = %q, want empty", tsk.Initiator)
+	}
+
+	assertForbidden(t, taskMgr.Submit(tsk.ID, "user-001"), task.ActionSubmit)
+	assertForbidden(t, taskMgr.Submit(tsk.ID, ""), task.ActionSubmit)
+	assertForbidden(t, taskMgr.Cancel(tsk.ID, "user-001", "no initiator"), task.ActionCancel)
+	assertForbidden(t, taskMgr.Cancel(tsk.ID, "", "no initiator"), task.ActionCancel)
+	assertTask(t, taskMgr, tsk.ID, types.TaskStatePending, "start")
+
+	if err := taskMgr.Cancel(tsk.ID, "admin-001", "cleanup"); err != nil {
+		t.Fatalf("Cancel() by admin failed: %v", err)
+	}
+	assertTask(t, taskMgr, tsk.ID, types.TaskStateCancelled, "start")
+}
+
+// TestDefaultAuthorizerSubmitPauseResume 测试只有发起人或管理员可以提交、暂停和恢复
+func TestDefaultAuthorizerSubmitPauseResume(t *testing.T) {
+	taskMgr, id := newAuthorizeTaskManager(t, task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))))
+
+	assertForbidden(t, taskMgr.Submit(id, "user-001"), task.ActionSubmit)
+	if err := taskMgr.Submit(id, "initiator-001"); err != nil {
+		t.Fatalf("Submit() by initiator failed: %v", err)
+	}
+
+	assertForbidden(t, taskMgr.Pause(id, "user-001", "not mine"), task.ActionPause)
+	if err := taskMgr.Pause(id, "initiator-001", "pause"); err != nil {
+		t.Fatalf("Pause() by initiator failed: %v", err)
+	}
+	assertForbidden(t, taskMgr.Resume(id, "user-001", "not mine"), task.ActionResume)
+	if err := taskMgr.Resume(id, "admin-001", "resume"); err != nil {
+		t.Fatalf("Resume() by admin failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateSubmitted, "approval-001")
+}
+
+// TestDefaultAuthorizerApproverManagement 测试加签、减签、替换审批人、回退和迁移的权限
+func TestDefaultAuthorizerApproverManagement(t *testing.T) {
+	reviewConfig := &node.ApprovalNodeConfig{
+		Mode:        node.ApprovalModeUnanimous,
+		Permissions: node.OperationPermissions{AllowAddApprover: true, AllowRemoveApprover: true},
+	}
+	taskMgr, id := submitModeTaskWithoutEngine(t, reviewConfig, []string{"user-001", "user-002"},
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"))))
+
+	// 非审批人不能为自己加签后审批
+	assertForbidden(t, taskMgr.AddApprover(id, "review", "user-009", "user-009", "self"), task.ActionAddApprover)
+	assertForbidden(t, taskMgr.AddApprover(id, "review", "initiator-001", "user-009", "initiator"), task.ActionAddApprover)
+	if err := taskMgr.AddApprover(id, "review", "user-001", "user-003", "need review"); err != nil {
+		t.Fatalf("AddApprover() by approver failed: %v", err)
+	}
+
+	// 减签、替换审批人、回退和迁移只有管理员可以操作
+	assertForbidden(t, taskMgr.RemoveApprover(id, "review", "user-001", "user-003", "remove"), task.ActionRemoveApprover)
+	assertForbidden(t, taskMgr.ReplaceApprover(id, "review", "user-001", "user-002", "user-004", "replace"), task.ActionReplaceApprover)
+	assertForbidden(t, taskMgr.RollbackToNode(id, "start", "initiator-001", "rollback"), task.ActionRollback)
+	assertForbidden(t, taskMgr.MigrateTaskToVersion(id, 1, nil, "initiator-001"), task.ActionMigrate)
+
+	if err := taskMgr.RemoveApprover(id, "review", "admin-001", "user-003", "remove"); err != nil {
+		t.Fatalf("RemoveApprover() by admin failed: %v", err)
+	}
+	if err := taskMgr.ReplaceApprover(id, "review", "admin-001", "user-002", "user-004", "replace"); err != nil {
+		t.Fatalf("ReplaceApprover() by admin failed: %v", err)
+	}
+	tsk, err := taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got := tsk.Approvers["review"]; len(got) != 2 || got[0] != "user-001" || got[1] != "user-004" {
+		t.Errorf("Approvers = %v, want [user-001 user-004]", got)
+	}
+}
+
+// TestDefaultAuthorizerUnassignedApprovers 测试节点没有审批人时的审批权限
+func TestDefaultAuthorizerUnassignedApprovers(t *testing.T) {
+	reviewConfig := &node.ApprovalNodeConfig{Mode: node.ApprovalModeSingle}
+
+	// 默认拒绝
+	taskMgr, id := submitModeTaskWithoutEngine(t, reviewConfig, nil)
+	assertForbidden(t, taskMgr.Approve(id, "review", "user-001", "ok"), task.ActionApprove)
+	assertForbidden(t, taskMgr.Reject(id, "review", "user-001", "no"), task.ActionReject)
+
+	// 显式允许
+	taskMgr, id = submitModeTaskWithoutEngine(t, reviewConfig, nil,
+		task.WithAuthorizer(task.NewDefaultAuthorizer(task.AllowUnassignedApprovers())))
+	if err := taskMgr.Approve(id, "review", "user-001", "ok"); err != nil {
+		t.Fatalf("Approve() with AllowUnassignedApprovers failed: %v", err)
+	}
+	tsk, err := taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if tsk.State != types.TaskStateApproved {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateApproved)
+	}
+}
+
+// TestDefaultAuthorizerApprove 测试只有节点的审批人可以审批和拒绝
+func TestDefaultAuthorizerApprove(t *testing.T) {
+	taskMgr, id := submitModeTask(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeOr,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002"}},
+	})
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() by manager failed: %v", err)
+	}
+
+	assertForbidden(t, taskMgr.Approve(id, "review", "user-009", "ok"), task.ActionApprove)
+	assertForbidden(t, taskMgr.Reject(id, "review", "user-009", "no"), task.ActionReject)
+	assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+
+	if err := taskMgr.Approve(id, "review", "user-002", "ok"); err != nil {
+		t.Fatalf("Approve() by approver failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+}
+
+// TestCustomAuthorizer 测试自定义授权策略
+func TestCustomAuthorizer(t *testing.T) {
+	var requests []*task.AuthorizationRequest
+	authorizer := task.AuthorizerFunc(func(ctx context.Context, req *task.AuthorizationRequest) error {
+		requests = append(requests, req)
+		if req.Action == task.ActionPause {
+			return &task.ForbiddenError{Action: req.Action, Actor: req.Actor, TaskID: req.Task.ID, Reason: "pause disabled"}
+		}
+		return nil
+	})
+
+	taskMgr, id := newAuthorizeTaskManager(t, task.WithAuthorizer(authorizer))
+	if err := taskMgr.Submit(id, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	assertForbidden(t, taskMgr.Pause(id, "initiator-001", "pause"), task.ActionPause)
+
+	// 自定义策略替换默认策略,其他人也可以取消
+	if err := taskMgr.Cancel(id, "user-001", "cancel"); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+
+	wantActions := []task.Action{task.ActionCreate, task.ActionSubmit, task.ActionPause, task.ActionCancel}
+	if len(requests) != len(wantActions) {
+		t.Fatalf("authorization requests = %d, want %d", len(requests), len(wantActions))
+	}
+	for i, want := range wantActions {
+		if requests[i].Action != want {
+			t.Errorf("requests[%d].Action = %q, want %q", i, requests[i].Action, want)
+		}
+	}
+	if requests[0].Actor != "initiator-001" || requests[3].Actor != "user-001" || requests[3].Task.Initiator != "initiator-001" {
+		t.Errorf("requests = %+v %+v", requests[0], requests[3])
+	}
+}
+
+// TestAllowAllAuthorizer 测试不做限制的授权策略
+func TestAllowAllAuthorizer(t *testing.T) {
+	taskMgr, id := newAuthorizeTaskManager(t, task.WithAuthorizer(task.AllowAllAuthorizer()))
+	if err := taskMgr.Cancel(id, "user-001", "cancel"); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateCancelled, "start")
+}
+
+// testAuthorizer 业务逻辑测试使用的授权策略
+// admin-001 为管理员,允许审批尚未获取审批人的节点(未启用流程引擎时节点没有审批人)
+func testAuthorizer() task.ManagerOption {
+	return task.WithAuthorizer(task.NewDefaultAuthorizer(task.WithAdmins("admin-001"), task.AllowUnassignedApprovers()))
+}
@@ -24,13 +24,13 @@ func TestCancel(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 取消任务
-	err = taskMgr.Cancel(tsk.ID, "user cancelled")
+	err = taskMgr.Cancel(tsk.ID, "initiator-001", "user cancelled")
 	if err != nil {
 		t.Fatalf("Cancel() failed: %v", err)
 	}
@@ -61,19 +61,19 @@ func TestCancelSubmittedTask(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 取消任务
-	err = taskMgr.Cancel(tsk.ID, "user cancelled")
+	err = taskMgr.Cancel(tsk.ID, "initiator-001", "user cancelled")
 	if err != nil {
 		t.Fatalf("Cancel() failed: %v", err)
 	}
@@ -100,17 +100,17 @@ func TestCancelApprovedTask(t *testing.T) {
 	}
 
 	// 创建任务管理器
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -122,7 +122,7 @@ func TestCancelApprovedTask(t *testing.T) {
 	}
 
 	// 尝试取消已通过的任务(应该失败)
-	err = taskMgr.Cancel(tsk.ID, "user cancelled")
+	err = taskMgr.Cancel(tsk.ID, "initiator-001", "user cancelled")
 	if err == nil {
 		t.Error("Cancel() should fail for approved task")
 	}
@@ -64,7 +64,7 @@ func TestTaskManagerCreate(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -102,7 +102,7 @@ func TestTaskManagerCreateWithParams(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000, "department": "IT"}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -125,7 +125,7 @@ func TestTaskManagerCreateTemplateNotFound(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{}`)
-	_, err := taskMgr.Create("non-existent", "biz-001", params)
+	_, err := taskMgr.CreateBy("non-existent", "biz-001", "initiator-001", params)
 	if err == nil {
 		t.Error("Create() should fail when template does not exist")
 	}
@@ -142,7 +142,7 @@ func TestTaskManagerCreateWithNilParams(t *testing.T) {
 
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed with nil params: %v", err)
 	}
@@ -163,7 +163,7 @@ func TestTaskManagerCreateInitializesRuntimeData(t *testing.T) {
 
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -0,0 +1,234 @@
+package task_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// newDelegationRule 创建当前时间前后一小时内生效的委托规则
+func newDelegationRule(id, delegator, delegate, templateID string) *task.DelegationRule {
+	now := time.Now()
+	return &task.DelegationRule{
+		ID:         id,
+		Delegator:  delegator,
+		Delegate:   delegate,
+		TemplateID: templateID,
+		StartAt:    now.Add(-time.Hour),
+		EndAt:      now.Add(time.Hour),
+	}
+}
+
+// TestDelegationRuleValidate 测试委托规则验证
+func TestDelegationRuleValidate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		rule    *task.DelegationRule
+		wantErr bool
+	}{
+		{"valid", newDelegationRule("rule-001", "alice", "bob", ""), false},
+		{"missing id", &task.DelegationRule{Delegator: "alice", Delegate: "bob", StartAt: now, EndAt: now.Add(time.Hour)}, true},
+		{"missing delegate", &task.DelegationRule{ID: "rule-001", Delegator: "alice", StartAt: now, EndAt: now.Add(time.Hour)}, true},
+		{"self delegation", &task.DelegationRule{ID: "rule-001", Delegator: "alice", Delegate: "alice", StartAt: now, EndAt: now.Add(time.Hour)}, true},
+		{"missing window", &task.DelegationRule{ID: "rule-001", Delegator: "alice", Delegate: "bob"}, true},
+		{"end before start", &task.DelegationRule{ID: "rule-001", Delegator: "alice", Delegate: "bob", StartAt: now, EndAt: now.Add(-time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestMemoryDelegationStore 测试内存委托规则存储
+func TestMemoryDelegationStore(t *testing.T) {
+	store := task.NewMemoryDelegationStore()
+	if err := store.Save(&task.DelegationRule{ID: "invalid"}); err == nil {
+		t.Error("Save() invalid rule should fail")
+	}
+
+	if err := store.Save(newDelegationRule("rule-001", "alice", "bob", "")); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if err := store.Save(newDelegationRule("rule-002", "alice", "carol", "tpl-001")); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	// 指定模板的规则优先于全局规则
+	now := time.Now()
+	if rule, err := store.FindActive("alice", "tpl-001", now); err != nil || rule == nil || rule.Delegate != "carol" {
+		t.Errorf("FindActive(tpl-001) = %+v, %v, want carol", rule, err)
+	}
+	if rule, err := store.FindActive("alice", "tpl-002", now); err != nil || rule == nil || rule.Delegate != "bob" {
+		t.Errorf("FindActive(tpl-002) = %+v, %v, want bob", rule, err)
+	}
+	if rule, err := store.FindActive("alice", "tpl-002", now.Add(2*time.Hour)); err != nil || rule != nil {
+		t.Errorf("FindActive() after expiry = %+v, %v, want nil", rule, err)
+	}
+
+	if err := store.Delete("rule-002"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if err := store.Delete("rule-002"); !stderrors.Is(err, errors.ErrDelegationNotFound) {
+		t.Errorf("Delete() missing = %v, want ErrDelegationNotFound", err)
+	}
+	if rules, _ := store.List("alice"); len(rules) != 1 || rules[0].ID != "rule-001" {
+		t.Errorf("List() = %+v", rules)
+	}
+}
+
+// TestApplyDelegations 测试按委托规则替换审批人
+func TestApplyDelegations(t *testing.T) {
+	store := task.NewMemoryDelegationStore()
+	// 委托链: alice -> bob -> carol,以及环: dave -> erin -> dave
+	for _, rule := range []*task.DelegationRule{
+		newDelegationRule("rule-001", "alice", "bob", ""),
+		newDelegationRule("rule-002", "bob", "carol", ""),
+		newDelegationRule("rule-003", "dave", "erin", ""),
+		newDelegationRule("rule-004", "erin", "dave", ""),
+		newDelegationRule("rule-005", "frank", "grace", "other-template"),
+	} {
+		if err := store.Save(rule); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+
+	tsk := &task.Task{ID: "task-001", TemplateID: "tpl-001"}
+	got, err := task.ApplyDelegations(store, tsk, "approval", []string{"alice", "dave", "frank", "carol"}, time.Now())
+	if err != nil {
+		t.Fatalf("ApplyDelegations() failed: %v", err)
+	}
+
+	// carol 本身也是审批人,去重后按其本人身份审批; frank 的规则不适用于该模板
+	want := []string{"carol", "erin", "frank"}
+	if len(got) != len(want) {
+		t.Fatalf("ApplyDelegations() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ApplyDelegations()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if _, exists := tsk.Delegations["approval"]["carol"]; exists {
+		t.Errorf("carol is an original approver and should not be recorded as delegate: %v", tsk.Delegations)
+	}
+	if tsk.Delegations["approval"]["erin"] != "dave" {
+		t.Errorf("Delegations = %v, want erin on behalf of dave", tsk.Delegations)
+	}
+
+	// 未配置存储时原样返回
+	if got, err := task.ApplyDelegations(nil, tsk, "approval", []string{"alice"}, time.Now()); err != nil || len(got) != 1 || got[0] != "alice" {
+		t.Errorf("ApplyDelegations(nil) = %v, %v", got, err)
+	}
+}
+
+// TestFlowEngineDelegation 测试节点激活时应用委托规则,审批记录保留原审批人
+func TestFlowEngineDelegation(t *testing.T) {
+	store := task.NewMemoryDelegationStore()
+	if err := store.Save(newDelegationRule("rule-001", "user-001", "user-009", "mode-template")); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	taskMgr, id := submitModeTask(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeUnanimous,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001", "user-002"}},
+	}, node.WithDelegationStore(store))
+
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() by manager failed: %v", err)
+	}
+
+	tsk, err := taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	approvers := tsk.Approvers["review"]
+	if len(approvers) != 2 || approvers[0] != "user-009" || approvers[1] != "user-002" {
+		t.Fatalf("Approvers = %v, want [user-009 user-002]", approvers)
+	}
+
+	if err := taskMgr.Approve(id, "review", "user-009", "ok"); err != nil {
+		t.Fatalf("Approve() by delegate failed: %v", err)
+	}
+	if err := taskMgr.Approve(id, "review", "user-002", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+
+	tsk, err = taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	found := false
+	for _, record := range tsk.Records {
+		switch record.Approver {
+		case "user-009":
+			found = true
+			if record.OnBehalfOf != "user-001" {
+				t.Errorf("OnBehalfOf = %q, want user-001", record.OnBehalfOf)
+			}
+		default:
+			if record.OnBehalfOf != "" {
+				t.Errorf("record of %q OnBehalfOf = %q, want empty", record.Approver, record.OnBehalfOf)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no record by delegate: %+v", tsk.Records)
+	}
+}
+
+// onCreateApproverConfig 任务创建时获取的固定审批人配置
+type onCreateApproverConfig []string
+
+func (c onCreateApproverConfig) GetApprovers(ctx context.Context, nc *node.NodeContext) ([]string, error) {
+	return append([]string(nil), c...), nil
+}
+
+func (c onCreateApproverConfig) GetTiming() node.ApproverTiming {
+	return node.ApproverTimingOnCreate
+}
+
+// TestFetchApproversOnCreateWithDelegation 测试任务创建时获取审批人应用委托规则
+func TestFetchApproversOnCreateWithDelegation(t *testing.T) {
+	store := task.NewMemoryDelegationStore()
+	if err := store.Save(newDelegationRule("rule-001", "manager-001", "manager-009", "")); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	tpl := &template.Template{
+		ID: "tpl-001",
+		Nodes: map[string]*template.Node{
+			"approval": {
+				ID:   "approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode: node.ApprovalModeSingle,
+					ApproverConfig: onCreateApproverConfig{"manager-001"},
+				},
+			},
+		},
+	}
+	tsk := &task.Task{ID: "task-001", TemplateID: "tpl-001"}
+
+	if err := node.FetchApproversOnCreateWithDelegation(tpl, tsk, nil, store); err != nil {
+		t.Fatalf("FetchApproversOnCreateWithDelegation() failed: %v", err)
+	}
+	if approvers := tsk.Approvers["approval"]; len(approvers) != 1 || approvers[0] != "manager-009" {
+		t.Errorf("Approvers = %v, want [manager-009]", approvers)
+	}
+	if tsk.Delegations["approval"]["manager-009"] != "manager-001" {
+		t.Errorf("Delegations = %v", tsk.Delegations)
+	}
+}
@@ -0,0 +1,226 @@
+package task_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// createMultiStageApprovalTemplate 创建多级审批模板
+// start -> condition -> manager -> finance -> end, amount <= 1000 时条件节点直接跳转到 end
+func createMultiStageApprovalTemplate() *template.Template {
+	return &template.Template{
+		ID:   "multi-stage-template",
+		Name: "Multi Stage Approval Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"condition": {
+				ID:   "condition",
+				Name: "Amount Check",
+				Type: template.NodeTypeCondition,
+				Config: &node.ConditionNodeConfig{
+					Condition: &node.Condition{
+						Type: "numeric",
+						Config: &node.NumericConditionConfig{
+							Field:    "amount",
+							Operator: "gt",
+							Value:    1000,
+							Source:   "task_params",
+						},
+					},
+					TrueNodeID:  "manager",
+					FalseNodeID: "end",
+				},
+			},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+				},
+			},
+			"finance": {
+				ID:   "finance",
+				Name: "Finance Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeUnanimous,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"finance-001", "finance-002"}},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "condition"},
+			{From: "condition", To: "manager"},
+			{From: "condition", To: "end"},
+			{From: "manager", To: "finance"},
+			{From: "finance", To: "end"},
+		},
+		Version: 1,
+	}
+}
+
+// createFlowEngineManager 创建启用流程引擎的任务管理器
+func createFlowEngineManager(t *testing.T, notifier *event.EventNotifier) task.TaskManager {
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(createMultiStageApprovalTemplate()); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	return task.NewTaskManagerWithNotifier(templateMgr, nil, notifier, task.WithFlowEngine(node.NewFlowEngine()))
+}
+
+// TestFlowEngineMultiStageApproval 测试启用流程引擎后任务按模板的边逐级审批
+func TestFlowEngineMultiStageApproval(t *testing.T) {
+	handler := &mockEventHandler{events: make([]*event.Event, 0)}
+	notifier := event.NewEventNotifier([]event.EventHandler{handler}, 100)
+	defer notifier.Stop()
+
+	taskMgr := createFlowEngineManager(t, notifier)
+
+	tsk, err := taskMgr.CreateBy("multi-stage-template", "biz-001", "initiator-001", json.RawMessage(`{"amount": 5000}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.CurrentNode != "manager" {
+		t.Fatalf("CurrentNode after submit = %q, want %q", tsk.CurrentNode, "manager")
+	}
+
+	// 只能审批当前节点
+	if err := taskMgr.Approve(tsk.ID, "finance", "finance-001", "ok"); err == nil {
+		t.Error("Approve() on non-current node should fail")
+	}
+
+	// 经理同意后进入财务审批,任务仍在审批中
+	if err := taskMgr.Approve(tsk.ID, "manager", "manager-001", "ok"); err != nil {
+		t.Fatalf("Approve() by manager failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.CurrentNode != "finance" {
+		t.Errorf("CurrentNode = %q, want %q", tsk.CurrentNode, "finance")
+	}
+	if tsk.State != types.TaskStateApproving {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateApproving)
+	}
+
+	// 会签: 第一位财务审批人同意后仍停留在财务节点
+	if err := taskMgr.Approve(tsk.ID, "finance", "finance-001", "ok"); err != nil {
+		t.Fatalf("Approve() by finance-001 failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.CurrentNode != "finance" || tsk.State != types.TaskStateApproving {
+		t.Errorf("CurrentNode = %q, State = %q, want finance and approving", tsk.CurrentNode, tsk.State)
+	}
+
+	// 全部同意后到达结束节点,任务通过
+	if err := taskMgr.Approve(tsk.ID, "finance", "finance-002", "ok"); err != nil {
+		t.Fatalf("Approve() by finance-002 failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.State != types.TaskStateApproved {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateApproved)
+	}
+	if tsk.CurrentNode != "end" {
+		t.Errorf("CurrentNode = %q, want %q", tsk.CurrentNode, "end")
+	}
+	if got := strings.Join(tsk.CompletedNodes, ","); got != "start,condition,manager,finance,end" {
+		t.Errorf("CompletedNodes = %q, want %q", got, "start,condition,manager,finance,end")
+	}
+	for _, nodeID := range []string{"start", "condition", "manager", "finance", "end"} {
+		if _, exists := tsk.NodeOutputs[nodeID]; !exists {
+			t.Errorf("NodeOutputs should contain output of node %q", nodeID)
+		}
+	}
+
+	// 验证生成了财务节点激活事件和任务通过事件(事件异步推送)
+	deadline := time.Now().Add(time.Second)
+	for {
+		var financeActivated, taskApproved bool
+		handler.mu.Lock()
+		for _, evt := range handler.events {
+			if evt.Type == event.EventTypeNodeActivated && evt.Node != nil && evt.Node.ID == "finance" {
+				financeActivated = true
+			}
+			if evt.Type == event.EventTypeTaskApproved {
+				taskApproved = true
+			}
+		}
+		handler.mu.Unlock()
+
+		if financeActivated && taskApproved {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("events not received: finance activated = %v, task approved = %v", financeActivated, taskApproved)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestFlowEngineSubmitReachesEnd 测试条件分支直接到达结束节点时提交即通过
+func TestFlowEngineSubmitReachesEnd(t *testing.T) {
+	taskMgr := createFlowEngineManager(t, nil)
+
+	tsk, err := taskMgr.CreateBy("multi-stage-template", "biz-002", "initiator-001", json.RawMessage(`{"amount": 100}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.State != types.TaskStateApproved {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateApproved)
+	}
+	if tsk.CurrentNode != "end" {
+		t.Errorf("CurrentNode = %q, want %q", tsk.CurrentNode, "end")
+	}
+}
+
+// TestFlowEngineSubmitCtxCanceled 测试上下文取消后提交失败且任务保持原状态
+func TestFlowEngineSubmitCtxCanceled(t *testing.T) {
+	taskMgr := createFlowEngineManager(t, nil)
+
+	tsk, err := taskMgr.CreateBy("multi-stage-template", "biz-003", "initiator-001", json.RawMessage(`{"amount": 5000}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := taskMgr.SubmitCtx(ctx, tsk.ID, "initiator-001"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubmitCtx() error = %v, want context.Canceled", err)
+	}
+
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.State != types.TaskStatePending {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStatePending)
+	}
+
+	// 使用有效上下文重新提交可以正常推进
+	if err := taskMgr.SubmitCtx(context.Background(), tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("SubmitCtx() failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.CurrentNode != "manager" {
+		t.Errorf("CurrentNode = %q, want %q", tsk.CurrentNode, "manager")
+	}
+}
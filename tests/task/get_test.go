@@ -2,8 +2,10 @@ package task_test
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"testing"
 
+	"github.com/mautops/approval-kit/internal/errors"
 	"github.com/mautops/approval-kit/internal/task"
 	"github.com/mautops/approval-kit/internal/template"
 )
@@ -23,7 +25,7 @@ func TestTaskManagerGet(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	createdTask, err := taskMgr.Create("tpl-001", "biz-001", params)
+	createdTask, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -53,8 +55,44 @@ func TestTaskManagerGetNotFound(t *testing.T) {
 
 	// 查询不存在的任务
 	_, err := taskMgr.Get("non-existent")
-	if err == nil {
-		t.Error("Get() should fail when task does not exist")
+	if !stderrors.Is(err, errors.ErrTaskNotFound) {
+		t.Errorf("Get() = %v, want ErrTaskNotFound", err)
+	}
+}
+
+// TestTaskManagerOperationsNotFound 测试操作不存在的任务时返回 ErrTaskNotFound
+// 超时调度器依赖该错误判断任务已不存在,不再重试
+func TestTaskManagerOperationsNotFound(t *testing.T) {
+	managers := map[string]task.TaskManager{
+		"memory": task.NewTaskManager(template.NewTemplateManager(), nil, testAuthorizer()),
+		"store":  task.NewTaskManager(template.NewTemplateManager(), nil, task.WithTaskStore(task.NewMemoryTaskStore()), testAuthorizer()),
+	}
+	for name, taskMgr := range managers {
+		t.Run(name, func(t *testing.T) {
+			ops := map[string]func() error{
+				"Submit":      func() error { return taskMgr.Submit("non-existent", "initiator-001") },
+				"Approve":     func() error { return taskMgr.Approve("non-existent", "approval-001", "user-001", "") },
+				"Reject":      func() error { return taskMgr.Reject("non-existent", "approval-001", "user-001", "") },
+				"Cancel":      func() error { return taskMgr.Cancel("non-existent", "initiator-001", "") },
+				"Withdraw":    func() error { return taskMgr.Withdraw("non-existent", "initiator-001", "") },
+				"Transfer":    func() error { return taskMgr.Transfer("non-existent", "approval-001", "user-001", "user-002", "") },
+				"AddApprover": func() error { return taskMgr.AddApprover("non-existent", "approval-001", "admin-001", "user-002", "") },
+				"RemoveApprover": func() error {
+					return taskMgr.RemoveApprover("non-existent", "approval-001", "admin-001", "user-001", "")
+				},
+				"HandleTimeout":   func() error { return taskMgr.HandleTimeout("non-existent") },
+				"HandleReminders": func() error { return taskMgr.HandleReminders("non-existent") },
+				"Remind":          func() error { return taskMgr.Remind("non-existent", "approval-001", "initiator-001", "") },
+				"Pause":           func() error { return taskMgr.Pause("non-existent", "initiator-001", "") },
+				"Resume":          func() error { return taskMgr.Resume("non-existent", "initiator-001", "") },
+				"RollbackToNode":  func() error { return taskMgr.RollbackToNode("non-existent", "start", "admin-001", "") },
+			}
+			for op, fn := range ops {
+				if err := fn(); !stderrors.Is(err, errors.ErrTaskNotFound) {
+					t.Errorf("%s() = %v, want ErrTaskNotFound", op, err)
+				}
+			}
+		})
 	}
 }
 
@@ -70,7 +108,7 @@ func TestTaskManagerGetIsolation(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	// 创建任务
-	createdTask, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	createdTask, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -109,7 +147,7 @@ func TestTaskManagerGetConcurrent(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	// 创建任务
-	createdTask, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	createdTask, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -149,4 +187,3 @@ func TestTaskManagerGetConcurrent(t *testing.T) {
 		t.Errorf("Get() succeeded %d times, want %d", successCount, concurrency)
 	}
 }
-
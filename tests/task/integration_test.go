@@ -17,7 +17,7 @@ func TestApprovalFlowEndToEnd(t *testing.T) {
 	_, taskManager, tmpl := createTestManagers(t)
 
 	// 4. 创建审批任务
-	tsk, err := taskManager.Create(tmpl.ID, "business-001", json.RawMessage(`{"amount": 1000}`))
+	tsk, err := taskManager.CreateBy(tmpl.ID, "business-001", "initiator-001", json.RawMessage(`{"amount": 1000}`))
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
@@ -31,7 +31,7 @@ func TestApprovalFlowEndToEnd(t *testing.T) {
 	}
 
 	// 5. 提交任务
-	err = taskManager.Submit(tsk.ID)
+	err = taskManager.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -95,13 +95,13 @@ func TestApprovalFlowWithRejection(t *testing.T) {
 	_, taskManager, tmpl := createTestManagers(t)
 
 	// 创建任务
-	tsk, err := taskManager.Create(tmpl.ID, "business-002", nil)
+	tsk, err := taskManager.CreateBy(tmpl.ID, "business-002", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = taskManager.Submit(tsk.ID)
+	err = taskManager.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -152,14 +152,14 @@ func TestApprovalFlowMultipleTasks(t *testing.T) {
 	// 创建多个任务
 	taskIDs := make([]string, 3)
 	for i := 0; i < 3; i++ {
-		tsk, err := taskManager.Create(tmpl.ID, "business-003", nil)
+		tsk, err := taskManager.CreateBy(tmpl.ID, "business-003", "initiator-001", nil)
 		if err != nil {
 			t.Fatalf("Failed to create task %d: %v", i, err)
 		}
 		taskIDs[i] = tsk.ID
 
 		// 提交任务
-		err = taskManager.Submit(tsk.ID)
+		err = taskManager.Submit(tsk.ID, "initiator-001")
 		if err != nil {
 			t.Fatalf("Failed to submit task %d: %v", i, err)
 		}
@@ -219,7 +219,7 @@ func createSimpleApprovalTemplate() *template.Template {
 // 返回: 模板管理器、任务管理器和已创建的模板
 func createTestManagers(t *testing.T) (template.TemplateManager, task.TaskManager, *template.Template) {
 	templateManager := template.NewTemplateManager()
-	taskManager := task.NewTaskManager(templateManager, nil)
+	taskManager := task.NewTaskManager(templateManager, nil, testAuthorizer())
 
 	tmpl := createSimpleApprovalTemplate()
 	err := templateManager.Create(tmpl)
@@ -236,13 +236,13 @@ func TestApprovalFlowWithWithdraw(t *testing.T) {
 	_, taskManager, tmpl := createTestManagers(t)
 
 	// 创建任务
-	tsk, err := taskManager.Create(tmpl.ID, "business-004", nil)
+	tsk, err := taskManager.CreateBy(tmpl.ID, "business-004", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = taskManager.Submit(tsk.ID)
+	err = taskManager.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -257,7 +257,7 @@ func TestApprovalFlowWithWithdraw(t *testing.T) {
 	}
 
 	// 撤回任务
-	err = taskManager.Withdraw(tsk.ID, "withdraw reason")
+	err = taskManager.Withdraw(tsk.ID, "initiator-001", "withdraw reason")
 	if err != nil {
 		t.Fatalf("Failed to withdraw task: %v", err)
 	}
@@ -331,16 +331,16 @@ func TestApprovalFlowWithRejectJump(t *testing.T) {
 	}
 
 	// 创建任务管理器
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	// 创建任务
-	tsk, err := taskMgr.Create("jump-template", "business-005", nil)
+	tsk, err := taskMgr.CreateBy("jump-template", "business-005", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Failed to submit task: %v", err)
 	}
@@ -1,6 +1,7 @@
 package task_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -30,11 +31,19 @@ func (m *taskManagerImpl) Create(templateID string, businessID string, params js
 	return nil, nil
 }
 
+func (m *taskManagerImpl) CreateBy(templateID string, businessID string, initiator string, params json.RawMessage) (*task.Task, error) {
+	return nil, nil
+}
+
 func (m *taskManagerImpl) Get(id string) (*task.Task, error) {
 	return nil, nil
 }
 
-func (m *taskManagerImpl) Submit(id string) error {
+func (m *taskManagerImpl) Submit(id string, actor string) error {
+	return nil
+}
+
+func (m *taskManagerImpl) SubmitCtx(ctx context.Context, id string, actor string) error {
 	return nil
 }
 
@@ -42,11 +51,19 @@ func (m *taskManagerImpl) Approve(id string, nodeID string, approver string, com
 	return nil
 }
 
+func (m *taskManagerImpl) ApproveCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error {
+	return nil
+}
+
 func (m *taskManagerImpl) Reject(id string, nodeID string, approver string, comment string) error {
 	return nil
 }
 
-func (m *taskManagerImpl) Cancel(id string, reason string) error {
+func (m *taskManagerImpl) RejectCtx(ctx context.Context, id string, nodeID string, approver string, comment string) error {
+	return nil
+}
+
+func (m *taskManagerImpl) Cancel(id string, actor string, reason string) error {
 	return nil
 }
 
@@ -58,11 +75,19 @@ func (m *taskManagerImpl) ApproveWithAttachments(id string, nodeID string, appro
 	return nil
 }
 
+func (m *taskManagerImpl) ApproveWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error {
+	return nil
+}
+
 func (m *taskManagerImpl) RejectWithAttachments(id string, nodeID string, approver string, comment string, attachments []string) error {
 	return nil
 }
 
-func (m *taskManagerImpl) Withdraw(id string, reason string) error {
+func (m *taskManagerImpl) RejectWithAttachmentsCtx(ctx context.Context, id string, nodeID string, approver string, comment string, attachments []string) error {
+	return nil
+}
+
+func (m *taskManagerImpl) Withdraw(id string, actor string, reason string) error {
 	return nil
 }
 
@@ -70,11 +95,11 @@ func (m *taskManagerImpl) Transfer(id string, nodeID string, fromApprover string
 	return nil
 }
 
-func (m *taskManagerImpl) AddApprover(id string, nodeID string, approver string, reason string) error {
+func (m *taskManagerImpl) AddApprover(id string, nodeID string, actor string, approver string, reason string) error {
 	return nil
 }
 
-func (m *taskManagerImpl) RemoveApprover(id string, nodeID string, approver string, reason string) error {
+func (m *taskManagerImpl) RemoveApprover(id string, nodeID string, actor string, approver string, reason string) error {
 	return nil
 }
 
@@ -82,19 +107,31 @@ func (m *taskManagerImpl) HandleTimeout(id string) error {
 	return nil
 }
 
-func (m *taskManagerImpl) Pause(id string, reason string) error {
+func (m *taskManagerImpl) HandleReminders(id string) error {
+	return nil
+}
+
+func (m *taskManagerImpl) Remind(id string, nodeID string, actor string, message string) error {
+	return nil
+}
+
+func (m *taskManagerImpl) Pause(id string, actor string, reason string) error {
+	return nil
+}
+
+func (m *taskManagerImpl) Resume(id string, actor string, reason string) error {
 	return nil
 }
 
-func (m *taskManagerImpl) Resume(id string, reason string) error {
+func (m *taskManagerImpl) RollbackToNode(id string, nodeID string, actor string, reason string) error {
 	return nil
 }
 
-func (m *taskManagerImpl) RollbackToNode(id string, nodeID string, reason string) error {
+func (m *taskManagerImpl) ReplaceApprover(id string, nodeID string, actor string, oldApprover string, newApprover string, reason string) error {
 	return nil
 }
 
-func (m *taskManagerImpl) ReplaceApprover(id string, nodeID string, oldApprover string, newApprover string, reason string) error {
+func (m *taskManagerImpl) MigrateTaskToVersion(id string, version int, nodeMapping map[string]string, actor string) error {
 	return nil
 }
 
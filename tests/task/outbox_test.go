@@ -0,0 +1,105 @@
+package task_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// pendingEventTypes 返回发件箱中处理器待投递的事件类型
+func pendingEventTypes(t *testing.T, outbox event.Outbox) []event.EventType {
+	t.Helper()
+	pending, err := outbox.Pending("billing", time.Now(), 0)
+	if err != nil {
+		t.Fatalf("Pending() failed: %v", err)
+	}
+	types := make([]event.EventType, 0, len(pending))
+	for _, p := range pending {
+		types = append(types, p.Entry.Event.Type)
+	}
+	return types
+}
+
+// TestOutboxWithoutNotifier 测试只设置发件箱时任务事件写入发件箱
+func TestOutboxWithoutNotifier(t *testing.T) {
+	for name, opts := range map[string][]task.ManagerOption{
+		"memory": nil,
+		"store":  {task.WithTaskStore(task.NewMemoryTaskStore())},
+	} {
+		t.Run(name, func(t *testing.T) {
+			outbox := event.NewMemoryOutbox()
+			taskMgr, id := newAuthorizeTaskManager(t, append(opts, task.WithOutbox(outbox))...)
+			if err := taskMgr.Submit(id, "initiator-001"); err != nil {
+				t.Fatalf("Submit() failed: %v", err)
+			}
+			// 操作失败时不写入事件
+			if err := taskMgr.Cancel(id, "user-001", "not mine"); err == nil {
+				t.Fatal("Cancel() by non-initiator should fail")
+			}
+			if err := taskMgr.Cancel(id, "initiator-001", "cancel"); err != nil {
+				t.Fatalf("Cancel() failed: %v", err)
+			}
+
+			types := pendingEventTypes(t, outbox)
+			want := []event.EventType{event.EventTypeTaskCreated, event.EventTypeTaskSubmitted, event.EventTypeNodeActivated, event.EventTypeTaskCancelled}
+			if !reflect.DeepEqual(types, want) {
+				t.Errorf("outbox events = %v, want %v", types, want)
+			}
+		})
+	}
+}
+
+// failingOutbox 写入失败的发件箱
+type failingOutbox struct {
+	*event.MemoryOutbox
+	fail bool
+}
+
+// Append 在 fail 为 true 时返回错误
+func (o *failingOutbox) Append(entries ...*event.OutboxEntry) error {
+	if o.fail {
+		return fmt.Errorf("outbox unavailable")
+	}
+	return o.MemoryOutbox.Append(entries...)
+}
+
+// TestOutboxAppendFailure 测试事件写入发件箱失败时操作返回错误,不静默丢弃事件
+func TestOutboxAppendFailure(t *testing.T) {
+	for name, opts := range map[string][]task.ManagerOption{
+		"memory": nil,
+		"store":  {task.WithTaskStore(task.NewMemoryTaskStore())},
+	} {
+		t.Run(name, func(t *testing.T) {
+			outbox := &failingOutbox{MemoryOutbox: event.NewMemoryOutbox()}
+			taskMgr, id := newAuthorizeTaskManager(t, append(opts, task.WithOutbox(outbox))...)
+
+			outbox.fail = true
+			err := taskMgr.Submit(id, "initiator-001")
+			if !stderrors.Is(err, errors.ErrOutboxAppendFailed) {
+				t.Fatalf("Submit() error = %v, want ErrOutboxAppendFailed", err)
+			}
+			// 任务已保存
+			assertTask(t, taskMgr, id, types.TaskStateSubmitted, "approval-001")
+
+			tsk, err := taskMgr.CreateBy("tpl-001", "biz-002", "initiator-001", nil)
+			if !stderrors.Is(err, errors.ErrOutboxAppendFailed) {
+				t.Fatalf("CreateBy() error = %v, want ErrOutboxAppendFailed", err)
+			}
+			if tsk == nil || tsk.ID == "" {
+				t.Fatalf("CreateBy() should return the saved task, got %v", tsk)
+			}
+
+			got := pendingEventTypes(t, outbox)
+			if want := []event.EventType{event.EventTypeTaskCreated}; !reflect.DeepEqual(got, want) {
+				t.Errorf("outbox events = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -25,13 +25,13 @@ func TestPause(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
@@ -67,19 +67,19 @@ func TestPauseSubmittedTask(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
@@ -110,19 +110,19 @@ func TestPauseApprovingTask(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
@@ -150,19 +150,19 @@ func TestPauseInvalidState(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 取消任务
-	err = taskMgr.Cancel(tsk.ID, "cancelled")
+	err = taskMgr.Cancel(tsk.ID, "initiator-001", "cancelled")
 	if err != nil {
 		t.Fatalf("Cancel() failed: %v", err)
 	}
 
 	// 尝试暂停已取消的任务(应该失败)
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err == nil {
 		t.Error("Pause() should fail for cancelled task")
 	}
@@ -173,7 +173,7 @@ func TestPauseNotFound(t *testing.T) {
 	templateMgr := template.NewTemplateManager()
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	err := taskMgr.Pause("non-existent", "user paused")
+	err := taskMgr.Pause("non-existent", "initiator-001", "user paused")
 	if err == nil {
 		t.Error("Pause() should fail for non-existent task")
 	}
@@ -191,13 +191,13 @@ func TestPauseStateHistory(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
@@ -237,7 +237,7 @@ func TestPauseTimestamp(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -245,7 +245,7 @@ func TestPauseTimestamp(t *testing.T) {
 	beforePause := time.Now()
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
@@ -148,12 +148,12 @@ func TestPermissionsValidation(t *testing.T) {
 			t.Fatalf("Create template failed: %v", err)
 		}
 
-		tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+		tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 		if err != nil {
 			t.Fatalf("Create() failed: %v", err)
 		}
 
-		err = taskMgr.Submit(tsk.ID)
+		err = taskMgr.Submit(tsk.ID, "initiator-001")
 		if err != nil {
 			t.Fatalf("Submit() failed: %v", err)
 		}
@@ -173,18 +173,18 @@ func TestPermissionsValidation(t *testing.T) {
 			t.Fatalf("Create template failed: %v", err)
 		}
 
-		tsk, err := taskMgr.Create("tpl-002", "biz-002", nil)
+		tsk, err := taskMgr.CreateBy("tpl-002", "biz-002", "initiator-001", nil)
 		if err != nil {
 			t.Fatalf("Create() failed: %v", err)
 		}
 
-		err = taskMgr.Submit(tsk.ID)
+		err = taskMgr.Submit(tsk.ID, "initiator-001")
 		if err != nil {
 			t.Fatalf("Submit() failed: %v", err)
 		}
 
 		// 尝试加签(应该失败,因为没有权限)
-		err = taskMgr.AddApprover(tsk.ID, "approval-node", "user-2", "reason")
+		err = taskMgr.AddApprover(tsk.ID, "approval-node", "admin-001", "user-2", "reason")
 		if err == nil {
 			t.Error("AddApprover() should fail when permission is not allowed")
 		}
@@ -198,18 +198,18 @@ func TestPermissionsValidation(t *testing.T) {
 			t.Fatalf("Create template failed: %v", err)
 		}
 
-		tsk, err := taskMgr.Create("tpl-003", "biz-003", nil)
+		tsk, err := taskMgr.CreateBy("tpl-003", "biz-003", "initiator-001", nil)
 		if err != nil {
 			t.Fatalf("Create() failed: %v", err)
 		}
 
-		err = taskMgr.Submit(tsk.ID)
+		err = taskMgr.Submit(tsk.ID, "initiator-001")
 		if err != nil {
 			t.Fatalf("Submit() failed: %v", err)
 		}
 
 		// 尝试减签(应该失败,因为没有权限)
-		err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "user-1", "reason")
+		err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "admin-001", "user-1", "reason")
 		if err == nil {
 			t.Error("RemoveApprover() should fail when permission is not allowed")
 		}
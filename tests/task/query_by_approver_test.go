@@ -19,39 +19,39 @@ func TestQueryByApprover(t *testing.T) {
 	}
 
 	// 创建任务管理器
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	// 创建多个任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	task1, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task1, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	task2, err := taskMgr.Create("tpl-001", "biz-002", params)
+	task2, err := taskMgr.CreateBy("tpl-001", "biz-002", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(task1.ID)
+	err = taskMgr.Submit(task1.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(task2.ID)
+	err = taskMgr.Submit(task2.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 通过 AddApprover 添加审批人(模板需要允许加签)
 	// 注意: createTestTemplateWithApprovalNode 需要配置 AllowAddApprover 权限
-	err = taskMgr.AddApprover(task1.ID, "approval-001", "user-001", "test add approver")
+	err = taskMgr.AddApprover(task1.ID, "approval-001", "admin-001", "user-001", "test add approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
-	err = taskMgr.AddApprover(task2.ID, "approval-001", "user-001", "test add approver")
+	err = taskMgr.AddApprover(task2.ID, "approval-001", "admin-001", "user-001", "test add approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
@@ -23,17 +23,17 @@ func TestQueryByBusinessID(t *testing.T) {
 
 	// 创建多个任务(关联不同业务)
 	params := json.RawMessage(`{"amount": 1000}`)
-	task1, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task1, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	task2, err := taskMgr.Create("tpl-001", "biz-002", params)
+	task2, err := taskMgr.CreateBy("tpl-001", "biz-002", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	task3, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task3, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
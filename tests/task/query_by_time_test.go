@@ -28,7 +28,7 @@ func TestQueryByTimeRange(t *testing.T) {
 
 	// 创建第一个任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	task1, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task1, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -36,7 +36,7 @@ func TestQueryByTimeRange(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	// 创建第二个任务
-	task2, err := taskMgr.Create("tpl-001", "biz-002", params)
+	task2, err := taskMgr.CreateBy("tpl-001", "biz-002", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -93,7 +93,7 @@ func TestQueryByStartTime(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	task1, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task1, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -141,7 +141,7 @@ func TestQueryByEndTime(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	task1, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task1, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
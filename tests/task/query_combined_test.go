@@ -37,23 +37,23 @@ func TestQueryCombined(t *testing.T) {
 
 	// 创建多个任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	task1, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task1, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	_, err = taskMgr.Create("tpl-001", "biz-002", params)
+	_, err = taskMgr.CreateBy("tpl-001", "biz-002", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	_, err = taskMgr.Create("tpl-002", "biz-001", params)
+	_, err = taskMgr.CreateBy("tpl-002", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交 task1
-	err = taskMgr.Submit(task1.ID)
+	err = taskMgr.Submit(task1.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -102,17 +102,17 @@ func TestQueryCombinedMultipleConditions(t *testing.T) {
 
 	// 创建多个任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	task1, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task1, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	task2, err := taskMgr.Create("tpl-001", "biz-002", params)
+	task2, err := taskMgr.CreateBy("tpl-001", "biz-002", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	task3, err := taskMgr.Create("tpl-001", "biz-001", params)
+	task3, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -171,12 +171,12 @@ func TestQueryNoFilter(t *testing.T) {
 
 	// 创建多个任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	_, err = taskMgr.Create("tpl-001", "biz-001", params)
+	_, err = taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	_, err = taskMgr.Create("tpl-001", "biz-002", params)
+	_, err = taskMgr.CreateBy("tpl-001", "biz-002", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -22,29 +22,29 @@ func TestRecordQuery(t *testing.T) {
 	}
 
 	// 创建任务管理器
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 手动设置审批人列表(因为固定审批人需要在节点激活时设置,这里为了测试手动设置)
 	// 通过 AddApprover 添加审批人(需要模板允许加签)
-	err = taskMgr.AddApprover(tsk.ID, "approval-001", "user-001", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-001", "admin-001", "user-001", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
-	err = taskMgr.AddApprover(tsk.ID, "approval-001", "user-002", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-001", "admin-001", "user-002", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
@@ -115,17 +115,17 @@ func TestRecordQueryByNodeID(t *testing.T) {
 	}
 
 	// 创建任务管理器
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -168,7 +168,7 @@ func TestRecordQueryEmpty(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
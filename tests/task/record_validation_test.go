@@ -165,6 +165,18 @@ func TestRecordValidation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid timeout action result",
+			record: &task.Record{
+				ID:        "record-001",
+				TaskID:    "task-001",
+				NodeID:    "node-001",
+				Approver:  "system",
+				Result:    "auto_approve",
+				CreatedAt: time.Now(),
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
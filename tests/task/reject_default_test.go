@@ -53,13 +53,13 @@ func TestRejectDefaultBehavior(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -118,13 +118,13 @@ func TestRejectConfigNotAccessor(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -54,12 +54,12 @@ func TestRejectJumpTargetNodeNotFound(t *testing.T) {
 	}
 
 	taskMgr := task.NewTaskManager(templateMgr, nil)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -115,13 +115,13 @@ func TestRejectJumpNoTargetNode(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -186,13 +186,13 @@ func TestRejectRollbackToStartNode(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -247,13 +247,13 @@ func TestRejectNonApprovalNode(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
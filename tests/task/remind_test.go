@@ -0,0 +1,238 @@
+package task_test
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// createRemindTemplate 创建或签审批节点的模板,审批节点配置自动催办
+func createRemindTemplate(policy *node.ReminderPolicy) *template.Template {
+	return &template.Template{
+		ID:   "remind-template",
+		Name: "Remind Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeUnanimous,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001", "manager-002"}},
+					ReminderPolicy: policy,
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "end"},
+		},
+	}
+}
+
+// submitRemindTask 创建模板和任务并提交
+func submitRemindTask(t *testing.T, policy *node.ReminderPolicy, notifier *event.EventNotifier, opts ...task.ManagerOption) (task.TaskManager, string) {
+	t.Helper()
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(createRemindTemplate(policy)); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	opts = append([]task.ManagerOption{task.WithFlowEngine(node.NewFlowEngine())}, opts...)
+	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier, opts...)
+
+	tsk, err := taskMgr.CreateBy("remind-template", "biz-001", "initiator-001", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	return taskMgr, tsk.ID
+}
+
+// TestRemindPendingApprovers 测试催办只针对尚未审批的审批人,并生成催办事件和催办历史
+func TestRemindPendingApprovers(t *testing.T) {
+	handler := &mockEventHandler{events: make([]*event.Event, 0), mu: sync.Mutex{}}
+	notifier := event.NewEventNotifier([]event.EventHandler{handler}, 10)
+	defer notifier.Stop()
+
+	taskMgr, id := submitRemindTask(t, nil, notifier)
+	if err := taskMgr.Approve(id, "manager", "manager-001", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+
+	if err := taskMgr.Remind(id, "manager", "initiator-001", "please approve"); err != nil {
+		t.Fatalf("Remind() failed: %v", err)
+	}
+
+	tsk, _ := taskMgr.Get(id)
+	if len(tsk.Reminders) != 1 {
+		t.Fatalf("len(Reminders) = %d, want 1", len(tsk.Reminders))
+	}
+	reminder := tsk.Reminders[0]
+	if reminder.Actor != "initiator-001" || reminder.Message != "please approve" || reminder.Automatic {
+		t.Errorf("Reminder = %+v", reminder)
+	}
+	if len(reminder.Approvers) != 1 || reminder.Approvers[0] != "manager-002" {
+		t.Errorf("Reminder.Approvers = %v, want [manager-002]", reminder.Approvers)
+	}
+
+	// 验证生成了催办事件(事件异步推送)
+	deadline := time.Now().Add(time.Second)
+	for {
+		var reminded *event.Event
+		handler.mu.Lock()
+		for _, evt := range handler.events {
+			if evt.Type == event.EventTypeApproverReminded {
+				reminded = evt
+			}
+		}
+		handler.mu.Unlock()
+
+		if reminded != nil {
+			if reminded.Reminder == nil || len(reminded.Reminder.Approvers) != 1 || reminded.Reminder.Approvers[0] != "manager-002" {
+				t.Errorf("Reminder event = %+v", reminded.Reminder)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("approver_reminded event not received")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestRemindRateLimited 测试同一审批人在催办间隔内只能被催办一次
+func TestRemindRateLimited(t *testing.T) {
+	clock := newFakeClock()
+	taskMgr, id := submitRemindTask(t, nil, nil, task.WithClock(clock))
+
+	if err := taskMgr.Remind(id, "manager", "initiator-001", ""); err != nil {
+		t.Fatalf("Remind() failed: %v", err)
+	}
+
+	clock.Advance(30 * time.Minute)
+	err := taskMgr.Remind(id, "manager", "initiator-001", "")
+	if !stderrors.Is(err, errors.ErrReminderRateLimited) {
+		t.Fatalf("Remind() error = %v, want ErrReminderRateLimited", err)
+	}
+
+	clock.Advance(30 * time.Minute)
+	if err := taskMgr.Remind(id, "manager", "initiator-001", ""); err != nil {
+		t.Fatalf("Remind() after interval failed: %v", err)
+	}
+
+	tsk, _ := taskMgr.Get(id)
+	if len(tsk.Reminders) != 2 {
+		t.Errorf("len(Reminders) = %d, want 2", len(tsk.Reminders))
+	}
+}
+
+// TestRemindValidation 测试催办的参数和状态校验
+func TestRemindValidation(t *testing.T) {
+	taskMgr, id := submitRemindTask(t, nil, nil, task.WithReminderInterval(0))
+
+	if err := taskMgr.Remind(id, "manager", "", ""); err == nil {
+		t.Error("Remind() should fail without actor")
+	}
+	if err := taskMgr.Remind(id, "end", "initiator-001", ""); err == nil {
+		t.Error("Remind() should fail on non-approval node")
+	}
+	if err := taskMgr.Remind("non-existent", "manager", "initiator-001", ""); err == nil {
+		t.Error("Remind() should fail for non-existent task")
+	}
+
+	// 不限制催办频率时可以连续催办
+	for i := 0; i < 2; i++ {
+		if err := taskMgr.Remind(id, "manager", "initiator-001", ""); err != nil {
+			t.Fatalf("Remind() failed: %v", err)
+		}
+	}
+
+	if err := taskMgr.Cancel(id, "initiator-001", "no longer needed"); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+	if err := taskMgr.Remind(id, "manager", "initiator-001", ""); !stderrors.Is(err, errors.ErrInvalidStateTransition) {
+		t.Errorf("Remind() on cancelled task error = %v, want ErrInvalidStateTransition", err)
+	}
+}
+
+// TestHandleRemindersAutomatic 测试按节点配置自动催办,达到最多次数后停止
+func TestHandleRemindersAutomatic(t *testing.T) {
+	clock := newFakeClock()
+	policy := &node.ReminderPolicy{Interval: 4 * time.Hour, MaxTimes: 2}
+	taskMgr, id := submitRemindTask(t, policy, nil, task.WithClock(clock))
+
+	automatic := func() int {
+		tsk, _ := taskMgr.Get(id)
+		count := 0
+		for _, reminder := range tsk.Reminders {
+			if reminder.Automatic {
+				count++
+			}
+		}
+		return count
+	}
+
+	clock.Advance(3 * time.Hour)
+	if err := taskMgr.HandleReminders(id); err != nil {
+		t.Fatalf("HandleReminders() failed: %v", err)
+	}
+	if got := automatic(); got != 0 {
+		t.Fatalf("automatic reminders = %d, want 0 before interval", got)
+	}
+
+	for i := 1; i <= 3; i++ {
+		clock.Advance(4 * time.Hour)
+		if err := taskMgr.HandleReminders(id); err != nil {
+			t.Fatalf("HandleReminders() failed: %v", err)
+		}
+	}
+	if got := automatic(); got != 2 {
+		t.Errorf("automatic reminders = %d, want 2 (MaxTimes)", got)
+	}
+
+	tsk, _ := taskMgr.Get(id)
+	if reminder := tsk.Reminders[0]; reminder.Actor != task.TimeoutApprover || len(reminder.Approvers) != 2 {
+		t.Errorf("Reminder = %+v", reminder)
+	}
+}
+
+// TestTimeoutSchedulerSendsReminders 测试超时调度器按自动催办配置触发催办
+func TestTimeoutSchedulerSendsReminders(t *testing.T) {
+	clock := newFakeClock()
+	scheduler := task.NewTimeoutScheduler(task.WithSchedulerClock(clock))
+	policy := &node.ReminderPolicy{Interval: time.Hour, MaxTimes: 1}
+	taskMgr, id := submitRemindTask(t, policy, nil, task.WithTimeoutScheduler(scheduler))
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	defer scheduler.Stop()
+
+	if scheduler.Pending() != 1 {
+		t.Fatalf("Pending() = %d, want 1", scheduler.Pending())
+	}
+
+	clock.Advance(time.Hour)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		tsk, _ := taskMgr.Get(id)
+		if len(tsk.Reminders) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("len(Reminders) = %d, want 1", len(tsk.Reminders))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -21,34 +21,34 @@ func TestRemoveApprover(t *testing.T) {
 	}
 
 	// 创建任务管理器
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-003", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-003", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 手动设置审批人列表(因为固定审批人需要在节点激活时设置)
-	err = taskMgr.AddApprover(tsk.ID, "approval-node", "user-1", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-node", "admin-001", "user-1", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
-	err = taskMgr.AddApprover(tsk.ID, "approval-node", "user-2", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-node", "admin-001", "user-2", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
 	// 减签
-	err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "user-1", "remove approver reason")
+	err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "admin-001", "user-1", "remove approver reason")
 	if err != nil {
 		t.Fatalf("RemoveApprover() failed: %v", err)
 	}
@@ -146,19 +146,19 @@ func TestRemoveApproverWithoutPermission(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-003", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-003", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 尝试减签(应该失败,因为没有权限)
-	err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "user-1", "remove approver reason")
+	err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "admin-001", "user-1", "remove approver reason")
 	if err == nil {
 		t.Error("RemoveApprover() should fail when permission is not allowed")
 	}
@@ -170,7 +170,7 @@ func TestRemoveApproverNotFound(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	// 尝试减签不存在的任务
-	err := taskMgr.RemoveApprover("non-existent", "approval-node", "user-1", "remove approver reason")
+	err := taskMgr.RemoveApprover("non-existent", "approval-node", "admin-001", "user-1", "remove approver reason")
 	if err == nil {
 		t.Error("RemoveApprover() should fail when task does not exist")
 	}
@@ -191,19 +191,19 @@ func TestRemoveApproverApproversNotFound(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-003", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-003", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 尝试减签(审批人列表为空)
-	err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "user-1", "remove approver reason")
+	err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "admin-001", "user-1", "remove approver reason")
 	if err == nil {
 		t.Error("RemoveApprover() should fail when approvers list is empty")
 	}
@@ -220,29 +220,29 @@ func TestRemoveApproverNotInList(t *testing.T) {
 	}
 
 	// 创建任务管理器
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-003", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-003", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 添加审批人
-	err = taskMgr.AddApprover(tsk.ID, "approval-node", "user-1", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-node", "admin-001", "user-1", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
 	// 尝试减签不在列表中的审批人
-	err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "user-999", "remove approver reason")
+	err = taskMgr.RemoveApprover(tsk.ID, "approval-node", "admin-001", "user-999", "remove approver reason")
 	if err == nil {
 		t.Error("RemoveApprover() should fail when approver is not in list")
 	}
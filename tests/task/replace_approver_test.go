@@ -61,16 +61,16 @@ func TestReplaceApprover(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -85,13 +85,13 @@ func TestReplaceApprover(t *testing.T) {
 	}
 
 	// 手动设置审批人列表(因为固定审批人需要在节点激活时设置)
-	err = taskMgr.AddApprover(tsk.ID, "approval-001", "user-001", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-001", "admin-001", "user-001", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
 	// 替换审批人
-	err = taskMgr.ReplaceApprover(tsk.ID, "approval-001", "user-001", "user-002", "user replaced")
+	err = taskMgr.ReplaceApprover(tsk.ID, "approval-001", "admin-001", "user-001", "user-002", "user replaced")
 	if err != nil {
 		t.Fatalf("ReplaceApprover() failed: %v", err)
 	}
@@ -127,28 +127,28 @@ func TestReplaceApproverNotFound(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 手动设置审批人列表
-	err = taskMgr.AddApprover(tsk.ID, "approval-001", "user-001", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-001", "admin-001", "user-001", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
 	// 尝试替换不存在的审批人(应该失败)
-	err = taskMgr.ReplaceApprover(tsk.ID, "approval-001", "non-existent", "user-002", "user replaced")
+	err = taskMgr.ReplaceApprover(tsk.ID, "approval-001", "admin-001", "non-existent", "user-002", "user replaced")
 	if err == nil {
 		t.Error("ReplaceApprover() should fail for non-existent approver")
 	}
@@ -163,22 +163,22 @@ func TestReplaceApproverAlreadyApproved(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 手动设置审批人列表
-	err = taskMgr.AddApprover(tsk.ID, "approval-001", "user-001", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-001", "admin-001", "user-001", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
@@ -190,7 +190,7 @@ func TestReplaceApproverAlreadyApproved(t *testing.T) {
 	}
 
 	// 尝试替换已审批的审批人(应该失败)
-	err = taskMgr.ReplaceApprover(tsk.ID, "approval-001", "user-001", "user-002", "user replaced")
+	err = taskMgr.ReplaceApprover(tsk.ID, "approval-001", "admin-001", "user-001", "user-002", "user replaced")
 	if err == nil {
 		t.Error("ReplaceApprover() should fail for already approved approver")
 	}
@@ -201,7 +201,7 @@ func TestReplaceApproverTaskNotFound(t *testing.T) {
 	templateMgr := template.NewTemplateManager()
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	err := taskMgr.ReplaceApprover("non-existent", "approval-001", "user-001", "user-002", "user replaced")
+	err := taskMgr.ReplaceApprover("non-existent", "approval-001", "admin-001", "user-001", "user-002", "user replaced")
 	if err == nil {
 		t.Error("ReplaceApprover() should fail for non-existent task")
 	}
@@ -216,16 +216,16 @@ func TestReplaceApproverRecord(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -240,7 +240,7 @@ func TestReplaceApproverRecord(t *testing.T) {
 	}
 
 	// 手动设置审批人列表
-	err = taskMgr.AddApprover(tsk.ID, "approval-001", "user-001", "setup approver")
+	err = taskMgr.AddApprover(tsk.ID, "approval-001", "admin-001", "user-001", "setup approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
@@ -253,7 +253,7 @@ func TestReplaceApproverRecord(t *testing.T) {
 	initialRecordCount := len(tsk.Records)
 
 	// 替换审批人
-	err = taskMgr.ReplaceApprover(tsk.ID, "approval-001", "user-001", "user-002", "user replaced")
+	err = taskMgr.ReplaceApprover(tsk.ID, "approval-001", "admin-001", "user-001", "user-002", "user replaced")
 	if err != nil {
 		t.Fatalf("ReplaceApprover() failed: %v", err)
 	}
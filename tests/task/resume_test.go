@@ -24,19 +24,19 @@ func TestResume(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
 
 	// 恢复任务
-	err = taskMgr.Resume(tsk.ID, "user resumed")
+	err = taskMgr.Resume(tsk.ID, "initiator-001", "user resumed")
 	if err != nil {
 		t.Fatalf("Resume() failed: %v", err)
 	}
@@ -72,25 +72,25 @@ func TestResumeSubmittedTask(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
 
 	// 恢复任务
-	err = taskMgr.Resume(tsk.ID, "user resumed")
+	err = taskMgr.Resume(tsk.ID, "initiator-001", "user resumed")
 	if err != nil {
 		t.Fatalf("Resume() failed: %v", err)
 	}
@@ -120,13 +120,13 @@ func TestResumeApprovingTask(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -141,13 +141,13 @@ func TestResumeApprovingTask(t *testing.T) {
 	}
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
 
 	// 恢复任务
-	err = taskMgr.Resume(tsk.ID, "user resumed")
+	err = taskMgr.Resume(tsk.ID, "initiator-001", "user resumed")
 	if err != nil {
 		t.Fatalf("Resume() failed: %v", err)
 	}
@@ -177,13 +177,13 @@ func TestResumeInvalidState(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 尝试恢复未暂停的任务(应该失败)
-	err = taskMgr.Resume(tsk.ID, "user resumed")
+	err = taskMgr.Resume(tsk.ID, "initiator-001", "user resumed")
 	if err == nil {
 		t.Error("Resume() should fail for non-paused task")
 	}
@@ -194,7 +194,7 @@ func TestResumeNotFound(t *testing.T) {
 	templateMgr := template.NewTemplateManager()
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	err := taskMgr.Resume("non-existent", "user resumed")
+	err := taskMgr.Resume("non-existent", "initiator-001", "user resumed")
 	if err == nil {
 		t.Error("Resume() should fail for non-existent task")
 	}
@@ -212,19 +212,19 @@ func TestResumeStateHistory(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 暂停任务
-	err = taskMgr.Pause(tsk.ID, "user paused")
+	err = taskMgr.Pause(tsk.ID, "initiator-001", "user paused")
 	if err != nil {
 		t.Fatalf("Pause() failed: %v", err)
 	}
 
 	// 恢复任务
-	err = taskMgr.Resume(tsk.ID, "user resumed")
+	err = taskMgr.Resume(tsk.ID, "initiator-001", "user resumed")
 	if err != nil {
 		t.Fatalf("Resume() failed: %v", err)
 	}
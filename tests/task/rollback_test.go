@@ -18,16 +18,16 @@ func TestRollbackToNode(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -48,7 +48,7 @@ func TestRollbackToNode(t *testing.T) {
 	}
 
 	// 回退到审批节点
-	err = taskMgr.RollbackToNode(tsk.ID, "approval-001", "user rollback")
+	err = taskMgr.RollbackToNode(tsk.ID, "approval-001", "admin-001", "user rollback")
 	if err != nil {
 		t.Fatalf("RollbackToNode() failed: %v", err)
 	}
@@ -80,13 +80,13 @@ func TestRollbackToNodeNotFound(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 尝试回退到不存在的节点(应该失败)
-	err = taskMgr.RollbackToNode(tsk.ID, "non-existent", "user rollback")
+	err = taskMgr.RollbackToNode(tsk.ID, "non-existent", "admin-001", "user rollback")
 	if err == nil {
 		t.Error("RollbackToNode() should fail for non-existent node")
 	}
@@ -104,13 +104,13 @@ func TestRollbackToNodeNotCompleted(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 尝试回退到未完成的节点(应该失败)
-	err = taskMgr.RollbackToNode(tsk.ID, "approval-001", "user rollback")
+	err = taskMgr.RollbackToNode(tsk.ID, "approval-001", "admin-001", "user rollback")
 	if err == nil {
 		t.Error("RollbackToNode() should fail for non-completed node")
 	}
@@ -121,7 +121,7 @@ func TestRollbackToNodeTaskNotFound(t *testing.T) {
 	templateMgr := template.NewTemplateManager()
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	err := taskMgr.RollbackToNode("non-existent", "approval-001", "user rollback")
+	err := taskMgr.RollbackToNode("non-existent", "approval-001", "admin-001", "user rollback")
 	if err == nil {
 		t.Error("RollbackToNode() should fail for non-existent task")
 	}
@@ -136,16 +136,16 @@ func TestRollbackToNodeStateHistory(t *testing.T) {
 		t.Fatalf("Create template failed: %v", err)
 	}
 
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -157,7 +157,7 @@ func TestRollbackToNodeStateHistory(t *testing.T) {
 	}
 
 	// 回退到审批节点
-	err = taskMgr.RollbackToNode(tsk.ID, "approval-001", "user rollback")
+	err = taskMgr.RollbackToNode(tsk.ID, "approval-001", "admin-001", "user rollback")
 	if err != nil {
 		t.Fatalf("RollbackToNode() failed: %v", err)
 	}
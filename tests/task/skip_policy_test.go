@@ -0,0 +1,175 @@
+package task_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// submitSkipTask 创建会审模板(可以设置模板全局跳过规则),由 user-002 发起任务并提交,经理审批通过后进入会审节点
+func submitSkipTask(t *testing.T, reviewConfig *node.ApprovalNodeConfig, policy *template.SkipPolicy, engineOpts ...node.FlowEngineOption) (task.TaskManager, string) {
+	t.Helper()
+	tpl := createModeTemplate(reviewConfig)
+	if policy != nil {
+		tpl.Config = &template.TemplateConfig{SkipPolicy: policy}
+	}
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(tpl); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	taskMgr := task.NewTaskManager(templateMgr, nil, task.WithFlowEngine(node.NewFlowEngine(engineOpts...)))
+
+	tsk, err := taskMgr.CreateBy("mode-template", "biz-001", "user-002", nil)
+	if err != nil {
+		t.Fatalf("CreateBy() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "user-002"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	if err := taskMgr.Approve(tsk.ID, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() by manager failed: %v", err)
+	}
+	return taskMgr, tsk.ID
+}
+
+// systemRecords 返回节点中指定审批结果的系统审批记录
+func systemRecords(t *testing.T, taskMgr task.TaskManager, id string, nodeID string, result string) []*task.Record {
+	t.Helper()
+	tsk, err := taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	var records []*task.Record
+	for _, record := range tsk.Records {
+		if record.NodeID == nodeID && record.Approver == task.SystemApprover && record.Result == result {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// TestSkipPolicyDuplicateApprover 测试已在之前的节点同意过的审批人自动同意
+func TestSkipPolicyDuplicateApprover(t *testing.T) {
+	taskMgr, id := submitSkipTask(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeUnanimous,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001", "user-003"}},
+		SkipPolicy:     &template.SkipPolicy{DuplicateApprover: template.SkipActionAutoApprove},
+	}, nil)
+	assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+
+	records := systemRecords(t, taskMgr, id, "review", task.RecordResultSkipApprover)
+	if len(records) != 1 || records[0].Comment != `auto-approved: approver "manager-001" already approved node "manager"` {
+		t.Fatalf("system records = %+v", records)
+	}
+	if err := records[0].Validate(); err != nil {
+		t.Errorf("Validate() system record failed: %v", err)
+	}
+
+	if err := taskMgr.Approve(id, "review", "user-003", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+}
+
+// TestSkipPolicyInitiatorAutoApprove 测试模板全局规则: 审批人是发起人时自动同意
+func TestSkipPolicyInitiatorAutoApprove(t *testing.T) {
+	taskMgr, id := submitSkipTask(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeSingle,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-002"}},
+	}, &template.SkipPolicy{InitiatorApprover: template.SkipActionAutoApprove})
+
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+	if records := systemRecords(t, taskMgr, id, "review", task.RecordResultSkipApprover); len(records) != 1 {
+		t.Errorf("system records = %+v, want one skip_approver record", records)
+	}
+}
+
+// TestSkipPolicyInitiatorEscalate 测试节点规则优先于模板全局规则: 发起人替换为其上级
+func TestSkipPolicyInitiatorEscalate(t *testing.T) {
+	directory := node.NewMemoryDirectory()
+	for _, user := range []*node.DirectoryUser{{ID: "lead"}, {ID: "user-002", Manager: "lead"}} {
+		if err := directory.AddUser(user); err != nil {
+			t.Fatalf("AddUser() failed: %v", err)
+		}
+	}
+
+	taskMgr, id := submitSkipTask(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeSequential,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-002", "user-003"}},
+		SkipPolicy:     &template.SkipPolicy{InitiatorApprover: template.SkipActionEscalate},
+	}, &template.SkipPolicy{InitiatorApprover: template.SkipActionAutoApprove}, node.WithDirectory(directory))
+
+	tsk, err := taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got := tsk.Approvers["review"]; !reflect.DeepEqual(got, []string{"lead", "user-003"}) {
+		t.Fatalf("Approvers[review] = %v, want [lead user-003]", got)
+	}
+	if len(tsk.Approvals["review"]) != 0 {
+		t.Errorf("Approvals[review] = %v, want none", tsk.Approvals["review"])
+	}
+	if records := systemRecords(t, taskMgr, id, "review", task.RecordResultEscalateInitiator); len(records) != 1 {
+		t.Errorf("system records = %+v, want one escalate_initiator record", records)
+	}
+
+	// 没有组织架构目录时无法升级,审批失败且任务保持不变
+	taskMgr, id = submitSkipTaskWithoutApprove(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeSingle,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-002"}},
+		SkipPolicy:     &template.SkipPolicy{InitiatorApprover: template.SkipActionEscalate},
+	})
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err == nil {
+		t.Error("Approve() should fail when initiator cannot be escalated")
+	}
+	assertTask(t, taskMgr, id, types.TaskStateSubmitted, "manager")
+}
+
+// submitSkipTaskWithoutApprove 与 submitSkipTask 相同,但不进行经理审批
+func submitSkipTaskWithoutApprove(t *testing.T, reviewConfig *node.ApprovalNodeConfig) (task.TaskManager, string) {
+	t.Helper()
+	templateMgr := newModeTemplateManager(t, reviewConfig)
+	taskMgr := task.NewTaskManager(templateMgr, nil, task.WithFlowEngine(node.NewFlowEngine()))
+	tsk, err := taskMgr.CreateBy("mode-template", "biz-001", "user-002", nil)
+	if err != nil {
+		t.Fatalf("CreateBy() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "user-002"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	return taskMgr, tsk.ID
+}
+
+// TestSkipPolicyEmptyApprovers 测试审批人列表为空时跳过节点
+func TestSkipPolicyEmptyApprovers(t *testing.T) {
+	reviewConfig := &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeOr,
+		ApproverConfig: &node.RoleApproverConfig{Role: "auditor"},
+		SkipPolicy:     &template.SkipPolicy{EmptyApprovers: template.SkipActionSkip},
+	}
+	taskMgr, id := submitSkipTask(t, reviewConfig, nil, node.WithDirectory(node.NewMemoryDirectory()))
+
+	assertTask(t, taskMgr, id, types.TaskStateApproved, "end")
+	records := systemRecords(t, taskMgr, id, "review", task.RecordResultSkipNode)
+	if len(records) != 1 || records[0].Comment != "node skipped: no approvers resolved" {
+		t.Errorf("system records = %+v, want one skip_node record", records)
+	}
+	tsk, err := taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if string(tsk.NodeOutputs["review"]) != `{"result": "approve", "skipped": true}` {
+		t.Errorf("NodeOutputs[review] = %s", tsk.NodeOutputs["review"])
+	}
+
+	// 未配置跳过规则时,没有审批人的节点无法激活
+	reviewConfig.SkipPolicy = nil
+	taskMgr, id = submitSkipTaskWithoutApprove(t, reviewConfig)
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err == nil {
+		t.Error("Approve() should fail when review node has no approvers")
+	}
+}
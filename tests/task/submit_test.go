@@ -24,7 +24,7 @@ func TestTaskManagerSubmit(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -35,7 +35,7 @@ func TestTaskManagerSubmit(t *testing.T) {
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -64,13 +64,13 @@ func TestTaskManagerSubmitStateTransition(t *testing.T) {
 
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -112,19 +112,19 @@ func TestTaskManagerSubmitInvalidState(t *testing.T) {
 
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 第一次提交应该成功
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 第二次提交应该失败(状态已经是 submitted)
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err == nil {
 		t.Error("Submit() should fail when task is already submitted")
 	}
@@ -136,7 +136,7 @@ func TestTaskManagerSubmitNotFound(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	// 尝试提交不存在的任务
-	err := taskMgr.Submit("non-existent")
+	err := taskMgr.Submit("non-existent", "initiator-001")
 	if err == nil {
 		t.Error("Submit() should fail when task does not exist")
 	}
@@ -153,7 +153,7 @@ func TestTaskManagerSubmitUpdatesTimestamp(t *testing.T) {
 
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", nil)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", nil)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -164,7 +164,7 @@ func TestTaskManagerSubmitUpdatesTimestamp(t *testing.T) {
 	originalUpdatedAt := tsk.UpdatedAt
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
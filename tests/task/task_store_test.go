@@ -0,0 +1,182 @@
+package task_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/event"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// TestMemoryTaskStoreVersioning 测试内存任务存储的乐观锁
+func TestMemoryTaskStoreVersioning(t *testing.T) {
+	store := task.NewMemoryTaskStore()
+	tsk := &task.Task{ID: "task-001", State: types.TaskStatePending}
+
+	if err := store.Save(tsk); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	stale, _ := store.Get("task-001")
+	fresh, _ := store.Get("task-001")
+
+	fresh.State = types.TaskStateSubmitted
+	if err := store.Save(fresh); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if fresh.Version != 2 {
+		t.Errorf("Version = %d, want 2", fresh.Version)
+	}
+	if err := store.Save(stale); !stderrors.Is(err, errors.ErrConcurrentModification) {
+		t.Errorf("Save() stale = %v, want ErrConcurrentModification", err)
+	}
+
+	if err := store.Delete("task-001"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := store.Get("task-001"); !stderrors.Is(err, errors.ErrTaskNotFound) {
+		t.Errorf("Get() after delete = %v, want ErrTaskNotFound", err)
+	}
+}
+
+// TestTaskManagerWithStore 测试任务管理器通过存储读写任务
+func TestTaskManagerWithStore(t *testing.T) {
+	store := task.NewMemoryTaskStore()
+	taskMgr, id := submitStoreTask(t, store)
+
+	// 其他任务管理器实例可以继续审批同一任务
+	other := task.NewTaskManager(nil, nil, task.WithTaskStore(store))
+	if _, err := other.Get(id); err != nil {
+		t.Fatalf("Get() from other manager failed: %v", err)
+	}
+
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	assertTask(t, taskMgr, id, types.TaskStateApproving, "review")
+
+	stored, err := store.Get(id)
+	if err != nil {
+		t.Fatalf("store.Get() failed: %v", err)
+	}
+	if stored.CurrentNode != "review" || len(stored.Records) != 1 {
+		t.Errorf("stored CurrentNode = %q, Records = %d", stored.CurrentNode, len(stored.Records))
+	}
+
+	// 失败的操作不写回存储
+	if err := taskMgr.Approve(id, "manager", "manager-001", ""); err == nil {
+		t.Fatal("Approve() on inactive node should fail")
+	}
+	if after, _ := store.Get(id); after.Version != stored.Version {
+		t.Errorf("Version = %d after failed operation, want %d", after.Version, stored.Version)
+	}
+
+	results, err := taskMgr.Query(&task.TaskFilter{State: types.TaskStateApproving})
+	if err != nil || len(results) != 1 {
+		t.Errorf("Query() = %d tasks, %v, want 1", len(results), err)
+	}
+}
+
+// submitStoreTask 创建使用指定存储的任务管理器,并创建、提交任务
+func submitStoreTask(t *testing.T, store task.TaskStore) (task.TaskManager, string) {
+	t.Helper()
+	templateMgr := newModeTemplateManager(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeSingle,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001"}},
+	})
+	taskMgr := task.NewTaskManager(templateMgr, nil, task.WithFlowEngine(node.NewFlowEngine()), task.WithTaskStore(store))
+
+	tsk, err := taskMgr.CreateBy("mode-template", "biz-001", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if tsk.Version != 1 {
+		t.Errorf("Version after Create() = %d, want 1", tsk.Version)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	return taskMgr, tsk.ID
+}
+
+// racingTaskStore 模拟其他进程并发修改任务的存储
+// race 为 true 时,Get 返回任务后由"其他进程"写回一次,使本次读到的任务版本过期
+type racingTaskStore struct {
+	task.TaskStore
+	race bool
+}
+
+func (s *racingTaskStore) Get(id string) (*task.Task, error) {
+	tsk, err := s.TaskStore.Get(id)
+	if err != nil || !s.race {
+		return tsk, err
+	}
+	s.race = false
+	other, _ := s.TaskStore.Get(id)
+	if err := s.TaskStore.Save(other); err != nil {
+		return nil, err
+	}
+	return tsk, nil
+}
+
+// TestTaskManagerWithStoreConflictDropsEvents 测试写回存储发生版本冲突时不推送事件
+func TestTaskManagerWithStoreConflictDropsEvents(t *testing.T) {
+	handler := &mockEventHandler{events: make([]*event.Event, 0)}
+	notifier := event.NewEventNotifier([]event.EventHandler{handler}, 10)
+	defer notifier.Stop()
+
+	store := &racingTaskStore{TaskStore: task.NewMemoryTaskStore()}
+	templateMgr := newModeTemplateManager(t, &node.ApprovalNodeConfig{
+		Mode:           node.ApprovalModeSingle,
+		ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"user-001"}},
+	})
+	taskMgr := task.NewTaskManagerWithNotifier(templateMgr, nil, notifier,
+		task.WithFlowEngine(node.NewFlowEngine()), task.WithTaskStore(store))
+	tsk, err := taskMgr.CreateBy("mode-template", "biz-001", "initiator-001", nil)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	waitForEvents(t, handler, 1)
+	handler.mu.Lock()
+	handler.events = handler.events[:0]
+	handler.mu.Unlock()
+
+	store.race = true
+	if err := taskMgr.Approve(tsk.ID, "manager", "manager-001", ""); !stderrors.Is(err, errors.ErrConcurrentModification) {
+		t.Fatalf("Approve() = %v, want ErrConcurrentModification", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	handler.mu.Lock()
+	if len(handler.events) != 0 {
+		t.Errorf("len(events) after conflict = %d, want 0", len(handler.events))
+	}
+	handler.mu.Unlock()
+
+	// 重试成功后推送事件
+	if err := taskMgr.Approve(tsk.ID, "manager", "manager-001", ""); err != nil {
+		t.Fatalf("Approve() retry failed: %v", err)
+	}
+	waitForEvents(t, handler, 1)
+}
+
+// waitForEvents 等待事件处理器收到至少 n 个事件
+func waitForEvents(t *testing.T, handler *mockEventHandler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		handler.mu.Lock()
+		got := len(handler.events)
+		handler.mu.Unlock()
+		if got >= n {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("received fewer than %d events", n)
+}
@@ -0,0 +1,165 @@
+package task_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// TestTaskPinnedToTemplateVersion 测试发布新版本模板不影响进行中的任务
+func TestTaskPinnedToTemplateVersion(t *testing.T) {
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(createTestTemplate()); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+
+	// 新版本要求必填审批意见
+	tplV2 := createTestTemplate()
+	tplV2.Nodes["approval-001"].Config.(*node.ApprovalNodeConfig).RequireCommentField = true
+	if err := templateMgr.Update("tpl-001", tplV2); err != nil {
+		t.Fatalf("Update template failed: %v", err)
+	}
+
+	// 任务仍按版本 1 执行,不填写审批意见也可以审批
+	if err := taskMgr.Approve(tsk.ID, "approval-001", "user-001", ""); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	tsk, err = taskMgr.Get(tsk.ID)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if tsk.TemplateVersion != 1 {
+		t.Errorf("Task.TemplateVersion = %d, want 1", tsk.TemplateVersion)
+	}
+	if tsk.GetState() != types.TaskStateApproved {
+		t.Errorf("Task state = %q, want %q", tsk.GetState(), types.TaskStateApproved)
+	}
+}
+
+// TestMigrateTaskToVersion 测试将任务迁移到新版本模板
+func TestMigrateTaskToVersion(t *testing.T) {
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(createTestTemplate()); err != nil {
+		t.Fatalf("Create template failed: %v", err)
+	}
+
+	store := task.NewMemoryTaskStore()
+	taskMgr := task.NewTaskManager(templateMgr, nil, task.WithTaskStore(store), testAuthorizer())
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+
+	// 模拟代理审批、催办和开始节点输出产生的节点数据
+	stored, err := store.Get(tsk.ID)
+	if err != nil {
+		t.Fatalf("store.Get() failed: %v", err)
+	}
+	stored.Delegations = map[string]map[string]string{"approval-001": {"user-009": "user-001"}}
+	stored.NodeOutputs["start"] = json.RawMessage(`{}`)
+	stored.Reminders = append(stored.Reminders, &task.Reminder{NodeID: "approval-001", Actor: "initiator-001", CreatedAt: time.Now()})
+	if err := store.Save(stored); err != nil {
+		t.Fatalf("store.Save() failed: %v", err)
+	}
+
+	// 新版本将审批节点重命名为 approval-manager
+	tplV2 := createTestTemplate()
+	approvalNode := tplV2.Nodes["approval-001"]
+	delete(tplV2.Nodes, "approval-001")
+	approvalNode.ID = "approval-manager"
+	tplV2.Nodes["approval-manager"] = approvalNode
+	tplV2.Edges = []*template.Edge{
+		{From: "start", To: "approval-manager"},
+		{From: "approval-manager", To: "end"},
+	}
+	if err := templateMgr.Update("tpl-001", tplV2); err != nil {
+		t.Fatalf("Update template failed: %v", err)
+	}
+
+	// 当前节点在新版本中不存在,未提供映射时迁移失败
+	if err := taskMgr.MigrateTaskToVersion(tsk.ID, 2, nil, "admin-001"); err == nil {
+		t.Fatal("MigrateTaskToVersion() should fail when current node does not exist in target version")
+	}
+	if err := taskMgr.MigrateTaskToVersion(tsk.ID, 3, nil, "admin-001"); err == nil {
+		t.Fatal("MigrateTaskToVersion() should fail for non-existent version")
+	}
+
+	// 映射的目标节点必须存在,且不同节点不能映射到同一节点
+	invalidMappings := []map[string]string{
+		{"approval-001": "approval-missing"},
+		{"approval-001": "approval-manager", "end": "approval-manager"},
+		{"approval-001": "start"},
+	}
+	for _, mapping := range invalidMappings {
+		if err := taskMgr.MigrateTaskToVersion(tsk.ID, 2, mapping, "admin-001"); err == nil {
+			t.Errorf("MigrateTaskToVersion(%v) should fail", mapping)
+		}
+	}
+
+	err = taskMgr.MigrateTaskToVersion(tsk.ID, 2, map[string]string{"approval-001": "approval-manager"}, "admin-001")
+	if err != nil {
+		t.Fatalf("MigrateTaskToVersion() failed: %v", err)
+	}
+
+	tsk, err = taskMgr.Get(tsk.ID)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if tsk.TemplateVersion != 2 {
+		t.Errorf("Task.TemplateVersion = %d, want 2", tsk.TemplateVersion)
+	}
+	if tsk.CurrentNode != "approval-manager" {
+		t.Errorf("Task.CurrentNode = %q, want %q", tsk.CurrentNode, "approval-manager")
+	}
+	if tsk.Delegations["approval-manager"]["user-009"] != "user-001" {
+		t.Errorf("Task.Delegations = %v, want approval-manager remapped", tsk.Delegations)
+	}
+
+	// 催办历史保持原节点 ID,节点映射记录在 NodeAliases 中
+	if tsk.Reminders[0].NodeID != "approval-001" {
+		t.Errorf("Reminders[0].NodeID = %q, want approval-001", tsk.Reminders[0].NodeID)
+	}
+	if tsk.NodeAliases["approval-001"] != "approval-manager" {
+		t.Errorf("Task.NodeAliases = %v, want approval-001 -> approval-manager", tsk.NodeAliases)
+	}
+
+	// 迁移生成审计记录
+	record := tsk.Records[len(tsk.Records)-1]
+	if record.Result != task.RecordResultMigrate || record.Approver != "admin-001" || record.NodeID != "approval-manager" {
+		t.Errorf("migrate record = %+v", record)
+	}
+
+	// 迁移后按新版本的节点审批
+	if err := taskMgr.Approve(tsk.ID, "approval-manager", "user-001", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	tsk, err = taskMgr.Get(tsk.ID)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if tsk.GetState() != types.TaskStateApproved {
+		t.Errorf("Task state = %q, want %q", tsk.GetState(), types.TaskStateApproved)
+	}
+
+	// 已结束的任务不能迁移
+	if err := taskMgr.MigrateTaskToVersion(tsk.ID, 1, map[string]string{"approval-manager": "approval-001"}, "admin-001"); err == nil {
+		t.Error("MigrateTaskToVersion() should fail for approved task")
+	}
+}
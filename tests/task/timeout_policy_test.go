@@ -0,0 +1,240 @@
+package task_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// createTimeoutPolicyTemplate 创建单审批节点模板,审批节点配置超时策略
+func createTimeoutPolicyTemplate(id string, policy *node.TimeoutPolicy) *template.Template {
+	return &template.Template{
+		ID:   id,
+		Name: "Timeout Policy Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+					TimeoutPolicy:  policy,
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "end"},
+		},
+	}
+}
+
+// submitTimeoutPolicyTask 创建模板和任务并提交
+func submitTimeoutPolicyTask(t *testing.T, tpl *template.Template, opts ...task.ManagerOption) (task.TaskManager, string) {
+	t.Helper()
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(tpl); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	opts = append([]task.ManagerOption{task.WithFlowEngine(node.NewFlowEngine())}, opts...)
+	taskMgr := task.NewTaskManager(templateMgr, nil, opts...)
+
+	tsk, err := taskMgr.CreateBy(tpl.ID, "biz-001", "initiator-001", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	return taskMgr, tsk.ID
+}
+
+// lastRecord 返回任务的最后一条审批记录
+func lastRecord(t *testing.T, taskMgr task.TaskManager, id string) *task.Record {
+	t.Helper()
+	tsk, err := taskMgr.Get(id)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if len(tsk.Records) == 0 {
+		t.Fatal("task has no records")
+	}
+	return tsk.Records[len(tsk.Records)-1]
+}
+
+// TestTimeoutPolicyNotifyEscalateAutoApprove 测试超时策略依次执行提醒、升级和自动同意
+func TestTimeoutPolicyNotifyEscalateAutoApprove(t *testing.T) {
+	clock := newFakeClock()
+	policy := &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+		{Type: node.TimeoutActionNotify, After: 24 * time.Hour},
+		{Type: node.TimeoutActionEscalate, After: 48 * time.Hour, EscalateTo: []string{"director-001"}},
+		{Type: node.TimeoutActionAutoApprove, After: 72 * time.Hour},
+	}}
+	taskMgr, id := submitTimeoutPolicyTask(t, createTimeoutPolicyTemplate("policy-approve", policy), task.WithClock(clock))
+
+	// 未到第一个动作的触发时间
+	clock.Advance(23 * time.Hour)
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	if tsk, _ := taskMgr.Get(id); len(tsk.Records) != 0 {
+		t.Fatalf("len(Records) = %d, want 0 before the first action is due", len(tsk.Records))
+	}
+
+	// 24h 提醒
+	clock.Advance(time.Hour)
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	record := lastRecord(t, taskMgr, id)
+	if record.Result != "notify" || record.Approver != task.TimeoutApprover {
+		t.Errorf("record = %s by %s, want notify by %s", record.Result, record.Approver, task.TimeoutApprover)
+	}
+	if err := record.Validate(); err != nil {
+		t.Errorf("Validate() failed: %v", err)
+	}
+
+	// 同一动作不会重复执行
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	if tsk, _ := taskMgr.Get(id); len(tsk.Records) != 1 {
+		t.Fatalf("len(Records) = %d, want 1", len(tsk.Records))
+	}
+
+	// 48h 升级到总监
+	clock.Advance(24 * time.Hour)
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	if record := lastRecord(t, taskMgr, id); record.Result != "escalate" {
+		t.Errorf("record.Result = %q, want escalate", record.Result)
+	}
+	tsk, _ := taskMgr.Get(id)
+	if approvers := tsk.Approvers["manager"]; len(approvers) != 1 || approvers[0] != "director-001" {
+		t.Errorf("Approvers[manager] = %v, want [director-001]", approvers)
+	}
+	if tsk.State == types.TaskStateApproved || tsk.State == types.TaskStateTimeout {
+		t.Fatalf("State = %q, task should still wait for approval", tsk.State)
+	}
+
+	// 72h 自动同意,流程结束
+	clock.Advance(24 * time.Hour)
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(id)
+	if tsk.State != types.TaskStateApproved {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateApproved)
+	}
+	if record := lastRecord(t, taskMgr, id); record.Result != "auto_approve" {
+		t.Errorf("record.Result = %q, want auto_approve", record.Result)
+	}
+	if approval := tsk.Approvals["manager"]["director-001"]; approval == nil || approval.Result != "approve" {
+		t.Errorf("Approvals[manager][director-001] = %+v, want approve", approval)
+	}
+}
+
+// TestTimeoutPolicyAutoReject 测试超时自动拒绝
+func TestTimeoutPolicyAutoReject(t *testing.T) {
+	clock := newFakeClock()
+	policy := &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+		{Type: node.TimeoutActionAutoReject, After: time.Hour},
+	}}
+	taskMgr, id := submitTimeoutPolicyTask(t, createTimeoutPolicyTemplate("policy-reject", policy), task.WithClock(clock))
+
+	clock.Advance(time.Hour)
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	tsk, _ := taskMgr.Get(id)
+	if tsk.State != types.TaskStateRejected {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateRejected)
+	}
+	if record := lastRecord(t, taskMgr, id); record.Result != "auto_reject" {
+		t.Errorf("record.Result = %q, want auto_reject", record.Result)
+	}
+}
+
+// TestTimeoutPolicyEscalationResolver 测试未配置升级目标时使用升级审批人解析函数
+func TestTimeoutPolicyEscalationResolver(t *testing.T) {
+	clock := newFakeClock()
+	policy := &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+		{Type: node.TimeoutActionEscalate, After: time.Hour},
+	}}
+	resolver := func(tsk *task.Task, nodeID string, approvers []string) ([]string, error) {
+		leaders := make([]string, len(approvers))
+		for i, approver := range approvers {
+			leaders[i] = approver + "-leader"
+		}
+		return leaders, nil
+	}
+	taskMgr, id := submitTimeoutPolicyTask(t, createTimeoutPolicyTemplate("policy-resolver", policy),
+		task.WithClock(clock), task.WithEscalationResolver(resolver))
+
+	clock.Advance(time.Hour)
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	tsk, _ := taskMgr.Get(id)
+	if approvers := tsk.Approvers["manager"]; len(approvers) != 1 || approvers[0] != "manager-001-leader" {
+		t.Errorf("Approvers[manager] = %v, want [manager-001-leader]", approvers)
+	}
+
+	// 升级后的审批人可以正常审批
+	if err := taskMgr.Approve(id, "manager", "manager-001-leader", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(id)
+	if tsk.State != types.TaskStateApproved {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateApproved)
+	}
+}
+
+// TestTimeoutPolicyEscalationWithoutTarget 测试没有升级目标时记录升级失败并继续执行后续超时动作
+func TestTimeoutPolicyEscalationWithoutTarget(t *testing.T) {
+	clock := newFakeClock()
+	policy := &node.TimeoutPolicy{Actions: []node.TimeoutAction{
+		{Type: node.TimeoutActionEscalate, After: time.Hour},
+		{Type: node.TimeoutActionAutoReject, After: 2 * time.Hour},
+	}}
+	taskMgr, id := submitTimeoutPolicyTask(t, createTimeoutPolicyTemplate("policy-no-target", policy), task.WithClock(clock))
+
+	clock.Advance(time.Hour)
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	record := lastRecord(t, taskMgr, id)
+	if record.Result != "escalate" || !strings.Contains(record.Comment, "escalation failed") {
+		t.Errorf("record = %q %q, want failed escalate record", record.Result, record.Comment)
+	}
+	tsk, _ := taskMgr.Get(id)
+	if approvers := tsk.Approvers["manager"]; len(approvers) != 1 || approvers[0] != "manager-001" {
+		t.Errorf("Approvers[manager] = %v, want [manager-001]", approvers)
+	}
+
+	// 失败的升级不再重复执行,后续动作按计划执行
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	if record := lastRecord(t, taskMgr, id); record.Result != "escalate" {
+		t.Errorf("record.Result = %q before next action is due, want escalate", record.Result)
+	}
+	clock.Advance(time.Hour)
+	if err := taskMgr.HandleTimeout(id); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(id)
+	if tsk.State != types.TaskStateRejected {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateRejected)
+	}
+}
@@ -0,0 +1,326 @@
+package task_test
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/task"
+	"github.com/mautops/approval-kit/internal/template"
+	"github.com/mautops/approval-kit/internal/types"
+)
+
+// fakeClock 可控时钟,Advance 推进时间并触发到期的等待
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !c.now.Before(w.at) {
+			w.ch <- c.now
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	c.waiters = remaining
+}
+
+// createTwoStageTimeoutTemplate 创建两级审批模板,每个审批节点超时时间为 1 小时
+func createTwoStageTimeoutTemplate() *template.Template {
+	timeout := time.Hour
+	return &template.Template{
+		ID:   "two-stage-timeout",
+		Name: "Two Stage Timeout Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					Timeout:        &timeout,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"manager-001"}},
+				},
+			},
+			"finance": {
+				ID:   "finance",
+				Name: "Finance Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					Timeout:        &timeout,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"finance-001"}},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "manager"},
+			{From: "manager", To: "finance"},
+			{From: "finance", To: "end"},
+		},
+	}
+}
+
+// newTimeoutTemplateManager 创建包含两级超时模板的模板管理器
+func newTimeoutTemplateManager(t *testing.T) template.TemplateManager {
+	templateMgr := template.NewTemplateManager()
+	if err := templateMgr.Create(createTwoStageTimeoutTemplate()); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	return templateMgr
+}
+
+// waitForState 等待任务进入指定状态
+func waitForState(t *testing.T, taskMgr task.TaskManager, id string, want types.TaskState) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		tsk, err := taskMgr.Get(id)
+		if err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		if tsk.State == want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	tsk, _ := taskMgr.Get(id)
+	t.Fatalf("task state = %q, want %q", tsk.State, want)
+}
+
+// TestTimeoutMeasuredFromNodeActivation 测试超时从当前节点激活时间开始计算,而不是提交时间
+func TestTimeoutMeasuredFromNodeActivation(t *testing.T) {
+	clock := newFakeClock()
+	taskMgr := task.NewTaskManager(newTimeoutTemplateManager(t), nil,
+		task.WithFlowEngine(node.NewFlowEngine()), task.WithClock(clock))
+
+	tsk, err := taskMgr.CreateBy("two-stage-timeout", "biz-001", "initiator-001", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+
+	// 经理在 50 分钟后审批,财务节点此时激活
+	clock.Advance(50 * time.Minute)
+	if err := taskMgr.Approve(tsk.ID, "manager", "manager-001", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if got := tsk.NodeActivatedAt["finance"]; !got.Equal(clock.Now()) {
+		t.Errorf("NodeActivatedAt[finance] = %v, want %v", got, clock.Now())
+	}
+
+	// 距提交已超过 1 小时,但财务节点激活未满 1 小时
+	clock.Advance(30 * time.Minute)
+	if err := taskMgr.HandleTimeout(tsk.ID); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.State == types.TaskStateTimeout {
+		t.Fatal("task should not time out before the current node's timeout elapses")
+	}
+
+	// 财务节点激活满 1 小时后超时
+	clock.Advance(30 * time.Minute)
+	if err := taskMgr.HandleTimeout(tsk.ID); err != nil {
+		t.Fatalf("HandleTimeout() failed: %v", err)
+	}
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.State != types.TaskStateTimeout {
+		t.Errorf("State = %q, want %q", tsk.State, types.TaskStateTimeout)
+	}
+}
+
+// TestTimeoutSchedulerFiresAutomatically 测试超时调度器到期后自动处理超时
+func TestTimeoutSchedulerFiresAutomatically(t *testing.T) {
+	clock := newFakeClock()
+	scheduler := task.NewTimeoutScheduler(task.WithSchedulerClock(clock))
+	taskMgr := task.NewTaskManager(newTimeoutTemplateManager(t), nil,
+		task.WithFlowEngine(node.NewFlowEngine()), task.WithTimeoutScheduler(scheduler))
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	defer scheduler.Stop()
+
+	tsk, err := taskMgr.CreateBy("two-stage-timeout", "biz-001", "initiator-001", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	if scheduler.Pending() != 1 {
+		t.Fatalf("Pending() = %d, want 1", scheduler.Pending())
+	}
+
+	// 节点推进后截止时间更新为新节点的截止时间
+	clock.Advance(50 * time.Minute)
+	if err := taskMgr.Approve(tsk.ID, "manager", "manager-001", "ok"); err != nil {
+		t.Fatalf("Approve() failed: %v", err)
+	}
+	clock.Advance(30 * time.Minute)
+	time.Sleep(20 * time.Millisecond)
+	tsk, _ = taskMgr.Get(tsk.ID)
+	if tsk.State != types.TaskStateApproving {
+		t.Fatalf("State = %q, want %q", tsk.State, types.TaskStateApproving)
+	}
+
+	clock.Advance(30 * time.Minute)
+	waitForState(t, taskMgr, tsk.ID, types.TaskStateTimeout)
+	if scheduler.Pending() != 0 {
+		t.Errorf("Pending() = %d, want 0", scheduler.Pending())
+	}
+}
+
+// TestTimeoutSchedulerRestoresFromStore 测试超时调度器启动时从存储恢复截止时间
+func TestTimeoutSchedulerRestoresFromStore(t *testing.T) {
+	clock := newFakeClock()
+	store := task.NewMemoryTaskStore()
+	templateMgr := newTimeoutTemplateManager(t)
+
+	// 第一个进程提交任务后退出(未启动调度器)
+	first := task.NewTaskManager(templateMgr, nil,
+		task.WithFlowEngine(node.NewFlowEngine()), task.WithTaskStore(store), task.WithClock(clock))
+	tsk, err := first.CreateBy("two-stage-timeout", "biz-001", "initiator-001", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := first.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+
+	// 重启后的进程使用同一存储启动调度器
+	clock.Advance(2 * time.Hour)
+	scheduler := task.NewTimeoutScheduler(task.WithSchedulerClock(clock))
+	second := task.NewTaskManager(templateMgr, nil,
+		task.WithFlowEngine(node.NewFlowEngine()), task.WithTaskStore(store), task.WithTimeoutScheduler(scheduler))
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	defer scheduler.Stop()
+
+	// 重启期间已到期的任务被立即处理
+	waitForState(t, second, tsk.ID, types.TaskStateTimeout)
+}
+
+// TestTimeoutSchedulerStartUnbound 测试未绑定任务管理器的调度器无法启动
+func TestTimeoutSchedulerStartUnbound(t *testing.T) {
+	scheduler := task.NewTimeoutScheduler()
+	if err := scheduler.Start(); err == nil {
+		t.Error("Start() should fail when scheduler is not bound to a task manager")
+	}
+	scheduler.Stop()
+}
+
+// flakyTaskStore 写入指定状态的任务时失败一次的存储
+type flakyTaskStore struct {
+	task.TaskStore
+	mu        sync.Mutex
+	failState types.TaskState
+}
+
+func (s *flakyTaskStore) Save(tsk *task.Task) error {
+	s.mu.Lock()
+	fail := s.failState != "" && tsk.State == s.failState
+	if fail {
+		s.failState = ""
+	}
+	s.mu.Unlock()
+	if fail {
+		return stderrors.New("store unavailable")
+	}
+	return s.TaskStore.Save(tsk)
+}
+
+// TestTimeoutSchedulerRetriesOnError 测试超时处理失败后按退避时间重试
+func TestTimeoutSchedulerRetriesOnError(t *testing.T) {
+	clock := newFakeClock()
+	store := &flakyTaskStore{TaskStore: task.NewMemoryTaskStore(), failState: types.TaskStateTimeout}
+	failed := make(chan error, 1)
+	scheduler := task.NewTimeoutScheduler(
+		task.WithSchedulerClock(clock),
+		task.WithSchedulerRetryBackoff(time.Minute, 10*time.Minute),
+		task.WithSchedulerErrorHandler(func(taskID string, err error) {
+			failed <- err
+		}),
+	)
+	taskMgr := task.NewTaskManager(newTimeoutTemplateManager(t), nil,
+		task.WithFlowEngine(node.NewFlowEngine()), task.WithTaskStore(store),
+		task.WithClock(clock), task.WithTimeoutScheduler(scheduler))
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	defer scheduler.Stop()
+
+	tsk, err := taskMgr.CreateBy("two-stage-timeout", "biz-001", "initiator-001", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := taskMgr.Submit(tsk.ID, "initiator-001"); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+
+	// 首次处理写回失败,任务重新登记
+	clock.Advance(time.Hour)
+	select {
+	case <-failed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout handling did not fail")
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for scheduler.Pending() != 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if scheduler.Pending() != 1 {
+		t.Fatalf("Pending() = %d after failure, want 1", scheduler.Pending())
+	}
+	if tsk, _ := taskMgr.Get(tsk.ID); tsk.State != types.TaskStateSubmitted {
+		t.Fatalf("State = %q after failure, want %q", tsk.State, types.TaskStateSubmitted)
+	}
+
+	// 退避时间到期后重试成功
+	clock.Advance(time.Minute)
+	waitForState(t, taskMgr, tsk.ID, types.TaskStateTimeout)
+}
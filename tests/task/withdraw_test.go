@@ -25,19 +25,19 @@ func TestWithdraw(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 撤回任务
-	err = taskMgr.Withdraw(tsk.ID, "user withdraw")
+	err = taskMgr.Withdraw(tsk.ID, "initiator-001", "user withdraw")
 	if err != nil {
 		t.Fatalf("Withdraw() failed: %v", err)
 	}
@@ -80,13 +80,13 @@ func TestWithdrawPendingTask(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 尝试撤回待审批任务(应该失败)
-	err = taskMgr.Withdraw(tsk.ID, "user withdraw")
+	err = taskMgr.Withdraw(tsk.ID, "initiator-001", "user withdraw")
 	if err == nil {
 		t.Error("Withdraw() should fail when task is pending")
 	}
@@ -107,13 +107,13 @@ func TestWithdrawApprovedTask(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 尝试撤回待审批的任务(应该失败,因为状态不是 submitted 或 approving)
-	err = taskMgr.Withdraw(tsk.ID, "user withdraw")
+	err = taskMgr.Withdraw(tsk.ID, "initiator-001", "user withdraw")
 	if err == nil {
 		t.Error("Withdraw() should fail when task is pending")
 	}
@@ -130,17 +130,17 @@ func TestWithdrawWithApprovalRecords(t *testing.T) {
 	}
 
 	// 创建任务管理器
-	taskMgr := task.NewTaskManager(templateMgr, nil)
+	taskMgr := task.NewTaskManager(templateMgr, nil, testAuthorizer())
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
@@ -160,13 +160,19 @@ func TestWithdrawWithApprovalRecords(t *testing.T) {
 		t.Fatalf("Update template failed: %v", err)
 	}
 
-	err = taskMgr.AddApprover(tsk.ID, "approval-001", "user-001", "test add approver")
+	// 任务固定使用创建时的模板版本,需要显式迁移到新版本
+	err = taskMgr.MigrateTaskToVersion(tsk.ID, 2, nil, "admin-001")
+	if err != nil {
+		t.Fatalf("MigrateTaskToVersion() failed: %v", err)
+	}
+
+	err = taskMgr.AddApprover(tsk.ID, "approval-001", "admin-001", "user-001", "test add approver")
 	if err != nil {
 		t.Fatalf("AddApprover() failed: %v", err)
 	}
 
 	// 尝试撤回(应该失败,因为有审批记录)
-	err = taskMgr.Withdraw(tsk.ID, "user withdraw")
+	err = taskMgr.Withdraw(tsk.ID, "initiator-001", "user withdraw")
 	if err == nil {
 		t.Error("Withdraw() should fail when task has approval records")
 	}
@@ -178,7 +184,7 @@ func TestWithdrawNotFound(t *testing.T) {
 	taskMgr := task.NewTaskManager(templateMgr, nil)
 
 	// 尝试撤回不存在的任务
-	err := taskMgr.Withdraw("non-existent", "user withdraw")
+	err := taskMgr.Withdraw("non-existent", "initiator-001", "user withdraw")
 	if err == nil {
 		t.Error("Withdraw() should fail when task does not exist")
 	}
@@ -199,19 +205,19 @@ func TestWithdrawStateHistory(t *testing.T) {
 
 	// 创建任务
 	params := json.RawMessage(`{"amount": 1000}`)
-	tsk, err := taskMgr.Create("tpl-001", "biz-001", params)
+	tsk, err := taskMgr.CreateBy("tpl-001", "biz-001", "initiator-001", params)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
 
 	// 提交任务
-	err = taskMgr.Submit(tsk.ID)
+	err = taskMgr.Submit(tsk.ID, "initiator-001")
 	if err != nil {
 		t.Fatalf("Submit() failed: %v", err)
 	}
 
 	// 撤回任务
-	err = taskMgr.Withdraw(tsk.ID, "user withdraw")
+	err = taskMgr.Withdraw(tsk.ID, "initiator-001", "user withdraw")
 	if err != nil {
 		t.Fatalf("Withdraw() failed: %v", err)
 	}
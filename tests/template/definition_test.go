@@ -0,0 +1,190 @@
+package template_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// createDefinitionTestTemplate 创建覆盖各类节点配置的测试模板
+func createDefinitionTestTemplate() *template.Template {
+	timeout := 48 * time.Hour
+	return &template.Template{
+		ID:          "tpl-expense",
+		Name:        "Expense Approval",
+		Description: "expense approval flow",
+		Version:     1,
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart, Order: 1},
+			"amount": {
+				ID:    "amount",
+				Name:  "Amount Check",
+				Type:  template.NodeTypeCondition,
+				Order: 2,
+				Config: &node.ConditionNodeConfig{
+					Condition: &node.Condition{
+						Type:   "numeric",
+						Config: &node.NumericConditionConfig{Field: "amount", Operator: "gt", Value: 1000, Source: "task_params"},
+					},
+					TrueNodeID:  "manager",
+					FalseNodeID: "end",
+				},
+			},
+			"manager": {
+				ID:    "manager",
+				Name:  "Manager Approval",
+				Type:  template.NodeTypeApproval,
+				Order: 3,
+				Config: &node.ApprovalNodeConfig{
+					Mode:                node.ApprovalModeUnanimous,
+					ApproverConfig:      &node.FixedApproverConfig{Approvers: []string{"alice", "bob"}},
+					Timeout:             &timeout,
+					RejectBehavior:      node.RejectBehaviorTerminate,
+					Permissions:         node.OperationPermissions{AllowTransfer: true, AllowAddApprover: true},
+					RequireCommentField: true,
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd, Order: 4},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "amount"},
+			{From: "amount", To: "manager", Condition: "true"},
+			{From: "amount", To: "end", Condition: "false"},
+			{From: "manager", To: "end"},
+		},
+		Config: &template.TemplateConfig{
+			Webhooks: []*template.WebhookConfig{
+				{
+					URL:     "https://example.com/hook",
+					Method:  "POST",
+					Headers: map[string]string{"X-Source": "approval-kit"},
+					Auth:    &template.AuthConfig{Type: "token", Token: "secret"},
+				},
+			},
+			SkipPolicy: &template.SkipPolicy{DuplicateApprover: template.SkipActionAutoApprove},
+		},
+	}
+}
+
+// TestTemplateDefinitionJSONRoundTrip 测试模板编码为 JSON 后解码保持一致
+func TestTemplateDefinitionJSONRoundTrip(t *testing.T) {
+	codec := node.NewNodeConfigCodec()
+	tpl := createDefinitionTestTemplate()
+
+	data, err := template.MarshalJSON(tpl, codec)
+	if err != nil {
+		t.Fatalf("MarshalJSON() failed: %v", err)
+	}
+	got, err := template.UnmarshalJSON(data, codec)
+	if err != nil {
+		t.Fatalf("UnmarshalJSON() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, tpl) {
+		t.Errorf("UnmarshalJSON() = %+v, want %+v", got, tpl)
+	}
+}
+
+// TestTemplateDefinitionYAMLRoundTrip 测试模板编码为 YAML 后解码保持一致
+func TestTemplateDefinitionYAMLRoundTrip(t *testing.T) {
+	codec := node.NewNodeConfigCodec()
+	tpl := createDefinitionTestTemplate()
+
+	data, err := template.MarshalYAML(tpl, codec)
+	if err != nil {
+		t.Fatalf("MarshalYAML() failed: %v", err)
+	}
+	if strings.Contains(string(data), "{") {
+		t.Errorf("MarshalYAML() should use block style, got:\n%s", data)
+	}
+	got, err := template.UnmarshalYAML(data, codec)
+	if err != nil {
+		t.Fatalf("UnmarshalYAML() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, tpl) {
+		t.Errorf("UnmarshalYAML() = %+v, want %+v", got, tpl)
+	}
+}
+
+// TestTemplateDefinitionFromYAMLFile 测试解析手写的 YAML 模板定义
+func TestTemplateDefinitionFromYAMLFile(t *testing.T) {
+	data := []byte(`
+id: tpl-leave
+name: Leave Approval
+nodes:
+  - id: start
+    name: Start
+    type: start
+  - id: leader
+    name: Leader Approval
+    type: approval
+    config:
+      mode: or
+      timeout: 24h
+      approver:
+        type: fixed
+        config:
+          approvers: [alice, bob]
+      permissions:
+        allow_transfer: true
+  - id: end
+    name: End
+    type: end
+edges:
+  - from: start
+    to: leader
+  - from: leader
+    to: end
+`)
+
+	tpl, err := template.UnmarshalYAML(data, node.NewNodeConfigCodec())
+	if err != nil {
+		t.Fatalf("UnmarshalYAML() failed: %v", err)
+	}
+	if err := tpl.Validate(); err != nil {
+		t.Fatalf("Validate() failed: %v", err)
+	}
+
+	config, ok := tpl.Nodes["leader"].Config.(*node.ApprovalNodeConfig)
+	if !ok {
+		t.Fatalf("leader config = %T, want *node.ApprovalNodeConfig", tpl.Nodes["leader"].Config)
+	}
+	if config.Mode != node.ApprovalModeOr {
+		t.Errorf("Mode = %q, want %q", config.Mode, node.ApprovalModeOr)
+	}
+	if config.Timeout == nil || *config.Timeout != 24*time.Hour {
+		t.Errorf("Timeout = %v, want 24h", config.Timeout)
+	}
+	if !config.Permissions.AllowTransfer {
+		t.Error("Permissions.AllowTransfer should be true")
+	}
+	approvers, ok := config.ApproverConfig.(*node.FixedApproverConfig)
+	if !ok || !reflect.DeepEqual(approvers.Approvers, []string{"alice", "bob"}) {
+		t.Errorf("ApproverConfig = %+v, want fixed approvers [alice bob]", config.ApproverConfig)
+	}
+}
+
+// TestTemplateDefinitionInvalid 测试无效的模板定义
+func TestTemplateDefinitionInvalid(t *testing.T) {
+	codec := node.NewNodeConfigCodec()
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "unknown field", data: `{"id":"tpl","name":"t","nodes":[],"edges":[],"unknown":1}`},
+		{name: "duplicate node", data: `{"id":"tpl","name":"t","nodes":[{"id":"a","name":"a","type":"start"},{"id":"a","name":"a","type":"end"}],"edges":[]}`},
+		{name: "unknown condition type", data: `{"id":"tpl","name":"t","nodes":[{"id":"c","name":"c","type":"condition","config":{"condition":{"type":"regex","config":{}}}}],"edges":[]}`},
+		{name: "invalid timeout", data: `{"id":"tpl","name":"t","nodes":[{"id":"a","name":"a","type":"approval","config":{"mode":"single","timeout":"soon"}}],"edges":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := template.UnmarshalJSON([]byte(tt.data), codec); err == nil {
+				t.Error("UnmarshalJSON() should fail")
+			}
+		})
+	}
+}
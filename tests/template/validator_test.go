@@ -0,0 +1,250 @@
+package template_test
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/mautops/approval-kit/internal/errors"
+	"github.com/mautops/approval-kit/internal/node"
+	"github.com/mautops/approval-kit/internal/template"
+)
+
+// createValidatorTestTemplate 创建一个结构完整的模板: start -> check -> (manager | end), manager -> end
+func createValidatorTestTemplate() *template.Template {
+	return &template.Template{
+		ID:   "tpl-validator",
+		Name: "Validator Template",
+		Nodes: map[string]*template.Node{
+			"start": {ID: "start", Name: "Start", Type: template.NodeTypeStart},
+			"check": {
+				ID:   "check",
+				Name: "Amount Check",
+				Type: template.NodeTypeCondition,
+				Config: &node.ConditionNodeConfig{
+					Condition: &node.Condition{
+						Type:   "numeric",
+						Config: &node.NumericConditionConfig{Field: "amount", Operator: "gt", Value: 1000, Source: "task_params"},
+					},
+					TrueNodeID:  "manager",
+					FalseNodeID: "end",
+				},
+			},
+			"manager": {
+				ID:   "manager",
+				Name: "Manager Approval",
+				Type: template.NodeTypeApproval,
+				Config: &node.ApprovalNodeConfig{
+					Mode:           node.ApprovalModeSingle,
+					ApproverConfig: &node.FixedApproverConfig{Approvers: []string{"alice"}},
+				},
+			},
+			"end": {ID: "end", Name: "End", Type: template.NodeTypeEnd},
+		},
+		Edges: []*template.Edge{
+			{From: "start", To: "check"},
+			{From: "manager", To: "end"},
+		},
+	}
+}
+
+// TestValidateTemplateValid 测试结构完整的模板没有问题
+func TestValidateTemplateValid(t *testing.T) {
+	result := template.ValidateTemplate(createValidatorTestTemplate())
+	if len(result.Issues) != 0 {
+		t.Errorf("ValidateTemplate() issues = %v, want none", result.Issues)
+	}
+	if !result.Valid() || result.Err() != nil {
+		t.Errorf("ValidateTemplate() should be valid, got %v", result.Err())
+	}
+}
+
+// TestValidateTemplateIssues 测试各类问题的检测和定位
+func TestValidateTemplateIssues(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(tpl *template.Template)
+		severity  template.Severity
+		nodeID    string
+		edgeIndex int
+		contains  string
+	}{
+		{
+			name:      "missing end node",
+			modify:    func(tpl *template.Template) { tpl.Nodes["end"].Type = template.NodeTypeApproval },
+			severity:  template.SeverityError,
+			edgeIndex: -1,
+			contains:  "at least one end node",
+		},
+		{
+			name: "edge references non-existent node",
+			modify: func(tpl *template.Template) {
+				tpl.Edges = append(tpl.Edges, &template.Edge{From: "manager", To: "ghost"})
+			},
+			severity:  template.SeverityError,
+			edgeIndex: 2,
+			contains:  `"ghost"`,
+		},
+		{
+			name: "invalid node config",
+			modify: func(tpl *template.Template) {
+				tpl.Nodes["manager"].Config.(*node.ApprovalNodeConfig).Mode = ""
+			},
+			severity:  template.SeverityError,
+			nodeID:    "manager",
+			edgeIndex: -1,
+			contains:  "approval mode is required",
+		},
+		{
+			name: "condition branch references non-existent node",
+			modify: func(tpl *template.Template) {
+				tpl.Nodes["check"].Config.(*node.ConditionNodeConfig).TrueNodeID = "director"
+			},
+			severity:  template.SeverityError,
+			nodeID:    "check",
+			edgeIndex: -1,
+			contains:  `"director"`,
+		},
+		{
+			name: "reject target references non-existent node",
+			modify: func(tpl *template.Template) {
+				config := tpl.Nodes["manager"].Config.(*node.ApprovalNodeConfig)
+				config.RejectBehavior = node.RejectBehaviorJump
+				config.RejectTargetNode = "draft"
+			},
+			severity:  template.SeverityError,
+			nodeID:    "manager",
+			edgeIndex: -1,
+			contains:  `"draft"`,
+		},
+		{
+			name:      "dangling node",
+			modify:    func(tpl *template.Template) { tpl.Edges = tpl.Edges[:1] },
+			severity:  template.SeverityError,
+			nodeID:    "manager",
+			edgeIndex: -1,
+			contains:  "no outgoing edge",
+		},
+		{
+			name: "unreachable node",
+			modify: func(tpl *template.Template) {
+				tpl.Nodes["orphan"] = &template.Node{ID: "orphan", Type: template.NodeTypeEnd}
+			},
+			severity:  template.SeverityWarning,
+			nodeID:    "orphan",
+			edgeIndex: -1,
+			contains:  "not reachable",
+		},
+		{
+			name: "invalid node skip policy",
+			modify: func(tpl *template.Template) {
+				tpl.Nodes["manager"].Config.(*node.ApprovalNodeConfig).SkipPolicy = &template.SkipPolicy{EmptyApprovers: template.SkipActionAutoApprove}
+			},
+			severity:  template.SeverityError,
+			nodeID:    "manager",
+			edgeIndex: -1,
+			contains:  "invalid empty_approvers skip action",
+		},
+		{
+			name: "invalid template skip policy",
+			modify: func(tpl *template.Template) {
+				tpl.Config = &template.TemplateConfig{SkipPolicy: &template.SkipPolicy{DuplicateApprover: template.SkipActionEscalate}}
+			},
+			severity:  template.SeverityError,
+			edgeIndex: -1,
+			contains:  "invalid duplicate_approver skip action",
+		},
+		{
+			name: "webhook signature without key",
+			modify: func(tpl *template.Template) {
+				tpl.Config = &template.TemplateConfig{Webhooks: []*template.WebhookConfig{
+					{URL: "https://example.com/hook", Auth: &template.AuthConfig{Type: template.AuthTypeSignature}},
+				}}
+			},
+			severity:  template.SeverityError,
+			edgeIndex: -1,
+			contains:  "signature auth requires a key",
+		},
+		{
+			name: "invalid webhook URL",
+			modify: func(tpl *template.Template) {
+				tpl.Config = &template.TemplateConfig{Webhooks: []*template.WebhookConfig{{URL: "example.com/hook"}}}
+			},
+			severity:  template.SeverityError,
+			edgeIndex: -1,
+			contains:  "invalid webhook URL",
+		},
+		{
+			name: "multiple outgoing edges without condition",
+			modify: func(tpl *template.Template) {
+				tpl.Edges = append(tpl.Edges, &template.Edge{From: "manager", To: "check"})
+			},
+			severity:  template.SeverityError,
+			nodeID:    "manager",
+			edgeIndex: -1,
+			contains:  "2 outgoing edges",
+		},
+		{
+			name: "cycle",
+			modify: func(tpl *template.Template) {
+				tpl.Edges[1] = &template.Edge{From: "manager", To: "check"}
+			},
+			severity:  template.SeverityWarning,
+			nodeID:    "manager",
+			edgeIndex: -1,
+			contains:  "check -> manager -> check",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := createValidatorTestTemplate()
+			tt.modify(tpl)
+
+			result := template.ValidateTemplate(tpl)
+			for _, issue := range result.Issues {
+				if issue.Severity == tt.severity && issue.NodeID == tt.nodeID && issue.EdgeIndex == tt.edgeIndex &&
+					strings.Contains(issue.Message, tt.contains) {
+					return
+				}
+			}
+			t.Errorf("ValidateTemplate() issues = %v, want %s issue at node %q edge %d containing %q",
+				result.Issues, tt.severity, tt.nodeID, tt.edgeIndex, tt.contains)
+		})
+	}
+}
+
+// TestValidateTemplateReportsAllIssues 测试验证器报告所有问题而不是在第一个问题处停止
+func TestValidateTemplateReportsAllIssues(t *testing.T) {
+	tpl := createValidatorTestTemplate()
+	tpl.ID = ""
+	tpl.Nodes["check"].Config.(*node.ConditionNodeConfig).FalseNodeID = "missing"
+	tpl.Edges = append(tpl.Edges, &template.Edge{From: "nowhere", To: "end"})
+
+	result := template.ValidateTemplate(tpl)
+	if got := len(result.Errors()); got != 3 {
+		t.Errorf("len(Errors()) = %d, want 3: %v", got, result.Issues)
+	}
+}
+
+// TestTemplateManagerStrictValidation 测试启用严格验证的模板管理器
+func TestTemplateManagerStrictValidation(t *testing.T) {
+	mgr := template.NewTemplateManager(template.WithStrictValidation())
+
+	// 基本验证可以通过但缺少结束节点的模板
+	err := mgr.Create(createTestTemplate())
+	if !stderrors.Is(err, errors.ErrInvalidTemplate) {
+		t.Fatalf("Create() error = %v, want ErrInvalidTemplate", err)
+	}
+	var validationErr *template.ValidationError
+	if !stderrors.As(err, &validationErr) {
+		t.Fatalf("Create() error = %T, want *template.ValidationError", err)
+	}
+	if len(validationErr.Issues) == 0 {
+		t.Error("ValidationError.Issues should not be empty")
+	}
+
+	if err := mgr.Create(createValidatorTestTemplate()); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+}